@@ -1,29 +1,49 @@
 package bot
 
 import (
+	"context"
 	"encoding/json"
+	"iter"
 	"net/http"
 	"sync"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/fsm"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/broadcast"
+	"github.com/enetx/tg/callback"
 	"github.com/enetx/tg/core"
+	"github.com/enetx/tg/ctx"
 	"github.com/enetx/tg/handlers"
 	"github.com/enetx/tg/input"
+	"github.com/enetx/tg/scheduler"
+	"github.com/enetx/tg/states"
 )
 
 // Bot represents a Telegram bot instance with all necessary components for handling updates,
 // managing middleware, and interacting with the Telegram Bot API.
 type Bot struct {
-	token       g.String                  // Bot token for API authentication
-	dispatcher  *ext.Dispatcher           // Event dispatcher for handling updates
-	updater     *ext.Updater              // Updater for receiving updates
-	mu          sync.RWMutex              // Protects concurrent access to middlewares
-	middlewares g.Slice[handlers.Handler] // Global middleware stack
-	On          *handlers.Handlers        // Event handlers for different update types
-	raw         *gotgbot.Bot              // Raw gotgbot instance for direct API access
+	token       g.String                     // Bot token for API authentication
+	dispatcher  *ext.Dispatcher              // Event dispatcher for handling updates
+	updater     *ext.Updater                 // Updater for receiving updates
+	mu          sync.RWMutex                 // Protects concurrent access to middlewares, hooks and webhook secret
+	middlewares g.Slice[handlers.Handler]    // Global middlewares added with Use
+	chain       g.Slice[handlers.Middleware] // Global middleware stack in order
+	On          *handlers.Handlers           // Event handlers for different update types
+	raw         *gotgbot.Bot                 // Raw gotgbot instance for direct API access
+	tasks       sync.WaitGroup               // Tracks background tasks started with Go
+	secret      g.String                     // Webhook secret token checked by WebhookHandler
+	onError     handlers.ErrorHandler        // Hook for errors returned by handlers
+	onPanic     handlers.PanicHandler        // Hook for panics recovered from handlers
+	registry    *handlers.Registry           // Registered handlers listed by Routes
+	std         context.Context              // Lifetime context of the bot, cancelled on shutdown
+	cancel      context.CancelFunc           // Cancels std
+	serving     context.Context              // Cancelled with ctx.ErrShutdown when graceful shutdown begins
+	stop        context.CancelCauseFunc      // Cancels serving
+	states      *states.Manager              // State machines created with FSM, used by InState filters
+	scheduler   *scheduler.Scheduler         // Runs delayed sends, deletions and other jobs
 }
 
 var _ core.BotAPI = (*Bot)(nil)
@@ -72,16 +92,18 @@ func (b *Bot) Command(cmd g.String, fn handlers.Handler) *handlers.Command {
 // Polling returns a Polling instance for receiving updates via long polling.
 func (b *Bot) Polling() *Polling {
 	return &Polling{
-		bot:  b,
-		opts: &ext.PollingOpts{GetUpdatesOpts: new(gotgbot.GetUpdatesOpts)},
+		bot:   b,
+		opts:  &ext.PollingOpts{GetUpdatesOpts: new(gotgbot.GetUpdatesOpts)},
+		drain: defaultDrainTimeout,
 	}
 }
 
 // Webhook returns a Webhook instance for receiving updates via webhook.
 func (b *Bot) Webhook() *SetWebhook {
 	return &SetWebhook{
-		bot:  b,
-		opts: new(gotgbot.SetWebhookOpts),
+		bot:   b,
+		opts:  new(gotgbot.SetWebhookOpts),
+		drain: defaultDrainTimeout,
 	}
 }
 
@@ -95,17 +117,179 @@ func (b *Bot) HandleWebhook(data []byte) error {
 	return b.dispatcher.ProcessUpdate(b.Raw(), &update, nil)
 }
 
+// Context returns the lifetime context of the bot. It is cancelled when graceful shutdown has drained
+// running handlers and tasks, or its drain timeout expires. Background tasks started with Go can use it
+// for requests that must still go out while the bot drains. Jobs of the scheduler, such as delayed sends
+// scheduled with After, are not cancelled by it.
+func (b *Bot) Context() context.Context {
+	if b.std == nil {
+		return context.Background()
+	}
+
+	return b.std
+}
+
+// Serving returns the context of the bot while it serves updates. It is cancelled with cause
+// ctx.ErrShutdown as soon as graceful shutdown begins, before running handlers are drained, and
+// the standard contexts of handlers, c.Std(), are derived from it.
+func (b *Bot) Serving() context.Context {
+	if b.serving == nil {
+		return b.Context()
+	}
+
+	return b.serving
+}
+
+// Go runs fn in a new goroutine tracked by the bot, so that graceful shutdown waits for it to finish.
+func (b *Bot) Go(fn func()) {
+	b.tasks.Go(fn)
+}
+
+// shutdown cancels the serving context, calls stop, drains the scheduler, running the jobs that fall
+// due before timeout expires, and then waits for all tracked background tasks to finish. Handlers
+// waiting on their context, e.g. for an answer to Ask, return at once. The lifetime context stays live
+// while the rest drains, so requests of tasks and jobs still go out, and is cancelled once draining is
+// done or timeout expires. It returns an error in the latter case.
+func (b *Bot) shutdown(timeout time.Duration, stop func() error) error {
+	if b.stop != nil {
+		b.stop(ctx.ErrShutdown)
+	}
+
+	if b.cancel != nil {
+		defer b.cancel()
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		var deadline time.Time
+		if b.scheduler != nil {
+			deadline = b.scheduler.Now().Add(timeout)
+		}
+
+		err := stop()
+
+		if b.scheduler != nil {
+			b.scheduler.Drain(deadline)
+		}
+
+		b.tasks.Wait()
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return g.Errorf("graceful shutdown timed out after {}", timeout)
+	}
+}
+
+// Scheduler returns the bot's scheduler. Delayed sends and deletions scheduled with After and
+// DeleteAfter are its jobs, and Schedule on a builder returns the ID of the job:
+//
+//	id := c.SendMessage("Reminder").After(time.Hour).Schedule().Unwrap()
+//	b.Scheduler().Cancel(id)
+//
+// Jobs are kept in memory unless a persistent store is set with JobStore when building the bot.
+// Graceful shutdown runs the jobs that fall due within its drain timeout, such as deletions of
+// self-destructing messages. Later jobs are not run: stored jobs run after the next start, while
+// in-memory jobs are dropped.
+func (b *Bot) Scheduler() *scheduler.Scheduler {
+	return b.scheduler
+}
+
+// Chat returns a context bound to the chat with the given ID, for sending outside of update handlers,
+// e.g. from an HTTP endpoint or a queue consumer. Its builders are the ones of handler contexts:
+//
+//	b.Chat(chatID).SendPhoto("chart.png").Caption("Daily chart").Send()
+//	b.Chat(chatID).EditMessageText("Updated").MessageID(messageID).Send()
+//
+// The context has no effective message or user, so builders that act on a message or a user
+// return ctx.ErrNoMessage or ctx.ErrNoUser unless one is set explicitly.
+func (b *Bot) Chat(id int64) *ctx.Context {
+	return ctx.Detached(b).Chat(id)
+}
+
+// SendMessage creates a new SendMessage request to send a text message to the chat with the given ID.
+func (b *Bot) SendMessage(chatID int64, text g.String) *ctx.SendMessage {
+	return b.Chat(chatID).SendMessage(text)
+}
+
+// Broadcast creates a broadcast of a message to many chats, paced to stay within flood limits:
+//
+//	report := b.Broadcast(slices.Values(subscribers)).
+//		Message(func(c *ctx.Context) error { return c.SendMessage("News").Send().Err() }).
+//		Send(context.Background())
+//
+// See the broadcast package for pausing, progress and failure reports.
+func (b *Bot) Broadcast(recipients iter.Seq[int64]) *broadcast.Broadcast {
+	return broadcast.New(b, recipients)
+}
+
+// CallbackStorage makes the bot resolve the tokens of storage in callback data before any handler
+// sees the update, so that buttons can carry more than 64 bytes:
+//
+//	payloads := b.CallbackStorage(callback.NewStorage(callback.NewMemory()))
+//	markup := keyboard.Inline().Storage(payloads).Text("Open", longData)
+func (b *Bot) CallbackStorage(storage *callback.Storage) *callback.Storage {
+	return storage.Attach(b)
+}
+
+// Schedule runs fn at the times of a cron expression, see scheduler.Parse:
+//
+//	b.Schedule("0 9 * * MON", func(c *ctx.Context) error {
+//		return c.Chat(channelID).SendMessage("Weekly report").Send().Err()
+//	}).In(berlin)
+//
+// fn gets a context that is not bound to an update, created with ctx.Detached. Its Std keeps the
+// values of the bot's context but is never cancelled, so a running job is not interrupted when the
+// bot stops; shutdown waits for it instead. Errors and panics of fn are passed to the scheduler's
+// error hook, see Scheduler().OnError.
+func (b *Bot) Schedule(expr g.String, fn handlers.Handler) *scheduler.Recurring {
+	return b.scheduler.Cron(expr, b.job(fn))
+}
+
+// Every runs fn every interval, first one interval from now. See Schedule.
+func (b *Bot) Every(interval time.Duration, fn handlers.Handler) *scheduler.Recurring {
+	return b.scheduler.Every(interval, b.job(fn))
+}
+
+// job adapts a handler to a scheduler job.
+func (b *Bot) job(fn handlers.Handler) func(std context.Context) error {
+	return func(std context.Context) error {
+		c := ctx.Detached(b)
+		c.SetStd(std)
+
+		return fn(c)
+	}
+}
+
 // Use adds a global middleware to the bot.
+// The middleware runs before the handler and stops the update by returning an error.
+// Use Around for middlewares that also run code after the handler.
 func (b *Bot) Use(middleware handlers.Handler) *Bot {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
 	b.middlewares.Push(middleware)
+	b.chain.Push(handlers.Before(middleware))
+
+	return b
+}
+
+// Around adds a global middleware that wraps every handler registered afterwards.
+// Global middlewares added with Use and Around run in the order they were added.
+func (b *Bot) Around(middleware handlers.Middleware) *Bot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.chain.Push(middleware)
 
 	return b
 }
 
-// Middlewares returns the current global middleware stack.
+// Middlewares returns the global middlewares added with Use.
 func (b *Bot) Middlewares() g.Slice[handlers.Handler] {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
@@ -113,6 +297,103 @@ func (b *Bot) Middlewares() g.Slice[handlers.Handler] {
 	return b.middlewares
 }
 
+// Chain returns all global middlewares added with Use and Around, in order.
+func (b *Bot) Chain() g.Slice[handlers.Middleware] {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.chain
+}
+
+// Scope returns a new handler scope with its own middleware stack.
+// Middlewares added to the scope with Use apply only to handlers registered through it.
+// Unlike On.Group, which selects a dispatcher group, the scope registers into group 0.
+//
+//	b.Scope().Use(requireAdmin).Command("ban", ban)
+func (b *Bot) Scope() *handlers.Handlers {
+	return handlers.NewHandlers(b)
+}
+
+// Registry returns the registry that records the bot's handlers.
+func (b *Bot) Registry() *handlers.Registry {
+	return b.registry
+}
+
+// Routes returns all registered handlers with their kind, filter and group,
+// ordered the way the dispatcher tries them. Call Remove on a route to unregister it.
+func (b *Bot) Routes() g.Slice[handlers.Route] {
+	return b.registry.Routes()
+}
+
+// FSM returns a manager that keeps a state machine cloned from template for every user,
+// in memory unless a store is set with Store. The manager also decides which handlers
+// restricted with InState match an update; a later call replaces it.
+//
+//	flow := b.FSM(template).Store(session.NewFile("states.json"))
+//	b.On.Message.Text(askAge).InState("name")
+func (b *Bot) FSM(template *fsm.FSM) *states.Manager {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.states = states.New(template)
+
+	return b.states
+}
+
+// State returns the current state of the update's user in the machine created with FSM,
+// see states.Manager.State, or None if the bot has no such machine.
+func (b *Bot) State(c *ctx.Context) g.Option[fsm.State] {
+	b.mu.RLock()
+	m := b.states
+	b.mu.RUnlock()
+
+	if m == nil {
+		return g.None[fsm.State]()
+	}
+
+	return m.State(c)
+}
+
+// OnError sets a hook called with the error returned by any handler or middleware.
+// Errors passed to the hook are not reported to the dispatcher.
+// Panics are passed to the hook as *handlers.PanicError unless OnPanic is set.
+// Use handlers.ReplyOnError to notify the user that something went wrong.
+func (b *Bot) OnError(fn handlers.ErrorHandler) *Bot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.onError = fn
+
+	return b
+}
+
+// OnPanic sets a hook called when a handler or middleware panics.
+// The panic is recovered, and the hook receives the panic value with its stack trace.
+func (b *Bot) OnPanic(fn handlers.PanicHandler) *Bot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.onPanic = fn
+
+	return b
+}
+
+// ErrorHandler returns the hook set with OnError.
+func (b *Bot) ErrorHandler() handlers.ErrorHandler {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.onError
+}
+
+// PanicHandler returns the hook set with OnPanic.
+func (b *Bot) PanicHandler() handlers.PanicHandler {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.onPanic
+}
+
 // GetMyDescription creates a new GetMyDescription request to get the bot's description.
 func (b *Bot) GetMyDescription() *GetMyDescription {
 	return &GetMyDescription{
@@ -1,6 +1,7 @@
 package bot
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -9,12 +10,20 @@ import (
 	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/handlers"
+	"github.com/enetx/tg/ratelimit"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
+	"github.com/enetx/tg/tgerr"
 )
 
 // BotBuilder provides a fluent interface for configuring and building Telegram bots.
 type BotBuilder struct {
-	token g.String
-	opts  *gotgbot.BotOpts
+	token  g.String
+	opts   *gotgbot.BotOpts
+	limits *ratelimit.Limits
+	jobs   scheduler.Store
+	retry  *retry.Policy
+	routes int
 }
 
 // UseTestEnvironment configures the bot to use Telegram's test environment.
@@ -68,19 +77,67 @@ func (b *BotBuilder) DefaultAPIURL(url g.String) *BotBuilder {
 	return b
 }
 
+// RateLimit enables flood control for all outgoing requests using the given limits.
+// Use ratelimit.New() for Telegram's documented limits. Unless a policy is set with Retry,
+// requests rejected with "Too Many Requests" are sent once more after the chat's pause, see retry.Flood.
+func (b *BotBuilder) RateLimit(limits *ratelimit.Limits) *BotBuilder {
+	b.limits = limits
+	return b
+}
+
+// Retry sets the default retry policy for all outgoing requests.
+// Individual requests can override it with their own Retry method.
+func (b *BotBuilder) Retry(policy *retry.Policy) *BotBuilder {
+	b.retry = policy
+	return b
+}
+
+// MaxRoutines sets how many updates the dispatcher handles at the same time, ext.DefaultMaxRoutines
+// by default; a negative value removes the limit. Handlers waiting for an answer with Ask hold their
+// routine until the answer arrives, so bots with many concurrent conversations need a higher limit.
+func (b *BotBuilder) MaxRoutines(n int) *BotBuilder {
+	b.routes = n
+	return b
+}
+
+// JobStore sets where the bot's scheduler keeps its jobs, such as delayed sends and deletions.
+// With a persistent store, e.g. scheduler.NewFile, pending jobs run after a restart.
+func (b *BotBuilder) JobStore(store scheduler.Store) *BotBuilder {
+	b.jobs = store
+	return b
+}
+
 // Build creates and initializes a new Bot instance with the configured settings.
 func (b *BotBuilder) Build() g.Result[*Bot] {
+	client := b.opts.BotClient
+	policy := b.retry
+
+	if b.limits != nil {
+		client = b.limits.Wrap(client)
+
+		if policy == nil {
+			policy = retry.Flood()
+		}
+	}
+
+	client = retry.Wrap(client, policy)
+	client = tgerr.Wrap(client)
+
 	raw := &gotgbot.Bot{
 		Token:     b.token.Std(),
-		BotClient: b.opts.BotClient,
+		BotClient: client,
 	}
 
 	bot := &Bot{
 		token:      b.token,
 		raw:        raw,
-		dispatcher: ext.NewDispatcher(nil),
+		dispatcher: ext.NewDispatcher(&ext.DispatcherOpts{MaxRoutines: b.routes}),
+		registry:   handlers.NewRegistry(),
 	}
 
+	bot.std, bot.cancel = context.WithCancel(context.Background())
+	bot.serving, bot.stop = context.WithCancelCause(bot.std)
+
 	bot.updater = ext.NewUpdater(bot.dispatcher, nil)
 	bot.On = handlers.NewHandlers(bot)
 
@@ -115,5 +172,14 @@ func (b *BotBuilder) Build() g.Result[*Bot] {
 		}
 	}
 
+	bot.scheduler = scheduler.New(bot)
+	if b.jobs != nil {
+		bot.scheduler.Store(b.jobs)
+	}
+
+	if err := bot.scheduler.Start(bot.std); err != nil {
+		return g.Err[*Bot](fmt.Errorf("failed to start scheduler: %w", err))
+	}
+
 	return g.Ok(bot)
 }
@@ -1,16 +1,19 @@
 package bot
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // Close represents a request to close the bot instance.
 type Close struct {
-	bot  *Bot
-	opts *gotgbot.CloseOpts
+	bot   *Bot
+	opts  *gotgbot.CloseOpts
+	retry *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -35,7 +38,13 @@ func (c *Close) APIURL(url g.String) *Close {
 	return c
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (c *Close) Retry(policy *retry.Policy) *Close {
+	c.retry = policy
+	return c
+}
+
 // Send closes the bot instance and returns the result.
 func (c *Close) Send() g.Result[bool] {
-	return g.ResultOf(c.bot.raw.Close(c.opts))
+	return g.ResultOf(c.bot.raw.CloseWithContext(retry.WithPolicy(context.Background(), c.retry), c.opts))
 }
@@ -1,16 +1,19 @@
 package bot
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // DeleteMyCommands represents a request to delete bot commands.
 type DeleteMyCommands struct {
-	bot  *Bot
-	opts *gotgbot.DeleteMyCommandsOpts
+	bot   *Bot
+	opts  *gotgbot.DeleteMyCommandsOpts
+	retry *retry.Policy
 }
 
 // Scope sets the scope for which to delete the commands.
@@ -93,7 +96,13 @@ func (dmc *DeleteMyCommands) APIURL(url g.String) *DeleteMyCommands {
 	return dmc
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (dmc *DeleteMyCommands) Retry(policy *retry.Policy) *DeleteMyCommands {
+	dmc.retry = policy
+	return dmc
+}
+
 // Send deletes the bot commands.
 func (dmc *DeleteMyCommands) Send() g.Result[bool] {
-	return g.ResultOf(dmc.bot.raw.DeleteMyCommands(dmc.opts))
+	return g.ResultOf(dmc.bot.raw.DeleteMyCommandsWithContext(retry.WithPolicy(context.Background(), dmc.retry), dmc.opts))
 }
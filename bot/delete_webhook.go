@@ -1,16 +1,19 @@
 package bot
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // DeleteWebhook represents a request to remove webhook integration.
 type DeleteWebhook struct {
-	bot  *Bot
-	opts *gotgbot.DeleteWebhookOpts
+	bot   *Bot
+	opts  *gotgbot.DeleteWebhookOpts
+	retry *retry.Policy
 }
 
 // DropPendingUpdates instructs to drop all pending updates.
@@ -41,7 +44,13 @@ func (dw *DeleteWebhook) APIURL(url g.String) *DeleteWebhook {
 	return dw
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (dw *DeleteWebhook) Retry(policy *retry.Policy) *DeleteWebhook {
+	dw.retry = policy
+	return dw
+}
+
 // Send removes the webhook integration.
 func (dw *DeleteWebhook) Send() g.Result[bool] {
-	return g.ResultOf(dw.bot.Raw().DeleteWebhook(dw.opts))
+	return g.ResultOf(dw.bot.Raw().DeleteWebhookWithContext(retry.WithPolicy(context.Background(), dw.retry), dw.opts))
 }
@@ -1,16 +1,19 @@
 package bot
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetMe represents a request to get basic information about the bot.
 type GetMe struct {
-	bot  *Bot
-	opts *gotgbot.GetMeOpts
+	bot   *Bot
+	opts  *gotgbot.GetMeOpts
+	retry *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -35,7 +38,13 @@ func (gm *GetMe) APIURL(url g.String) *GetMe {
 	return gm
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gm *GetMe) Retry(policy *retry.Policy) *GetMe {
+	gm.retry = policy
+	return gm
+}
+
 // Send gets basic information about the bot.
 func (gm *GetMe) Send() g.Result[*gotgbot.User] {
-	return g.ResultOf(gm.bot.Raw().GetMe(gm.opts))
+	return g.ResultOf(gm.bot.Raw().GetMeWithContext(retry.WithPolicy(context.Background(), gm.retry), gm.opts))
 }
@@ -1,16 +1,19 @@
 package bot
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetMyCommands represents a request to get bot commands.
 type GetMyCommands struct {
-	bot  *Bot
-	opts *gotgbot.GetMyCommandsOpts
+	bot   *Bot
+	opts  *gotgbot.GetMyCommandsOpts
+	retry *retry.Policy
 }
 
 // Scope sets the scope for which to get the commands.
@@ -93,7 +96,13 @@ func (gmc *GetMyCommands) APIURL(url g.String) *GetMyCommands {
 	return gmc
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gmc *GetMyCommands) Retry(policy *retry.Policy) *GetMyCommands {
+	gmc.retry = policy
+	return gmc
+}
+
 // Send gets the bot commands.
 func (gmc *GetMyCommands) Send() g.Result[g.Slice[gotgbot.BotCommand]] {
-	return g.ResultOf[g.Slice[gotgbot.BotCommand]](gmc.bot.raw.GetMyCommands(gmc.opts))
+	return g.ResultOf[g.Slice[gotgbot.BotCommand]](gmc.bot.raw.GetMyCommandsWithContext(retry.WithPolicy(context.Background(), gmc.retry), gmc.opts))
 }
@@ -1,16 +1,19 @@
 package bot
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetMyDefaultAdministratorRights represents a request to get the bot's default administrator rights.
 type GetMyDefaultAdministratorRights struct {
-	bot  *Bot
-	opts *gotgbot.GetMyDefaultAdministratorRightsOpts
+	bot   *Bot
+	opts  *gotgbot.GetMyDefaultAdministratorRightsOpts
+	retry *retry.Policy
 }
 
 // ForChannels sets whether to get rights for channels.
@@ -41,7 +44,13 @@ func (gmdar *GetMyDefaultAdministratorRights) APIURL(url g.String) *GetMyDefault
 	return gmdar
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gmdar *GetMyDefaultAdministratorRights) Retry(policy *retry.Policy) *GetMyDefaultAdministratorRights {
+	gmdar.retry = policy
+	return gmdar
+}
+
 // Send gets the bot's default administrator rights.
 func (gmdar *GetMyDefaultAdministratorRights) Send() g.Result[*gotgbot.ChatAdministratorRights] {
-	return g.ResultOf(gmdar.bot.Raw().GetMyDefaultAdministratorRights(gmdar.opts))
+	return g.ResultOf(gmdar.bot.Raw().GetMyDefaultAdministratorRightsWithContext(retry.WithPolicy(context.Background(), gmdar.retry), gmdar.opts))
 }
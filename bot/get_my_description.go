@@ -1,16 +1,19 @@
 package bot
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetMyDescription represents a request to get the bot's description.
 type GetMyDescription struct {
-	bot  *Bot
-	opts *gotgbot.GetMyDescriptionOpts
+	bot   *Bot
+	opts  *gotgbot.GetMyDescriptionOpts
+	retry *retry.Policy
 }
 
 // Language sets the language code for getting the description.
@@ -41,7 +44,13 @@ func (gmd *GetMyDescription) APIURL(url g.String) *GetMyDescription {
 	return gmd
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gmd *GetMyDescription) Retry(policy *retry.Policy) *GetMyDescription {
+	gmd.retry = policy
+	return gmd
+}
+
 // Send gets the bot's description and returns the result.
 func (gmd *GetMyDescription) Send() g.Result[*gotgbot.BotDescription] {
-	return g.ResultOf(gmd.bot.raw.GetMyDescription(gmd.opts))
+	return g.ResultOf(gmd.bot.raw.GetMyDescriptionWithContext(retry.WithPolicy(context.Background(), gmd.retry), gmd.opts))
 }
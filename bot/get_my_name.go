@@ -1,16 +1,19 @@
 package bot
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetMyName represents a request to get the bot's name.
 type GetMyName struct {
-	bot  *Bot
-	opts *gotgbot.GetMyNameOpts
+	bot   *Bot
+	opts  *gotgbot.GetMyNameOpts
+	retry *retry.Policy
 }
 
 // Language sets the language code for getting the name.
@@ -41,7 +44,13 @@ func (gmn *GetMyName) APIURL(url g.String) *GetMyName {
 	return gmn
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gmn *GetMyName) Retry(policy *retry.Policy) *GetMyName {
+	gmn.retry = policy
+	return gmn
+}
+
 // Send gets the bot's name and returns the result.
 func (gmn *GetMyName) Send() g.Result[*gotgbot.BotName] {
-	return g.ResultOf(gmn.bot.raw.GetMyName(gmn.opts))
+	return g.ResultOf(gmn.bot.raw.GetMyNameWithContext(retry.WithPolicy(context.Background(), gmn.retry), gmn.opts))
 }
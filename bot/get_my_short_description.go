@@ -1,16 +1,19 @@
 package bot
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetMyShortDescription represents a request to get the bot's short description.
 type GetMyShortDescription struct {
-	bot  *Bot
-	opts *gotgbot.GetMyShortDescriptionOpts
+	bot   *Bot
+	opts  *gotgbot.GetMyShortDescriptionOpts
+	retry *retry.Policy
 }
 
 // Language sets the language code for getting the short description.
@@ -41,7 +44,13 @@ func (gmsd *GetMyShortDescription) APIURL(url g.String) *GetMyShortDescription {
 	return gmsd
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gmsd *GetMyShortDescription) Retry(policy *retry.Policy) *GetMyShortDescription {
+	gmsd.retry = policy
+	return gmsd
+}
+
 // Send gets the bot's short description and returns the result.
 func (gmsd *GetMyShortDescription) Send() g.Result[*gotgbot.BotShortDescription] {
-	return g.ResultOf(gmsd.bot.raw.GetMyShortDescription(gmsd.opts))
+	return g.ResultOf(gmsd.bot.raw.GetMyShortDescriptionWithContext(retry.WithPolicy(context.Background(), gmsd.retry), gmsd.opts))
 }
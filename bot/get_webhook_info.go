@@ -1,16 +1,19 @@
 package bot
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetWebhookInfo represents a request to get current webhook status.
 type GetWebhookInfo struct {
-	bot  *Bot
-	opts *gotgbot.GetWebhookInfoOpts
+	bot   *Bot
+	opts  *gotgbot.GetWebhookInfoOpts
+	retry *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -35,7 +38,13 @@ func (gwi *GetWebhookInfo) APIURL(url g.String) *GetWebhookInfo {
 	return gwi
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gwi *GetWebhookInfo) Retry(policy *retry.Policy) *GetWebhookInfo {
+	gwi.retry = policy
+	return gwi
+}
+
 // Send gets the current webhook status.
 func (gwi *GetWebhookInfo) Send() g.Result[*gotgbot.WebhookInfo] {
-	return g.ResultOf(gwi.bot.Raw().GetWebhookInfo(gwi.opts))
+	return g.ResultOf(gwi.bot.Raw().GetWebhookInfoWithContext(retry.WithPolicy(context.Background(), gwi.retry), gwi.opts))
 }
@@ -1,16 +1,19 @@
 package bot
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // LogOut represents a request to log out from the cloud Bot API server.
 type LogOut struct {
-	bot  *Bot
-	opts *gotgbot.LogOutOpts
+	bot   *Bot
+	opts  *gotgbot.LogOutOpts
+	retry *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -35,7 +38,13 @@ func (lo *LogOut) APIURL(url g.String) *LogOut {
 	return lo
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (lo *LogOut) Retry(policy *retry.Policy) *LogOut {
+	lo.retry = policy
+	return lo
+}
+
 // Send logs out from the cloud Bot API server and returns the result.
 func (lo *LogOut) Send() g.Result[bool] {
-	return g.ResultOf(lo.bot.raw.LogOut(lo.opts))
+	return g.ResultOf(lo.bot.raw.LogOutWithContext(retry.WithPolicy(context.Background(), lo.retry), lo.opts))
 }
@@ -1,14 +1,26 @@
 package bot
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/types/updates"
 )
 
+// defaultDrainTimeout bounds how long graceful shutdown waits for in-flight work.
+const defaultDrainTimeout = 30 * time.Second
+
 type Polling struct {
 	bot     *Bot
 	opts    *ext.PollingOpts
+	drain   time.Duration
 	started bool
 }
 
@@ -45,13 +57,34 @@ func (p *Polling) AllowedUpdates(upds ...updates.UpdateType) *Polling {
 	return p
 }
 
-func (p *Polling) Start() {
+// DrainTimeout sets how long Start waits for running handlers and scheduled
+// jobs that are already running once shutdown begins.
+func (p *Polling) DrainTimeout(duration time.Duration) *Polling {
+	p.drain = duration
+	return p
+}
+
+// Start begins long polling and blocks until ctx is cancelled or the process receives SIGINT or SIGTERM.
+// On shutdown it stops fetching updates and waits for running handlers and jobs,
+// bounded by the drain timeout. A clean shutdown returns nil.
+func (p *Polling) Start(ctx context.Context) error {
+	if p.started {
+		return errors.New("polling already started")
+	}
+
+	p.started = true
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	if err := p.bot.updater.StartPolling(p.bot.Raw(), p.opts); err != nil {
-		panic("failed to start polling: " + err.Error())
+		return fmt.Errorf("failed to start polling: %w", err)
 	}
 
 	g.Println("bot started")
-	p.bot.updater.Idle()
+	<-ctx.Done()
+
+	return p.bot.shutdown(p.drain, p.bot.updater.Stop)
 }
 
 func (p *Polling) Opts() *ext.PollingOpts {
@@ -1,16 +1,19 @@
 package bot
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // RemoveMyProfilePhoto represents a request to remove the bot's profile photo.
 type RemoveMyProfilePhoto struct {
-	bot  *Bot
-	opts *gotgbot.RemoveMyProfilePhotoOpts
+	bot   *Bot
+	opts  *gotgbot.RemoveMyProfilePhotoOpts
+	retry *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -35,7 +38,13 @@ func (rmpp *RemoveMyProfilePhoto) APIURL(url g.String) *RemoveMyProfilePhoto {
 	return rmpp
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (rmpp *RemoveMyProfilePhoto) Retry(policy *retry.Policy) *RemoveMyProfilePhoto {
+	rmpp.retry = policy
+	return rmpp
+}
+
 // Send removes the bot's profile photo and returns the result.
 func (rmpp *RemoveMyProfilePhoto) Send() g.Result[bool] {
-	return g.ResultOf(rmpp.bot.raw.RemoveMyProfilePhoto(rmpp.opts))
+	return g.ResultOf(rmpp.bot.raw.RemoveMyProfilePhotoWithContext(retry.WithPolicy(context.Background(), rmpp.retry), rmpp.opts))
 }
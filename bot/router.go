@@ -0,0 +1,161 @@
+package bot
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/enetx/g"
+)
+
+// Router serves webhook updates for many bots from a single http.Handler.
+//
+// Every bot is mounted under <prefix>/<bot id> and verified with its own secret token.
+// Bots can be added and removed while the router is serving requests.
+type Router struct {
+	domain g.String
+	prefix g.String
+	tlsCrt g.String
+	tlsKey g.String
+	drain  time.Duration
+	bots   *g.MapSafe[int64, *Bot]
+}
+
+var _ http.Handler = (*Router)(nil)
+
+// NewRouter creates a new Router that mounts bots under the "/bot" path prefix.
+func NewRouter() *Router {
+	return &Router{
+		prefix: "/bot",
+		drain:  defaultDrainTimeout,
+		bots:   g.NewMapSafe[int64, *Bot](),
+	}
+}
+
+// Domain sets the public domain used to build webhook URLs for added bots.
+func (r *Router) Domain(domain g.String) *Router {
+	r.domain = domain
+	return r
+}
+
+// Prefix sets the path prefix under which bots are mounted.
+func (r *Router) Prefix(prefix g.String) *Router {
+	r.prefix = prefix.Trim().StripPrefix("/").StripSuffix("/")
+	if !r.prefix.IsEmpty() {
+		r.prefix = "/" + r.prefix
+	}
+
+	return r
+}
+
+// TLS configures Start and Listen to serve HTTPS using the given certificate and private key files.
+func (r *Router) TLS(certFile, keyFile g.String) *Router {
+	r.tlsCrt = certFile
+	r.tlsKey = keyFile
+
+	return r
+}
+
+// DrainTimeout sets how long Start waits for running handlers and scheduled
+// jobs that are already running in all bots once shutdown begins.
+func (r *Router) DrainTimeout(duration time.Duration) *Router {
+	r.drain = duration
+	return r
+}
+
+// Path returns the path under which the bot with the given ID is mounted.
+func (r *Router) Path(id int64) g.String {
+	return r.prefix + "/" + g.Int(id).String()
+}
+
+// Add mounts b under <prefix>/<bot id> and returns its webhook configuration,
+// preset with the router domain, the bot path and a random secret token.
+// The secret is enforced as soon as the bot is mounted, so requests without it are
+// rejected even before Register points Telegram at the router.
+// Adding a bot with the same ID replaces the previous one.
+func (r *Router) Add(b *Bot) *SetWebhook {
+	id := b.Raw().Id
+
+	webhook := b.Webhook().
+		Domain(r.domain).
+		Path(r.Path(id)).
+		SecretToken(g.String(rand.Text()))
+
+	r.bots.Insert(id, b)
+
+	return webhook
+}
+
+// Remove unmounts the bot with the given ID and returns it if it was mounted.
+// The webhook itself stays registered on Telegram's side; use Bot.DeleteWebhook to remove it.
+func (r *Router) Remove(id int64) g.Option[*Bot] {
+	return r.bots.Remove(id)
+}
+
+// Get returns the bot mounted with the given ID.
+func (r *Router) Get(id int64) g.Option[*Bot] {
+	return r.bots.Get(id)
+}
+
+// Bots returns all mounted bots.
+func (r *Router) Bots() g.Slice[*Bot] {
+	return r.bots.Values()
+}
+
+// ServeHTTP routes the request to the webhook handler of the bot mounted at the request path.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	rest := g.String(req.URL.Path)
+	if !rest.StartsWith(r.prefix + "/") {
+		http.NotFound(w, req)
+		return
+	}
+
+	id := rest.StripPrefix(r.prefix + "/").TryInt()
+	if id.IsErr() {
+		http.NotFound(w, req)
+		return
+	}
+
+	b := r.bots.Get(id.Ok().Int64())
+	if b.IsNone() {
+		http.Error(w, "unknown bot", http.StatusNotFound)
+		return
+	}
+
+	b.Some().WebhookHandler().ServeHTTP(w, req)
+}
+
+// Listen serves all mounted bots on addr until the process receives SIGINT or SIGTERM.
+// It is a shorthand for Start with a background context.
+func (r *Router) Listen(addr g.String) error {
+	return r.Start(context.Background(), addr)
+}
+
+// Start serves all mounted bots on addr until ctx is cancelled or the process receives SIGINT or SIGTERM.
+// On shutdown it stops accepting requests and waits for running handlers and jobs of every bot,
+// bounded by the drain timeout. A clean shutdown returns nil.
+func (r *Router) Start(ctx context.Context, addr g.String) error {
+	srv := &http.Server{Addr: addr.Std(), Handler: r, ReadHeaderTimeout: 10 * time.Second}
+
+	g.Println("router started")
+
+	return serve(ctx, srv, r.tlsCrt, r.tlsKey, func() error {
+		deadline := time.Now().Add(r.drain)
+
+		sctx, cancel := context.WithDeadline(context.Background(), deadline)
+		defer cancel()
+
+		if err := srv.Shutdown(sctx); err != nil {
+			return err
+		}
+
+		var errs []error
+		for _, b := range r.Bots() {
+			errs = append(errs, b.shutdown(time.Until(deadline), func() error { return nil }))
+		}
+
+		return errors.Join(errs...)
+	})
+}
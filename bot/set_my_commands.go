@@ -1,10 +1,12 @@
 package bot
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetMyCommands represents a request to set bot commands.
@@ -12,6 +14,7 @@ type SetMyCommands struct {
 	bot      *Bot
 	commands g.Slice[gotgbot.BotCommand]
 	opts     *gotgbot.SetMyCommandsOpts
+	retry    *retry.Policy
 }
 
 // AddCommand adds a command to the command list.
@@ -110,6 +113,12 @@ func (smc *SetMyCommands) APIURL(url g.String) *SetMyCommands {
 	return smc
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (smc *SetMyCommands) Retry(policy *retry.Policy) *SetMyCommands {
+	smc.retry = policy
+	return smc
+}
+
 // Send sets the bot commands.
 func (smc *SetMyCommands) Send() g.Result[bool] {
 	if len(smc.commands) == 0 {
@@ -120,5 +129,5 @@ func (smc *SetMyCommands) Send() g.Result[bool] {
 		return g.Err[bool](g.Errorf("too many commands: {} (maximum 100)", smc.commands.Len()))
 	}
 
-	return g.ResultOf(smc.bot.raw.SetMyCommands(smc.commands, smc.opts))
+	return g.ResultOf(smc.bot.raw.SetMyCommandsWithContext(retry.WithPolicy(context.Background(), smc.retry), smc.commands, smc.opts))
 }
@@ -1,17 +1,20 @@
 package bot
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 	"github.com/enetx/tg/types/rights"
 )
 
 // SetMyDefaultAdministratorRights represents a request to set the bot's default administrator rights.
 type SetMyDefaultAdministratorRights struct {
-	bot  *Bot
-	opts *gotgbot.SetMyDefaultAdministratorRightsOpts
+	bot   *Bot
+	opts  *gotgbot.SetMyDefaultAdministratorRightsOpts
+	retry *retry.Policy
 }
 
 // Rights sets the administrator rights.
@@ -48,7 +51,13 @@ func (smdar *SetMyDefaultAdministratorRights) APIURL(url g.String) *SetMyDefault
 	return smdar
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (smdar *SetMyDefaultAdministratorRights) Retry(policy *retry.Policy) *SetMyDefaultAdministratorRights {
+	smdar.retry = policy
+	return smdar
+}
+
 // Send sets the bot's default administrator rights.
 func (smdar *SetMyDefaultAdministratorRights) Send() g.Result[bool] {
-	return g.ResultOf(smdar.bot.Raw().SetMyDefaultAdministratorRights(smdar.opts))
+	return g.ResultOf(smdar.bot.Raw().SetMyDefaultAdministratorRightsWithContext(retry.WithPolicy(context.Background(), smdar.retry), smdar.opts))
 }
@@ -1,16 +1,19 @@
 package bot
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetMyDescription represents a request to set the bot's description.
 type SetMyDescription struct {
-	bot  *Bot
-	opts *gotgbot.SetMyDescriptionOpts
+	bot   *Bot
+	opts  *gotgbot.SetMyDescriptionOpts
+	retry *retry.Policy
 }
 
 // Description sets the bot's description text (0-512 characters).
@@ -53,7 +56,13 @@ func (smd *SetMyDescription) APIURL(url g.String) *SetMyDescription {
 	return smd
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (smd *SetMyDescription) Retry(policy *retry.Policy) *SetMyDescription {
+	smd.retry = policy
+	return smd
+}
+
 // Send sets the bot's description and returns the result.
 func (smd *SetMyDescription) Send() g.Result[bool] {
-	return g.ResultOf(smd.bot.raw.SetMyDescription(smd.opts))
+	return g.ResultOf(smd.bot.raw.SetMyDescriptionWithContext(retry.WithPolicy(context.Background(), smd.retry), smd.opts))
 }
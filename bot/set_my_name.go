@@ -1,16 +1,19 @@
 package bot
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetMyName represents a request to set the bot's name.
 type SetMyName struct {
-	bot  *Bot
-	opts *gotgbot.SetMyNameOpts
+	bot   *Bot
+	opts  *gotgbot.SetMyNameOpts
+	retry *retry.Policy
 }
 
 // Name sets the bot's name (0-64 characters).
@@ -53,7 +56,13 @@ func (smn *SetMyName) APIURL(url g.String) *SetMyName {
 	return smn
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (smn *SetMyName) Retry(policy *retry.Policy) *SetMyName {
+	smn.retry = policy
+	return smn
+}
+
 // Send sets the bot's name and returns the result.
 func (smn *SetMyName) Send() g.Result[bool] {
-	return g.ResultOf(smn.bot.raw.SetMyName(smn.opts))
+	return g.ResultOf(smn.bot.raw.SetMyNameWithContext(retry.WithPolicy(context.Background(), smn.retry), smn.opts))
 }
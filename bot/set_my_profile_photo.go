@@ -1,10 +1,12 @@
 package bot
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetMyProfilePhoto represents a request to set the bot's profile photo.
@@ -12,6 +14,7 @@ type SetMyProfilePhoto struct {
 	bot   *Bot
 	photo gotgbot.InputProfilePhoto
 	opts  *gotgbot.SetMyProfilePhotoOpts
+	retry *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -36,7 +39,13 @@ func (smpp *SetMyProfilePhoto) APIURL(url g.String) *SetMyProfilePhoto {
 	return smpp
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (smpp *SetMyProfilePhoto) Retry(policy *retry.Policy) *SetMyProfilePhoto {
+	smpp.retry = policy
+	return smpp
+}
+
 // Send sets the bot's profile photo and returns the result.
 func (smpp *SetMyProfilePhoto) Send() g.Result[bool] {
-	return g.ResultOf(smpp.bot.raw.SetMyProfilePhoto(smpp.photo, smpp.opts))
+	return g.ResultOf(smpp.bot.raw.SetMyProfilePhotoWithContext(retry.WithPolicy(context.Background(), smpp.retry), smpp.photo, smpp.opts))
 }
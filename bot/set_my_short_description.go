@@ -1,16 +1,19 @@
 package bot
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetMyShortDescription represents a request to set the bot's short description.
 type SetMyShortDescription struct {
-	bot  *Bot
-	opts *gotgbot.SetMyShortDescriptionOpts
+	bot   *Bot
+	opts  *gotgbot.SetMyShortDescriptionOpts
+	retry *retry.Policy
 }
 
 // Description sets the bot's short description text (0-120 characters).
@@ -53,7 +56,13 @@ func (smsd *SetMyShortDescription) APIURL(url g.String) *SetMyShortDescription {
 	return smsd
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (smsd *SetMyShortDescription) Retry(policy *retry.Policy) *SetMyShortDescription {
+	smsd.retry = policy
+	return smsd
+}
+
 // Send sets the bot's short description and returns the result.
 func (smsd *SetMyShortDescription) Send() g.Result[bool] {
-	return g.ResultOf(smsd.bot.raw.SetMyShortDescription(smsd.opts))
+	return g.ResultOf(smsd.bot.raw.SetMyShortDescriptionWithContext(retry.WithPolicy(context.Background(), smsd.retry), smsd.opts))
 }
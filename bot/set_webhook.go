@@ -1,7 +1,11 @@
 package bot
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
@@ -14,6 +18,9 @@ type SetWebhook struct {
 	path   g.String
 	opts   *gotgbot.SetWebhookOpts
 	cert   *os.File
+	tlsCrt g.String
+	tlsKey g.String
+	drain  time.Duration
 }
 
 func (w *SetWebhook) Certificate(path g.String) *SetWebhook {
@@ -38,8 +45,12 @@ func (w *SetWebhook) Path(s g.String) *SetWebhook {
 	return w
 }
 
+// SecretToken sets the secret token Telegram sends with every update. Bot.WebhookHandler
+// rejects requests without it from now on, even before the webhook is registered.
 func (w *SetWebhook) SecretToken(s g.String) *SetWebhook {
 	w.opts.SecretToken = s.Std()
+	w.bot.setSecret(s)
+
 	return w
 }
 
@@ -74,7 +85,59 @@ func (w *SetWebhook) Register() g.Result[bool] {
 
 	url := w.domain.StripSuffix("/") + "/" + w.path.StripPrefix("/")
 
-	return g.ResultOf(w.bot.Raw().SetWebhook(url.Std(), w.opts))
+	result := g.ResultOf(w.bot.Raw().SetWebhook(url.Std(), w.opts))
+	if result.IsOk() {
+		w.bot.setSecret(g.String(w.opts.SecretToken))
+	}
+
+	return result
+}
+
+// TLS configures Start and Listen to serve HTTPS using the given certificate and private key files.
+func (w *SetWebhook) TLS(certFile, keyFile g.String) *SetWebhook {
+	w.tlsCrt = certFile
+	w.tlsKey = keyFile
+
+	return w
+}
+
+// DrainTimeout sets how long Start waits for running handlers and scheduled
+// jobs that are already running once shutdown begins.
+func (w *SetWebhook) DrainTimeout(duration time.Duration) *SetWebhook {
+	w.drain = duration
+	return w
+}
+
+// Listen registers the webhook and serves updates on addr until the process receives SIGINT or SIGTERM.
+// It is a shorthand for Start with a background context.
+func (w *SetWebhook) Listen(addr g.String) error {
+	return w.Start(context.Background(), addr)
+}
+
+// Start registers the webhook and serves updates on addr until ctx is cancelled or the process
+// receives SIGINT or SIGTERM. Requests are accepted only on the configured path and are verified
+// by Bot.WebhookHandler. On shutdown it stops accepting requests and waits for running handlers
+// and jobs, bounded by the drain timeout. A clean shutdown returns nil.
+func (w *SetWebhook) Start(ctx context.Context, addr g.String) error {
+	if result := w.Register(); result.IsErr() {
+		return fmt.Errorf("failed to register webhook: %w", result.Err())
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/"+w.path.StripPrefix("/").Std(), w.bot.WebhookHandler())
+
+	srv := &http.Server{Addr: addr.Std(), Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	g.Println("bot started")
+
+	return serve(ctx, srv, w.tlsCrt, w.tlsKey, func() error {
+		return w.bot.shutdown(w.drain, func() error {
+			sctx, cancel := context.WithTimeout(context.Background(), w.drain)
+			defer cancel()
+
+			return srv.Shutdown(sctx)
+		})
+	})
 }
 
 func (w *SetWebhook) Opts() *gotgbot.SetWebhookOpts {
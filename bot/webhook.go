@@ -0,0 +1,117 @@
+package bot
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/g"
+)
+
+const (
+	// secretTokenHeader is the header Telegram uses to deliver the webhook secret token.
+	secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+	// maxWebhookBodySize limits the size of an incoming webhook request body.
+	maxWebhookBodySize = 1 << 20
+)
+
+// WebhookHandler returns an http.Handler that receives Telegram updates for the bot.
+//
+// The handler only accepts POST requests, verifies the secret token configured via
+// SetWebhook.SecretToken, limits the request body size and dispatches the decoded update.
+// It responds with 405 for other methods, 401 for a missing or wrong secret, 413 for oversized
+// bodies and 400 for malformed updates. Errors returned by handlers do not change the 200
+// response, so Telegram does not redeliver updates the bot has already processed.
+func (b *Bot) WebhookHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !b.validSecret(r.Header.Get(secretTokenHeader)) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+
+		var update gotgbot.Update
+		if err := json.Unmarshal(body, &update); err != nil {
+			http.Error(w, "invalid update", http.StatusBadRequest)
+			return
+		}
+
+		b.dispatcher.ProcessUpdate(b.Raw(), &update, nil)
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// validSecret reports whether token matches the configured webhook secret.
+// When no secret is configured, every request is accepted.
+func (b *Bot) validSecret(token string) bool {
+	b.mu.RLock()
+	secret := b.secret
+	b.mu.RUnlock()
+
+	if secret.IsEmpty() {
+		return true
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), secret.Bytes()) == 1
+}
+
+// setSecret stores the webhook secret token checked by WebhookHandler.
+func (b *Bot) setSecret(secret g.String) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.secret = secret
+}
+
+// serve runs srv until ctx is cancelled or the process receives SIGINT or SIGTERM, then calls shutdown.
+// When certFile is set, the server uses TLS with the given certificate and key files.
+func serve(ctx context.Context, srv *http.Server, certFile, keyFile g.String, shutdown func() error) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errc := make(chan error, 1)
+	go func() {
+		if certFile.IsEmpty() {
+			errc <- srv.ListenAndServe()
+			return
+		}
+
+		errc <- srv.ListenAndServeTLS(certFile.Std(), keyFile.Std())
+	}()
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("webhook server failed: %w", err)
+		}
+	case <-ctx.Done():
+	}
+
+	return shutdown()
+}
@@ -0,0 +1,224 @@
+// Package broadcast sends a message to many chats within Telegram's flood limits.
+//
+// A broadcast paces its requests, waits out "Too Many Requests" answers and keeps going when
+// single recipients fail. It can be paused, resumed and cancelled while it runs, and its report
+// classifies the failed recipients:
+//
+//	run := b.Broadcast(slices.Values(subscribers)).
+//		Message(func(c *ctx.Context) error {
+//			return c.SendMessage("Version 2.0 is out!").Send().Err()
+//		}).
+//		OnInactive(func(chatID int64, reason broadcast.Reason) { store.Deactivate(chatID) }).
+//		Start(context.Background())
+//
+//	report := run.Wait()
+//	log.Printf("sent %d, blocked %d", report.Sent, report.Count(broadcast.Blocked))
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"time"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/core"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/tgerr"
+)
+
+// ErrNoMessage is reported for every recipient of a broadcast started without Message.
+var ErrNoMessage = errors.New("broadcast has no message, set one with Message")
+
+// Reason classifies why a message could not be delivered to a recipient.
+type Reason int
+
+const (
+	// Other is any failure not covered by the other reasons.
+	Other Reason = iota
+
+	// Blocked means the user blocked the bot, or the bot was removed from the group or channel.
+	Blocked
+
+	// Deactivated means the user deleted their account.
+	Deactivated
+
+	// ChatNotFound means the chat does not exist or the bot never talked to the user.
+	ChatNotFound
+
+	// Flood means Telegram kept answering "Too Many Requests" after all retries.
+	Flood
+)
+
+// String returns the name of the reason.
+func (r Reason) String() string {
+	switch r {
+	case Blocked:
+		return "blocked"
+	case Deactivated:
+		return "deactivated"
+	case ChatNotFound:
+		return "chat not found"
+	case Flood:
+		return "flood"
+	default:
+		return "other"
+	}
+}
+
+// Inactive reports whether the recipient can no longer be reached and should not get
+// further broadcasts: it blocked the bot, was deactivated or does not exist.
+func (r Reason) Inactive() bool {
+	return r == Blocked || r == Deactivated || r == ChatNotFound
+}
+
+// Classify returns the reason for a failed send.
+func Classify(err error) Reason {
+	err = tgerr.Classify(err)
+
+	switch {
+	case errors.Is(err, tgerr.ErrBotBlocked), errors.Is(err, tgerr.ErrBotKicked):
+		return Blocked
+	case errors.Is(err, tgerr.ErrUserDeactivated):
+		return Deactivated
+	case errors.Is(err, tgerr.ErrChatNotFound), errors.Is(err, tgerr.ErrUserNotFound):
+		return ChatNotFound
+	case errors.Is(err, tgerr.ErrTooManyRequests):
+		return Flood
+	default:
+		return Other
+	}
+}
+
+// Failure is a recipient the message could not be delivered to.
+type Failure struct {
+	ChatID int64
+	Reason Reason
+	Err    error
+}
+
+// Progress is the state of a running broadcast.
+type Progress struct {
+	Done    int           // Recipients processed so far
+	Sent    int           // Recipients the message was delivered to
+	Failed  int           // Recipients the message could not be delivered to
+	Elapsed time.Duration // Time since the broadcast started
+}
+
+// Report is the outcome of a finished broadcast.
+type Report struct {
+	Progress
+	Failures  g.Slice[Failure] // Failed recipients in the order they failed
+	Cancelled bool             // Whether the broadcast was cancelled before reaching every recipient
+}
+
+// Count returns the number of recipients that failed for reason.
+func (r Report) Count(reason Reason) int {
+	return r.Failures.Iter().Filter(func(f Failure) bool { return f.Reason == reason }).Count().Std()
+}
+
+// Chats returns the IDs of the recipients that failed for reason.
+func (r Report) Chats(reason Reason) g.Slice[int64] {
+	chats := g.NewSlice[int64]()
+
+	for _, f := range r.Failures {
+		if f.Reason == reason {
+			chats.Push(f.ChatID)
+		}
+	}
+
+	return chats
+}
+
+// Broadcast configures a message sent to many chats. Create it with New or Bot.Broadcast.
+type Broadcast struct {
+	bot        core.BotAPI
+	recipients iter.Seq[int64]
+	message    func(c *ctx.Context) error
+	n          int
+	per        time.Duration
+	workers    int
+	retries    int
+	onProgress func(Progress)
+	onInactive func(chatID int64, reason Reason)
+}
+
+// New returns a broadcast to the given chats. By default it sends 25 messages per second,
+// just below Telegram's global limit, with 8 requests in flight, and retries a recipient
+// up to 3 times after "Too Many Requests".
+func New(bot core.BotAPI, recipients iter.Seq[int64]) *Broadcast {
+	return &Broadcast{
+		bot:        bot,
+		recipients: recipients,
+		n:          25,
+		per:        time.Second,
+		workers:    8,
+		retries:    3,
+	}
+}
+
+// Message sets the function that sends the message to a recipient. It gets a context bound to the
+// recipient's chat, see ctx.Detached, and returns the error of the send.
+func (b *Broadcast) Message(fn func(c *ctx.Context) error) *Broadcast {
+	b.message = fn
+	return b
+}
+
+// Rate sets how many recipients are sent to per interval. A non-positive n disables pacing.
+func (b *Broadcast) Rate(n int, per time.Duration) *Broadcast {
+	b.n = n
+	b.per = per
+
+	return b
+}
+
+// Workers sets how many recipients are sent to concurrently.
+func (b *Broadcast) Workers(n int) *Broadcast {
+	b.workers = max(n, 1)
+	return b
+}
+
+// FloodRetries sets how many times a recipient is retried after "Too Many Requests".
+// Every such answer pauses the whole broadcast for the advised delay.
+func (b *Broadcast) FloodRetries(n int) *Broadcast {
+	b.retries = max(n, 0)
+	return b
+}
+
+// OnProgress sets a function called after every recipient with the progress so far.
+// Calls are not concurrent and come in order.
+func (b *Broadcast) OnProgress(fn func(Progress)) *Broadcast {
+	b.onProgress = fn
+	return b
+}
+
+// OnInactive sets a function called for every recipient that can no longer be reached,
+// see Reason.Inactive, e.g. to mark blocked users inactive in the application's store.
+func (b *Broadcast) OnInactive(fn func(chatID int64, reason Reason)) *Broadcast {
+	b.onInactive = fn
+	return b
+}
+
+// Start starts sending in the background and returns the running broadcast.
+// Cancelling std cancels the broadcast like Cancel: sends in flight complete.
+func (b *Broadcast) Start(std context.Context) *Run {
+	stop, cancel := context.WithCancel(std)
+
+	r := &Run{
+		b:       b,
+		std:     context.WithoutCancel(std),
+		stop:    stop,
+		cancel:  cancel,
+		started: time.Now(),
+		done:    make(chan struct{}),
+	}
+
+	go r.run()
+
+	return r
+}
+
+// Send sends to every recipient and returns the report. See Start.
+func (b *Broadcast) Send(std context.Context) Report {
+	return b.Start(std).Wait()
+}
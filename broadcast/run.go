@@ -0,0 +1,275 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/tgerr"
+)
+
+// Run is a broadcast in progress.
+type Run struct {
+	b       *Broadcast
+	std     context.Context    // Context of the sends, not cancelled, so that sends in flight complete
+	stop    context.Context    // Cancelled to stop handing out recipients
+	cancel  context.CancelFunc // Cancels stop
+	started time.Time
+	done    chan struct{}
+
+	mu        sync.Mutex
+	progress  Progress
+	failures  g.Slice[Failure]
+	paused    chan struct{} // Closed by Resume; nil while not paused
+	flood     time.Time     // Sending is paused until then after "Too Many Requests"
+	cancelled bool
+	report    Report
+
+	notify sync.Mutex // Serializes progress and inactive callbacks
+}
+
+// Pause stops sending to further recipients until Resume. Sends in flight complete.
+func (r *Run) Pause() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.paused == nil {
+		r.paused = make(chan struct{})
+	}
+}
+
+// Resume continues a paused broadcast.
+func (r *Run) Resume() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.paused != nil {
+		close(r.paused)
+		r.paused = nil
+	}
+}
+
+// Paused reports whether the broadcast is paused.
+func (r *Run) Paused() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.paused != nil
+}
+
+// Cancel stops the broadcast. Sends in flight complete, and the remaining recipients are skipped.
+func (r *Run) Cancel() {
+	r.mu.Lock()
+	r.cancelled = true
+	r.mu.Unlock()
+
+	r.cancel()
+}
+
+// Progress returns the progress so far.
+func (r *Run) Progress() Progress {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p := r.progress
+	p.Elapsed = time.Since(r.started)
+
+	return p
+}
+
+// Done returns a channel that is closed when the broadcast is finished.
+func (r *Run) Done() <-chan struct{} {
+	return r.done
+}
+
+// Wait waits for the broadcast to finish and returns its report.
+func (r *Run) Wait() Report {
+	<-r.done
+	return r.report
+}
+
+// run hands the recipients to the workers at the configured rate and builds the report.
+func (r *Run) run() {
+	defer close(r.done)
+	defer r.cancel()
+
+	jobs := make(chan int64)
+
+	var wg sync.WaitGroup
+	for range r.b.workers {
+		wg.Go(func() {
+			for id := range jobs {
+				r.deliver(id)
+			}
+		})
+	}
+
+	next := time.Now()
+
+	for id := range r.b.recipients {
+		at, ok := r.slot(next)
+		if !ok {
+			break
+		}
+
+		next = at
+
+		select {
+		case jobs <- id:
+		case <-r.stop.Done():
+		}
+
+		if r.stop.Err() != nil {
+			break
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.progress.Elapsed = time.Since(r.started)
+	r.report = Report{
+		Progress:  r.progress,
+		Failures:  r.failures,
+		Cancelled: r.cancelled || r.stop.Err() != nil,
+	}
+}
+
+// slot waits until the next recipient may be sent to, respecting pauses, flood waits and the rate,
+// and returns the earliest time of the following slot. It returns false once the broadcast is cancelled.
+func (r *Run) slot(next time.Time) (time.Time, bool) {
+	for {
+		if !r.gate() {
+			return next, false
+		}
+
+		r.mu.Lock()
+		at := next
+		if r.flood.After(at) {
+			at = r.flood
+		}
+		r.mu.Unlock()
+
+		if d := time.Until(at); d > 0 {
+			if !r.sleep(d) {
+				return next, false
+			}
+
+			continue
+		}
+
+		if r.b.n <= 0 || r.b.per <= 0 {
+			return time.Now(), true
+		}
+
+		return time.Now().Add(r.b.per / time.Duration(r.b.n)), true
+	}
+}
+
+// gate blocks while the broadcast is paused. It returns false once the broadcast is cancelled.
+func (r *Run) gate() bool {
+	for {
+		r.mu.Lock()
+		paused := r.paused
+		r.mu.Unlock()
+
+		if paused == nil {
+			return r.stop.Err() == nil
+		}
+
+		select {
+		case <-paused:
+		case <-r.stop.Done():
+			return false
+		}
+	}
+}
+
+// sleep waits for d. It returns false if the broadcast is cancelled in the meantime.
+func (r *Run) sleep(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-r.stop.Done():
+		return false
+	}
+}
+
+// deliver sends the message to a recipient, retrying after "Too Many Requests", and records the outcome.
+func (r *Run) deliver(id int64) {
+	var err error
+
+	for attempt := 0; ; attempt++ {
+		err = r.send(id)
+
+		var flood *tgerr.FloodWait
+		if !errors.As(tgerr.Classify(err), &flood) {
+			break
+		}
+
+		r.mu.Lock()
+		if until := time.Now().Add(flood.RetryAfter); until.After(r.flood) {
+			r.flood = until
+		}
+		r.mu.Unlock()
+
+		if attempt >= r.b.retries || !r.gate() || !r.sleep(flood.RetryAfter) {
+			break
+		}
+	}
+
+	r.record(id, err)
+}
+
+// send sends the message to a recipient.
+func (r *Run) send(id int64) error {
+	if r.b.message == nil {
+		return ErrNoMessage
+	}
+
+	c := ctx.Detached(r.b.bot).Chat(id)
+	c.SetStd(r.std)
+
+	return r.b.message(c)
+}
+
+// record adds the outcome of a recipient to the progress and runs the callbacks.
+func (r *Run) record(id int64, err error) {
+	r.notify.Lock()
+	defer r.notify.Unlock()
+
+	r.mu.Lock()
+
+	r.progress.Done++
+
+	var failure Failure
+	if err == nil {
+		r.progress.Sent++
+	} else {
+		r.progress.Failed++
+		failure = Failure{ChatID: id, Reason: Classify(err), Err: err}
+		r.failures.Push(failure)
+	}
+
+	p := r.progress
+	p.Elapsed = time.Since(r.started)
+
+	r.mu.Unlock()
+
+	if err != nil && failure.Reason.Inactive() && r.b.onInactive != nil {
+		r.b.onInactive(id, failure.Reason)
+	}
+
+	if r.b.onProgress != nil {
+		r.b.onProgress(p)
+	}
+}
@@ -0,0 +1,395 @@
+// Package callback packs typed values into the callback data of inline keyboard buttons.
+//
+// A Codec encodes the exported fields of a struct into compact callback data, such as "vote:16:1"
+// for an upvote of post 42, and decodes it back when the button is pressed. A signed codec appends
+// an HMAC, so users cannot forge callback data with values the bot never sent:
+//
+//	type Vote struct {
+//		PostID int64
+//		Up     bool
+//	}
+//
+//	votes := callback.New[Vote]("vote").Sign(secret)
+//
+//	markup := keyboard.Inline().
+//		Data("👍", votes, Vote{PostID: 42, Up: true}).
+//		Data("👎", votes, Vote{PostID: 42})
+//
+//	b.On.Callback.Data(votes, func(c *ctx.Context, v Vote) error {
+//		return c.AnswerCallbackQuery(g.Format("Voted for {}", v.PostID)).Send().Err()
+//	})
+package callback
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/keyboard"
+)
+
+// MaxLen is the maximum length of callback data accepted by Telegram, in bytes.
+const MaxLen = keyboard.MaxCallbackLen
+
+// sigLen is the number of HMAC bytes kept in signed callback data.
+const sigLen = 9
+
+var (
+	// ErrPrefix is returned by codecs whose prefix contains a colon, the field separator.
+	ErrPrefix = errors.New("callback prefix must not contain colons")
+
+	// ErrUnsupported is returned for types with fields that cannot be encoded.
+	// Supported are strings, booleans, integers and floats.
+	ErrUnsupported = errors.New("unsupported callback data type")
+
+	// ErrTooLong is returned when the encoded data exceeds MaxLen and the codec has no Storage.
+	ErrTooLong = keyboard.ErrTooLong
+
+	// ErrMismatch is returned when decoding data that was not encoded by the codec.
+	ErrMismatch = errors.New("callback data does not match the codec prefix")
+
+	// ErrMalformed is returned when the data has the codec prefix but cannot be decoded.
+	ErrMalformed = errors.New("malformed callback data")
+
+	// ErrSignature is returned by a signed codec when the signature is missing or invalid.
+	ErrSignature = errors.New("invalid callback data signature")
+
+	// ErrType is returned by EncodeValue and Bind for values and handlers of another type than the codec.
+	ErrType = errors.New("type does not match the codec")
+)
+
+// escaper and unescaper protect the field separator inside string fields.
+var (
+	escaper   = strings.NewReplacer("%", "%25", ":", "%3A")
+	unescaper = strings.NewReplacer("%3A", ":", "%25", "%")
+)
+
+// Binder binds untyped handlers to a codec, see Codec.Bind and Callback.Data.
+type Binder interface {
+	// Bind returns the handler for fn, which must be a func(*ctx.Context, T) error for the codec type T.
+	Bind(fn any) g.Result[Handler]
+}
+
+var (
+	_ keyboard.Codec = (*Codec[struct{}])(nil)
+	_ Binder         = (*Codec[struct{}])(nil)
+)
+
+// Handler is a typed callback handler bound to a codec, see Codec.Handle and Callback.Data.
+type Handler interface {
+	// Match reports whether data was encoded by the codec of the handler.
+	Match(data g.String) bool
+
+	// Handle decodes the callback data of the update and calls the typed handler.
+	Handle(c *ctx.Context) error
+}
+
+// Codec encodes values of type T into callback data and decodes them back.
+//
+// The data is the prefix followed by the fields in declaration order, separated by colons.
+// Integers are written in base 36. Unexported fields and fields tagged `callback:"-"` are skipped.
+// Since fields are positional, reordering them invalidates buttons sent earlier.
+// T may also be a single string, boolean or number.
+type Codec[T any] struct {
+	prefix  g.String
+	key     []byte
+	storage *Storage
+	fields  []int // Indexes of the encoded struct fields, nil if T is not a struct
+	err     error
+}
+
+// New returns a codec for T whose data starts with prefix. The prefix tells codecs apart
+// and must not contain colons; otherwise Encode and Decode fail with ErrPrefix.
+func New[T any](prefix g.String) *Codec[T] {
+	c := &Codec[T]{prefix: prefix}
+
+	if prefix.Contains(":") {
+		c.err = fmt.Errorf("%w: %q", ErrPrefix, prefix)
+		return c
+	}
+
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		if !supported(typ.Kind()) {
+			c.err = fmt.Errorf("%w: %s", ErrUnsupported, typ)
+		}
+
+		return c
+	}
+
+	c.fields = make([]int, 0, typ.NumField())
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() || field.Tag.Get("callback") == "-" {
+			continue
+		}
+
+		if !supported(field.Type.Kind()) {
+			c.err = fmt.Errorf("%w: field %s of type %s", ErrUnsupported, field.Name, field.Type)
+			break
+		}
+
+		c.fields = append(c.fields, i)
+	}
+
+	return c
+}
+
+// Sign makes the codec append an HMAC-SHA256 of the data, keyed with key, and reject data
+// without a valid one. The signature takes 13 of the 64 bytes.
+func (c *Codec[T]) Sign(key []byte) *Codec[T] {
+	c.key = key
+	return c
+}
+
+// Storage makes Encode put data over MaxLen into storage and return its token instead of failing.
+// The storage must be attached to the bot, see Bot.CallbackStorage.
+func (c *Codec[T]) Storage(storage *Storage) *Codec[T] {
+	c.storage = storage
+	return c
+}
+
+// Prefix returns the prefix of the codec.
+func (c *Codec[T]) Prefix() g.String { return c.prefix }
+
+// Encode packs v into callback data.
+func (c *Codec[T]) Encode(v T) g.Result[g.String] {
+	if c.err != nil {
+		return g.Err[g.String](c.err)
+	}
+
+	var b strings.Builder
+	b.WriteString(c.prefix.Std())
+
+	for value := range c.values(reflect.ValueOf(&v).Elem()) {
+		b.WriteByte(':')
+		b.WriteString(encode(value))
+	}
+
+	if c.key != nil {
+		sig := c.sign(b.String())
+		b.WriteByte(':')
+		b.WriteString(sig)
+	}
+
+	if b.Len() > MaxLen {
+		if c.storage != nil {
+			return c.storage.Shorten(g.String(b.String()))
+		}
+
+		return g.Err[g.String](fmt.Errorf("%w: %d bytes", ErrTooLong, b.Len()))
+	}
+
+	return g.Ok(g.String(b.String()))
+}
+
+// Decode unpacks callback data encoded by the codec.
+func (c *Codec[T]) Decode(data g.String) g.Result[T] {
+	if c.err != nil {
+		return g.Err[T](c.err)
+	}
+
+	if !c.Match(data) {
+		return g.Err[T](ErrMismatch)
+	}
+
+	raw := data.Std()
+
+	if c.key != nil {
+		i := strings.LastIndexByte(raw, ':')
+		if i < len(c.prefix) || !hmac.Equal([]byte(raw[i+1:]), []byte(c.sign(raw[:i]))) {
+			return g.Err[T](ErrSignature)
+		}
+
+		raw = raw[:i]
+	}
+
+	var parts []string
+	if rest := raw[len(c.prefix):]; rest != "" {
+		parts = strings.Split(rest[1:], ":")
+	}
+
+	var v T
+
+	i := 0
+	for value := range c.values(reflect.ValueOf(&v).Elem()) {
+		if i >= len(parts) {
+			return g.Err[T](fmt.Errorf("%w: expected more fields", ErrMalformed))
+		}
+
+		if err := decode(parts[i], value); err != nil {
+			return g.Err[T](fmt.Errorf("%w: %w", ErrMalformed, err))
+		}
+
+		i++
+	}
+
+	if i != len(parts) {
+		return g.Err[T](fmt.Errorf("%w: expected %d fields, got %d", ErrMalformed, i, len(parts)))
+	}
+
+	return g.Ok(v)
+}
+
+// Match reports whether data starts with the prefix of the codec. It does not verify the signature.
+func (c *Codec[T]) Match(data g.String) bool {
+	if !data.StartsWith(c.prefix) {
+		return false
+	}
+
+	return len(data) == len(c.prefix) || data[len(c.prefix)] == ':'
+}
+
+// Handle binds fn to the codec. Data that fails to decode, e.g. because
+// of a forged signature, does not reach fn and is reported as the handler error.
+func (c *Codec[T]) Handle(fn func(c *ctx.Context, v T) error) Handler {
+	return handler[T]{codec: c, fn: fn}
+}
+
+// Bind binds fn to the codec like Handle, for Callback.Data. It fails with ErrType
+// if fn is not a func(*ctx.Context, T) error.
+func (c *Codec[T]) Bind(fn any) g.Result[Handler] {
+	typed, ok := fn.(func(*ctx.Context, T) error)
+	if !ok {
+		return g.Err[Handler](fmt.Errorf("%w: handler is %T, want %T", ErrType, fn, typed))
+	}
+
+	return g.Ok(c.Handle(typed))
+}
+
+// EncodeValue encodes v like Encode, for keyboard.InlineKeyboard.Data. It fails with ErrType
+// if v is not a T.
+func (c *Codec[T]) EncodeValue(v any) g.Result[g.String] {
+	typed, ok := v.(T)
+	if !ok {
+		return g.Err[g.String](fmt.Errorf("%w: value is %T, want %T", ErrType, v, typed))
+	}
+
+	return c.Encode(typed)
+}
+
+// values yields the settable values of the encoded fields of v.
+func (c *Codec[T]) values(v reflect.Value) iter.Seq[reflect.Value] {
+	return func(yield func(reflect.Value) bool) {
+		if c.fields == nil {
+			yield(v)
+			return
+		}
+
+		for _, i := range c.fields {
+			if !yield(v.Field(i)) {
+				return
+			}
+		}
+	}
+}
+
+// sign returns the truncated, base64 encoded HMAC of data.
+func (c *Codec[T]) sign(data string) string {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(data))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:sigLen])
+}
+
+type handler[T any] struct {
+	codec *Codec[T]
+	fn    func(c *ctx.Context, v T) error
+}
+
+func (h handler[T]) Match(data g.String) bool { return h.codec.Match(data) }
+
+func (h handler[T]) Handle(c *ctx.Context) error {
+	if c.Callback == nil {
+		return errors.New("update has no callback query")
+	}
+
+	v, err := h.codec.Decode(g.String(c.Callback.Data)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to decode callback data: %w", err)
+	}
+
+	return h.fn(c, v)
+}
+
+// supported reports whether values of kind can be encoded.
+func supported(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// encode writes a single field value.
+func encode(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return escaper.Replace(v.String())
+	case reflect.Bool:
+		if v.Bool() {
+			return "1"
+		}
+
+		return "0"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 36)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 36)
+	default:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	}
+}
+
+// decode parses a single field value into v.
+func decode(s string, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(unescaper.Replace(s))
+	case reflect.Bool:
+		switch s {
+		case "1":
+			v.SetBool(true)
+		case "0":
+			v.SetBool(false)
+		default:
+			return fmt.Errorf("invalid boolean %q", s)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 36, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 36, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(n)
+	default:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(f)
+	}
+
+	return nil
+}
@@ -0,0 +1,271 @@
+package callback
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/core"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/keyboard"
+)
+
+// TokenPrefix starts the short tokens that replace stored callback data.
+const TokenPrefix = "~"
+
+// tokenLen is the length of a token: the prefix and 16 base64 encoded bytes,
+// 12 bytes of the data hash followed by a 4 byte checksum of them.
+const tokenLen = len(TokenPrefix) + 22
+
+// tokenTag separates the checksum of tokens from other uses of the hash.
+const tokenTag = "tg/callback/token"
+
+// storageGroup is the dispatcher group of the token resolver. It runs before all other
+// handlers, including pending Ask and Wait calls, so they all see the stored data.
+const storageGroup = math.MinInt
+
+// sweepInterval is how often expired payloads are released by Memory.
+const sweepInterval = time.Minute
+
+var _ keyboard.Storage = (*Storage)(nil)
+
+// Store persists callback data that does not fit into a button. Implementations must be safe
+// for concurrent use and treat expired payloads as missing.
+type Store interface {
+	// Load returns the data stored under token, or None if there is none.
+	Load(std context.Context, token g.String) g.Result[g.Option[g.String]]
+
+	// Save stores data under token until expires, replacing a previous payload and expiry.
+	Save(std context.Context, token g.String, data g.String, expires time.Time) error
+}
+
+// Storage keeps callback data over 64 bytes in a Store and puts a short token into the button
+// instead. Attach it to the bot to resolve tokens before callback handlers see the data:
+//
+//	payloads := callback.NewStorage(callback.NewMemory()).TTL(7 * 24 * time.Hour)
+//	b.CallbackStorage(payloads)
+//
+//	markup := keyboard.Inline().Storage(payloads).Text("Details", longData)
+//
+// Handlers then match and read the original data as if it were in the button.
+type Storage struct {
+	store     Store
+	ttl       time.Duration
+	now       func() time.Time
+	onExpired func(c *ctx.Context) error
+}
+
+// NewStorage returns a storage that keeps payloads in store for 24 hours.
+func NewStorage(store Store) *Storage {
+	return &Storage{store: store, ttl: 24 * time.Hour, now: time.Now}
+}
+
+// TTL sets how long a payload is kept after its button was last built. Pressing a button whose
+// payload expired calls the OnExpired handler. A non-positive ttl keeps payloads forever.
+func (s *Storage) TTL(ttl time.Duration) *Storage {
+	s.ttl = ttl
+	return s
+}
+
+// Clock sets the function used to read the current time, for tests.
+func (s *Storage) Clock(now func() time.Time) *Storage {
+	s.now = now
+	return s
+}
+
+// OnExpired sets the handler for presses of buttons whose payload is gone.
+// By default the callback query is answered with "This button has expired".
+func (s *Storage) OnExpired(fn func(c *ctx.Context) error) *Storage {
+	s.onExpired = fn
+	return s
+}
+
+// Shorten returns data unchanged if it fits into a button, and otherwise stores it and returns
+// its token. The token is derived from the data, so building the same button again reuses it
+// and extends the expiry.
+func (s *Storage) Shorten(data g.String) g.Result[g.String] {
+	return s.ShortenContext(context.Background(), data)
+}
+
+// ShortenContext is like Shorten, with std passed to the store.
+func (s *Storage) ShortenContext(std context.Context, data g.String) g.Result[g.String] {
+	if len(data) <= MaxLen {
+		return g.Ok(data)
+	}
+
+	token := tokenOf(data)
+
+	var expires time.Time
+	if s.ttl > 0 {
+		expires = s.now().Add(s.ttl)
+	}
+
+	if err := s.store.Save(std, token, data, expires); err != nil {
+		return g.Err[g.String](fmt.Errorf("failed to store callback data: %w", err))
+	}
+
+	return g.Ok(token)
+}
+
+// tokenOf returns the token of data.
+func tokenOf(data g.String) g.String {
+	sum := sha256.Sum256([]byte(data))
+	return g.String(TokenPrefix + base64.RawURLEncoding.EncodeToString(checksum(sum[:12])))
+}
+
+// checksum returns id followed by its 4 byte checksum.
+func checksum(id []byte) []byte {
+	sum := sha256.Sum256(append([]byte(tokenTag), id...))
+	return append(id[:len(id):len(id)], sum[:4]...)
+}
+
+// IsToken reports whether data is a token issued by a Storage. Other data is never taken for a token:
+// tokens carry a checksum, and contain no colons, which separate the prefix of codec data.
+func IsToken(data g.String) bool {
+	if len(data) != tokenLen || !data.StartsWith(TokenPrefix) {
+		return false
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(data[len(TokenPrefix):].Std())
+
+	return err == nil && len(raw) == 16 && bytes.Equal(raw, checksum(raw[:12]))
+}
+
+// Resolve returns the data stored under token, or None if it expired.
+func (s *Storage) Resolve(std context.Context, token g.String) g.Result[g.Option[g.String]] {
+	return s.store.Load(std, token)
+}
+
+// Attach registers the token resolver in the dispatcher of bot, see Bot.CallbackStorage.
+func (s *Storage) Attach(bot core.BotAPI) *Storage {
+	bot.Dispatcher().AddHandlerToGroup(resolver{s, bot}, storageGroup)
+	return s
+}
+
+// resolver replaces tokens in callback queries with their stored data.
+type resolver struct {
+	storage *Storage
+	bot     core.BotAPI
+}
+
+// CheckUpdate reports whether the update is a callback query carrying a token.
+func (r resolver) CheckUpdate(_ *gotgbot.Bot, ectx *ext.Context) bool {
+	q := ectx.CallbackQuery
+	return q != nil && IsToken(g.String(q.Data))
+}
+
+// HandleUpdate swaps the token for its data and lets the update continue to the other groups.
+// Updates with an expired token are answered by the OnExpired handler and go no further.
+func (r resolver) HandleUpdate(_ *gotgbot.Bot, ectx *ext.Context) error {
+	c := ctx.New(r.bot, ectx)
+
+	data, err := r.storage.Resolve(c.Std(), g.String(ectx.CallbackQuery.Data)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to load callback data: %w", err)
+	}
+
+	if payload, ok := data.Option(); ok {
+		ectx.CallbackQuery.Data = payload.Std()
+		return ext.ContinueGroups
+	}
+
+	if r.storage.onExpired != nil {
+		if err := r.storage.onExpired(c); err != nil {
+			return err
+		}
+
+		return ext.EndGroups
+	}
+
+	if err := c.AnswerCallbackQuery("This button has expired").Send().Err(); err != nil {
+		return err
+	}
+
+	return ext.EndGroups
+}
+
+// Name returns the name of the handler in the dispatcher.
+func (resolver) Name() string {
+	return "tg.callback_storage"
+}
+
+// Memory is a Store that keeps payloads in memory. Payloads are lost on restart
+// and are not shared between instances.
+type Memory struct {
+	mu       sync.Mutex
+	payloads map[g.String]payload
+	now      func() time.Time
+	swept    time.Time
+}
+
+type payload struct {
+	data    g.String
+	expires time.Time
+}
+
+// NewMemory returns an empty in-memory store.
+func NewMemory() *Memory {
+	return &Memory{payloads: make(map[g.String]payload), now: time.Now}
+}
+
+// Clock sets the function used to read the current time, for tests.
+func (m *Memory) Clock(now func() time.Time) *Memory {
+	m.now = now
+	return m
+}
+
+// Load returns the data stored under token, or None if there is none or it has expired.
+func (m *Memory) Load(_ context.Context, token g.String) g.Result[g.Option[g.String]] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p, ok := m.payloads[token]
+	if !ok || p.expired(m.now()) {
+		return g.Ok(g.None[g.String]())
+	}
+
+	return g.Ok(g.Some(p.data))
+}
+
+// Save stores data under token until expires. Expired payloads are released at most once a minute.
+func (m *Memory) Save(_ context.Context, token g.String, data g.String, expires time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+
+	if now.Sub(m.swept) >= sweepInterval {
+		m.swept = now
+
+		for key, p := range m.payloads {
+			if p.expired(now) {
+				delete(m.payloads, key)
+			}
+		}
+	}
+
+	m.payloads[token] = payload{data: data, expires: expires}
+
+	return nil
+}
+
+// Len returns the number of stored payloads, including expired ones not yet released.
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.payloads)
+}
+
+// expired reports whether the payload has expired at now.
+func (p payload) expired(now time.Time) bool {
+	return !p.expires.IsZero() && !now.Before(p.expires)
+}
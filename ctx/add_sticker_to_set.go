@@ -6,6 +6,7 @@ import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/file"
+	"github.com/enetx/tg/retry"
 )
 
 // AddStickerToSet represents a request to add a sticker to an existing set.
@@ -15,6 +16,7 @@ type AddStickerToSet struct {
 	name    g.String
 	sticker gotgbot.InputSticker
 	opts    *gotgbot.AddStickerToSetOpts
+	retry   *retry.Policy
 }
 
 // File sets the sticker file.
@@ -75,7 +77,13 @@ func (ats *AddStickerToSet) APIURL(url g.String) *AddStickerToSet {
 	return ats
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ats *AddStickerToSet) Retry(policy *retry.Policy) *AddStickerToSet {
+	ats.retry = policy
+	return ats
+}
+
 // Send adds the sticker to the set and returns the result.
 func (ats *AddStickerToSet) Send() g.Result[bool] {
-	return g.ResultOf(ats.ctx.Bot.Raw().AddStickerToSet(ats.userID, ats.name.Std(), ats.sticker, ats.opts))
+	return g.ResultOf(ats.ctx.Bot.Raw().AddStickerToSetWithContext(retry.WithPolicy(ats.ctx.Std(), ats.retry), ats.userID, ats.name.Std(), ats.sticker, ats.opts))
 }
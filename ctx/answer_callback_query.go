@@ -5,12 +5,14 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 type AnswerCallbackQuery struct {
-	ctx  *Context
-	text g.String
-	opts *gotgbot.AnswerCallbackQueryOpts
+	ctx   *Context
+	text  g.String
+	opts  *gotgbot.AnswerCallbackQueryOpts
+	retry *retry.Policy
 }
 
 // URL sets a URL to be opened by the user's client when the button is pressed.
@@ -53,8 +55,18 @@ func (acq *AnswerCallbackQuery) APIURL(url g.String) *AnswerCallbackQuery {
 	return acq
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (acq *AnswerCallbackQuery) Retry(policy *retry.Policy) *AnswerCallbackQuery {
+	acq.retry = policy
+	return acq
+}
+
 // Send sends the callback query answer and returns the result.
 func (acq *AnswerCallbackQuery) Send() g.Result[bool] {
 	acq.opts.Text = acq.text.Std()
-	return g.ResultOf(acq.ctx.Update.CallbackQuery.Answer(acq.ctx.Bot.Raw(), acq.opts))
+	return g.ResultOf(acq.ctx.Bot.Raw().AnswerCallbackQueryWithContext(
+		retry.WithPolicy(acq.ctx.Std(), acq.retry),
+		acq.ctx.Update.CallbackQuery.Id,
+		acq.opts,
+	))
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/inline"
+	"github.com/enetx/tg/retry"
 )
 
 // AnswerGuestQuery represents a request to reply to a received guest message.
@@ -14,6 +15,7 @@ type AnswerGuestQuery struct {
 	guestQueryID g.String
 	result       inline.QueryResult
 	opts         *gotgbot.AnswerGuestQueryOpts
+	retry        *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -38,9 +40,15 @@ func (agq *AnswerGuestQuery) APIURL(url g.String) *AnswerGuestQuery {
 	return agq
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (agq *AnswerGuestQuery) Retry(policy *retry.Policy) *AnswerGuestQuery {
+	agq.retry = policy
+	return agq
+}
+
 // Send answers the guest query and returns the result.
 func (agq *AnswerGuestQuery) Send() g.Result[*gotgbot.SentGuestMessage] {
-	return g.ResultOf(agq.ctx.Bot.Raw().AnswerGuestQuery(
+	return g.ResultOf(agq.ctx.Bot.Raw().AnswerGuestQueryWithContext(retry.WithPolicy(agq.ctx.Std(), agq.retry),
 		agq.guestQueryID.Std(),
 		agq.result.Build(),
 		agq.opts,
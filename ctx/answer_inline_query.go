@@ -7,6 +7,7 @@ import (
 	"github.com/enetx/g"
 	"github.com/enetx/g/ref"
 	"github.com/enetx/tg/inline"
+	"github.com/enetx/tg/retry"
 )
 
 // AnswerInlineQuery represents a request to answer an inline query.
@@ -15,6 +16,7 @@ type AnswerInlineQuery struct {
 	inlineQueryID g.String
 	results       g.Slice[gotgbot.InlineQueryResult]
 	opts          *gotgbot.AnswerInlineQueryOpts
+	retry         *retry.Policy
 }
 
 // AddResult adds a single result builder to the inline query.
@@ -105,7 +107,13 @@ func (aiq *AnswerInlineQuery) APIURL(url g.String) *AnswerInlineQuery {
 	return aiq
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (aiq *AnswerInlineQuery) Retry(policy *retry.Policy) *AnswerInlineQuery {
+	aiq.retry = policy
+	return aiq
+}
+
 // Send answers the inline query and returns the result.
 func (aiq *AnswerInlineQuery) Send() g.Result[bool] {
-	return g.ResultOf(aiq.ctx.Bot.Raw().AnswerInlineQuery(aiq.inlineQueryID.Std(), aiq.results, aiq.opts))
+	return g.ResultOf(aiq.ctx.Bot.Raw().AnswerInlineQueryWithContext(retry.WithPolicy(aiq.ctx.Std(), aiq.retry), aiq.inlineQueryID.Std(), aiq.results, aiq.opts))
 }
@@ -5,12 +5,14 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 type AnswerPreCheckoutQuery struct {
-	ctx  *Context
-	ok   bool
-	opts *gotgbot.AnswerPreCheckoutQueryOpts
+	ctx   *Context
+	ok    bool
+	opts  *gotgbot.AnswerPreCheckoutQueryOpts
+	retry *retry.Policy
 }
 
 // Ok marks the pre-checkout query as successful.
@@ -49,6 +51,12 @@ func (apcq *AnswerPreCheckoutQuery) APIURL(url g.String) *AnswerPreCheckoutQuery
 	return apcq
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (apcq *AnswerPreCheckoutQuery) Retry(policy *retry.Policy) *AnswerPreCheckoutQuery {
+	apcq.retry = policy
+	return apcq
+}
+
 // Send answers the pre-checkout query and returns the result.
 func (apcq *AnswerPreCheckoutQuery) Send() g.Result[bool] {
 	query := apcq.ctx.Update.PreCheckoutQuery
@@ -56,5 +64,10 @@ func (apcq *AnswerPreCheckoutQuery) Send() g.Result[bool] {
 		return g.Err[bool](g.Errorf("no precheckout query"))
 	}
 
-	return g.ResultOf(query.Answer(apcq.ctx.Bot.Raw(), apcq.ok, apcq.opts))
+	return g.ResultOf(apcq.ctx.Bot.Raw().AnswerPreCheckoutQueryWithContext(
+		retry.WithPolicy(apcq.ctx.Std(), apcq.retry),
+		query.Id,
+		apcq.ok,
+		apcq.opts,
+	))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // ShippingOptionBuilder helps build shipping options with prices.
@@ -40,6 +41,7 @@ type AnswerShippingQuery struct {
 	ok      bool
 	options g.Slice[gotgbot.ShippingOption]
 	opts    *gotgbot.AnswerShippingQueryOpts
+	retry   *retry.Policy
 }
 
 // Ok marks the shipping query as successful and sets shipping options.
@@ -99,6 +101,12 @@ func (asq *AnswerShippingQuery) APIURL(url g.String) *AnswerShippingQuery {
 	return asq
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (asq *AnswerShippingQuery) Retry(policy *retry.Policy) *AnswerShippingQuery {
+	asq.retry = policy
+	return asq
+}
+
 // Send answers the shipping query and returns the result.
 func (asq *AnswerShippingQuery) Send() g.Result[bool] {
 	query := asq.ctx.Update.ShippingQuery
@@ -110,5 +118,10 @@ func (asq *AnswerShippingQuery) Send() g.Result[bool] {
 		asq.opts.ShippingOptions = asq.options
 	}
 
-	return g.ResultOf(query.Answer(asq.ctx.Bot.Raw(), asq.ok, asq.opts))
+	return g.ResultOf(asq.ctx.Bot.Raw().AnswerShippingQueryWithContext(
+		retry.WithPolicy(asq.ctx.Std(), asq.retry),
+		query.Id,
+		asq.ok,
+		asq.opts,
+	))
 }
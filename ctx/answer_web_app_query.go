@@ -6,6 +6,7 @@ import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/inline"
+	"github.com/enetx/tg/retry"
 )
 
 // AnswerWebAppQuery represents a request to answer a web app query.
@@ -15,6 +16,7 @@ type AnswerWebAppQuery struct {
 	result        inline.QueryResult
 	opts          *gotgbot.AnswerWebAppQueryOpts
 	err           error
+	retry         *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -39,9 +41,15 @@ func (awaq *AnswerWebAppQuery) APIURL(url g.String) *AnswerWebAppQuery {
 	return awaq
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (awaq *AnswerWebAppQuery) Retry(policy *retry.Policy) *AnswerWebAppQuery {
+	awaq.retry = policy
+	return awaq
+}
+
 // Send answers the web app query and returns the result.
 func (awaq *AnswerWebAppQuery) Send() g.Result[*gotgbot.SentWebAppMessage] {
-	return g.ResultOf(awaq.ctx.Bot.Raw().AnswerWebAppQuery(
+	return g.ResultOf(awaq.ctx.Bot.Raw().AnswerWebAppQueryWithContext(retry.WithPolicy(awaq.ctx.Std(), awaq.retry),
 		awaq.webAppQueryID.Std(),
 		awaq.result.Build(),
 		awaq.opts,
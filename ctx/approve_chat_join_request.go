@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // ApproveChatJoinRequest represents a request to approve a chat join request.
@@ -13,6 +14,7 @@ type ApproveChatJoinRequest struct {
 	userID int64
 	opts   *gotgbot.ApproveChatJoinRequestOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -43,8 +45,18 @@ func (acjr *ApproveChatJoinRequest) APIURL(url g.String) *ApproveChatJoinRequest
 	return acjr
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (acjr *ApproveChatJoinRequest) Retry(policy *retry.Policy) *ApproveChatJoinRequest {
+	acjr.retry = policy
+	return acjr
+}
+
 // Send approves the chat join request and returns the result.
 func (acjr *ApproveChatJoinRequest) Send() g.Result[bool] {
-	chatID := acjr.chatID.UnwrapOr(acjr.ctx.EffectiveChat.Id)
-	return g.ResultOf(acjr.ctx.Bot.Raw().ApproveChatJoinRequest(chatID, acjr.userID, acjr.opts))
+	chatID, err := acjr.ctx.chat(acjr.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(acjr.ctx.Bot.Raw().ApproveChatJoinRequestWithContext(retry.WithPolicy(acjr.ctx.Std(), acjr.retry), chatID, acjr.userID, acjr.opts))
 }
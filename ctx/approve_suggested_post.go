@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // ApproveSuggestedPost represents a request to approve a suggested post.
@@ -13,6 +14,7 @@ type ApproveSuggestedPost struct {
 	chatID    g.Option[int64]
 	messageID g.Option[int64]
 	opts      *gotgbot.ApproveSuggestedPostOpts
+	retry     *retry.Policy
 }
 
 // ChatID sets the target direct messages chat ID.
@@ -61,10 +63,23 @@ func (asp *ApproveSuggestedPost) APIURL(url g.String) *ApproveSuggestedPost {
 	return asp
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (asp *ApproveSuggestedPost) Retry(policy *retry.Policy) *ApproveSuggestedPost {
+	asp.retry = policy
+	return asp
+}
+
 // Send approves the suggested post.
 func (asp *ApproveSuggestedPost) Send() g.Result[bool] {
-	chatID := asp.chatID.UnwrapOr(asp.ctx.EffectiveChat.Id)
-	messageID := asp.messageID.UnwrapOr(asp.ctx.EffectiveMessage.MessageId)
+	chatID, err := asp.ctx.chat(asp.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	messageID, err := asp.ctx.message(asp.messageID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
 
-	return g.ResultOf(asp.ctx.Bot.Raw().ApproveSuggestedPost(chatID, messageID, asp.opts))
+	return g.ResultOf(asp.ctx.Bot.Raw().ApproveSuggestedPostWithContext(retry.WithPolicy(asp.ctx.Std(), asp.retry), chatID, messageID, asp.opts))
 }
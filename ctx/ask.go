@@ -0,0 +1,361 @@
+package ctx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/core"
+	"github.com/enetx/tg/keyboard"
+)
+
+// askGroup is the dispatcher group of the handler that delivers answers. It runs before
+// all other groups, so that an answer is not handled as a regular update. Only the resolver
+// of stored callback data, see callback.Storage, runs earlier.
+const askGroup = math.MinInt + 1
+
+// DefaultAskTimeout is how long Ask and Wait wait for the answer unless Timeout sets otherwise.
+const DefaultAskTimeout = 10 * time.Minute
+
+// ErrAskNoUser is returned by Ask and Wait for updates without both a user and a chat.
+var ErrAskNoUser = errors.New("update has no user and chat to wait for")
+
+// AskTimeoutError is returned by Ask and Wait when no answer arrives within the timeout.
+type AskTimeoutError struct {
+	Timeout time.Duration // Timeout that expired
+}
+
+func (e *AskTimeoutError) Error() string {
+	return fmt.Sprintf("no answer within %s", e.Timeout)
+}
+
+// AskCancelError is returned by Ask and Wait when the user sends a cancel command, when a newer
+// Ask of the same user in the same chat replaces the pending one, or when the standard context of
+// the handler is cancelled, e.g. with cause ErrShutdown once the bot begins to shut down.
+type AskCancelError struct {
+	Command g.String // Cancel command without the slash, empty if the wait was not cancelled by the user
+	Context *Context // Update that cancelled the wait, e.g. to reply to the cancel command
+	Cause   error    // Cause of the cancelled standard context, nil if the wait was not cancelled by it
+}
+
+func (e *AskCancelError) Error() string {
+	switch {
+	case e.Cause != nil:
+		return fmt.Sprintf("wait cancelled: %v", e.Cause)
+	case e.Command.IsEmpty():
+		return "wait replaced by a newer one"
+	default:
+		return fmt.Sprintf("cancelled with /%s", e.Command)
+	}
+}
+
+func (e *AskCancelError) Unwrap() error { return e.Cause }
+
+// Filter reports whether an update is the expected answer.
+type Filter func(c *Context) bool
+
+// Ask represents a question to the user whose answer is awaited by the handler.
+type Ask struct {
+	ctx     *Context
+	prompt  g.Option[*SendMessage]
+	timeout time.Duration
+	cancel  g.Slice[g.String]
+}
+
+// Ask sends text to the chat of the update and waits for the answer of the same user in that chat.
+// The handler is suspended while other updates keep being dispatched, so Ask needs concurrent
+// update processing, which is the default. Every pending Ask holds one of the dispatcher's
+// routines, ext.DefaultMaxRoutines unless raised with MaxRoutines when building the bot, and
+// updates queue up once all of them are taken. The wait ends after DefaultAskTimeout, or as
+// soon as the bot begins to shut down.
+//
+//	answer := c.Ask("Your name?").Timeout(5 * time.Minute).Text()
+func (ctx *Context) Ask(text g.String) *Ask {
+	return &Ask{
+		ctx:     ctx,
+		prompt:  g.Some(ctx.SendMessage(text)),
+		timeout: DefaultAskTimeout,
+		cancel:  g.SliceOf[g.String]("cancel"),
+	}
+}
+
+// Wait waits for the next update of the same user in the chat of the update without sending a prompt.
+func (ctx *Context) Wait() *Ask {
+	return &Ask{ctx: ctx, timeout: DefaultAskTimeout, cancel: g.SliceOf[g.String]("cancel")}
+}
+
+// HTML sets the prompt's parse mode to HTML.
+func (a *Ask) HTML() *Ask {
+	if a.prompt.IsSome() {
+		a.prompt.Some().HTML()
+	}
+
+	return a
+}
+
+// Markdown sets the prompt's parse mode to MarkdownV2.
+func (a *Ask) Markdown() *Ask {
+	if a.prompt.IsSome() {
+		a.prompt.Some().Markdown()
+	}
+
+	return a
+}
+
+// Markup sets the reply markup of the prompt, e.g. an inline keyboard answered with a callback.
+func (a *Ask) Markup(kb keyboard.Keyboard) *Ask {
+	if a.prompt.IsSome() {
+		a.prompt.Some().Markup(kb)
+	}
+
+	return a
+}
+
+// ForceReply makes the client show a reply interface for the prompt.
+func (a *Ask) ForceReply() *Ask {
+	if a.prompt.IsSome() {
+		a.prompt.Some().ForceReply()
+	}
+
+	return a
+}
+
+// Timeout sets how long to wait for the answer before failing with *AskTimeoutError, DefaultAskTimeout
+// if not set. A zero duration waits as long as the standard context of the handler is live.
+func (a *Ask) Timeout(duration time.Duration) *Ask {
+	a.timeout = duration
+	return a
+}
+
+// Cancel sets the commands, without the slash, that abort the wait with *AskCancelError.
+// The default is /cancel; call Cancel without arguments to disable cancellation.
+func (a *Ask) Cancel(commands ...g.String) *Ask {
+	a.cancel = commands
+	return a
+}
+
+// Text waits for a text message and returns its text.
+func (a *Ask) Text() g.Result[g.String] {
+	answer := a.Expect(func(c *Context) bool { return c.EffectiveMessage != nil && c.EffectiveMessage.Text != "" })
+	if answer.IsErr() {
+		return g.Err[g.String](answer.Err())
+	}
+
+	return g.Ok(g.String(answer.Ok().EffectiveMessage.Text))
+}
+
+// Expect sends the prompt and waits for the next update of the same user in the same chat that
+// matches filter; a nil filter matches any update. The answer is not passed to other handlers,
+// updates that do not match are dispatched as usual. The returned context sends its requests
+// with the standard context of the asking handler.
+func (a *Ask) Expect(filter Filter) g.Result[*Context] {
+	key, ok := askKeyOf(a.ctx)
+	if !ok {
+		return g.Err[*Context](ErrAskNoUser)
+	}
+
+	std := a.ctx.Std()
+	if a.timeout > 0 {
+		var cancel context.CancelFunc
+		std, cancel = context.WithTimeout(std, a.timeout)
+		defer cancel()
+	}
+
+	convs := conversationsOf(a.ctx.Bot)
+	w := &waiter{filter: filter, cancel: a.cancel, answer: make(chan askAnswer, 1)}
+
+	convs.add(key, w, a.ctx)
+	defer convs.remove(key, w)
+
+	if a.prompt.IsSome() {
+		if err := a.prompt.Some().Send().Err(); err != nil {
+			return g.Err[*Context](fmt.Errorf("failed to send prompt: %w", err))
+		}
+	}
+
+	select {
+	case answer := <-w.answer:
+		if answer.err != nil {
+			return g.Err[*Context](answer.err)
+		}
+
+		answer.ctx.SetStd(a.ctx.Std())
+
+		return g.Ok(answer.ctx)
+	case <-std.Done():
+		if a.ctx.Std().Err() == nil {
+			return g.Err[*Context](&AskTimeoutError{Timeout: a.timeout})
+		}
+
+		return g.Err[*Context](&AskCancelError{Cause: context.Cause(a.ctx.Std())})
+	}
+}
+
+// askKey identifies the user in a chat whose answer is awaited.
+type askKey struct {
+	chat int64
+	user int64
+}
+
+// askKeyOf returns the key of the user and chat of the update.
+func askKeyOf(c *Context) (askKey, bool) {
+	if c.EffectiveChat == nil || c.EffectiveUser == nil {
+		return askKey{}, false
+	}
+
+	return askKey{c.EffectiveChat.Id, c.EffectiveUser.Id}, true
+}
+
+// askAnswer is the outcome of a wait delivered by the dispatcher.
+type askAnswer struct {
+	ctx *Context
+	err error
+}
+
+// waiter is a pending wait for an answer.
+type waiter struct {
+	filter Filter
+	cancel g.Slice[g.String]
+	answer chan askAnswer
+}
+
+// match returns the outcome of the wait if c answers or cancels it.
+func (w *waiter) match(c *Context) (askAnswer, bool) {
+	if command := commandOf(c); command.IsSome() && w.cancel.Contains(command.Some()) {
+		return askAnswer{err: &AskCancelError{Command: command.Some(), Context: c}}, true
+	}
+
+	if w.filter == nil || w.filter(c) {
+		return askAnswer{ctx: c}, true
+	}
+
+	return askAnswer{}, false
+}
+
+// commandOf returns the command of a message update without the slash and bot username.
+func commandOf(c *Context) g.Option[g.String] {
+	if c.EffectiveMessage == nil || !strings.HasPrefix(c.EffectiveMessage.Text, "/") {
+		return g.None[g.String]()
+	}
+
+	command, _, _ := strings.Cut(c.EffectiveMessage.Text[1:], " ")
+	command, _, _ = strings.Cut(command, "@")
+
+	return g.Some(g.String(command))
+}
+
+// dispatchers maps every dispatcher used with Ask or Wait to its conversations.
+var dispatchers sync.Map
+
+// conversations holds the pending waits of a dispatcher and delivers answers to them.
+type conversations struct {
+	bot     core.BotAPI
+	mu      sync.Mutex
+	waiting map[askKey]*waiter
+}
+
+// conversationsOf returns the conversations of the bot's dispatcher,
+// adding the handler that delivers answers on first use.
+func conversationsOf(bot core.BotAPI) *conversations {
+	d := bot.Dispatcher()
+
+	if convs, ok := dispatchers.Load(d); ok {
+		return convs.(*conversations)
+	}
+
+	convs, loaded := dispatchers.LoadOrStore(d, &conversations{bot: bot, waiting: make(map[askKey]*waiter)})
+	if !loaded {
+		d.AddHandlerToGroup(convs.(*conversations), askGroup)
+	}
+
+	return convs.(*conversations)
+}
+
+// add registers w for key. A pending wait for the same key is cancelled in favour of w.
+func (cs *conversations) add(key askKey, w *waiter, c *Context) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if prev, ok := cs.waiting[key]; ok {
+		prev.answer <- askAnswer{err: &AskCancelError{Context: c}}
+	}
+
+	cs.waiting[key] = w
+}
+
+// remove unregisters w if it is still pending for key.
+func (cs *conversations) remove(key askKey, w *waiter) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cs.waiting[key] == w {
+		delete(cs.waiting, key)
+	}
+}
+
+// pending returns the wait registered for the update's user and chat.
+func (cs *conversations) pending(ectx *ext.Context) (askKey, *waiter, bool) {
+	c := &Context{EffectiveChat: ectx.EffectiveChat, EffectiveUser: ectx.EffectiveUser}
+
+	key, ok := askKeyOf(c)
+	if !ok {
+		return askKey{}, nil, false
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	w, ok := cs.waiting[key]
+
+	return key, w, ok
+}
+
+// CheckUpdate reports whether the update answers or cancels a pending wait.
+func (cs *conversations) CheckUpdate(_ *gotgbot.Bot, ectx *ext.Context) bool {
+	_, w, ok := cs.pending(ectx)
+	if !ok {
+		return false
+	}
+
+	_, ok = w.match(New(cs.bot, ectx))
+
+	return ok
+}
+
+// HandleUpdate delivers the update to the pending wait and ends its processing.
+// If the wait ended in the meantime, the update continues to the other groups.
+func (cs *conversations) HandleUpdate(_ *gotgbot.Bot, ectx *ext.Context) error {
+	key, w, ok := cs.pending(ectx)
+	if !ok {
+		return ext.ContinueGroups
+	}
+
+	answer, ok := w.match(New(cs.bot, ectx))
+	if !ok {
+		return ext.ContinueGroups
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cs.waiting[key] != w {
+		return ext.ContinueGroups
+	}
+
+	delete(cs.waiting, key)
+	w.answer <- answer
+
+	return ext.EndGroups
+}
+
+// Name returns the name of the handler in the dispatcher.
+func (cs *conversations) Name() string {
+	return "tg.ask"
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 type BanChatMember struct {
@@ -12,6 +13,7 @@ type BanChatMember struct {
 	opts   *gotgbot.BanChatMemberOpts
 	userID int64
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID for the ban action.
@@ -59,8 +61,18 @@ func (b *BanChatMember) APIURL(url g.String) *BanChatMember {
 	return b
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (b *BanChatMember) Retry(policy *retry.Policy) *BanChatMember {
+	b.retry = policy
+	return b
+}
+
 // Send executes the ban action and returns the result.
 func (b *BanChatMember) Send() g.Result[bool] {
-	chatID := b.chatID.UnwrapOr(b.ctx.EffectiveChat.Id)
-	return g.ResultOf(b.ctx.Bot.Raw().BanChatMember(chatID, b.userID, b.opts))
+	chatID, err := b.ctx.chat(b.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(b.ctx.Bot.Raw().BanChatMemberWithContext(retry.WithPolicy(b.ctx.Std(), b.retry), chatID, b.userID, b.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // BanChatSenderChat represents a request to ban a sender chat in a chat.
@@ -13,6 +14,7 @@ type BanChatSenderChat struct {
 	senderChatID int64
 	opts         *gotgbot.BanChatSenderChatOpts
 	chatID       g.Option[int64]
+	retry        *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -43,10 +45,21 @@ func (bcsc *BanChatSenderChat) APIURL(url g.String) *BanChatSenderChat {
 	return bcsc
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (bcsc *BanChatSenderChat) Retry(policy *retry.Policy) *BanChatSenderChat {
+	bcsc.retry = policy
+	return bcsc
+}
+
 // Send bans the sender chat from the target chat.
 func (bcsc *BanChatSenderChat) Send() g.Result[bool] {
-	return g.ResultOf(bcsc.ctx.Bot.Raw().BanChatSenderChat(
-		bcsc.chatID.UnwrapOr(bcsc.ctx.EffectiveChat.Id),
+	chatID, err := bcsc.ctx.chat(bcsc.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(bcsc.ctx.Bot.Raw().BanChatSenderChatWithContext(retry.WithPolicy(bcsc.ctx.Std(), bcsc.retry),
+		chatID,
 		bcsc.senderChatID,
 		bcsc.opts,
 	))
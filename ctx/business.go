@@ -7,5 +7,5 @@ import (
 
 // Business creates a business API handler for the given connection ID.
 func (ctx *Context) Business(connectionID g.String) *business.Account {
-	return business.NewAccount(ctx.Bot, connectionID)
+	return business.NewAccount(ctx.Bot, connectionID).WithContext(ctx.Std())
 }
@@ -1,6 +1,8 @@
 package business
 
 import (
+	"context"
+
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 )
@@ -9,6 +11,7 @@ import (
 type Account struct {
 	bot    Bot
 	connID g.String
+	std    context.Context
 }
 
 // NewAccount creates a new Account instance bound to the given bot and connection ID.
@@ -16,9 +19,16 @@ func NewAccount(bot Bot, connectionID g.String) *Account {
 	return &Account{
 		bot:    bot,
 		connID: connectionID,
+		std:    context.Background(),
 	}
 }
 
+// WithContext sets the standard context passed to the requests of the account.
+func (a *Account) WithContext(std context.Context) *Account {
+	a.std = std
+	return a
+}
+
 // Name returns a builder for setting the account's first and last name.
 func (a *Account) SetName(firstName g.String) *SetName {
 	return &SetName{
@@ -83,6 +93,7 @@ func (a *Account) Balance() *Balance {
 	return &Balance{
 		bot:    a.bot,
 		connID: a.connID,
+		std:    a.std,
 	}
 }
 
@@ -91,6 +102,7 @@ func (a *Account) Message() *Message {
 	return &Message{
 		bot:    a.bot,
 		connID: a.connID,
+		std:    a.std,
 	}
 }
 
@@ -1,6 +1,8 @@
 package business
 
 import (
+	"context"
+
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 )
@@ -9,6 +11,7 @@ import (
 type Balance struct {
 	bot    Bot
 	connID g.String
+	std    context.Context
 }
 
 // GetStarBalance creates a request to retrieve the current star balance.
@@ -16,6 +19,7 @@ func (b *Balance) GetStarBalance() *GetStarBalance {
 	return &GetStarBalance{
 		bot:    b.bot,
 		connID: b.connID,
+		std:    b.std,
 		opts:   new(gotgbot.GetBusinessAccountStarBalanceOpts),
 	}
 }
@@ -25,6 +29,7 @@ func (b *Balance) TransferStars(amount int64) *TransferStars {
 	return &TransferStars{
 		bot:    b.bot,
 		connID: b.connID,
+		std:    b.std,
 		amount: amount,
 		opts:   new(gotgbot.TransferBusinessAccountStarsOpts),
 	}
@@ -35,6 +40,7 @@ func (b *Balance) GetGifts() *GetGifts {
 	return &GetGifts{
 		bot:    b.bot,
 		connID: b.connID,
+		std:    b.std,
 		opts:   new(gotgbot.GetBusinessAccountGiftsOpts),
 	}
 }
@@ -1,10 +1,12 @@
 package business
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // Delete is a request builder for deleting business messages.
@@ -13,6 +15,8 @@ type Delete struct {
 	connID     g.String
 	messageIDs g.Slice[int64]
 	opts       *gotgbot.DeleteBusinessMessagesOpts
+	retry      *retry.Policy
+	std        context.Context
 }
 
 // Timeout sets a custom timeout for this request.
@@ -37,9 +41,15 @@ func (d *Delete) APIURL(url g.String) *Delete {
 	return d
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (d *Delete) Retry(policy *retry.Policy) *Delete {
+	d.retry = policy
+	return d
+}
+
 // Send executes the Delete request.
 func (d *Delete) Send() g.Result[bool] {
-	return g.ResultOf(d.bot.Raw().DeleteBusinessMessages(
+	return g.ResultOf(d.bot.Raw().DeleteBusinessMessagesWithContext(retry.WithPolicy(d.std, d.retry),
 		d.connID.Std(),
 		d.messageIDs,
 		d.opts,
@@ -1,10 +1,12 @@
 package business
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetStarBalance request builder for star balance.
@@ -12,6 +14,8 @@ type GetStarBalance struct {
 	bot    Bot
 	connID g.String
 	opts   *gotgbot.GetBusinessAccountStarBalanceOpts
+	retry  *retry.Policy
+	std    context.Context
 }
 
 // Timeout sets a custom timeout for this request.
@@ -36,7 +40,13 @@ func (gb *GetStarBalance) APIURL(url g.String) *GetStarBalance {
 	return gb
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gb *GetStarBalance) Retry(policy *retry.Policy) *GetStarBalance {
+	gb.retry = policy
+	return gb
+}
+
 // Send executes the GetStarBalance request.
 func (gb *GetStarBalance) Send() g.Result[*gotgbot.StarAmount] {
-	return g.ResultOf(gb.bot.Raw().GetBusinessAccountStarBalance(gb.connID.Std(), gb.opts))
+	return g.ResultOf(gb.bot.Raw().GetBusinessAccountStarBalanceWithContext(retry.WithPolicy(gb.std, gb.retry), gb.connID.Std(), gb.opts))
 }
@@ -5,12 +5,14 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetConnection is a request builder for retrieving business connection info.
 type GetConnection struct {
 	account *Account
 	opts    *gotgbot.GetBusinessConnectionOpts
+	retry   *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -35,9 +37,15 @@ func (gc *GetConnection) APIURL(url g.String) *GetConnection {
 	return gc
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gc *GetConnection) Retry(policy *retry.Policy) *GetConnection {
+	gc.retry = policy
+	return gc
+}
+
 // Send executes the Get request.
 func (gc *GetConnection) Send() g.Result[*gotgbot.BusinessConnection] {
-	return g.ResultOf(gc.account.bot.Raw().GetBusinessConnection(
+	return g.ResultOf(gc.account.bot.Raw().GetBusinessConnectionWithContext(retry.WithPolicy(gc.account.std, gc.retry),
 		gc.account.connID.Std(),
 		gc.opts,
 	))
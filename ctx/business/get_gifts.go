@@ -1,10 +1,12 @@
 package business
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetGifts is a request builder for retrieving gifts from a business account.
@@ -13,6 +15,8 @@ type GetGifts struct {
 	bot    Bot
 	connID g.String
 	opts   *gotgbot.GetBusinessAccountGiftsOpts
+	retry  *retry.Policy
+	std    context.Context
 }
 
 // ExcludeUnsaved sets the request to exclude gifts that aren't saved to the account's profile page.
@@ -98,10 +102,16 @@ func (ggs *GetGifts) APIURL(url g.String) *GetGifts {
 	return ggs
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ggs *GetGifts) Retry(policy *retry.Policy) *GetGifts {
+	ggs.retry = policy
+	return ggs
+}
+
 // Send executes the request to retrieve gifts from the business account.
 // Returns OwnedGifts wrapped in g.Result.
 func (ggs *GetGifts) Send() g.Result[*gotgbot.OwnedGifts] {
-	return g.ResultOf(ggs.bot.Raw().GetBusinessAccountGifts(
+	return g.ResultOf(ggs.bot.Raw().GetBusinessAccountGiftsWithContext(retry.WithPolicy(ggs.std, ggs.retry),
 		ggs.connID.Std(),
 		ggs.opts,
 	))
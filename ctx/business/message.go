@@ -1,6 +1,8 @@
 package business
 
 import (
+	"context"
+
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 )
@@ -10,6 +12,7 @@ import (
 type Message struct {
 	bot    Bot
 	connID g.String
+	std    context.Context
 }
 
 // Read creates a request to mark a specific business message as read.
@@ -17,6 +20,7 @@ func (m *Message) Read(chatID, messageID int64) *Read {
 	return &Read{
 		bot:       m.bot,
 		connID:    m.connID,
+		std:       m.std,
 		chatID:    chatID,
 		messageID: messageID,
 		opts:      new(gotgbot.ReadBusinessMessageOpts),
@@ -28,6 +32,7 @@ func (m *Message) Delete(messageIDs g.Slice[int64]) *Delete {
 	return &Delete{
 		bot:        m.bot,
 		connID:     m.connID,
+		std:        m.std,
 		messageIDs: messageIDs,
 		opts:       new(gotgbot.DeleteBusinessMessagesOpts),
 	}
@@ -1,10 +1,12 @@
 package business
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // Read is a request builder for marking a business message as read.
@@ -14,6 +16,8 @@ type Read struct {
 	chatID    int64
 	messageID int64
 	opts      *gotgbot.ReadBusinessMessageOpts
+	retry     *retry.Policy
+	std       context.Context
 }
 
 // Timeout sets a custom timeout for this request.
@@ -38,9 +42,15 @@ func (r *Read) APIURL(url g.String) *Read {
 	return r
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (r *Read) Retry(policy *retry.Policy) *Read {
+	r.retry = policy
+	return r
+}
+
 // Send executes the Read request.
 func (r *Read) Send() g.Result[bool] {
-	return g.ResultOf(r.bot.Raw().ReadBusinessMessage(
+	return g.ResultOf(r.bot.Raw().ReadBusinessMessageWithContext(retry.WithPolicy(r.std, r.retry),
 		r.connID.Std(),
 		r.chatID,
 		r.messageID,
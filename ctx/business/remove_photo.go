@@ -5,12 +5,14 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // RemovePhoto is a request builder for removing the business account profile photo.
 type RemovePhoto struct {
 	account *Account
 	opts    *gotgbot.RemoveBusinessAccountProfilePhotoOpts
+	retry   *retry.Policy
 }
 
 // Public removes the public profile photo if present.
@@ -41,9 +43,15 @@ func (rp *RemovePhoto) APIURL(url g.String) *RemovePhoto {
 	return rp
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (rp *RemovePhoto) Retry(policy *retry.Policy) *RemovePhoto {
+	rp.retry = policy
+	return rp
+}
+
 // Send executes the RemovePhoto request.
 func (rp *RemovePhoto) Send() g.Result[bool] {
-	return g.ResultOf(rp.account.bot.Raw().RemoveBusinessAccountProfilePhoto(
+	return g.ResultOf(rp.account.bot.Raw().RemoveBusinessAccountProfilePhotoWithContext(retry.WithPolicy(rp.account.std, rp.retry),
 		rp.account.connID.Std(),
 		rp.opts,
 	))
@@ -6,6 +6,7 @@ import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/input"
+	"github.com/enetx/tg/retry"
 )
 
 // SetAnimatedPhoto is a request builder for setting the business account animated profile photo.
@@ -14,6 +15,7 @@ type SetAnimatedPhoto struct {
 	animation          g.String
 	mainFrameTimestamp g.Option[float64]
 	opts               *gotgbot.SetBusinessAccountProfilePhotoOpts
+	retry              *retry.Policy
 }
 
 // MainFrame sets the timestamp in seconds of the frame that will be used as the static profile photo.
@@ -50,6 +52,12 @@ func (sap *SetAnimatedPhoto) APIURL(url g.String) *SetAnimatedPhoto {
 	return sap
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sap *SetAnimatedPhoto) Retry(policy *retry.Policy) *SetAnimatedPhoto {
+	sap.retry = policy
+	return sap
+}
+
 // Send executes the SetAnimatedPhoto request.
 func (sap *SetAnimatedPhoto) Send() g.Result[bool] {
 	animated := input.AnimatedPhoto(sap.animation)
@@ -58,7 +66,7 @@ func (sap *SetAnimatedPhoto) Send() g.Result[bool] {
 		animated.MainFrameTimestamp(sap.mainFrameTimestamp.Some())
 	}
 
-	return g.ResultOf(sap.account.bot.Raw().SetBusinessAccountProfilePhoto(
+	return g.ResultOf(sap.account.bot.Raw().SetBusinessAccountProfilePhotoWithContext(retry.WithPolicy(sap.account.std, sap.retry),
 		sap.account.connID.Std(),
 		animated.Build(),
 		sap.opts,
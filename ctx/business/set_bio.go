@@ -5,12 +5,14 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetBio is a request builder for setting the business account bio.
 type SetBio struct {
 	account *Account
 	opts    *gotgbot.SetBusinessAccountBioOpts
+	retry   *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -35,9 +37,15 @@ func (sb *SetBio) APIURL(url g.String) *SetBio {
 	return sb
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sb *SetBio) Retry(policy *retry.Policy) *SetBio {
+	sb.retry = policy
+	return sb
+}
+
 // Send executes the SetBio request.
 func (sb *SetBio) Send() g.Result[bool] {
-	return g.ResultOf(sb.account.bot.Raw().SetBusinessAccountBio(
+	return g.ResultOf(sb.account.bot.Raw().SetBusinessAccountBioWithContext(retry.WithPolicy(sb.account.std, sb.retry),
 		sb.account.connID.Std(),
 		sb.opts,
 	))
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetGiftSettings is a request builder for changing gift privacy settings in a business account.
@@ -14,6 +15,7 @@ type SetGiftSettings struct {
 	showGiftButton    bool
 	acceptedGiftTypes gotgbot.AcceptedGiftTypes
 	opts              *gotgbot.SetBusinessAccountGiftSettingsOpts
+	retry             *retry.Policy
 }
 
 // ShowGiftButton sets whether a button for sending a gift should always be shown in the input field.
@@ -92,10 +94,16 @@ func (sgs *SetGiftSettings) APIURL(url g.String) *SetGiftSettings {
 	return sgs
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sgs *SetGiftSettings) Retry(policy *retry.Policy) *SetGiftSettings {
+	sgs.retry = policy
+	return sgs
+}
+
 // Send executes the request to change gift settings.
 // Returns true on success wrapped in g.Result.
 func (sgs *SetGiftSettings) Send() g.Result[bool] {
-	return g.ResultOf(sgs.account.bot.Raw().SetBusinessAccountGiftSettings(
+	return g.ResultOf(sgs.account.bot.Raw().SetBusinessAccountGiftSettingsWithContext(retry.WithPolicy(sgs.account.std, sgs.retry),
 		sgs.account.connID.Std(),
 		sgs.showGiftButton,
 		sgs.acceptedGiftTypes,
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetName is a request builder for setting the account's name.
@@ -12,6 +13,7 @@ type SetName struct {
 	account   *Account
 	firstName g.String
 	opts      *gotgbot.SetBusinessAccountNameOpts
+	retry     *retry.Policy
 }
 
 // LastName sets the optional last name.
@@ -42,9 +44,15 @@ func (sn *SetName) APIURL(url g.String) *SetName {
 	return sn
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sn *SetName) Retry(policy *retry.Policy) *SetName {
+	sn.retry = policy
+	return sn
+}
+
 // Send executes the SetName request.
 func (sn *SetName) Send() g.Result[bool] {
-	return g.ResultOf(sn.account.bot.Raw().SetBusinessAccountName(
+	return g.ResultOf(sn.account.bot.Raw().SetBusinessAccountNameWithContext(retry.WithPolicy(sn.account.std, sn.retry),
 		sn.account.connID.Std(),
 		sn.firstName.Std(),
 		sn.opts,
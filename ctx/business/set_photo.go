@@ -6,6 +6,7 @@ import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/input"
+	"github.com/enetx/tg/retry"
 )
 
 // SetPhoto is a request builder for setting the business account profile photo.
@@ -13,6 +14,7 @@ type SetPhoto struct {
 	account *Account
 	photo   g.String
 	opts    *gotgbot.SetBusinessAccountProfilePhotoOpts
+	retry   *retry.Policy
 }
 
 // Public marks the profile photo as publicly visible.
@@ -43,9 +45,15 @@ func (sp *SetPhoto) APIURL(url g.String) *SetPhoto {
 	return sp
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sp *SetPhoto) Retry(policy *retry.Policy) *SetPhoto {
+	sp.retry = policy
+	return sp
+}
+
 // Send executes the SetPhoto request.
 func (sp *SetPhoto) Send() g.Result[bool] {
-	return g.ResultOf(sp.account.bot.Raw().SetBusinessAccountProfilePhoto(
+	return g.ResultOf(sp.account.bot.Raw().SetBusinessAccountProfilePhotoWithContext(retry.WithPolicy(sp.account.std, sp.retry),
 		sp.account.connID.Std(),
 		input.StaticPhoto(sp.photo).Build(),
 		sp.opts,
@@ -5,12 +5,14 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetUsername is a request builder for setting the account's username.
 type SetUsername struct {
 	account *Account
 	opts    *gotgbot.SetBusinessAccountUsernameOpts
+	retry   *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -35,9 +37,15 @@ func (su *SetUsername) APIURL(url g.String) *SetUsername {
 	return su
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (su *SetUsername) Retry(policy *retry.Policy) *SetUsername {
+	su.retry = policy
+	return su
+}
+
 // Send executes the SetUsername request.
 func (su *SetUsername) Send() g.Result[bool] {
-	return g.ResultOf(su.account.bot.Raw().SetBusinessAccountUsername(
+	return g.ResultOf(su.account.bot.Raw().SetBusinessAccountUsernameWithContext(retry.WithPolicy(su.account.std, su.retry),
 		su.account.connID.Std(),
 		su.opts,
 	))
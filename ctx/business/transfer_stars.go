@@ -1,10 +1,12 @@
 package business
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // TransferStars request builder for sending stars.
@@ -13,6 +15,8 @@ type TransferStars struct {
 	connID g.String
 	amount int64
 	opts   *gotgbot.TransferBusinessAccountStarsOpts
+	retry  *retry.Policy
+	std    context.Context
 }
 
 // Timeout sets a custom timeout for this request.
@@ -37,7 +41,13 @@ func (t *TransferStars) APIURL(url g.String) *TransferStars {
 	return t
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (t *TransferStars) Retry(policy *retry.Policy) *TransferStars {
+	t.retry = policy
+	return t
+}
+
 // Send executes the Transfer request.
 func (t *TransferStars) Send() g.Result[bool] {
-	return g.ResultOf(t.bot.Raw().TransferBusinessAccountStars(t.connID.Std(), t.amount, t.opts))
+	return g.ResultOf(t.bot.Raw().TransferBusinessAccountStarsWithContext(retry.WithPolicy(t.std, t.retry), t.connID.Std(), t.amount, t.opts))
 }
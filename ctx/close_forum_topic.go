@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // CloseForumTopic represents a request to close a forum topic.
@@ -13,6 +14,7 @@ type CloseForumTopic struct {
 	messageThreadID int64
 	opts            *gotgbot.CloseForumTopicOpts
 	chatID          g.Option[int64]
+	retry           *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -37,6 +39,12 @@ func (cft *CloseForumTopic) APIURL(url g.String) *CloseForumTopic {
 	return cft
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (cft *CloseForumTopic) Retry(policy *retry.Policy) *CloseForumTopic {
+	cft.retry = policy
+	return cft
+}
+
 // ChatID sets the target chat ID for this request.
 func (cft *CloseForumTopic) ChatID(id int64) *CloseForumTopic {
 	cft.chatID = g.Some(id)
@@ -45,6 +53,10 @@ func (cft *CloseForumTopic) ChatID(id int64) *CloseForumTopic {
 
 // Send executes the CloseForumTopic request.
 func (cft *CloseForumTopic) Send() g.Result[bool] {
-	chatID := cft.chatID.UnwrapOr(cft.ctx.EffectiveChat.Id)
-	return g.ResultOf(cft.ctx.Bot.Raw().CloseForumTopic(chatID, cft.messageThreadID, cft.opts))
+	chatID, err := cft.ctx.chat(cft.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(cft.ctx.Bot.Raw().CloseForumTopicWithContext(retry.WithPolicy(cft.ctx.Std(), cft.retry), chatID, cft.messageThreadID, cft.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // CloseGeneralForumTopic represents a request to close the general forum topic.
@@ -12,6 +13,7 @@ type CloseGeneralForumTopic struct {
 	ctx    *Context
 	opts   *gotgbot.CloseGeneralForumTopicOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -36,6 +38,12 @@ func (cgft *CloseGeneralForumTopic) APIURL(url g.String) *CloseGeneralForumTopic
 	return cgft
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (cgft *CloseGeneralForumTopic) Retry(policy *retry.Policy) *CloseGeneralForumTopic {
+	cgft.retry = policy
+	return cgft
+}
+
 // ChatID sets the target chat ID for this request.
 func (cgft *CloseGeneralForumTopic) ChatID(id int64) *CloseGeneralForumTopic {
 	cgft.chatID = g.Some(id)
@@ -44,6 +52,10 @@ func (cgft *CloseGeneralForumTopic) ChatID(id int64) *CloseGeneralForumTopic {
 
 // Send executes the CloseGeneralForumTopic request.
 func (cgft *CloseGeneralForumTopic) Send() g.Result[bool] {
-	chatID := cgft.chatID.UnwrapOr(cgft.ctx.EffectiveChat.Id)
-	return g.ResultOf(cgft.ctx.Bot.Raw().CloseGeneralForumTopic(chatID, cgft.opts))
+	chatID, err := cgft.ctx.chat(cgft.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(cgft.ctx.Bot.Raw().CloseGeneralForumTopicWithContext(retry.WithPolicy(cgft.ctx.Std(), cgft.retry), chatID, cgft.opts))
 }
@@ -1,6 +1,9 @@
 package ctx
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
@@ -30,6 +33,8 @@ type Context struct {
 	EffectiveUser    *gotgbot.User          // The user who sent the update
 	Update           *gotgbot.Update        // The original update object
 	Raw              *ext.Context           // The raw gotgbot context for advanced usage
+	values           *values                // Values set by middlewares and handlers for this update
+	std              context.Context        // Standard context passed to outgoing requests
 }
 
 // New creates a new Context instance from a bot and raw gotgbot context.
@@ -44,9 +49,43 @@ func New(bot core.BotAPI, raw *ext.Context) *Context {
 		EffectiveUser:    raw.EffectiveUser,
 		Update:           raw.Update,
 		Raw:              raw,
+		values:           valuesOf(raw),
+		std:              updateStd(bot, raw.Update),
 	}
 }
 
+// Detached creates a Context that is not bound to an update, for code that runs outside of handlers,
+// such as recurring jobs. It has no effective chat, message or user; bind it to a chat with Chat:
+//
+//	c := ctx.Detached(b)
+//	c.Chat(chatID).SendMessage("Good morning").Send()
+func Detached(bot core.BotAPI) *Context {
+	return New(bot, &ext.Context{Update: new(gotgbot.Update)})
+}
+
+// Chat returns a copy of the context whose effective chat is the chat with the given ID,
+// so that its builders send to that chat.
+func (ctx *Context) Chat(id int64) *Context {
+	c := *ctx
+	c.EffectiveChat = &gotgbot.Chat{Id: id}
+
+	return &c
+}
+
+// Std returns the standard context of the update. Every request sent through the context's
+// builders uses it, so the requests are aborted once it is done. Handlers get a context of their
+// own update that is cancelled with cause ErrShutdown as soon as the bot begins to shut down, or
+// when a Timeout middleware expires, and can pass it on to their own calls. It is not cancelled
+// when the handler returns, so goroutines started by the handler may keep using it.
+func (ctx *Context) Std() context.Context {
+	return ctx.std
+}
+
+// SetStd replaces the standard context of the update, e.g. to add a deadline in a middleware.
+func (ctx *Context) SetStd(std context.Context) {
+	ctx.std = std
+}
+
 // BanChatMember creates a new BanChatMember request to ban a user from the chat.
 func (ctx *Context) BanChatMember(userID int64) *BanChatMember {
 	return &BanChatMember{
@@ -408,6 +447,16 @@ func (ctx *Context) SendMessageDraft(draftID int64) *SendMessageDraft {
 	}
 }
 
+// Stream creates a new Stream that sends text as it is generated, through message drafts
+// where they are supported, and finalizes it with regular messages.
+func (ctx *Context) Stream() *Stream {
+	return &Stream{
+		ctx:     ctx,
+		draftID: newDraftID(),
+		opts:    new(gotgbot.SendMessageOpts),
+	}
+}
+
 // SetChatMemberTag creates a new SetChatMemberTag request to set a tag for a regular
 // member in a group or supergroup. The bot must be an administrator with the
 // can_manage_tags right.
@@ -804,6 +853,14 @@ func (ctx *Context) DeleteStory(businessConnectionID g.String, storyID int64) *D
 
 // IsAdmin checks if the effective user is an administrator in the current chat.
 func (ctx *Context) IsAdmin() g.Result[bool] {
+	if ctx.EffectiveChat == nil {
+		return g.Err[bool](ErrNoChat)
+	}
+
+	if ctx.EffectiveUser == nil {
+		return g.Err[bool](ErrNoUser)
+	}
+
 	member, err := ctx.Bot.Raw().GetChatMember(ctx.EffectiveChat.Id, ctx.EffectiveUser.Id, nil)
 	if err != nil {
 		return g.Err[bool](err)
@@ -814,6 +871,10 @@ func (ctx *Context) IsAdmin() g.Result[bool] {
 
 // Args returns command arguments from the message text, excluding the command itself.
 func (ctx *Context) Args() g.Slice[g.String] {
+	if ctx.EffectiveMessage == nil {
+		return nil
+	}
+
 	return g.String(ctx.EffectiveMessage.Text).Fields().Skip(1).Collect()
 }
 
@@ -1531,28 +1592,126 @@ func (ctx *Context) DeclineSuggestedPost() *DeclineSuggestedPost {
 	}
 }
 
+// timers calls send now, or schedules it after the delay as a job of the bot's scheduler,
+// and schedules deletion of the sent message.
 func (ctx *Context) timers(
 	after g.Option[time.Duration],
 	deleteAfter g.Option[time.Duration],
-	send func() g.Result[*gotgbot.Message],
+	send func(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message],
 ) g.Result[*gotgbot.Message] {
 	if after.IsSome() {
-		go func() {
-			<-time.After(after.Some())
-			msg := send()
-			if msg.IsOk() && deleteAfter.IsSome() {
-				ctx.DeleteMessage().MessageID(msg.Ok().MessageId).After(deleteAfter.Some()).Send()
-			}
-		}()
+		if job := schedule(ctx, after, deleteAfter, send); job.IsErr() {
+			return g.Err[*gotgbot.Message](job.Err())
+		}
 
 		return g.Ok[*gotgbot.Message](nil)
 	}
 
-	msg := send()
+	msg := send(ctx.std, ctx.Bot.Raw())
 
 	if msg.IsOk() && deleteAfter.IsSome() {
-		ctx.DeleteMessage().MessageID(msg.Ok().MessageId).After(deleteAfter.Some()).Send()
+		ctx.DeleteMessage().ChatID(msg.Ok().Chat.Id).MessageID(msg.Ok().MessageId).After(deleteAfter.Some()).Send()
 	}
 
 	return msg
 }
+
+// keyboardErr returns the error of a keyboard whose buttons could not all be built, such as
+// callback data over 64 bytes, see keyboard.InlineKeyboard.Err. Requests with it fail before sending.
+func keyboardErr(kb keyboard.Keyboard) error {
+	if k, ok := kb.(interface{ Err() error }); ok {
+		if err := k.Err(); err != nil {
+			return fmt.Errorf("failed to build keyboard: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// stdOf returns the lifetime context of the bot if it provides one, or a background context.
+func stdOf(bot core.BotAPI) context.Context {
+	if b, ok := bot.(interface{ Context() context.Context }); ok {
+		return b.Context()
+	}
+
+	return context.Background()
+}
+
+// updateStd returns the standard context of an update, derived from the serving context of the bot,
+// which is cancelled when shutdown begins, or from its lifetime context if it has none.
+func updateStd(bot core.BotAPI, update *gotgbot.Update) context.Context {
+	std := stdOf(bot)
+	if b, ok := bot.(interface{ Serving() context.Context }); ok {
+		std = b.Serving()
+	}
+
+	return context.WithValue(std, updateKey{}, update)
+}
+
+// updateKey is the key of the update in the standard context of a Context.
+type updateKey struct{}
+
+// UpdateOf returns the update whose Context the standard context std was made for, if any.
+func UpdateOf(std context.Context) g.Option[*gotgbot.Update] {
+	if update, ok := std.Value(updateKey{}).(*gotgbot.Update); ok && update != nil {
+		return g.Some(update)
+	}
+
+	return g.None[*gotgbot.Update]()
+}
+
+var (
+	// ErrShutdown is the cause of the standard contexts of handlers once the bot begins to shut down.
+	ErrShutdown = errors.New("bot is shutting down")
+
+	// ErrNoChat is returned by requests sent from a context without an effective chat, such as a
+	// detached one, when no chat was set with To or ChatID.
+	ErrNoChat = errors.New("no chat to send to, set one with To or ChatID")
+
+	// ErrNoMessage is returned by requests on a message sent from a context without an effective
+	// message when no message was set with MessageID.
+	ErrNoMessage = errors.New("no message to act on, set one with MessageID")
+
+	// ErrNoUser is returned by requests for a user sent from a context without an effective user
+	// when no user was set with UserID.
+	ErrNoUser = errors.New("no user to act on, set one with UserID")
+)
+
+// chat returns id if set, or the ID of the effective chat.
+func (ctx *Context) chat(id g.Option[int64]) g.Result[int64] {
+	if id.IsSome() {
+		return g.Ok(id.Some())
+	}
+
+	if ctx.EffectiveChat == nil {
+		return g.Err[int64](ErrNoChat)
+	}
+
+	return g.Ok(ctx.EffectiveChat.Id)
+}
+
+// message returns id if set, or the ID of the effective message.
+func (ctx *Context) message(id g.Option[int64]) g.Result[int64] {
+	if id.IsSome() {
+		return g.Ok(id.Some())
+	}
+
+	if ctx.EffectiveMessage == nil {
+		return g.Err[int64](ErrNoMessage)
+	}
+
+	return g.Ok(ctx.EffectiveMessage.MessageId)
+}
+
+// user returns id if set, or the ID of the effective user.
+func (ctx *Context) user(id g.Option[int64]) g.Result[int64] {
+	if id.IsSome() {
+		return g.Ok(id.Some())
+	}
+
+	if ctx.EffectiveUser == nil {
+		return g.Err[int64](ErrNoUser)
+	}
+
+	return g.Ok(ctx.EffectiveUser.Id)
+}
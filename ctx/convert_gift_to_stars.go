@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // ConvertGiftToStars is a request builder for converting gifts to stars.
@@ -13,6 +14,7 @@ type ConvertGiftToStars struct {
 	businessConnectionID g.String
 	ownedGiftID          g.String
 	opts                 *gotgbot.ConvertGiftToStarsOpts
+	retry                *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -37,9 +39,15 @@ func (cgts *ConvertGiftToStars) APIURL(url g.String) *ConvertGiftToStars {
 	return cgts
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (cgts *ConvertGiftToStars) Retry(policy *retry.Policy) *ConvertGiftToStars {
+	cgts.retry = policy
+	return cgts
+}
+
 // Send executes the ConvertGiftToStars request.
 func (cgts *ConvertGiftToStars) Send() g.Result[bool] {
-	return g.ResultOf(cgts.ctx.Bot.Raw().ConvertGiftToStars(
+	return g.ResultOf(cgts.ctx.Bot.Raw().ConvertGiftToStarsWithContext(retry.WithPolicy(cgts.ctx.Std(), cgts.retry),
 		cgts.businessConnectionID.Std(),
 		cgts.ownedGiftID.Std(),
 		cgts.opts,
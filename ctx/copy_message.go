@@ -1,6 +1,7 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
@@ -9,6 +10,8 @@ import (
 	"github.com/enetx/tg/entities"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/suggested"
 	"github.com/enetx/tg/types/effects"
 )
@@ -21,6 +24,8 @@ type CopyMessage struct {
 	toChatID    g.Option[int64]
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // CaptionEntities sets custom entities for the copied message caption.
@@ -74,6 +79,8 @@ func (c *CopyMessage) Protect() *CopyMessage {
 // Markup sets the reply markup keyboard for the copied message.
 func (c *CopyMessage) Markup(kb keyboard.Keyboard) *CopyMessage {
 	c.opts.ReplyMarkup = kb.Markup()
+	c.markupErr = keyboardErr(kb)
+
 	return c
 }
 
@@ -137,6 +144,12 @@ func (c *CopyMessage) APIURL(url g.String) *CopyMessage {
 	return c
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (c *CopyMessage) Retry(policy *retry.Policy) *CopyMessage {
+	c.retry = policy
+	return c
+}
+
 // SuggestedPost sets suggested post parameters for direct messages chats.
 func (c *CopyMessage) SuggestedPost(params *suggested.PostParameters) *CopyMessage {
 	if params != nil {
@@ -160,20 +173,19 @@ func (c *CopyMessage) DirectMessagesTopic(topicID int64) *CopyMessage {
 // Send copies the message to the target chat and returns the result.
 func (c *CopyMessage) Send() g.Result[*gotgbot.MessageId] {
 	if c.after.IsSome() {
-		go func() {
-			<-time.After(c.after.Some())
-			chatID := c.toChatID.UnwrapOr(c.ctx.EffectiveChat.Id)
-			msgID, err := c.ctx.Bot.Raw().CopyMessage(chatID, c.fromChatID, c.messageID, c.opts)
-			if err == nil && msgID != nil && c.deleteAfter.IsSome() {
-				c.ctx.DeleteMessage().MessageID(msgID.MessageId).ChatID(chatID).After(c.deleteAfter.Some()).Send()
-			}
-		}()
+		if job := c.Schedule(); job.IsErr() {
+			return g.Err[*gotgbot.MessageId](job.Err())
+		}
 
 		return g.Ok[*gotgbot.MessageId](nil)
 	}
 
-	chatID := c.toChatID.UnwrapOr(c.ctx.EffectiveChat.Id)
-	result := g.ResultOf(c.ctx.Bot.Raw().CopyMessage(chatID, c.fromChatID, c.messageID, c.opts))
+	chatID, err := c.ctx.chat(c.toChatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.MessageId](err)
+	}
+
+	result := c.send(c.ctx.Std(), c.ctx.Bot.Raw())
 
 	if result.IsOk() && c.deleteAfter.IsSome() {
 		c.ctx.DeleteMessage().MessageID(result.Ok().MessageId).ChatID(chatID).After(c.deleteAfter.Some()).Send()
@@ -181,3 +193,23 @@ func (c *CopyMessage) Send() g.Result[*gotgbot.MessageId] {
 
 	return result
 }
+
+// Schedule schedules the message to be copied after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (c *CopyMessage) Schedule() g.Result[scheduler.JobID] {
+	return schedule(c.ctx, c.after, c.deleteAfter, c.send)
+}
+
+// send copies the message through raw.
+func (c *CopyMessage) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.MessageId] {
+	if c.markupErr != nil {
+		return g.Err[*gotgbot.MessageId](c.markupErr)
+	}
+
+	chatID, err := c.ctx.chat(c.toChatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.MessageId](err)
+	}
+
+	return g.ResultOf(raw.CopyMessageWithContext(retry.WithPolicy(std, c.retry), chatID, c.fromChatID, c.messageID, c.opts))
+}
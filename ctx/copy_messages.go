@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // CopyMessages represents a request to copy multiple messages.
@@ -14,6 +15,7 @@ type CopyMessages struct {
 	fromChatID g.Option[int64]
 	messageIDs g.Slice[int64]
 	opts       *gotgbot.CopyMessagesOpts
+	retry      *retry.Policy
 }
 
 // To sets the target chat ID for copying messages.
@@ -86,6 +88,12 @@ func (cm *CopyMessages) APIURL(url g.String) *CopyMessages {
 	return cm
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (cm *CopyMessages) Retry(policy *retry.Policy) *CopyMessages {
+	cm.retry = policy
+	return cm
+}
+
 // DirectMessagesTopic sets the direct messages topic ID for the message.
 func (cm *CopyMessages) DirectMessagesTopic(topicID int64) *CopyMessages {
 	cm.opts.DirectMessagesTopicId = topicID
@@ -108,10 +116,14 @@ func (cm *CopyMessages) Send() g.Result[g.Slice[gotgbot.MessageId]] {
 		return g.Err[g.Slice[gotgbot.MessageId]](g.Errorf("source chat ID must be specified"))
 	}
 
-	chatID := cm.chatID.UnwrapOr(cm.ctx.EffectiveChat.Id)
+	chatID, err := cm.ctx.chat(cm.chatID).Result()
+	if err != nil {
+		return g.Err[g.Slice[gotgbot.MessageId]](err)
+	}
+
 	fromChatID := cm.fromChatID.Some()
 
-	result, err := cm.ctx.Bot.Raw().CopyMessages(chatID, fromChatID, cm.messageIDs, cm.opts)
+	result, err := cm.ctx.Bot.Raw().CopyMessagesWithContext(retry.WithPolicy(cm.ctx.Std(), cm.retry), chatID, fromChatID, cm.messageIDs, cm.opts)
 
 	return g.ResultOf[g.Slice[gotgbot.MessageId]](result, err)
 }
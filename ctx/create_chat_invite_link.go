@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // CreateChatInviteLink represents a request to create a new chat invite link.
@@ -12,6 +13,7 @@ type CreateChatInviteLink struct {
 	ctx    *Context
 	opts   *gotgbot.CreateChatInviteLinkOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -72,8 +74,18 @@ func (ccil *CreateChatInviteLink) APIURL(url g.String) *CreateChatInviteLink {
 	return ccil
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ccil *CreateChatInviteLink) Retry(policy *retry.Policy) *CreateChatInviteLink {
+	ccil.retry = policy
+	return ccil
+}
+
 // Send creates the chat invite link and returns the result.
 func (ccil *CreateChatInviteLink) Send() g.Result[*gotgbot.ChatInviteLink] {
-	chatID := ccil.chatID.UnwrapOr(ccil.ctx.EffectiveChat.Id)
-	return g.ResultOf(ccil.ctx.Bot.Raw().CreateChatInviteLink(chatID, ccil.opts))
+	chatID, err := ccil.ctx.chat(ccil.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.ChatInviteLink](err)
+	}
+
+	return g.ResultOf(ccil.ctx.Bot.Raw().CreateChatInviteLinkWithContext(retry.WithPolicy(ccil.ctx.Std(), ccil.retry), chatID, ccil.opts))
 }
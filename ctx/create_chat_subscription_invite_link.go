@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // CreateChatSubscriptionInviteLink represents a request to create a subscription invite link.
@@ -14,6 +15,7 @@ type CreateChatSubscriptionInviteLink struct {
 	subscriptionPrice  int64
 	opts               *gotgbot.CreateChatSubscriptionInviteLinkOpts
 	chatID             g.Option[int64]
+	retry              *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -50,10 +52,21 @@ func (ccsil *CreateChatSubscriptionInviteLink) APIURL(url g.String) *CreateChatS
 	return ccsil
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ccsil *CreateChatSubscriptionInviteLink) Retry(policy *retry.Policy) *CreateChatSubscriptionInviteLink {
+	ccsil.retry = policy
+	return ccsil
+}
+
 // Send creates the subscription invite link.
 func (ccsil *CreateChatSubscriptionInviteLink) Send() g.Result[*gotgbot.ChatInviteLink] {
-	return g.ResultOf(ccsil.ctx.Bot.Raw().CreateChatSubscriptionInviteLink(
-		ccsil.chatID.UnwrapOr(ccsil.ctx.EffectiveChat.Id),
+	chatID, err := ccsil.ctx.chat(ccsil.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.ChatInviteLink](err)
+	}
+
+	return g.ResultOf(ccsil.ctx.Bot.Raw().CreateChatSubscriptionInviteLinkWithContext(retry.WithPolicy(ccsil.ctx.Std(), ccsil.retry),
+		chatID,
 		ccsil.subscriptionPeriod,
 		ccsil.subscriptionPrice,
 		ccsil.opts,
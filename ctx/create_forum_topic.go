@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // CreateForumTopic represents a request to create a forum topic.
@@ -13,6 +14,7 @@ type CreateForumTopic struct {
 	name   g.String
 	opts   *gotgbot.CreateForumTopicOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // IconColor sets the color of the topic icon in RGB format.
@@ -49,6 +51,12 @@ func (cf *CreateForumTopic) APIURL(url g.String) *CreateForumTopic {
 	return cf
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (cf *CreateForumTopic) Retry(policy *retry.Policy) *CreateForumTopic {
+	cf.retry = policy
+	return cf
+}
+
 // ChatID sets the target chat ID for this request.
 func (cf *CreateForumTopic) ChatID(id int64) *CreateForumTopic {
 	cf.chatID = g.Some(id)
@@ -57,6 +65,10 @@ func (cf *CreateForumTopic) ChatID(id int64) *CreateForumTopic {
 
 // Send executes the CreateForumTopic request.
 func (cf *CreateForumTopic) Send() g.Result[*gotgbot.ForumTopic] {
-	chatID := cf.chatID.UnwrapOr(cf.ctx.EffectiveChat.Id)
-	return g.ResultOf(cf.ctx.Bot.Raw().CreateForumTopic(chatID, cf.name.Std(), cf.opts))
+	chatID, err := cf.ctx.chat(cf.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.ForumTopic](err)
+	}
+
+	return g.ResultOf(cf.ctx.Bot.Raw().CreateForumTopicWithContext(retry.WithPolicy(cf.ctx.Std(), cf.retry), chatID, cf.name.Std(), cf.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // CreateInvoiceLink represents a request to create an invoice link.
@@ -16,6 +17,7 @@ type CreateInvoiceLink struct {
 	currency g.String
 	prices   g.Slice[gotgbot.LabeledPrice]
 	opts     *gotgbot.CreateInvoiceLinkOpts
+	retry    *retry.Policy
 }
 
 // Price adds a labeled price item to the invoice link.
@@ -134,9 +136,15 @@ func (cil *CreateInvoiceLink) APIURL(url g.String) *CreateInvoiceLink {
 	return cil
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (cil *CreateInvoiceLink) Retry(policy *retry.Policy) *CreateInvoiceLink {
+	cil.retry = policy
+	return cil
+}
+
 // Send creates the invoice link and returns the result.
 func (cil *CreateInvoiceLink) Send() g.Result[g.String] {
-	link, err := cil.ctx.Bot.Raw().CreateInvoiceLink(
+	link, err := cil.ctx.Bot.Raw().CreateInvoiceLinkWithContext(retry.WithPolicy(cil.ctx.Std(), cil.retry),
 		cil.title.Std(),
 		cil.desc.Std(),
 		cil.payload.Std(),
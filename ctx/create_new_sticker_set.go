@@ -7,6 +7,7 @@ import (
 	"github.com/enetx/g"
 	"github.com/enetx/tg/file"
 	"github.com/enetx/tg/input"
+	"github.com/enetx/tg/retry"
 )
 
 // CreateNewStickerSet represents a request to create a new sticker set.
@@ -17,6 +18,7 @@ type CreateNewStickerSet struct {
 	title    g.String
 	stickers g.Slice[gotgbot.InputSticker]
 	opts     *gotgbot.CreateNewStickerSetOpts
+	retry    *retry.Policy
 }
 
 // StickerBuilder represents a builder for individual stickers.
@@ -99,6 +101,12 @@ func (cns *CreateNewStickerSet) APIURL(url g.String) *CreateNewStickerSet {
 	return cns
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (cns *CreateNewStickerSet) Retry(policy *retry.Policy) *CreateNewStickerSet {
+	cns.retry = policy
+	return cns
+}
+
 // Send creates the new sticker set and returns the result.
 func (cns *CreateNewStickerSet) Send() g.Result[bool] {
 	if len(cns.stickers) == 0 {
@@ -106,5 +114,5 @@ func (cns *CreateNewStickerSet) Send() g.Result[bool] {
 	}
 
 	return g.ResultOf(cns.ctx.Bot.Raw().
-		CreateNewStickerSet(cns.userID, cns.name.Std(), cns.title.Std(), gotgbot.InputStickers(cns.stickers), cns.opts))
+		CreateNewStickerSetWithContext(retry.WithPolicy(cns.ctx.Std(), cns.retry), cns.userID, cns.name.Std(), cns.title.Std(), gotgbot.InputStickers(cns.stickers), cns.opts))
 }
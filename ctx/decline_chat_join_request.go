@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // DeclineChatJoinRequest represents a request to decline a chat join request.
@@ -13,6 +14,7 @@ type DeclineChatJoinRequest struct {
 	userID int64
 	opts   *gotgbot.DeclineChatJoinRequestOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -43,8 +45,18 @@ func (dcjr *DeclineChatJoinRequest) APIURL(url g.String) *DeclineChatJoinRequest
 	return dcjr
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (dcjr *DeclineChatJoinRequest) Retry(policy *retry.Policy) *DeclineChatJoinRequest {
+	dcjr.retry = policy
+	return dcjr
+}
+
 // Send declines the chat join request and returns the result.
 func (dcjr *DeclineChatJoinRequest) Send() g.Result[bool] {
-	chatID := dcjr.chatID.UnwrapOr(dcjr.ctx.EffectiveChat.Id)
-	return g.ResultOf(dcjr.ctx.Bot.Raw().DeclineChatJoinRequest(chatID, dcjr.userID, dcjr.opts))
+	chatID, err := dcjr.ctx.chat(dcjr.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(dcjr.ctx.Bot.Raw().DeclineChatJoinRequestWithContext(retry.WithPolicy(dcjr.ctx.Std(), dcjr.retry), chatID, dcjr.userID, dcjr.opts))
 }
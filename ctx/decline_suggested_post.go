@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // DeclineSuggestedPost represents a request to decline a suggested post.
@@ -13,6 +14,7 @@ type DeclineSuggestedPost struct {
 	chatID    g.Option[int64]
 	messageID g.Option[int64]
 	opts      *gotgbot.DeclineSuggestedPostOpts
+	retry     *retry.Policy
 }
 
 // Comment sets the comment for the creator of the suggested post, 0-128 characters.
@@ -55,10 +57,23 @@ func (dsp *DeclineSuggestedPost) APIURL(url g.String) *DeclineSuggestedPost {
 	return dsp
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (dsp *DeclineSuggestedPost) Retry(policy *retry.Policy) *DeclineSuggestedPost {
+	dsp.retry = policy
+	return dsp
+}
+
 // Send declines the suggested post.
 func (dsp *DeclineSuggestedPost) Send() g.Result[bool] {
-	chatID := dsp.chatID.UnwrapOr(dsp.ctx.EffectiveChat.Id)
-	messageID := dsp.messageID.UnwrapOr(dsp.ctx.EffectiveMessage.MessageId)
+	chatID, err := dsp.ctx.chat(dsp.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	messageID, err := dsp.ctx.message(dsp.messageID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
 
-	return g.ResultOf(dsp.ctx.Bot.Raw().DeclineSuggestedPost(chatID, messageID, dsp.opts))
+	return g.ResultOf(dsp.ctx.Bot.Raw().DeclineSuggestedPostWithContext(retry.WithPolicy(dsp.ctx.Std(), dsp.retry), chatID, messageID, dsp.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // DeleteAllMessageReactions represents a request to remove up to 10000 recent
@@ -14,6 +15,7 @@ type DeleteAllMessageReactions struct {
 	ctx    *Context
 	opts   *gotgbot.DeleteAllMessageReactionsOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID where reactions will be removed.
@@ -58,8 +60,18 @@ func (damr *DeleteAllMessageReactions) APIURL(url g.String) *DeleteAllMessageRea
 	return damr
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (damr *DeleteAllMessageReactions) Retry(policy *retry.Policy) *DeleteAllMessageReactions {
+	damr.retry = policy
+	return damr
+}
+
 // Send removes the reactions and returns the result.
 func (damr *DeleteAllMessageReactions) Send() g.Result[bool] {
-	chatID := damr.chatID.UnwrapOr(damr.ctx.EffectiveChat.Id)
-	return g.ResultOf(damr.ctx.Bot.Raw().DeleteAllMessageReactions(chatID, damr.opts))
+	chatID, err := damr.ctx.chat(damr.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(damr.ctx.Bot.Raw().DeleteAllMessageReactionsWithContext(retry.WithPolicy(damr.ctx.Std(), damr.retry), chatID, damr.opts))
 }
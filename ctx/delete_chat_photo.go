@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // DeleteChatPhoto represents a request to delete the chat photo.
@@ -12,6 +13,7 @@ type DeleteChatPhoto struct {
 	ctx    *Context
 	opts   *gotgbot.DeleteChatPhotoOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID for this request.
@@ -42,8 +44,18 @@ func (dcp *DeleteChatPhoto) APIURL(url g.String) *DeleteChatPhoto {
 	return dcp
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (dcp *DeleteChatPhoto) Retry(policy *retry.Policy) *DeleteChatPhoto {
+	dcp.retry = policy
+	return dcp
+}
+
 // Send executes the DeleteChatPhoto request.
 func (dcp *DeleteChatPhoto) Send() g.Result[bool] {
-	chatID := dcp.chatID.UnwrapOr(dcp.ctx.EffectiveChat.Id)
-	return g.ResultOf(dcp.ctx.Bot.Raw().DeleteChatPhoto(chatID, dcp.opts))
+	chatID, err := dcp.ctx.chat(dcp.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(dcp.ctx.Bot.Raw().DeleteChatPhotoWithContext(retry.WithPolicy(dcp.ctx.Std(), dcp.retry), chatID, dcp.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // DeleteChatStickerSet represents a request to delete a chat's sticker set.
@@ -12,6 +13,7 @@ type DeleteChatStickerSet struct {
 	ctx    *Context
 	opts   *gotgbot.DeleteChatStickerSetOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -42,8 +44,18 @@ func (dcss *DeleteChatStickerSet) APIURL(url g.String) *DeleteChatStickerSet {
 	return dcss
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (dcss *DeleteChatStickerSet) Retry(policy *retry.Policy) *DeleteChatStickerSet {
+	dcss.retry = policy
+	return dcss
+}
+
 // Send deletes the chat sticker set and returns the result.
 func (dcss *DeleteChatStickerSet) Send() g.Result[bool] {
-	chatID := dcss.chatID.UnwrapOr(dcss.ctx.EffectiveChat.Id)
-	return g.ResultOf(dcss.ctx.Bot.Raw().DeleteChatStickerSet(chatID, dcss.opts))
+	chatID, err := dcss.ctx.chat(dcss.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(dcss.ctx.Bot.Raw().DeleteChatStickerSetWithContext(retry.WithPolicy(dcss.ctx.Std(), dcss.retry), chatID, dcss.opts))
 }
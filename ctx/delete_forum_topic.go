@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // DeleteForumTopic represents a request to delete a forum topic.
@@ -13,6 +14,7 @@ type DeleteForumTopic struct {
 	messageThreadID int64
 	opts            *gotgbot.DeleteForumTopicOpts
 	chatID          g.Option[int64]
+	retry           *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -37,6 +39,12 @@ func (dft *DeleteForumTopic) APIURL(url g.String) *DeleteForumTopic {
 	return dft
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (dft *DeleteForumTopic) Retry(policy *retry.Policy) *DeleteForumTopic {
+	dft.retry = policy
+	return dft
+}
+
 // ChatID sets the target chat ID for this request.
 func (dft *DeleteForumTopic) ChatID(id int64) *DeleteForumTopic {
 	dft.chatID = g.Some(id)
@@ -45,6 +53,10 @@ func (dft *DeleteForumTopic) ChatID(id int64) *DeleteForumTopic {
 
 // Send executes the DeleteForumTopic request.
 func (dft *DeleteForumTopic) Send() g.Result[bool] {
-	chatID := dft.chatID.UnwrapOr(dft.ctx.EffectiveChat.Id)
-	return g.ResultOf(dft.ctx.Bot.Raw().DeleteForumTopic(chatID, dft.messageThreadID, dft.opts))
+	chatID, err := dft.ctx.chat(dft.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(dft.ctx.Bot.Raw().DeleteForumTopicWithContext(retry.WithPolicy(dft.ctx.Std(), dft.retry), chatID, dft.messageThreadID, dft.opts))
 }
@@ -1,11 +1,13 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
-	"github.com/enetx/tg/core"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 )
 
 type DeleteMessage struct {
@@ -14,6 +16,7 @@ type DeleteMessage struct {
 	messageID g.Option[int64]
 	after     g.Option[time.Duration]
 	opts      *gotgbot.DeleteMessageOpts
+	retry     *retry.Policy
 }
 
 // After schedules the message deletion after the specified duration.
@@ -56,30 +59,43 @@ func (dm *DeleteMessage) APIURL(url g.String) *DeleteMessage {
 	return dm
 }
 
-// Send deletes the message and returns the result.
-func (dm *DeleteMessage) Send() g.Result[bool] {
-	chatID := dm.chatID.UnwrapOr(dm.ctx.EffectiveChat.Id)
-	messageID := dm.messageID.UnwrapOr(dm.ctx.EffectiveMessage.MessageId)
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (dm *DeleteMessage) Retry(policy *retry.Policy) *DeleteMessage {
+	dm.retry = policy
+	return dm
+}
 
+// Send deletes the message and returns the result. With After, the deletion is scheduled as a job
+// of the bot's scheduler and Send returns true once it is scheduled.
+func (dm *DeleteMessage) Send() g.Result[bool] {
 	if dm.after.IsSome() {
-		delay := dm.after.Some()
-		dm.after = g.None[time.Duration]()
+		if job := dm.Schedule(); job.IsErr() {
+			return g.Err[bool](job.Err())
+		}
 
-		bot := dm.ctx.Bot
+		return g.Ok(true)
+	}
 
-		var opts *gotgbot.DeleteMessageOpts
-		if dm.opts != nil {
-			ocp := *dm.opts
-			opts = &ocp
-		}
+	return dm.send(dm.ctx.Std(), dm.ctx.Bot.Raw())
+}
+
+// Schedule schedules the deletion after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (dm *DeleteMessage) Schedule() g.Result[scheduler.JobID] {
+	return schedule(dm.ctx, dm.after, g.None[time.Duration](), dm.send)
+}
 
-		go func(bot core.BotAPI, chatID, messageID int64, opts *gotgbot.DeleteMessageOpts, delay time.Duration) {
-			<-time.After(delay)
-			bot.Raw().DeleteMessage(chatID, messageID, opts)
-		}(bot, chatID, messageID, opts, delay)
+// send deletes the message through raw.
+func (dm *DeleteMessage) send(std context.Context, raw *gotgbot.Bot) g.Result[bool] {
+	chatID, err := dm.ctx.chat(dm.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
 
-		return g.Ok(true)
+	messageID, err := dm.ctx.message(dm.messageID).Result()
+	if err != nil {
+		return g.Err[bool](err)
 	}
 
-	return g.ResultOf(dm.ctx.Bot.Raw().DeleteMessage(chatID, messageID, dm.opts))
+	return g.ResultOf(raw.DeleteMessageWithContext(retry.WithPolicy(std, dm.retry), chatID, messageID, dm.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // DeleteMessageReaction represents a request to remove a reaction from a message.
@@ -13,6 +14,7 @@ type DeleteMessageReaction struct {
 	messageID int64
 	opts      *gotgbot.DeleteMessageReactionOpts
 	chatID    g.Option[int64]
+	retry     *retry.Policy
 }
 
 // ChatID sets the target chat ID where the message is located.
@@ -57,8 +59,18 @@ func (dmr *DeleteMessageReaction) APIURL(url g.String) *DeleteMessageReaction {
 	return dmr
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (dmr *DeleteMessageReaction) Retry(policy *retry.Policy) *DeleteMessageReaction {
+	dmr.retry = policy
+	return dmr
+}
+
 // Send removes the reaction and returns the result.
 func (dmr *DeleteMessageReaction) Send() g.Result[bool] {
-	chatID := dmr.chatID.UnwrapOr(dmr.ctx.EffectiveChat.Id)
-	return g.ResultOf(dmr.ctx.Bot.Raw().DeleteMessageReaction(chatID, dmr.messageID, dmr.opts))
+	chatID, err := dmr.ctx.chat(dmr.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(dmr.ctx.Bot.Raw().DeleteMessageReactionWithContext(retry.WithPolicy(dmr.ctx.Std(), dmr.retry), chatID, dmr.messageID, dmr.opts))
 }
@@ -1,11 +1,13 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
-	"github.com/enetx/tg/core"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 )
 
 // DeleteMessages represents a request to delete multiple messages simultaneously.
@@ -15,6 +17,7 @@ type DeleteMessages struct {
 	messageIDs g.Slice[int64]
 	after      g.Option[time.Duration]
 	opts       *gotgbot.DeleteMessagesOpts
+	retry      *retry.Policy
 }
 
 // ChatID sets the target chat ID for the delete action.
@@ -63,8 +66,34 @@ func (dm *DeleteMessages) APIURL(url g.String) *DeleteMessages {
 	return dm
 }
 
-// Send deletes the messages and returns the result.
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (dm *DeleteMessages) Retry(policy *retry.Policy) *DeleteMessages {
+	dm.retry = policy
+	return dm
+}
+
+// Send deletes the messages and returns the result. With After, the deletion is scheduled as a job
+// of the bot's scheduler and Send returns true once it is scheduled.
 func (dm *DeleteMessages) Send() g.Result[bool] {
+	if dm.after.IsSome() {
+		if job := dm.Schedule(); job.IsErr() {
+			return g.Err[bool](job.Err())
+		}
+
+		return g.Ok(true)
+	}
+
+	return dm.send(dm.ctx.Std(), dm.ctx.Bot.Raw())
+}
+
+// Schedule schedules the deletion after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (dm *DeleteMessages) Schedule() g.Result[scheduler.JobID] {
+	return schedule(dm.ctx, dm.after, g.None[time.Duration](), dm.send)
+}
+
+// send deletes the messages through raw.
+func (dm *DeleteMessages) send(std context.Context, raw *gotgbot.Bot) g.Result[bool] {
 	if dm.messageIDs.IsEmpty() {
 		return g.Err[bool](g.Errorf("no message IDs specified for deletion"))
 	}
@@ -73,33 +102,10 @@ func (dm *DeleteMessages) Send() g.Result[bool] {
 		return g.Err[bool](g.Errorf("too many message IDs: {} (maximum 100)", dm.messageIDs.Len()))
 	}
 
-	chatID := dm.chatID.UnwrapOr(dm.ctx.EffectiveChat.Id)
-
-	if dm.after.IsSome() {
-		delay := dm.after.Some()
-		dm.after = g.None[time.Duration]()
-
-		bot := dm.ctx.Bot
-
-		var opts *gotgbot.DeleteMessagesOpts
-		if dm.opts != nil {
-			ocp := *dm.opts
-			opts = &ocp
-		}
-
-		go func(bot core.BotAPI, chatID int64, messageIDs []int64, opts *gotgbot.DeleteMessagesOpts, delay time.Duration) {
-			<-time.After(delay)
-			bot.Raw().DeleteMessages(chatID, messageIDs, opts)
-		}(
-			bot,
-			chatID,
-			dm.messageIDs,
-			opts,
-			delay,
-		)
-
-		return g.Ok(true)
+	chatID, err := dm.ctx.chat(dm.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
 	}
 
-	return g.ResultOf(dm.ctx.Bot.Raw().DeleteMessages(chatID, dm.messageIDs, dm.opts))
+	return g.ResultOf(raw.DeleteMessagesWithContext(retry.WithPolicy(std, dm.retry), chatID, dm.messageIDs, dm.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // DeleteStickerFromSet represents a request to delete a sticker from a set.
@@ -12,6 +13,7 @@ type DeleteStickerFromSet struct {
 	ctx     *Context
 	sticker gotgbot.InputFileOrString
 	opts    *gotgbot.DeleteStickerFromSetOpts
+	retry   *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -36,7 +38,13 @@ func (dsfs *DeleteStickerFromSet) APIURL(url g.String) *DeleteStickerFromSet {
 	return dsfs
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (dsfs *DeleteStickerFromSet) Retry(policy *retry.Policy) *DeleteStickerFromSet {
+	dsfs.retry = policy
+	return dsfs
+}
+
 // Send deletes the sticker from the set.
 func (dsfs *DeleteStickerFromSet) Send() g.Result[bool] {
-	return g.ResultOf(dsfs.ctx.Bot.Raw().DeleteStickerFromSet(dsfs.sticker, dsfs.opts))
+	return g.ResultOf(dsfs.ctx.Bot.Raw().DeleteStickerFromSetWithContext(retry.WithPolicy(dsfs.ctx.Std(), dsfs.retry), dsfs.sticker, dsfs.opts))
 }
@@ -5,13 +5,15 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // DeleteStickerSet represents a request to delete a sticker set.
 type DeleteStickerSet struct {
-	ctx  *Context
-	name g.String
-	opts *gotgbot.DeleteStickerSetOpts
+	ctx   *Context
+	name  g.String
+	opts  *gotgbot.DeleteStickerSetOpts
+	retry *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -36,7 +38,13 @@ func (dss *DeleteStickerSet) APIURL(url g.String) *DeleteStickerSet {
 	return dss
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (dss *DeleteStickerSet) Retry(policy *retry.Policy) *DeleteStickerSet {
+	dss.retry = policy
+	return dss
+}
+
 // Send deletes the sticker set.
 func (dss *DeleteStickerSet) Send() g.Result[bool] {
-	return g.ResultOf(dss.ctx.Bot.Raw().DeleteStickerSet(dss.name.Std(), dss.opts))
+	return g.ResultOf(dss.ctx.Bot.Raw().DeleteStickerSetWithContext(retry.WithPolicy(dss.ctx.Std(), dss.retry), dss.name.Std(), dss.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // DeleteStory represents a request to delete a story.
@@ -13,6 +14,7 @@ type DeleteStory struct {
 	businessConnectionID g.String
 	storyID              int64
 	opts                 *gotgbot.DeleteStoryOpts
+	retry                *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -37,9 +39,15 @@ func (ds *DeleteStory) APIURL(url g.String) *DeleteStory {
 	return ds
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ds *DeleteStory) Retry(policy *retry.Policy) *DeleteStory {
+	ds.retry = policy
+	return ds
+}
+
 // Send executes the DeleteStory request.
 func (ds *DeleteStory) Send() g.Result[bool] {
-	return g.ResultOf(ds.ctx.Bot.Raw().DeleteStory(
+	return g.ResultOf(ds.ctx.Bot.Raw().DeleteStoryWithContext(retry.WithPolicy(ds.ctx.Std(), ds.retry),
 		ds.businessConnectionID.Std(),
 		ds.storyID,
 		ds.opts,
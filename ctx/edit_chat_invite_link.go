@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // EditChatInviteLink represents a request to edit an existing chat invite link.
@@ -13,6 +14,7 @@ type EditChatInviteLink struct {
 	inviteLink g.String
 	opts       *gotgbot.EditChatInviteLinkOpts
 	chatID     g.Option[int64]
+	retry      *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -73,8 +75,18 @@ func (ecil *EditChatInviteLink) APIURL(url g.String) *EditChatInviteLink {
 	return ecil
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ecil *EditChatInviteLink) Retry(policy *retry.Policy) *EditChatInviteLink {
+	ecil.retry = policy
+	return ecil
+}
+
 // Send edits the chat invite link and returns the result.
 func (ecil *EditChatInviteLink) Send() g.Result[*gotgbot.ChatInviteLink] {
-	chatID := ecil.chatID.UnwrapOr(ecil.ctx.EffectiveChat.Id)
-	return g.ResultOf(ecil.ctx.Bot.Raw().EditChatInviteLink(chatID, ecil.inviteLink.Std(), ecil.opts))
+	chatID, err := ecil.ctx.chat(ecil.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.ChatInviteLink](err)
+	}
+
+	return g.ResultOf(ecil.ctx.Bot.Raw().EditChatInviteLinkWithContext(retry.WithPolicy(ecil.ctx.Std(), ecil.retry), chatID, ecil.inviteLink.Std(), ecil.opts))
 }
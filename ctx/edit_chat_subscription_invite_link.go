@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // EditChatSubscriptionInviteLink represents a request to edit a subscription invite link.
@@ -13,6 +14,7 @@ type EditChatSubscriptionInviteLink struct {
 	inviteLink g.String
 	opts       *gotgbot.EditChatSubscriptionInviteLinkOpts
 	chatID     g.Option[int64]
+	retry      *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -49,10 +51,21 @@ func (ecsil *EditChatSubscriptionInviteLink) APIURL(url g.String) *EditChatSubsc
 	return ecsil
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ecsil *EditChatSubscriptionInviteLink) Retry(policy *retry.Policy) *EditChatSubscriptionInviteLink {
+	ecsil.retry = policy
+	return ecsil
+}
+
 // Send edits the subscription invite link.
 func (ecsil *EditChatSubscriptionInviteLink) Send() g.Result[*gotgbot.ChatInviteLink] {
-	return g.ResultOf(ecsil.ctx.Bot.Raw().EditChatSubscriptionInviteLink(
-		ecsil.chatID.UnwrapOr(ecsil.ctx.EffectiveChat.Id),
+	chatID, err := ecsil.ctx.chat(ecsil.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.ChatInviteLink](err)
+	}
+
+	return g.ResultOf(ecsil.ctx.Bot.Raw().EditChatSubscriptionInviteLinkWithContext(retry.WithPolicy(ecsil.ctx.Std(), ecsil.retry),
+		chatID,
 		ecsil.inviteLink.Std(),
 		ecsil.opts,
 	))
@@ -6,6 +6,7 @@ import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/g/ref"
+	"github.com/enetx/tg/retry"
 )
 
 // EditForumTopic represents a request to edit a forum topic.
@@ -14,6 +15,7 @@ type EditForumTopic struct {
 	messageThreadID int64
 	opts            *gotgbot.EditForumTopicOpts
 	chatID          g.Option[int64]
+	retry           *retry.Policy
 }
 
 // Name sets the new name of the topic.
@@ -50,6 +52,12 @@ func (eft *EditForumTopic) APIURL(url g.String) *EditForumTopic {
 	return eft
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (eft *EditForumTopic) Retry(policy *retry.Policy) *EditForumTopic {
+	eft.retry = policy
+	return eft
+}
+
 // ChatID sets the target chat ID for this request.
 func (eft *EditForumTopic) ChatID(id int64) *EditForumTopic {
 	eft.chatID = g.Some(id)
@@ -58,6 +66,10 @@ func (eft *EditForumTopic) ChatID(id int64) *EditForumTopic {
 
 // Send executes the EditForumTopic request.
 func (eft *EditForumTopic) Send() g.Result[bool] {
-	chatID := eft.chatID.UnwrapOr(eft.ctx.EffectiveChat.Id)
-	return g.ResultOf(eft.ctx.Bot.Raw().EditForumTopic(chatID, eft.messageThreadID, eft.opts))
+	chatID, err := eft.ctx.chat(eft.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(eft.ctx.Bot.Raw().EditForumTopicWithContext(retry.WithPolicy(eft.ctx.Std(), eft.retry), chatID, eft.messageThreadID, eft.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // EditGeneralForumTopic represents a request to edit the general forum topic.
@@ -13,6 +14,7 @@ type EditGeneralForumTopic struct {
 	name   g.String
 	opts   *gotgbot.EditGeneralForumTopicOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -37,6 +39,12 @@ func (egft *EditGeneralForumTopic) APIURL(url g.String) *EditGeneralForumTopic {
 	return egft
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (egft *EditGeneralForumTopic) Retry(policy *retry.Policy) *EditGeneralForumTopic {
+	egft.retry = policy
+	return egft
+}
+
 // ChatID sets the target chat ID for this request.
 func (egft *EditGeneralForumTopic) ChatID(id int64) *EditGeneralForumTopic {
 	egft.chatID = g.Some(id)
@@ -45,6 +53,10 @@ func (egft *EditGeneralForumTopic) ChatID(id int64) *EditGeneralForumTopic {
 
 // Send executes the EditGeneralForumTopic request.
 func (egft *EditGeneralForumTopic) Send() g.Result[bool] {
-	chatID := egft.chatID.UnwrapOr(egft.ctx.EffectiveChat.Id)
-	return g.ResultOf(egft.ctx.Bot.Raw().EditGeneralForumTopic(chatID, egft.name.Std(), egft.opts))
+	chatID, err := egft.ctx.chat(egft.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(egft.ctx.Bot.Raw().EditGeneralForumTopicWithContext(retry.WithPolicy(egft.ctx.Std(), egft.retry), chatID, egft.name.Std(), egft.opts))
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/enetx/g"
 	"github.com/enetx/tg/entities"
 	"github.com/enetx/tg/keyboard"
+	"github.com/enetx/tg/retry"
 )
 
 // EditMessageCaption represents a request to edit a message caption.
@@ -15,6 +16,8 @@ type EditMessageCaption struct {
 	opts      *gotgbot.EditMessageCaptionOpts
 	chatID    g.Option[int64]
 	messageID g.Option[int64]
+	retry     *retry.Policy
+	markupErr error
 }
 
 // ChatID sets the target chat ID for the caption edit.
@@ -71,6 +74,8 @@ func (emc *EditMessageCaption) Markup(kb keyboard.Keyboard) *EditMessageCaption
 		emc.opts.ReplyMarkup = markup
 	}
 
+	emc.markupErr = keyboardErr(kb)
+
 	return emc
 }
 
@@ -96,11 +101,33 @@ func (emc *EditMessageCaption) APIURL(url g.String) *EditMessageCaption {
 	return emc
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (emc *EditMessageCaption) Retry(policy *retry.Policy) *EditMessageCaption {
+	emc.retry = policy
+	return emc
+}
+
 // Send edits the message caption and returns the result.
 func (emc *EditMessageCaption) Send() g.Result[*gotgbot.Message] {
-	emc.opts.ChatId = emc.chatID.UnwrapOr(emc.ctx.EffectiveChat.Id)
-	emc.opts.MessageId = emc.messageID.UnwrapOr(emc.ctx.EffectiveMessage.MessageId)
+	if emc.markupErr != nil {
+		return g.Err[*gotgbot.Message](emc.markupErr)
+	}
+
+	if emc.opts.InlineMessageId == "" {
+		chatID, err := emc.ctx.chat(emc.chatID).Result()
+		if err != nil {
+			return g.Err[*gotgbot.Message](err)
+		}
+
+		messageID, err := emc.ctx.message(emc.messageID).Result()
+		if err != nil {
+			return g.Err[*gotgbot.Message](err)
+		}
+
+		emc.opts.ChatId = chatID
+		emc.opts.MessageId = messageID
+	}
 
-	msg, _, err := emc.ctx.Bot.Raw().EditMessageCaption(emc.opts)
+	msg, _, err := emc.ctx.Bot.Raw().EditMessageCaptionWithContext(retry.WithPolicy(emc.ctx.Std(), emc.retry), emc.opts)
 	return g.ResultOf(msg, err)
 }
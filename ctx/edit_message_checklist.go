@@ -6,6 +6,7 @@ import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/keyboard"
+	"github.com/enetx/tg/retry"
 )
 
 // EditMessageChecklist represents a request to edit a checklist message.
@@ -17,6 +18,8 @@ type EditMessageChecklist struct {
 	chatID               g.Option[int64]
 	messageID            g.Option[int64]
 	taskIDCounter        int64
+	retry                *retry.Policy
+	markupErr            error
 }
 
 // Task starts building a new checklist task.
@@ -72,6 +75,8 @@ func (emc *EditMessageChecklist) Markup(kb keyboard.Keyboard) *EditMessageCheckl
 		emc.opts.ReplyMarkup = markup
 	}
 
+	emc.markupErr = keyboardErr(kb)
+
 	return emc
 }
 
@@ -97,8 +102,18 @@ func (emc *EditMessageChecklist) APIURL(url g.String) *EditMessageChecklist {
 	return emc
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (emc *EditMessageChecklist) Retry(policy *retry.Policy) *EditMessageChecklist {
+	emc.retry = policy
+	return emc
+}
+
 // Send edits the checklist message and returns the result.
 func (emc *EditMessageChecklist) Send() g.Result[*gotgbot.Message] {
+	if emc.markupErr != nil {
+		return g.Err[*gotgbot.Message](emc.markupErr)
+	}
+
 	if len(emc.checklist.Tasks) == 0 {
 		return g.Err[*gotgbot.Message](g.Errorf("no tasks in checklist"))
 	}
@@ -108,9 +123,16 @@ func (emc *EditMessageChecklist) Send() g.Result[*gotgbot.Message] {
 	}
 
 	// Handle regular message editing
-	chatID := emc.chatID.UnwrapOr(emc.ctx.EffectiveChat.Id)
-	messageID := emc.messageID.UnwrapOr(emc.ctx.EffectiveMessage.MessageId)
+	chatID, err := emc.ctx.chat(emc.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	messageID, err := emc.ctx.message(emc.messageID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
 
 	return g.ResultOf(emc.ctx.Bot.Raw().
-		EditMessageChecklist(emc.businessConnectionID.Std(), chatID, messageID, emc.checklist, emc.opts))
+		EditMessageChecklistWithContext(retry.WithPolicy(emc.ctx.Std(), emc.retry), emc.businessConnectionID.Std(), chatID, messageID, emc.checklist, emc.opts))
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/enetx/g"
 	"github.com/enetx/g/ref"
 	"github.com/enetx/tg/keyboard"
+	"github.com/enetx/tg/retry"
 )
 
 // EditMessageLiveLocation represents a request to edit a live location message.
@@ -17,6 +18,8 @@ type EditMessageLiveLocation struct {
 	opts      *gotgbot.EditMessageLiveLocationOpts
 	chatID    g.Option[int64]
 	messageID g.Option[int64]
+	retry     *retry.Policy
+	markupErr error
 }
 
 // ChatID sets the target chat ID.
@@ -73,6 +76,8 @@ func (emll *EditMessageLiveLocation) Markup(kb *keyboard.InlineKeyboard) *EditMe
 		emll.opts.ReplyMarkup = markup
 	}
 
+	emll.markupErr = keyboardErr(kb)
+
 	return emll
 }
 
@@ -98,11 +103,34 @@ func (emll *EditMessageLiveLocation) APIURL(url g.String) *EditMessageLiveLocati
 	return emll
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (emll *EditMessageLiveLocation) Retry(policy *retry.Policy) *EditMessageLiveLocation {
+	emll.retry = policy
+	return emll
+}
+
 // Send edits the live location message.
 func (emll *EditMessageLiveLocation) Send() g.Result[*gotgbot.Message] {
-	emll.opts.ChatId = emll.chatID.UnwrapOr(emll.ctx.EffectiveChat.Id)
-	emll.opts.MessageId = emll.messageID.UnwrapOr(emll.ctx.EffectiveMessage.MessageId)
-	msg, _, err := emll.ctx.Bot.Raw().EditMessageLiveLocation(emll.latitude, emll.longitude, emll.opts)
+	if emll.markupErr != nil {
+		return g.Err[*gotgbot.Message](emll.markupErr)
+	}
+
+	if emll.opts.InlineMessageId == "" {
+		chatID, err := emll.ctx.chat(emll.chatID).Result()
+		if err != nil {
+			return g.Err[*gotgbot.Message](err)
+		}
+
+		messageID, err := emll.ctx.message(emll.messageID).Result()
+		if err != nil {
+			return g.Err[*gotgbot.Message](err)
+		}
+
+		emll.opts.ChatId = chatID
+		emll.opts.MessageId = messageID
+	}
+
+	msg, _, err := emll.ctx.Bot.Raw().EditMessageLiveLocationWithContext(retry.WithPolicy(emll.ctx.Std(), emll.retry), emll.latitude, emll.longitude, emll.opts)
 
 	return g.ResultOf(msg, err)
 }
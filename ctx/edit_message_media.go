@@ -7,6 +7,7 @@ import (
 	"github.com/enetx/g"
 	"github.com/enetx/tg/input"
 	"github.com/enetx/tg/keyboard"
+	"github.com/enetx/tg/retry"
 )
 
 // EditMessageMedia represents a request to edit message media.
@@ -16,6 +17,8 @@ type EditMessageMedia struct {
 	opts      *gotgbot.EditMessageMediaOpts
 	chatID    g.Option[int64]
 	messageID g.Option[int64]
+	retry     *retry.Policy
+	markupErr error
 }
 
 // ChatID sets the target chat ID for the media edit.
@@ -48,6 +51,8 @@ func (emm *EditMessageMedia) Markup(kb keyboard.Keyboard) *EditMessageMedia {
 		emm.opts.ReplyMarkup = markup
 	}
 
+	emm.markupErr = keyboardErr(kb)
+
 	return emm
 }
 
@@ -73,11 +78,33 @@ func (emm *EditMessageMedia) APIURL(url g.String) *EditMessageMedia {
 	return emm
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (emm *EditMessageMedia) Retry(policy *retry.Policy) *EditMessageMedia {
+	emm.retry = policy
+	return emm
+}
+
 // Send edits the message media and returns the result.
 func (emm *EditMessageMedia) Send() g.Result[*gotgbot.Message] {
-	emm.opts.ChatId = emm.chatID.UnwrapOr(emm.ctx.EffectiveChat.Id)
-	emm.opts.MessageId = emm.messageID.UnwrapOr(emm.ctx.EffectiveMessage.MessageId)
+	if emm.markupErr != nil {
+		return g.Err[*gotgbot.Message](emm.markupErr)
+	}
+
+	if emm.opts.InlineMessageId == "" {
+		chatID, err := emm.ctx.chat(emm.chatID).Result()
+		if err != nil {
+			return g.Err[*gotgbot.Message](err)
+		}
+
+		messageID, err := emm.ctx.message(emm.messageID).Result()
+		if err != nil {
+			return g.Err[*gotgbot.Message](err)
+		}
+
+		emm.opts.ChatId = chatID
+		emm.opts.MessageId = messageID
+	}
 
-	msg, _, err := emm.ctx.Bot.Raw().EditMessageMedia(emm.media.Build(), emm.opts)
+	msg, _, err := emm.ctx.Bot.Raw().EditMessageMediaWithContext(retry.WithPolicy(emm.ctx.Std(), emm.retry), emm.media.Build(), emm.opts)
 	return g.ResultOf(msg, err)
 }
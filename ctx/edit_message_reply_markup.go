@@ -6,6 +6,7 @@ import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/keyboard"
+	"github.com/enetx/tg/retry"
 )
 
 type EditMessageReplyMarkup struct {
@@ -14,6 +15,7 @@ type EditMessageReplyMarkup struct {
 	kb        keyboard.Keyboard
 	chatID    g.Option[int64]
 	messageID g.Option[int64]
+	retry     *retry.Policy
 }
 
 // ChatID sets the target chat ID for the markup edit.
@@ -62,17 +64,40 @@ func (emrm *EditMessageReplyMarkup) APIURL(url g.String) *EditMessageReplyMarkup
 	return emrm
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (emrm *EditMessageReplyMarkup) Retry(policy *retry.Policy) *EditMessageReplyMarkup {
+	emrm.retry = policy
+	return emrm
+}
+
 // Send edits the message reply markup and returns the result.
 func (emrm *EditMessageReplyMarkup) Send() g.Result[*gotgbot.Message] {
 	if emrm.kb != nil {
+		if err := keyboardErr(emrm.kb); err != nil {
+			return g.Err[*gotgbot.Message](err)
+		}
+
 		if markup, ok := emrm.kb.Markup().(gotgbot.InlineKeyboardMarkup); ok {
 			emrm.opts.ReplyMarkup = markup
 		}
 	}
 
-	emrm.opts.ChatId = emrm.chatID.UnwrapOr(emrm.ctx.EffectiveChat.Id)
-	emrm.opts.MessageId = emrm.messageID.UnwrapOr(emrm.ctx.EffectiveMessage.MessageId)
-	msg, _, err := emrm.ctx.Bot.Raw().EditMessageReplyMarkup(emrm.opts)
+	if emrm.opts.InlineMessageId == "" {
+		chatID, err := emrm.ctx.chat(emrm.chatID).Result()
+		if err != nil {
+			return g.Err[*gotgbot.Message](err)
+		}
+
+		messageID, err := emrm.ctx.message(emrm.messageID).Result()
+		if err != nil {
+			return g.Err[*gotgbot.Message](err)
+		}
+
+		emrm.opts.ChatId = chatID
+		emrm.opts.MessageId = messageID
+	}
+
+	msg, _, err := emrm.ctx.Bot.Raw().EditMessageReplyMarkupWithContext(retry.WithPolicy(emrm.ctx.Std(), emrm.retry), emrm.opts)
 
 	return g.ResultOf(msg, err)
 }
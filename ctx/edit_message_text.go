@@ -8,6 +8,7 @@ import (
 	"github.com/enetx/tg/entities"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/preview"
+	"github.com/enetx/tg/retry"
 )
 
 type EditMessageText struct {
@@ -16,6 +17,8 @@ type EditMessageText struct {
 	chatID    g.Option[int64]
 	messageID g.Option[int64]
 	opts      *gotgbot.EditMessageTextOpts
+	retry     *retry.Policy
+	markupErr error
 }
 
 // Entities sets custom entities for the edited text.
@@ -82,12 +85,20 @@ func (emt *EditMessageText) APIURL(url g.String) *EditMessageText {
 	return emt
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (emt *EditMessageText) Retry(policy *retry.Policy) *EditMessageText {
+	emt.retry = policy
+	return emt
+}
+
 // Markup sets the reply markup keyboard for the edited message.
 func (emt *EditMessageText) Markup(kb keyboard.Keyboard) *EditMessageText {
 	if markup, ok := kb.Markup().(gotgbot.InlineKeyboardMarkup); ok {
 		emt.opts.ReplyMarkup = markup
 	}
 
+	emt.markupErr = keyboardErr(kb)
+
 	return emt
 }
 
@@ -99,9 +110,26 @@ func (emt *EditMessageText) Preview(p *preview.Preview) *EditMessageText {
 
 // Send edits the message text and returns the result.
 func (emt *EditMessageText) Send() g.Result[*gotgbot.Message] {
-	emt.opts.ChatId = emt.chatID.UnwrapOr(emt.ctx.EffectiveChat.Id)
-	emt.opts.MessageId = emt.messageID.UnwrapOr(emt.ctx.EffectiveMessage.MessageId)
-	msg, _, err := emt.ctx.Bot.Raw().EditMessageText(emt.text.Std(), emt.opts)
+	if emt.markupErr != nil {
+		return g.Err[*gotgbot.Message](emt.markupErr)
+	}
+
+	if emt.opts.InlineMessageId == "" {
+		chatID, err := emt.ctx.chat(emt.chatID).Result()
+		if err != nil {
+			return g.Err[*gotgbot.Message](err)
+		}
+
+		messageID, err := emt.ctx.message(emt.messageID).Result()
+		if err != nil {
+			return g.Err[*gotgbot.Message](err)
+		}
+
+		emt.opts.ChatId = chatID
+		emt.opts.MessageId = messageID
+	}
+
+	msg, _, err := emt.ctx.Bot.Raw().EditMessageTextWithContext(retry.WithPolicy(emt.ctx.Std(), emt.retry), emt.text.Std(), emt.opts)
 
 	return g.ResultOf(msg, err)
 }
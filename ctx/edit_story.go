@@ -8,6 +8,7 @@ import (
 	"github.com/enetx/tg/areas"
 	"github.com/enetx/tg/entities"
 	"github.com/enetx/tg/input"
+	"github.com/enetx/tg/retry"
 )
 
 // EditStory represents a request to edit an existing story.
@@ -17,6 +18,7 @@ type EditStory struct {
 	storyID              int64
 	content              input.StoryContent
 	opts                 *gotgbot.EditStoryOpts
+	retry                *retry.Policy
 }
 
 // Caption sets the new caption text for the story.
@@ -71,9 +73,15 @@ func (es *EditStory) APIURL(url g.String) *EditStory {
 	return es
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (es *EditStory) Retry(policy *retry.Policy) *EditStory {
+	es.retry = policy
+	return es
+}
+
 // Send executes the EditStory request.
 func (es *EditStory) Send() g.Result[*gotgbot.Story] {
-	return g.ResultOf(es.ctx.Bot.Raw().EditStory(
+	return g.ResultOf(es.ctx.Bot.Raw().EditStoryWithContext(retry.WithPolicy(es.ctx.Std(), es.retry),
 		es.businessConnectionID.Std(),
 		es.storyID,
 		es.content.Build(),
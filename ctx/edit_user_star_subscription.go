@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // EditUserStarSubscription is a request builder for editing user star subscriptions.
@@ -14,6 +15,7 @@ type EditUserStarSubscription struct {
 	telegramPaymentChargeID g.String
 	isCanceled              bool
 	opts                    *gotgbot.EditUserStarSubscriptionOpts
+	retry                   *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -38,9 +40,15 @@ func (c *EditUserStarSubscription) APIURL(url g.String) *EditUserStarSubscriptio
 	return c
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (c *EditUserStarSubscription) Retry(policy *retry.Policy) *EditUserStarSubscription {
+	c.retry = policy
+	return c
+}
+
 // Send executes the EditUserStarSubscription request.
 func (c *EditUserStarSubscription) Send() g.Result[bool] {
-	return g.ResultOf(c.ctx.Bot.Raw().EditUserStarSubscription(
+	return g.ResultOf(c.ctx.Bot.Raw().EditUserStarSubscriptionWithContext(retry.WithPolicy(c.ctx.Std(), c.retry),
 		c.userID,
 		c.telegramPaymentChargeID.Std(),
 		c.isCanceled,
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // ExportChatInviteLink represents a request to export a chat invite link.
@@ -12,6 +13,7 @@ type ExportChatInviteLink struct {
 	ctx    *Context
 	opts   *gotgbot.ExportChatInviteLinkOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -42,10 +44,20 @@ func (ecil *ExportChatInviteLink) APIURL(url g.String) *ExportChatInviteLink {
 	return ecil
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ecil *ExportChatInviteLink) Retry(policy *retry.Policy) *ExportChatInviteLink {
+	ecil.retry = policy
+	return ecil
+}
+
 // Send exports the chat invite link and returns the result.
 func (ecil *ExportChatInviteLink) Send() g.Result[g.String] {
-	chatID := ecil.chatID.UnwrapOr(ecil.ctx.EffectiveChat.Id)
-	link, err := ecil.ctx.Bot.Raw().ExportChatInviteLink(chatID, ecil.opts)
+	chatID, err := ecil.ctx.chat(ecil.chatID).Result()
+	if err != nil {
+		return g.Err[g.String](err)
+	}
+
+	link, err := ecil.ctx.Bot.Raw().ExportChatInviteLinkWithContext(retry.WithPolicy(ecil.ctx.Std(), ecil.retry), chatID, ecil.opts)
 
 	return g.ResultOf(g.String(link), err)
 }
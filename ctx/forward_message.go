@@ -1,10 +1,13 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/suggested"
 	"github.com/enetx/tg/types/effects"
 )
@@ -17,6 +20,7 @@ type ForwardMessage struct {
 	toChatID    g.Option[int64]
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
+	retry       *retry.Policy
 }
 
 // After schedules the forward to be sent after the specified duration.
@@ -71,6 +75,12 @@ func (fm *ForwardMessage) APIURL(url g.String) *ForwardMessage {
 	return fm
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (fm *ForwardMessage) Retry(policy *retry.Policy) *ForwardMessage {
+	fm.retry = policy
+	return fm
+}
+
 // SuggestedPost sets suggested post parameters for direct messages chats.
 func (fm *ForwardMessage) SuggestedPost(params *suggested.PostParameters) *ForwardMessage {
 	if params != nil {
@@ -93,8 +103,21 @@ func (fm *ForwardMessage) DirectMessagesTopic(topicID int64) *ForwardMessage {
 
 // Send forwards the message to the target chat and returns the result.
 func (fm *ForwardMessage) Send() g.Result[*gotgbot.Message] {
-	return fm.ctx.timers(fm.after, fm.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := fm.toChatID.UnwrapOr(fm.ctx.EffectiveChat.Id)
-		return g.ResultOf(fm.ctx.Bot.Raw().ForwardMessage(chatID, fm.fromChatID, fm.messageID, fm.opts))
-	})
+	return fm.ctx.timers(fm.after, fm.deleteAfter, fm.send)
+}
+
+// Schedule schedules the message to be forwarded after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (fm *ForwardMessage) Schedule() g.Result[scheduler.JobID] {
+	return schedule(fm.ctx, fm.after, fm.deleteAfter, fm.send)
+}
+
+// send forwards the message through raw.
+func (fm *ForwardMessage) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	chatID, err := fm.ctx.chat(fm.toChatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	return g.ResultOf(raw.ForwardMessageWithContext(retry.WithPolicy(std, fm.retry), chatID, fm.fromChatID, fm.messageID, fm.opts))
 }
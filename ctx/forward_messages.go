@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // ForwardMessages represents a request to forward multiple messages.
@@ -14,6 +15,7 @@ type ForwardMessages struct {
 	fromChatID g.Option[int64]
 	messageIDs g.Slice[int64]
 	opts       *gotgbot.ForwardMessagesOpts
+	retry      *retry.Policy
 }
 
 // To sets the target chat ID for forwarding messages.
@@ -80,6 +82,12 @@ func (fms *ForwardMessages) APIURL(url g.String) *ForwardMessages {
 	return fms
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (fms *ForwardMessages) Retry(policy *retry.Policy) *ForwardMessages {
+	fms.retry = policy
+	return fms
+}
+
 // DirectMessagesTopic sets the direct messages topic ID for the message.
 func (fms *ForwardMessages) DirectMessagesTopic(topicID int64) *ForwardMessages {
 	fms.opts.DirectMessagesTopicId = topicID
@@ -102,10 +110,14 @@ func (fms *ForwardMessages) Send() g.Result[g.Slice[gotgbot.MessageId]] {
 		return g.Err[g.Slice[gotgbot.MessageId]](g.Errorf("source chat ID must be specified"))
 	}
 
-	chatID := fms.chatID.UnwrapOr(fms.ctx.EffectiveChat.Id)
+	chatID, err := fms.ctx.chat(fms.chatID).Result()
+	if err != nil {
+		return g.Err[g.Slice[gotgbot.MessageId]](err)
+	}
+
 	fromChatID := fms.fromChatID.Some()
 
-	result, err := fms.ctx.Bot.Raw().ForwardMessages(chatID, fromChatID, fms.messageIDs, fms.opts)
+	result, err := fms.ctx.Bot.Raw().ForwardMessagesWithContext(retry.WithPolicy(fms.ctx.Std(), fms.retry), chatID, fromChatID, fms.messageIDs, fms.opts)
 
 	return g.ResultOf[g.Slice[gotgbot.MessageId]](result, err)
 }
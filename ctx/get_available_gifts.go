@@ -5,12 +5,14 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetAvailableGifts is a request builder for getting available gifts.
 type GetAvailableGifts struct {
-	ctx  *Context
-	opts *gotgbot.GetAvailableGiftsOpts
+	ctx   *Context
+	opts  *gotgbot.GetAvailableGiftsOpts
+	retry *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -35,7 +37,13 @@ func (gags *GetAvailableGifts) APIURL(url g.String) *GetAvailableGifts {
 	return gags
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gags *GetAvailableGifts) Retry(policy *retry.Policy) *GetAvailableGifts {
+	gags.retry = policy
+	return gags
+}
+
 // Send executes the GetAvailableGifts request.
 func (gags *GetAvailableGifts) Send() g.Result[*gotgbot.Gifts] {
-	return g.ResultOf(gags.ctx.Bot.Raw().GetAvailableGifts(gags.opts))
+	return g.ResultOf(gags.ctx.Bot.Raw().GetAvailableGiftsWithContext(retry.WithPolicy(gags.ctx.Std(), gags.retry), gags.opts))
 }
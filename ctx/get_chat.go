@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetChat represents a request to get chat information.
@@ -12,6 +13,7 @@ type GetChat struct {
 	ctx    *Context
 	opts   *gotgbot.GetChatOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID for this request.
@@ -53,8 +55,18 @@ func (gc *GetChat) APIURL(url g.String) *GetChat {
 	return gc
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gc *GetChat) Retry(policy *retry.Policy) *GetChat {
+	gc.retry = policy
+	return gc
+}
+
 // Send executes the GetChat request and returns full chat information.
 func (gc *GetChat) Send() g.Result[*gotgbot.ChatFullInfo] {
-	chatID := gc.chatID.UnwrapOr(gc.ctx.EffectiveChat.Id)
-	return g.ResultOf(gc.ctx.Bot.Raw().GetChat(chatID, gc.opts))
+	chatID, err := gc.ctx.chat(gc.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.ChatFullInfo](err)
+	}
+
+	return g.ResultOf(gc.ctx.Bot.Raw().GetChatWithContext(retry.WithPolicy(gc.ctx.Std(), gc.retry), chatID, gc.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetChatAdministrators represents a request to get chat administrators.
@@ -12,6 +13,7 @@ type GetChatAdministrators struct {
 	ctx    *Context
 	opts   *gotgbot.GetChatAdministratorsOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID for this request.
@@ -48,10 +50,20 @@ func (gca *GetChatAdministrators) APIURL(url g.String) *GetChatAdministrators {
 	return gca
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gca *GetChatAdministrators) Retry(policy *retry.Policy) *GetChatAdministrators {
+	gca.retry = policy
+	return gca
+}
+
 // Send executes the GetChatAdministrators request.
 func (gca *GetChatAdministrators) Send() g.Result[g.Slice[gotgbot.ChatMember]] {
-	chatID := gca.chatID.UnwrapOr(gca.ctx.EffectiveChat.Id)
-	members, err := gca.ctx.Bot.Raw().GetChatAdministrators(chatID, gca.opts)
+	chatID, err := gca.ctx.chat(gca.chatID).Result()
+	if err != nil {
+		return g.Err[g.Slice[gotgbot.ChatMember]](err)
+	}
+
+	members, err := gca.ctx.Bot.Raw().GetChatAdministratorsWithContext(retry.WithPolicy(gca.ctx.Std(), gca.retry), chatID, gca.opts)
 
 	return g.ResultOf[g.Slice[gotgbot.ChatMember]](members, err)
 }
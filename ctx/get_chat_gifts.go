@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetChatGifts represents a request to get gifts received by a chat.
@@ -12,6 +13,7 @@ type GetChatGifts struct {
 	ctx    *Context
 	opts   *gotgbot.GetChatGiftsOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID for this request.
@@ -102,8 +104,18 @@ func (gcg *GetChatGifts) APIURL(url g.String) *GetChatGifts {
 	return gcg
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gcg *GetChatGifts) Retry(policy *retry.Policy) *GetChatGifts {
+	gcg.retry = policy
+	return gcg
+}
+
 // Send executes the GetChatGifts request and returns the chat's gifts.
 func (gcg *GetChatGifts) Send() g.Result[*gotgbot.OwnedGifts] {
-	chatID := gcg.chatID.UnwrapOr(gcg.ctx.EffectiveChat.Id)
-	return g.ResultOf(gcg.ctx.Bot.Raw().GetChatGifts(chatID, gcg.opts))
+	chatID, err := gcg.ctx.chat(gcg.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.OwnedGifts](err)
+	}
+
+	return g.ResultOf(gcg.ctx.Bot.Raw().GetChatGiftsWithContext(retry.WithPolicy(gcg.ctx.Std(), gcg.retry), chatID, gcg.opts))
 }
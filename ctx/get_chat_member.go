@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetChatMember represents a request to get information about a chat member.
@@ -13,6 +14,7 @@ type GetChatMember struct {
 	userID int64
 	opts   *gotgbot.GetChatMemberOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID for this request.
@@ -49,8 +51,18 @@ func (gcm *GetChatMember) APIURL(url g.String) *GetChatMember {
 	return gcm
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gcm *GetChatMember) Retry(policy *retry.Policy) *GetChatMember {
+	gcm.retry = policy
+	return gcm
+}
+
 // Send executes the GetChatMember request and returns chat member information.
 func (gcm *GetChatMember) Send() g.Result[gotgbot.ChatMember] {
-	chatID := gcm.chatID.UnwrapOr(gcm.ctx.EffectiveChat.Id)
-	return g.ResultOf(gcm.ctx.Bot.Raw().GetChatMember(chatID, gcm.userID, gcm.opts))
+	chatID, err := gcm.ctx.chat(gcm.chatID).Result()
+	if err != nil {
+		return g.Err[gotgbot.ChatMember](err)
+	}
+
+	return g.ResultOf(gcm.ctx.Bot.Raw().GetChatMemberWithContext(retry.WithPolicy(gcm.ctx.Std(), gcm.retry), chatID, gcm.userID, gcm.opts))
 }
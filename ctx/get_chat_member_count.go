@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetChatMemberCount represents a request to get the chat member count.
@@ -12,6 +13,7 @@ type GetChatMemberCount struct {
 	ctx    *Context
 	opts   *gotgbot.GetChatMemberCountOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID for this request.
@@ -42,10 +44,20 @@ func (gcm *GetChatMemberCount) APIURL(url g.String) *GetChatMemberCount {
 	return gcm
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gcm *GetChatMemberCount) Retry(policy *retry.Policy) *GetChatMemberCount {
+	gcm.retry = policy
+	return gcm
+}
+
 // Send executes the GetChatMemberCount request.
 func (gcm *GetChatMemberCount) Send() g.Result[g.Int] {
-	chatID := gcm.chatID.UnwrapOr(gcm.ctx.EffectiveChat.Id)
-	count, err := gcm.ctx.Bot.Raw().GetChatMemberCount(chatID, gcm.opts)
+	chatID, err := gcm.ctx.chat(gcm.chatID).Result()
+	if err != nil {
+		return g.Err[g.Int](err)
+	}
+
+	count, err := gcm.ctx.Bot.Raw().GetChatMemberCountWithContext(retry.WithPolicy(gcm.ctx.Std(), gcm.retry), chatID, gcm.opts)
 
 	return g.ResultOf(g.Int(count), err)
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetChatMenuButton represents a request to get the menu button of a chat.
@@ -12,6 +13,7 @@ type GetChatMenuButton struct {
 	ctx    *Context
 	chatID g.Option[*int64]
 	opts   *gotgbot.GetChatMenuButtonOpts
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -42,8 +44,14 @@ func (gcmb *GetChatMenuButton) APIURL(url g.String) *GetChatMenuButton {
 	return gcmb
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gcmb *GetChatMenuButton) Retry(policy *retry.Policy) *GetChatMenuButton {
+	gcmb.retry = policy
+	return gcmb
+}
+
 // Send gets the chat menu button.
 func (gcmb *GetChatMenuButton) Send() g.Result[gotgbot.MenuButton] {
 	gcmb.opts.ChatId = gcmb.chatID.UnwrapOrDefault()
-	return g.ResultOf(gcmb.ctx.Bot.Raw().GetChatMenuButton(gcmb.opts))
+	return g.ResultOf(gcmb.ctx.Bot.Raw().GetChatMenuButtonWithContext(retry.WithPolicy(gcmb.ctx.Std(), gcmb.retry), gcmb.opts))
 }
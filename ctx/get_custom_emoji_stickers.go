@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetCustomEmojiStickers represents a request to get custom emoji stickers.
@@ -12,6 +13,7 @@ type GetCustomEmojiStickers struct {
 	ctx            *Context
 	customEmojiIDs g.Slice[g.String]
 	opts           *gotgbot.GetCustomEmojiStickersOpts
+	retry          *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -36,8 +38,14 @@ func (gces *GetCustomEmojiStickers) APIURL(url g.String) *GetCustomEmojiStickers
 	return gces
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gces *GetCustomEmojiStickers) Retry(policy *retry.Policy) *GetCustomEmojiStickers {
+	gces.retry = policy
+	return gces
+}
+
 // Send retrieves the custom emoji stickers.
 func (gces *GetCustomEmojiStickers) Send() g.Result[g.Slice[gotgbot.Sticker]] {
-	stickers, err := gces.ctx.Bot.Raw().GetCustomEmojiStickers(g.TransformSlice(gces.customEmojiIDs, g.String.Std), gces.opts)
+	stickers, err := gces.ctx.Bot.Raw().GetCustomEmojiStickersWithContext(retry.WithPolicy(gces.ctx.Std(), gces.retry), g.TransformSlice(gces.customEmojiIDs, g.String.Std), gces.opts)
 	return g.ResultOf[g.Slice[gotgbot.Sticker]](stickers, err)
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetFile represents a request to get a file.
@@ -12,6 +13,7 @@ type GetFile struct {
 	ctx    *Context
 	fileID g.String
 	opts   *gotgbot.GetFileOpts
+	retry  *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -36,7 +38,13 @@ func (gf *GetFile) APIURL(url g.String) *GetFile {
 	return gf
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gf *GetFile) Retry(policy *retry.Policy) *GetFile {
+	gf.retry = policy
+	return gf
+}
+
 // Send gets the file and returns the result.
 func (gf *GetFile) Send() g.Result[*gotgbot.File] {
-	return g.ResultOf(gf.ctx.Bot.Raw().GetFile(gf.fileID.Std(), gf.opts))
+	return g.ResultOf(gf.ctx.Bot.Raw().GetFileWithContext(retry.WithPolicy(gf.ctx.Std(), gf.retry), gf.fileID.Std(), gf.opts))
 }
@@ -5,12 +5,14 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetForumTopicIconStickers represents a request to get forum topic icon stickers.
 type GetForumTopicIconStickers struct {
-	ctx  *Context
-	opts *gotgbot.GetForumTopicIconStickersOpts
+	ctx   *Context
+	opts  *gotgbot.GetForumTopicIconStickersOpts
+	retry *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -35,7 +37,13 @@ func (gftis *GetForumTopicIconStickers) APIURL(url g.String) *GetForumTopicIconS
 	return gftis
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gftis *GetForumTopicIconStickers) Retry(policy *retry.Policy) *GetForumTopicIconStickers {
+	gftis.retry = policy
+	return gftis
+}
+
 // Send gets the custom emoji stickers that can be used as forum topic icons.
 func (gftis *GetForumTopicIconStickers) Send() g.Result[g.Slice[gotgbot.Sticker]] {
-	return g.ResultOf[g.Slice[gotgbot.Sticker]](gftis.ctx.Bot.Raw().GetForumTopicIconStickers(gftis.opts))
+	return g.ResultOf[g.Slice[gotgbot.Sticker]](gftis.ctx.Bot.Raw().GetForumTopicIconStickersWithContext(retry.WithPolicy(gftis.ctx.Std(), gftis.retry), gftis.opts))
 }
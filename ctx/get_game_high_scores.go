@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetGameHighScores represents a request to get high scores for a game.
@@ -15,6 +16,7 @@ type GetGameHighScores struct {
 	chatID          g.Option[int64]
 	messageID       g.Option[int64]
 	inlineMessageID g.Option[g.String]
+	retry           *retry.Policy
 }
 
 // UserID sets the user ID to get scores for.
@@ -63,15 +65,31 @@ func (gghs *GetGameHighScores) APIURL(url g.String) *GetGameHighScores {
 	return gghs
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gghs *GetGameHighScores) Retry(policy *retry.Policy) *GetGameHighScores {
+	gghs.retry = policy
+	return gghs
+}
+
 // Send gets the game high scores and returns the result.
 func (gghs *GetGameHighScores) Send() g.Result[g.Slice[gotgbot.GameHighScore]] {
-	gghs.opts.ChatId = gghs.chatID.UnwrapOr(gghs.ctx.EffectiveChat.Id)
-	gghs.opts.MessageId = gghs.messageID.UnwrapOr(gghs.ctx.EffectiveMessage.MessageId)
-
 	if gghs.inlineMessageID.IsSome() {
 		gghs.opts.InlineMessageId = gghs.inlineMessageID.Some().Std()
+	} else {
+		chatID, err := gghs.ctx.chat(gghs.chatID).Result()
+		if err != nil {
+			return g.Err[g.Slice[gotgbot.GameHighScore]](err)
+		}
+
+		messageID, err := gghs.ctx.message(gghs.messageID).Result()
+		if err != nil {
+			return g.Err[g.Slice[gotgbot.GameHighScore]](err)
+		}
+
+		gghs.opts.ChatId = chatID
+		gghs.opts.MessageId = messageID
 	}
 
-	scores, err := gghs.ctx.Bot.Raw().GetGameHighScores(gghs.userID, gghs.opts)
+	scores, err := gghs.ctx.Bot.Raw().GetGameHighScoresWithContext(retry.WithPolicy(gghs.ctx.Std(), gghs.retry), gghs.userID, gghs.opts)
 	return g.ResultOf[g.Slice[gotgbot.GameHighScore]](scores, err)
 }
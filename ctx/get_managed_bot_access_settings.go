@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetManagedBotAccessSettings represents a request to get the access settings of a managed bot.
@@ -12,6 +13,7 @@ type GetManagedBotAccessSettings struct {
 	ctx    *Context
 	userID int64
 	opts   *gotgbot.GetManagedBotAccessSettingsOpts
+	retry  *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -36,7 +38,13 @@ func (gmbas *GetManagedBotAccessSettings) APIURL(url g.String) *GetManagedBotAcc
 	return gmbas
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gmbas *GetManagedBotAccessSettings) Retry(policy *retry.Policy) *GetManagedBotAccessSettings {
+	gmbas.retry = policy
+	return gmbas
+}
+
 // Send retrieves the managed bot access settings and returns the result.
 func (gmbas *GetManagedBotAccessSettings) Send() g.Result[*gotgbot.BotAccessSettings] {
-	return g.ResultOf(gmbas.ctx.Bot.Raw().GetManagedBotAccessSettings(gmbas.userID, gmbas.opts))
+	return g.ResultOf(gmbas.ctx.Bot.Raw().GetManagedBotAccessSettingsWithContext(retry.WithPolicy(gmbas.ctx.Std(), gmbas.retry), gmbas.userID, gmbas.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetManagedBotToken represents a request to get the token of a managed bot.
@@ -12,6 +13,7 @@ type GetManagedBotToken struct {
 	ctx    *Context
 	userID int64
 	opts   *gotgbot.GetManagedBotTokenOpts
+	retry  *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -36,8 +38,14 @@ func (gmbt *GetManagedBotToken) APIURL(url g.String) *GetManagedBotToken {
 	return gmbt
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gmbt *GetManagedBotToken) Retry(policy *retry.Policy) *GetManagedBotToken {
+	gmbt.retry = policy
+	return gmbt
+}
+
 // Send retrieves the managed bot token and returns the result.
 func (gmbt *GetManagedBotToken) Send() g.Result[g.String] {
-	token, err := gmbt.ctx.Bot.Raw().GetManagedBotToken(gmbt.userID, gmbt.opts)
+	token, err := gmbt.ctx.Bot.Raw().GetManagedBotTokenWithContext(retry.WithPolicy(gmbt.ctx.Std(), gmbt.retry), gmbt.userID, gmbt.opts)
 	return g.ResultOf(g.String(token), err)
 }
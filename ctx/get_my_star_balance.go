@@ -5,12 +5,14 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetMyStarBalance is a request builder for getting bot's star balance.
 type GetMyStarBalance struct {
-	ctx  *Context
-	opts *gotgbot.GetMyStarBalanceOpts
+	ctx   *Context
+	opts  *gotgbot.GetMyStarBalanceOpts
+	retry *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -35,7 +37,13 @@ func (gmsb *GetMyStarBalance) APIURL(url g.String) *GetMyStarBalance {
 	return gmsb
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gmsb *GetMyStarBalance) Retry(policy *retry.Policy) *GetMyStarBalance {
+	gmsb.retry = policy
+	return gmsb
+}
+
 // Send executes the GetMyStarBalance request.
 func (gmsb *GetMyStarBalance) Send() g.Result[*gotgbot.StarAmount] {
-	return g.ResultOf(gmsb.ctx.Bot.Raw().GetMyStarBalance(gmsb.opts))
+	return g.ResultOf(gmsb.ctx.Bot.Raw().GetMyStarBalanceWithContext(retry.WithPolicy(gmsb.ctx.Std(), gmsb.retry), gmsb.opts))
 }
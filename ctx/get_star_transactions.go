@@ -5,12 +5,14 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetStarTransactions is a request builder for getting star transaction history.
 type GetStarTransactions struct {
-	ctx  *Context
-	opts *gotgbot.GetStarTransactionsOpts
+	ctx   *Context
+	opts  *gotgbot.GetStarTransactionsOpts
+	retry *retry.Policy
 }
 
 // Offset sets the number of transactions to skip.
@@ -47,7 +49,13 @@ func (gsts *GetStarTransactions) APIURL(url g.String) *GetStarTransactions {
 	return gsts
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gsts *GetStarTransactions) Retry(policy *retry.Policy) *GetStarTransactions {
+	gsts.retry = policy
+	return gsts
+}
+
 // Send executes the GetStarTransactions request.
 func (gsts *GetStarTransactions) Send() g.Result[*gotgbot.StarTransactions] {
-	return g.ResultOf(gsts.ctx.Bot.Raw().GetStarTransactions(gsts.opts))
+	return g.ResultOf(gsts.ctx.Bot.Raw().GetStarTransactionsWithContext(retry.WithPolicy(gsts.ctx.Std(), gsts.retry), gsts.opts))
 }
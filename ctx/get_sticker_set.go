@@ -5,13 +5,15 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetStickerSet represents a request to get sticker set information.
 type GetStickerSet struct {
-	ctx  *Context
-	name g.String
-	opts *gotgbot.GetStickerSetOpts
+	ctx   *Context
+	name  g.String
+	opts  *gotgbot.GetStickerSetOpts
+	retry *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -36,7 +38,13 @@ func (gss *GetStickerSet) APIURL(url g.String) *GetStickerSet {
 	return gss
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gss *GetStickerSet) Retry(policy *retry.Policy) *GetStickerSet {
+	gss.retry = policy
+	return gss
+}
+
 // Send retrieves the sticker set information.
 func (gss *GetStickerSet) Send() g.Result[*gotgbot.StickerSet] {
-	return g.ResultOf(gss.ctx.Bot.Raw().GetStickerSet(gss.name.Std(), gss.opts))
+	return g.ResultOf(gss.ctx.Bot.Raw().GetStickerSetWithContext(retry.WithPolicy(gss.ctx.Std(), gss.retry), gss.name.Std(), gss.opts))
 }
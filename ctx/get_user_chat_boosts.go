@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetUserChatBoosts represents a request to get user chat boosts.
@@ -13,6 +14,7 @@ type GetUserChatBoosts struct {
 	userID int64
 	opts   *gotgbot.GetUserChatBoostsOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -43,10 +45,21 @@ func (gucb *GetUserChatBoosts) APIURL(url g.String) *GetUserChatBoosts {
 	return gucb
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gucb *GetUserChatBoosts) Retry(policy *retry.Policy) *GetUserChatBoosts {
+	gucb.retry = policy
+	return gucb
+}
+
 // Send gets the user chat boosts.
 func (gucb *GetUserChatBoosts) Send() g.Result[*gotgbot.UserChatBoosts] {
-	return g.ResultOf(gucb.ctx.Bot.Raw().GetUserChatBoosts(
-		gucb.chatID.UnwrapOr(gucb.ctx.EffectiveChat.Id),
+	chatID, err := gucb.ctx.chat(gucb.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.UserChatBoosts](err)
+	}
+
+	return g.ResultOf(gucb.ctx.Bot.Raw().GetUserChatBoostsWithContext(retry.WithPolicy(gucb.ctx.Std(), gucb.retry),
+		chatID,
 		gucb.userID,
 		gucb.opts,
 	))
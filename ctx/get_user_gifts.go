@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetUserGifts represents a request to get user gifts.
@@ -12,6 +13,7 @@ type GetUserGifts struct {
 	ctx    *Context
 	userID int64
 	opts   *gotgbot.GetUserGiftsOpts
+	retry  *retry.Policy
 }
 
 // ExcludeUnlimited excludes gifts that can be purchased an unlimited number of times.
@@ -84,7 +86,13 @@ func (gug *GetUserGifts) APIURL(url g.String) *GetUserGifts {
 	return gug
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gug *GetUserGifts) Retry(policy *retry.Policy) *GetUserGifts {
+	gug.retry = policy
+	return gug
+}
+
 // Send executes the GetUserGifts request and returns the user's gifts.
 func (gug *GetUserGifts) Send() g.Result[*gotgbot.OwnedGifts] {
-	return g.ResultOf(gug.ctx.Bot.Raw().GetUserGifts(gug.userID, gug.opts))
+	return g.ResultOf(gug.ctx.Bot.Raw().GetUserGiftsWithContext(retry.WithPolicy(gug.ctx.Std(), gug.retry), gug.userID, gug.opts))
 }
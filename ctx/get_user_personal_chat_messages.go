@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetUserPersonalChatMessages represents a request to fetch the last messages from
@@ -14,6 +15,7 @@ type GetUserPersonalChatMessages struct {
 	userID int64
 	limit  int64
 	opts   *gotgbot.GetUserPersonalChatMessagesOpts
+	retry  *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -38,9 +40,15 @@ func (gupcm *GetUserPersonalChatMessages) APIURL(url g.String) *GetUserPersonalC
 	return gupcm
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gupcm *GetUserPersonalChatMessages) Retry(policy *retry.Policy) *GetUserPersonalChatMessages {
+	gupcm.retry = policy
+	return gupcm
+}
+
 // Send retrieves the personal chat messages and returns the result.
 func (gupcm *GetUserPersonalChatMessages) Send() g.Result[g.Slice[gotgbot.Message]] {
 	return g.ResultOf[g.Slice[gotgbot.Message]](
-		gupcm.ctx.Bot.Raw().GetUserPersonalChatMessages(gupcm.userID, gupcm.limit, gupcm.opts),
+		gupcm.ctx.Bot.Raw().GetUserPersonalChatMessagesWithContext(retry.WithPolicy(gupcm.ctx.Std(), gupcm.retry), gupcm.userID, gupcm.limit, gupcm.opts),
 	)
 }
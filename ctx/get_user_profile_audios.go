@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetUserProfileAudios represents a request to get user profile audios.
@@ -12,6 +13,7 @@ type GetUserProfileAudios struct {
 	ctx    *Context
 	userID int64
 	opts   *gotgbot.GetUserProfileAudiosOpts
+	retry  *retry.Policy
 }
 
 // Offset sets the sequential number of the first audio to be returned.
@@ -48,7 +50,13 @@ func (gupa *GetUserProfileAudios) APIURL(url g.String) *GetUserProfileAudios {
 	return gupa
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gupa *GetUserProfileAudios) Retry(policy *retry.Policy) *GetUserProfileAudios {
+	gupa.retry = policy
+	return gupa
+}
+
 // Send gets user profile audios and returns the result.
 func (gupa *GetUserProfileAudios) Send() g.Result[*gotgbot.UserProfileAudios] {
-	return g.ResultOf(gupa.ctx.Bot.Raw().GetUserProfileAudios(gupa.userID, gupa.opts))
+	return g.ResultOf(gupa.ctx.Bot.Raw().GetUserProfileAudiosWithContext(retry.WithPolicy(gupa.ctx.Std(), gupa.retry), gupa.userID, gupa.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // GetUserProfilePhotos represents a request to get user profile photos.
@@ -12,6 +13,7 @@ type GetUserProfilePhotos struct {
 	ctx    *Context
 	userID int64
 	opts   *gotgbot.GetUserProfilePhotosOpts
+	retry  *retry.Policy
 }
 
 // Offset sets the sequential number of the first photo to be returned.
@@ -48,7 +50,13 @@ func (gupp *GetUserProfilePhotos) APIURL(url g.String) *GetUserProfilePhotos {
 	return gupp
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gupp *GetUserProfilePhotos) Retry(policy *retry.Policy) *GetUserProfilePhotos {
+	gupp.retry = policy
+	return gupp
+}
+
 // Send gets user profile photos and returns the result.
 func (gupp *GetUserProfilePhotos) Send() g.Result[*gotgbot.UserProfilePhotos] {
-	return g.ResultOf(gupp.ctx.Bot.Raw().GetUserProfilePhotos(gupp.userID, gupp.opts))
+	return g.ResultOf(gupp.ctx.Bot.Raw().GetUserProfilePhotosWithContext(retry.WithPolicy(gupp.ctx.Std(), gupp.retry), gupp.userID, gupp.opts))
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/entities"
+	"github.com/enetx/tg/retry"
 )
 
 // GiftPremiumSubscription represents a request to gift premium subscription to a user.
@@ -15,6 +16,7 @@ type GiftPremiumSubscription struct {
 	monthCount int64
 	starCount  int64
 	opts       *gotgbot.GiftPremiumSubscriptionOpts
+	retry      *retry.Policy
 }
 
 // Text sets the text that will be shown along with the service message.
@@ -63,9 +65,15 @@ func (gps *GiftPremiumSubscription) APIURL(url g.String) *GiftPremiumSubscriptio
 	return gps
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (gps *GiftPremiumSubscription) Retry(policy *retry.Policy) *GiftPremiumSubscription {
+	gps.retry = policy
+	return gps
+}
+
 // Send gifts the premium subscription to the user.
 func (gps *GiftPremiumSubscription) Send() g.Result[bool] {
-	return g.ResultOf(gps.ctx.Bot.Raw().GiftPremiumSubscription(
+	return g.ResultOf(gps.ctx.Bot.Raw().GiftPremiumSubscriptionWithContext(retry.WithPolicy(gps.ctx.Std(), gps.retry),
 		gps.userID,
 		gps.monthCount,
 		gps.starCount,
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // HideGeneralForumTopic represents a request to hide the general forum topic.
@@ -12,6 +13,7 @@ type HideGeneralForumTopic struct {
 	ctx    *Context
 	opts   *gotgbot.HideGeneralForumTopicOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -42,10 +44,21 @@ func (hgft *HideGeneralForumTopic) APIURL(url g.String) *HideGeneralForumTopic {
 	return hgft
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (hgft *HideGeneralForumTopic) Retry(policy *retry.Policy) *HideGeneralForumTopic {
+	hgft.retry = policy
+	return hgft
+}
+
 // Send hides the general forum topic.
 func (hgft *HideGeneralForumTopic) Send() g.Result[bool] {
-	return g.ResultOf(hgft.ctx.Bot.Raw().HideGeneralForumTopic(
-		hgft.chatID.UnwrapOr(hgft.ctx.EffectiveChat.Id),
+	chatID, err := hgft.ctx.chat(hgft.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(hgft.ctx.Bot.Raw().HideGeneralForumTopicWithContext(retry.WithPolicy(hgft.ctx.Std(), hgft.retry),
+		chatID,
 		hgft.opts,
 	))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // LeaveChat represents a request to leave a chat.
@@ -12,6 +13,7 @@ type LeaveChat struct {
 	ctx    *Context
 	opts   *gotgbot.LeaveChatOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -42,8 +44,18 @@ func (lc *LeaveChat) APIURL(url g.String) *LeaveChat {
 	return lc
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (lc *LeaveChat) Retry(policy *retry.Policy) *LeaveChat {
+	lc.retry = policy
+	return lc
+}
+
 // Send leaves the chat and returns the result.
 func (lc *LeaveChat) Send() g.Result[bool] {
-	chatID := lc.chatID.UnwrapOr(lc.ctx.EffectiveChat.Id)
-	return g.ResultOf(lc.ctx.Bot.Raw().LeaveChat(chatID, lc.opts))
+	chatID, err := lc.ctx.chat(lc.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(lc.ctx.Bot.Raw().LeaveChatWithContext(retry.WithPolicy(lc.ctx.Std(), lc.retry), chatID, lc.opts))
 }
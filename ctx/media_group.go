@@ -1,6 +1,7 @@
 package ctx
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -8,6 +9,8 @@ import (
 	"github.com/enetx/g"
 	"github.com/enetx/tg/input"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/types/effects"
 )
 
@@ -18,6 +21,7 @@ type MediaGroup struct {
 	chatID      g.Option[int64]
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
+	retry       *retry.Policy
 }
 
 // After schedules the media group to be sent after the specified duration.
@@ -140,6 +144,12 @@ func (mg *MediaGroup) APIURL(url g.String) *MediaGroup {
 	return mg
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (mg *MediaGroup) Retry(policy *retry.Policy) *MediaGroup {
+	mg.retry = policy
+	return mg
+}
+
 // DirectMessagesTopic sets the direct messages topic ID for the message.
 func (mg *MediaGroup) DirectMessagesTopic(topicID int64) *MediaGroup {
 	mg.opts.DirectMessagesTopicId = topicID
@@ -148,36 +158,42 @@ func (mg *MediaGroup) DirectMessagesTopic(topicID int64) *MediaGroup {
 
 // Send sends the media group to Telegram and returns the result.
 func (mg *MediaGroup) Send() g.Result[g.Slice[gotgbot.Message]] {
-	if mg.media.Len() == 0 {
-		return g.Err[g.Slice[gotgbot.Message]](errors.New("no media added to media group"))
-	}
-
-	chatID := mg.chatID.UnwrapOr(mg.ctx.EffectiveChat.Id)
-	media := g.TransformSlice(mg.media, input.Media.Build)
-
-	send := func() g.Result[g.Slice[gotgbot.Message]] {
-		return g.ResultOf[g.Slice[gotgbot.Message]](mg.ctx.Bot.Raw().SendMediaGroup(chatID, gotgbot.InputMedias(media), mg.opts))
-	}
-
 	if mg.after.IsSome() {
-		go func() {
-			<-time.After(mg.after.Some())
-			msgs := send()
-			if msgs.IsOk() && mg.deleteAfter.IsSome() {
-				ids := g.TransformSlice(msgs.Ok(), func(m gotgbot.Message) int64 { return m.MessageId })
-				mg.ctx.DeleteMessages().ChatID(chatID).MessageIDs(ids).After(mg.deleteAfter.Some()).Send()
-			}
-		}()
+		if job := mg.Schedule(); job.IsErr() {
+			return g.Err[g.Slice[gotgbot.Message]](job.Err())
+		}
 
 		return g.Ok[g.Slice[gotgbot.Message]](nil)
 	}
 
-	msgs := send()
+	msgs := mg.send(mg.ctx.Std(), mg.ctx.Bot.Raw())
 
-	if msgs.IsOk() && mg.deleteAfter.IsSome() {
+	if msgs.IsOk() && !msgs.Ok().IsEmpty() && mg.deleteAfter.IsSome() {
 		ids := g.TransformSlice(msgs.Ok(), func(m gotgbot.Message) int64 { return m.MessageId })
-		mg.ctx.DeleteMessages().ChatID(chatID).MessageIDs(ids).After(mg.deleteAfter.Some()).Send()
+		mg.ctx.DeleteMessages().ChatID(msgs.Ok()[0].Chat.Id).MessageIDs(ids).After(mg.deleteAfter.Some()).Send()
 	}
 
 	return msgs
 }
+
+// Schedule schedules the media group to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (mg *MediaGroup) Schedule() g.Result[scheduler.JobID] {
+	return schedule(mg.ctx, mg.after, mg.deleteAfter, mg.send)
+}
+
+// send sends the media group through raw.
+func (mg *MediaGroup) send(std context.Context, raw *gotgbot.Bot) g.Result[g.Slice[gotgbot.Message]] {
+	if mg.media.Len() == 0 {
+		return g.Err[g.Slice[gotgbot.Message]](errors.New("no media added to media group"))
+	}
+
+	chatID, err := mg.ctx.chat(mg.chatID).Result()
+	if err != nil {
+		return g.Err[g.Slice[gotgbot.Message]](err)
+	}
+
+	media := g.TransformSlice(mg.media, input.Media.Build)
+
+	return g.ResultOf[g.Slice[gotgbot.Message]](raw.SendMediaGroupWithContext(retry.WithPolicy(std, mg.retry), chatID, gotgbot.InputMedias(media), mg.opts))
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetMessageReaction represents a request to set reactions on a message.
@@ -14,6 +15,7 @@ type SetMessageReaction struct {
 	reactions g.Slice[gotgbot.ReactionType]
 	opts      *gotgbot.SetMessageReactionOpts
 	chatID    g.Option[int64]
+	retry     *retry.Policy
 }
 
 // ChatID sets the chat ID where the message is located.
@@ -72,10 +74,20 @@ func (smr *SetMessageReaction) APIURL(url g.String) *SetMessageReaction {
 	return smr
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (smr *SetMessageReaction) Retry(policy *retry.Policy) *SetMessageReaction {
+	smr.retry = policy
+	return smr
+}
+
 // Send sets the message reactions and returns the result.
 func (smr *SetMessageReaction) Send() g.Result[bool] {
-	chatID := smr.chatID.UnwrapOr(smr.ctx.EffectiveChat.Id)
+	chatID, err := smr.ctx.chat(smr.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
 	smr.opts.Reaction = smr.reactions
 
-	return g.ResultOf(smr.ctx.Bot.Raw().SetMessageReaction(chatID, smr.messageID, smr.opts))
+	return g.ResultOf(smr.ctx.Bot.Raw().SetMessageReactionWithContext(retry.WithPolicy(smr.ctx.Std(), smr.retry), chatID, smr.messageID, smr.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // PinChatMessage represents a request to pin a message.
@@ -13,6 +14,7 @@ type PinChatMessage struct {
 	messageID int64
 	opts      *gotgbot.PinChatMessageOpts
 	chatID    g.Option[int64]
+	retry     *retry.Policy
 }
 
 // ChatID sets the target chat ID for this request.
@@ -55,8 +57,18 @@ func (pcm *PinChatMessage) APIURL(url g.String) *PinChatMessage {
 	return pcm
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (pcm *PinChatMessage) Retry(policy *retry.Policy) *PinChatMessage {
+	pcm.retry = policy
+	return pcm
+}
+
 // Send executes the PinChatMessage request.
 func (pcm *PinChatMessage) Send() g.Result[bool] {
-	chatID := pcm.chatID.UnwrapOr(pcm.ctx.EffectiveChat.Id)
-	return g.ResultOf(pcm.ctx.Bot.Raw().PinChatMessage(chatID, pcm.messageID, pcm.opts))
+	chatID, err := pcm.ctx.chat(pcm.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(pcm.ctx.Bot.Raw().PinChatMessageWithContext(retry.WithPolicy(pcm.ctx.Std(), pcm.retry), chatID, pcm.messageID, pcm.opts))
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/enetx/tg/areas"
 	"github.com/enetx/tg/entities"
 	"github.com/enetx/tg/input"
+	"github.com/enetx/tg/retry"
 )
 
 // PostStory represents a request to post a story to a business account.
@@ -17,6 +18,7 @@ type PostStory struct {
 	content              input.StoryContent
 	activePeriod         int64
 	opts                 *gotgbot.PostStoryOpts
+	retry                *retry.Policy
 }
 
 // Caption sets the story caption text.
@@ -89,9 +91,15 @@ func (ps *PostStory) APIURL(url g.String) *PostStory {
 	return ps
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ps *PostStory) Retry(policy *retry.Policy) *PostStory {
+	ps.retry = policy
+	return ps
+}
+
 // Send executes the PostStory request.
 func (ps *PostStory) Send() g.Result[*gotgbot.Story] {
-	return g.ResultOf(ps.ctx.Bot.Raw().PostStory(
+	return g.ResultOf(ps.ctx.Bot.Raw().PostStoryWithContext(retry.WithPolicy(ps.ctx.Std(), ps.retry),
 		ps.businessConnectionID.Std(),
 		ps.content.Build(),
 		ps.activePeriod,
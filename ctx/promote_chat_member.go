@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 	"github.com/enetx/tg/types/roles"
 )
 
@@ -14,6 +15,7 @@ type PromoteChatMember struct {
 	roles  bool
 	userID int64
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID for the promote action.
@@ -52,12 +54,22 @@ func (p *PromoteChatMember) APIURL(url g.String) *PromoteChatMember {
 	return p
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (p *PromoteChatMember) Retry(policy *retry.Policy) *PromoteChatMember {
+	p.retry = policy
+	return p
+}
+
 // Send promotes the user to administrator and returns the result.
 func (p *PromoteChatMember) Send() g.Result[bool] {
 	if !p.roles {
 		return g.Err[bool](g.Errorf("roles are required"))
 	}
 
-	chatID := p.chatID.UnwrapOr(p.ctx.EffectiveChat.Id)
-	return g.ResultOf(p.ctx.Bot.Raw().PromoteChatMember(chatID, p.userID, p.opts))
+	chatID, err := p.ctx.chat(p.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(p.ctx.Bot.Raw().PromoteChatMemberWithContext(retry.WithPolicy(p.ctx.Std(), p.retry), chatID, p.userID, p.opts))
 }
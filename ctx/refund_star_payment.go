@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 type RefundStarPayment struct {
@@ -12,6 +13,7 @@ type RefundStarPayment struct {
 	userID   g.Option[int64]
 	chargeID g.String
 	opts     *gotgbot.RefundStarPaymentOpts
+	retry    *retry.Policy
 }
 
 // UserID sets the user ID for the refund.
@@ -42,8 +44,18 @@ func (rsp *RefundStarPayment) APIURL(url g.String) *RefundStarPayment {
 	return rsp
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (rsp *RefundStarPayment) Retry(policy *retry.Policy) *RefundStarPayment {
+	rsp.retry = policy
+	return rsp
+}
+
 // Send processes the star payment refund and returns the result.
 func (rsp *RefundStarPayment) Send() g.Result[bool] {
-	userID := rsp.userID.UnwrapOr(rsp.ctx.EffectiveUser.Id)
-	return g.ResultOf(rsp.ctx.Bot.Raw().RefundStarPayment(userID, rsp.chargeID.Std(), rsp.opts))
+	userID, err := rsp.ctx.user(rsp.userID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(rsp.ctx.Bot.Raw().RefundStarPaymentWithContext(retry.WithPolicy(rsp.ctx.Std(), rsp.retry), userID, rsp.chargeID.Std(), rsp.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // RemoveChatVerification represents a request to remove chat verification.
@@ -12,6 +13,7 @@ type RemoveChatVerification struct {
 	ctx    *Context
 	chatID int64
 	opts   *gotgbot.RemoveChatVerificationOpts
+	retry  *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -36,7 +38,13 @@ func (rcv *RemoveChatVerification) APIURL(url g.String) *RemoveChatVerification
 	return rcv
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (rcv *RemoveChatVerification) Retry(policy *retry.Policy) *RemoveChatVerification {
+	rcv.retry = policy
+	return rcv
+}
+
 // Send removes chat verification.
 func (rcv *RemoveChatVerification) Send() g.Result[bool] {
-	return g.ResultOf(rcv.ctx.Bot.Raw().RemoveChatVerification(rcv.chatID, rcv.opts))
+	return g.ResultOf(rcv.ctx.Bot.Raw().RemoveChatVerificationWithContext(retry.WithPolicy(rcv.ctx.Std(), rcv.retry), rcv.chatID, rcv.opts))
 }
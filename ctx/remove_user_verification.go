@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // RemoveUserVerification represents a request to remove user verification.
@@ -12,6 +13,7 @@ type RemoveUserVerification struct {
 	ctx    *Context
 	userID int64
 	opts   *gotgbot.RemoveUserVerificationOpts
+	retry  *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -36,7 +38,13 @@ func (ruv *RemoveUserVerification) APIURL(url g.String) *RemoveUserVerification
 	return ruv
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ruv *RemoveUserVerification) Retry(policy *retry.Policy) *RemoveUserVerification {
+	ruv.retry = policy
+	return ruv
+}
+
 // Send removes user verification.
 func (ruv *RemoveUserVerification) Send() g.Result[bool] {
-	return g.ResultOf(ruv.ctx.Bot.Raw().RemoveUserVerification(ruv.userID, ruv.opts))
+	return g.ResultOf(ruv.ctx.Bot.Raw().RemoveUserVerificationWithContext(retry.WithPolicy(ruv.ctx.Std(), ruv.retry), ruv.userID, ruv.opts))
 }
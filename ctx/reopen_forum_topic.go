@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // ReopenForumTopic represents a request to reopen a forum topic.
@@ -13,6 +14,7 @@ type ReopenForumTopic struct {
 	messageThreadID int64
 	opts            *gotgbot.ReopenForumTopicOpts
 	chatID          g.Option[int64]
+	retry           *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -37,6 +39,12 @@ func (rft *ReopenForumTopic) APIURL(url g.String) *ReopenForumTopic {
 	return rft
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (rft *ReopenForumTopic) Retry(policy *retry.Policy) *ReopenForumTopic {
+	rft.retry = policy
+	return rft
+}
+
 // ChatID sets the target chat ID for this request.
 func (rft *ReopenForumTopic) ChatID(id int64) *ReopenForumTopic {
 	rft.chatID = g.Some(id)
@@ -45,6 +53,10 @@ func (rft *ReopenForumTopic) ChatID(id int64) *ReopenForumTopic {
 
 // Send executes the ReopenForumTopic request.
 func (rft *ReopenForumTopic) Send() g.Result[bool] {
-	chatID := rft.chatID.UnwrapOr(rft.ctx.EffectiveChat.Id)
-	return g.ResultOf(rft.ctx.Bot.Raw().ReopenForumTopic(chatID, rft.messageThreadID, rft.opts))
+	chatID, err := rft.ctx.chat(rft.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(rft.ctx.Bot.Raw().ReopenForumTopicWithContext(retry.WithPolicy(rft.ctx.Std(), rft.retry), chatID, rft.messageThreadID, rft.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // ReopenGeneralForumTopic represents a request to reopen the general forum topic.
@@ -12,6 +13,7 @@ type ReopenGeneralForumTopic struct {
 	ctx    *Context
 	opts   *gotgbot.ReopenGeneralForumTopicOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -42,10 +44,21 @@ func (rgft *ReopenGeneralForumTopic) APIURL(url g.String) *ReopenGeneralForumTop
 	return rgft
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (rgft *ReopenGeneralForumTopic) Retry(policy *retry.Policy) *ReopenGeneralForumTopic {
+	rgft.retry = policy
+	return rgft
+}
+
 // Send reopens the general forum topic.
 func (rgft *ReopenGeneralForumTopic) Send() g.Result[bool] {
-	return g.ResultOf(rgft.ctx.Bot.Raw().ReopenGeneralForumTopic(
-		rgft.chatID.UnwrapOr(rgft.ctx.EffectiveChat.Id),
+	chatID, err := rgft.ctx.chat(rgft.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(rgft.ctx.Bot.Raw().ReopenGeneralForumTopicWithContext(retry.WithPolicy(rgft.ctx.Std(), rgft.retry),
+		chatID,
 		rgft.opts,
 	))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // ReplaceManagedBotToken represents a request to revoke the current token of a managed bot
@@ -13,6 +14,7 @@ type ReplaceManagedBotToken struct {
 	ctx    *Context
 	userID int64
 	opts   *gotgbot.ReplaceManagedBotTokenOpts
+	retry  *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -37,8 +39,14 @@ func (rmbt *ReplaceManagedBotToken) APIURL(url g.String) *ReplaceManagedBotToken
 	return rmbt
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (rmbt *ReplaceManagedBotToken) Retry(policy *retry.Policy) *ReplaceManagedBotToken {
+	rmbt.retry = policy
+	return rmbt
+}
+
 // Send replaces the managed bot token and returns the new token.
 func (rmbt *ReplaceManagedBotToken) Send() g.Result[g.String] {
-	token, err := rmbt.ctx.Bot.Raw().ReplaceManagedBotToken(rmbt.userID, rmbt.opts)
+	token, err := rmbt.ctx.Bot.Raw().ReplaceManagedBotTokenWithContext(retry.WithPolicy(rmbt.ctx.Std(), rmbt.retry), rmbt.userID, rmbt.opts)
 	return g.ResultOf(g.String(token), err)
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // ReplaceStickerInSet represents a request to replace a sticker in a sticker set.
@@ -15,6 +16,7 @@ type ReplaceStickerInSet struct {
 	oldSticker g.String
 	sticker    gotgbot.InputSticker
 	opts       *gotgbot.ReplaceStickerInSetOpts
+	retry      *retry.Policy
 }
 
 // Sticker sets the new sticker to replace the old one.
@@ -45,9 +47,15 @@ func (rsis *ReplaceStickerInSet) APIURL(url g.String) *ReplaceStickerInSet {
 	return rsis
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (rsis *ReplaceStickerInSet) Retry(policy *retry.Policy) *ReplaceStickerInSet {
+	rsis.retry = policy
+	return rsis
+}
+
 // Send replaces the sticker in the sticker set.
 func (rsis *ReplaceStickerInSet) Send() g.Result[bool] {
-	return g.ResultOf(rsis.ctx.Bot.Raw().ReplaceStickerInSet(
+	return g.ResultOf(rsis.ctx.Bot.Raw().ReplaceStickerInSetWithContext(retry.WithPolicy(rsis.ctx.Std(), rsis.retry),
 		rsis.userID,
 		rsis.name.Std(),
 		rsis.oldSticker.Std(),
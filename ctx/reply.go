@@ -1,6 +1,7 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
@@ -9,6 +10,8 @@ import (
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/preview"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/types/effects"
 )
 
@@ -18,6 +21,8 @@ type Reply struct {
 	opts        *gotgbot.SendMessageOpts
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // Entities sets custom entities for the reply text.
@@ -73,6 +78,8 @@ func (r *Reply) Reply(params *reply.Parameters) *Reply {
 // Markup sets the reply markup keyboard for the reply message.
 func (r *Reply) Markup(kb keyboard.Keyboard) *Reply {
 	r.opts.ReplyMarkup = kb.Markup()
+	r.markupErr = keyboardErr(kb)
+
 	return r
 }
 
@@ -140,9 +147,65 @@ func (r *Reply) APIURL(url g.String) *Reply {
 	return r
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (r *Reply) Retry(policy *retry.Policy) *Reply {
+	r.retry = policy
+	return r
+}
+
+// Split sends a reply too long for one message as several messages, see SendMessage.Split.
+// Only the first message replies to the effective message.
+func (r *Reply) Split() *Split {
+	return splitText(r.ctx, r.text, r.opts, func() g.Result[int64] {
+		if r.ctx.EffectiveMessage == nil {
+			return g.Err[int64](ErrNoMessage)
+		}
+
+		if r.opts.ReplyParameters == nil {
+			r.opts.ReplyParameters = new(gotgbot.ReplyParameters)
+		}
+
+		if r.opts.ReplyParameters.MessageId == 0 {
+			r.opts.ReplyParameters.MessageId = r.ctx.EffectiveMessage.MessageId
+		}
+
+		return g.Ok(r.ctx.EffectiveMessage.Chat.Id)
+	}, r.after, r.deleteAfter, r.retry)
+}
+
 // Send sends the reply message and returns the result.
 func (r *Reply) Send() g.Result[*gotgbot.Message] {
-	return r.ctx.timers(r.after, r.deleteAfter, func() g.Result[*gotgbot.Message] {
-		return g.ResultOf(r.ctx.EffectiveMessage.Reply(r.ctx.Bot.Raw(), r.text.Std(), r.opts))
-	})
+	return r.ctx.timers(r.after, r.deleteAfter, r.send)
+}
+
+// Schedule schedules the reply message to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (r *Reply) Schedule() g.Result[scheduler.JobID] {
+	return schedule(r.ctx, r.after, r.deleteAfter, r.send)
+}
+
+// send sends the reply message through raw.
+func (r *Reply) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if r.markupErr != nil {
+		return g.Err[*gotgbot.Message](r.markupErr)
+	}
+
+	if r.ctx.EffectiveMessage == nil {
+		return g.Err[*gotgbot.Message](ErrNoMessage)
+	}
+
+	if r.opts.ReplyParameters == nil || r.opts.ReplyParameters.MessageId == 0 {
+		if r.opts.ReplyParameters == nil {
+			r.opts.ReplyParameters = new(gotgbot.ReplyParameters)
+		}
+
+		r.opts.ReplyParameters.MessageId = r.ctx.EffectiveMessage.MessageId
+	}
+
+	return g.ResultOf(raw.SendMessageWithContext(
+		retry.WithPolicy(std, r.retry),
+		r.ctx.EffectiveMessage.Chat.Id,
+		r.text.Std(),
+		r.opts,
+	))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // RepostStory represents a request to repost a story from another business account.
@@ -15,6 +16,7 @@ type RepostStory struct {
 	fromStoryID          int64
 	activePeriod         int64
 	opts                 *gotgbot.RepostStoryOpts
+	retry                *retry.Policy
 }
 
 // PostToChatPage determines if the story should be posted to the chat page as well.
@@ -57,9 +59,15 @@ func (rs *RepostStory) APIURL(url g.String) *RepostStory {
 	return rs
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (rs *RepostStory) Retry(policy *retry.Policy) *RepostStory {
+	rs.retry = policy
+	return rs
+}
+
 // Send executes the RepostStory request and returns the reposted story.
 func (rs *RepostStory) Send() g.Result[*gotgbot.Story] {
-	return g.ResultOf(rs.ctx.Bot.Raw().RepostStory(
+	return g.ResultOf(rs.ctx.Bot.Raw().RepostStoryWithContext(retry.WithPolicy(rs.ctx.Std(), rs.retry),
 		rs.businessConnectionID.Std(),
 		rs.fromChatID,
 		rs.fromStoryID,
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 	"github.com/enetx/tg/types/permissions"
 )
 
@@ -15,6 +16,7 @@ type RestrictChatMember struct {
 	autoPermissions bool
 	userID          int64
 	chatID          g.Option[int64]
+	retry           *retry.Policy
 }
 
 // ChatID sets the target chat ID for the restrict action.
@@ -68,14 +70,24 @@ func (r *RestrictChatMember) APIURL(url g.String) *RestrictChatMember {
 	return r
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (r *RestrictChatMember) Retry(policy *retry.Policy) *RestrictChatMember {
+	r.retry = policy
+	return r
+}
+
 // Send restricts the user's permissions and returns the result.
 func (r *RestrictChatMember) Send() g.Result[bool] {
 	if r.permissions == nil {
 		return g.Err[bool](g.Errorf("permissions are required"))
 	}
 
-	chatID := r.chatID.UnwrapOr(r.ctx.EffectiveChat.Id)
+	chatID, err := r.ctx.chat(r.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
 	r.opts.UseIndependentChatPermissions = !r.autoPermissions
 
-	return g.ResultOf(r.ctx.Bot.Raw().RestrictChatMember(chatID, r.userID, *r.permissions, r.opts))
+	return g.ResultOf(r.ctx.Bot.Raw().RestrictChatMemberWithContext(retry.WithPolicy(r.ctx.Std(), r.retry), chatID, r.userID, *r.permissions, r.opts))
 }
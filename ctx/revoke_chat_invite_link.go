@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // RevokeChatInviteLink represents a request to revoke a chat invite link.
@@ -13,6 +14,7 @@ type RevokeChatInviteLink struct {
 	inviteLink g.String
 	opts       *gotgbot.RevokeChatInviteLinkOpts
 	chatID     g.Option[int64]
+	retry      *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -43,8 +45,18 @@ func (rcil *RevokeChatInviteLink) APIURL(url g.String) *RevokeChatInviteLink {
 	return rcil
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (rcil *RevokeChatInviteLink) Retry(policy *retry.Policy) *RevokeChatInviteLink {
+	rcil.retry = policy
+	return rcil
+}
+
 // Send revokes the chat invite link and returns the result.
 func (rcil *RevokeChatInviteLink) Send() g.Result[*gotgbot.ChatInviteLink] {
-	chatID := rcil.chatID.UnwrapOr(rcil.ctx.EffectiveChat.Id)
-	return g.ResultOf(rcil.ctx.Bot.Raw().RevokeChatInviteLink(chatID, rcil.inviteLink.Std(), rcil.opts))
+	chatID, err := rcil.ctx.chat(rcil.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.ChatInviteLink](err)
+	}
+
+	return g.ResultOf(rcil.ctx.Bot.Raw().RevokeChatInviteLinkWithContext(retry.WithPolicy(rcil.ctx.Std(), rcil.retry), chatID, rcil.inviteLink.Std(), rcil.opts))
 }
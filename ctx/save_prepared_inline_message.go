@@ -6,6 +6,7 @@ import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/inline"
+	"github.com/enetx/tg/retry"
 )
 
 // SavePreparedInlineMessage represents a request to save prepared inline message.
@@ -14,6 +15,7 @@ type SavePreparedInlineMessage struct {
 	userID int64
 	result inline.QueryResult
 	opts   *gotgbot.SavePreparedInlineMessageOpts
+	retry  *retry.Policy
 }
 
 // AllowUserChats allows the message to be sent to user chats.
@@ -62,9 +64,15 @@ func (spim *SavePreparedInlineMessage) APIURL(url g.String) *SavePreparedInlineM
 	return spim
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (spim *SavePreparedInlineMessage) Retry(policy *retry.Policy) *SavePreparedInlineMessage {
+	spim.retry = policy
+	return spim
+}
+
 // Send saves the prepared inline message.
 func (spim *SavePreparedInlineMessage) Send() g.Result[*gotgbot.PreparedInlineMessage] {
-	return g.ResultOf(spim.ctx.Bot.Raw().SavePreparedInlineMessage(
+	return g.ResultOf(spim.ctx.Bot.Raw().SavePreparedInlineMessageWithContext(retry.WithPolicy(spim.ctx.Std(), spim.retry),
 		spim.userID,
 		spim.result.Build(),
 		spim.opts,
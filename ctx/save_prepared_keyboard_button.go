@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SavePreparedKeyboardButton represents a request to store a keyboard button that can
@@ -15,6 +16,7 @@ type SavePreparedKeyboardButton struct {
 	userID int64
 	button gotgbot.KeyboardButton
 	opts   *gotgbot.SavePreparedKeyboardButtonOpts
+	retry  *retry.Policy
 }
 
 // Text sets the text of the button. If only text, icon_custom_emoji_id, and style are used,
@@ -92,7 +94,13 @@ func (spkb *SavePreparedKeyboardButton) APIURL(url g.String) *SavePreparedKeyboa
 	return spkb
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (spkb *SavePreparedKeyboardButton) Retry(policy *retry.Policy) *SavePreparedKeyboardButton {
+	spkb.retry = policy
+	return spkb
+}
+
 // Send stores the prepared keyboard button and returns the result.
 func (spkb *SavePreparedKeyboardButton) Send() g.Result[*gotgbot.PreparedKeyboardButton] {
-	return g.ResultOf(spkb.ctx.Bot.Raw().SavePreparedKeyboardButton(spkb.userID, spkb.button, spkb.opts))
+	return g.ResultOf(spkb.ctx.Bot.Raw().SavePreparedKeyboardButtonWithContext(retry.WithPolicy(spkb.ctx.Std(), spkb.retry), spkb.userID, spkb.button, spkb.opts))
 }
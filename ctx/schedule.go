@@ -0,0 +1,58 @@
+package ctx
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/core"
+	"github.com/enetx/tg/scheduler"
+)
+
+// schedulers holds the in-memory schedulers of bots that do not provide their own.
+var schedulers sync.Map
+
+// schedulerOf returns the scheduler of the bot. Bots that do not provide one get an
+// in-memory scheduler, started on first use.
+func schedulerOf(bot core.BotAPI) *scheduler.Scheduler {
+	if b, ok := bot.(interface{ Scheduler() *scheduler.Scheduler }); ok {
+		if s := b.Scheduler(); s != nil {
+			return s
+		}
+	}
+
+	if s, ok := schedulers.Load(bot); ok {
+		return s.(*scheduler.Scheduler)
+	}
+
+	s, loaded := schedulers.LoadOrStore(bot, scheduler.New(bot))
+	if !loaded {
+		_ = s.(*scheduler.Scheduler).Start(stdOf(bot))
+	}
+
+	return s.(*scheduler.Scheduler)
+}
+
+// schedule records the request made by send and adds a job of the bot's scheduler that sends it
+// after the delay. The messages it sends are deleted after deleteAfter, if set. Errors that send
+// returns without making a request, such as invalid options, are returned right away.
+func schedule[T any](
+	ctx *Context,
+	after g.Option[time.Duration],
+	deleteAfter g.Option[time.Duration],
+	send func(std context.Context, raw *gotgbot.Bot) g.Result[T],
+) g.Result[scheduler.JobID] {
+	req := scheduler.Record(ctx.Bot.Raw(), func(raw *gotgbot.Bot) error { return send(ctx.std, raw).Err() })
+	if req.IsErr() {
+		return g.Err[scheduler.JobID](req.Err())
+	}
+
+	r := req.Ok()
+	r.DeleteAfter = deleteAfter.UnwrapOrDefault()
+
+	s := schedulerOf(ctx.Bot)
+
+	return s.Request(s.Now().Add(after.UnwrapOrDefault()), r)
+}
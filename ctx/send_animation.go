@@ -1,6 +1,7 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
@@ -8,6 +9,8 @@ import (
 	"github.com/enetx/tg/entities"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/suggested"
 	"github.com/enetx/tg/types/effects"
 )
@@ -22,6 +25,8 @@ type SendAnimation struct {
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
 	err         error
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // CaptionEntities sets custom entities for the animation caption.
@@ -87,6 +92,8 @@ func (sa *SendAnimation) Effect(effect effects.EffectType) *SendAnimation {
 // Markup sets the reply markup keyboard for the animation message.
 func (sa *SendAnimation) Markup(kb keyboard.Keyboard) *SendAnimation {
 	sa.opts.ReplyMarkup = kb.Markup()
+	sa.markupErr = keyboardErr(kb)
+
 	return sa
 }
 
@@ -153,6 +160,12 @@ func (sa *SendAnimation) APIURL(url g.String) *SendAnimation {
 	return sa
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sa *SendAnimation) Retry(policy *retry.Policy) *SendAnimation {
+	sa.retry = policy
+	return sa
+}
+
 // Business sets the business connection ID for the animation message.
 func (sa *SendAnimation) Business(id g.String) *SendAnimation {
 	sa.opts.BusinessConnectionId = id.Std()
@@ -199,6 +212,21 @@ func (sa *SendAnimation) DirectMessagesTopic(topicID int64) *SendAnimation {
 
 // Send sends the animation message to Telegram and returns the result.
 func (sa *SendAnimation) Send() g.Result[*gotgbot.Message] {
+	return sa.ctx.timers(sa.after, sa.deleteAfter, sa.send)
+}
+
+// Schedule schedules the animation message to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (sa *SendAnimation) Schedule() g.Result[scheduler.JobID] {
+	return schedule(sa.ctx, sa.after, sa.deleteAfter, sa.send)
+}
+
+// send sends the animation message through raw.
+func (sa *SendAnimation) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if sa.markupErr != nil {
+		return g.Err[*gotgbot.Message](sa.markupErr)
+	}
+
 	if sa.err != nil {
 		return g.Err[*gotgbot.Message](sa.err)
 	}
@@ -211,8 +239,10 @@ func (sa *SendAnimation) Send() g.Result[*gotgbot.Message] {
 		defer sa.thumb.Close()
 	}
 
-	return sa.ctx.timers(sa.after, sa.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := sa.chatID.UnwrapOr(sa.ctx.EffectiveChat.Id)
-		return g.ResultOf(sa.ctx.Bot.Raw().SendAnimation(chatID, sa.doc, sa.opts))
-	})
+	chatID, err := sa.ctx.chat(sa.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	return g.ResultOf(raw.SendAnimationWithContext(retry.WithPolicy(std, sa.retry), chatID, sa.doc, sa.opts))
 }
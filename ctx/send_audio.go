@@ -1,6 +1,7 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
@@ -8,6 +9,8 @@ import (
 	"github.com/enetx/tg/entities"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/suggested"
 	"github.com/enetx/tg/types/effects"
 )
@@ -22,6 +25,8 @@ type SendAudio struct {
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
 	err         error
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // CaptionEntities sets custom entities for the audio caption.
@@ -87,6 +92,8 @@ func (sa *SendAudio) Effect(effect effects.EffectType) *SendAudio {
 // Markup sets the reply markup keyboard for the audio message.
 func (sa *SendAudio) Markup(kb keyboard.Keyboard) *SendAudio {
 	sa.opts.ReplyMarkup = kb.Markup()
+	sa.markupErr = keyboardErr(kb)
+
 	return sa
 }
 
@@ -134,6 +141,12 @@ func (sa *SendAudio) APIURL(url g.String) *SendAudio {
 	return sa
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sa *SendAudio) Retry(policy *retry.Policy) *SendAudio {
+	sa.retry = policy
+	return sa
+}
+
 // Business sets the business connection ID for the audio message.
 func (sa *SendAudio) Business(id g.String) *SendAudio {
 	sa.opts.BusinessConnectionId = id.Std()
@@ -186,6 +199,21 @@ func (sa *SendAudio) DirectMessagesTopic(topicID int64) *SendAudio {
 
 // Send sends the audio message to Telegram and returns the result.
 func (sa *SendAudio) Send() g.Result[*gotgbot.Message] {
+	return sa.ctx.timers(sa.after, sa.deleteAfter, sa.send)
+}
+
+// Schedule schedules the audio message to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (sa *SendAudio) Schedule() g.Result[scheduler.JobID] {
+	return schedule(sa.ctx, sa.after, sa.deleteAfter, sa.send)
+}
+
+// send sends the audio message through raw.
+func (sa *SendAudio) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if sa.markupErr != nil {
+		return g.Err[*gotgbot.Message](sa.markupErr)
+	}
+
 	if sa.err != nil {
 		return g.Err[*gotgbot.Message](sa.err)
 	}
@@ -198,8 +226,10 @@ func (sa *SendAudio) Send() g.Result[*gotgbot.Message] {
 		defer sa.thumb.Close()
 	}
 
-	return sa.ctx.timers(sa.after, sa.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := sa.chatID.UnwrapOr(sa.ctx.EffectiveChat.Id)
-		return g.ResultOf(sa.ctx.Bot.Raw().SendAudio(chatID, sa.doc, sa.opts))
-	})
+	chatID, err := sa.ctx.chat(sa.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	return g.ResultOf(raw.SendAudioWithContext(retry.WithPolicy(std, sa.retry), chatID, sa.doc, sa.opts))
 }
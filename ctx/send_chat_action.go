@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 	"github.com/enetx/tg/types/chataction"
 )
 
@@ -13,6 +14,7 @@ type SendChatAction struct {
 	action string
 	opts   *gotgbot.SendChatActionOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // To sets the target chat ID for the chat action.
@@ -116,8 +118,18 @@ func (sca *SendChatAction) APIURL(url g.String) *SendChatAction {
 	return sca
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sca *SendChatAction) Retry(policy *retry.Policy) *SendChatAction {
+	sca.retry = policy
+	return sca
+}
+
 // Send sends the chat action to Telegram and returns the result.
 func (sca *SendChatAction) Send() g.Result[bool] {
-	chatID := sca.chatID.UnwrapOr(sca.ctx.EffectiveChat.Id)
-	return g.ResultOf(sca.ctx.Bot.Raw().SendChatAction(chatID, sca.action, sca.opts))
+	chatID, err := sca.ctx.chat(sca.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(sca.ctx.Bot.Raw().SendChatActionWithContext(retry.WithPolicy(sca.ctx.Std(), sca.retry), chatID, sca.action, sca.opts))
 }
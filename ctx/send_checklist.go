@@ -1,12 +1,15 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 )
 
 // SendChecklist represents a request to send a checklist.
@@ -19,6 +22,8 @@ type SendChecklist struct {
 	after                g.Option[time.Duration]
 	deleteAfter          g.Option[time.Duration]
 	taskIDCounter        int64
+	retry                *retry.Policy
+	markupErr            error
 }
 
 // Task starts building a new checklist task.
@@ -80,6 +85,8 @@ func (sc *SendChecklist) Markup(kb keyboard.Keyboard) *SendChecklist {
 		sc.opts.ReplyMarkup = markup
 	}
 
+	sc.markupErr = keyboardErr(kb)
+
 	return sc
 }
 
@@ -119,8 +126,29 @@ func (sc *SendChecklist) APIURL(url g.String) *SendChecklist {
 	return sc
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sc *SendChecklist) Retry(policy *retry.Policy) *SendChecklist {
+	sc.retry = policy
+	return sc
+}
+
 // Send sends the checklist message to Telegram and returns the result.
 func (sc *SendChecklist) Send() g.Result[*gotgbot.Message] {
+	return sc.ctx.timers(sc.after, sc.deleteAfter, sc.send)
+}
+
+// Schedule schedules the checklist message to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (sc *SendChecklist) Schedule() g.Result[scheduler.JobID] {
+	return schedule(sc.ctx, sc.after, sc.deleteAfter, sc.send)
+}
+
+// send sends the checklist message through raw.
+func (sc *SendChecklist) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if sc.markupErr != nil {
+		return g.Err[*gotgbot.Message](sc.markupErr)
+	}
+
 	if len(sc.checklist.Tasks) == 0 {
 		return g.Err[*gotgbot.Message](g.Errorf("no tasks added to checklist"))
 	}
@@ -129,8 +157,10 @@ func (sc *SendChecklist) Send() g.Result[*gotgbot.Message] {
 		return g.Err[*gotgbot.Message](g.Errorf("too many tasks: {} (maximum 100)", len(sc.checklist.Tasks)))
 	}
 
-	return sc.ctx.timers(sc.after, sc.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := sc.chatID.UnwrapOr(sc.ctx.EffectiveChat.Id)
-		return g.ResultOf(sc.ctx.Bot.Raw().SendChecklist(sc.businessConnectionID.Std(), chatID, sc.checklist, sc.opts))
-	})
+	chatID, err := sc.ctx.chat(sc.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	return g.ResultOf(raw.SendChecklistWithContext(retry.WithPolicy(std, sc.retry), sc.businessConnectionID.Std(), chatID, sc.checklist, sc.opts))
 }
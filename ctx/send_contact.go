@@ -1,12 +1,15 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/suggested"
 	"github.com/enetx/tg/types/effects"
 )
@@ -19,6 +22,8 @@ type SendContact struct {
 	chatID      g.Option[int64]
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // After schedules the contact to be sent after the specified duration.
@@ -60,6 +65,8 @@ func (sc *SendContact) Effect(effect effects.EffectType) *SendContact {
 // Markup sets the reply markup keyboard for the contact message.
 func (sc *SendContact) Markup(kb keyboard.Keyboard) *SendContact {
 	sc.opts.ReplyMarkup = kb.Markup()
+	sc.markupErr = keyboardErr(kb)
+
 	return sc
 }
 
@@ -105,6 +112,12 @@ func (sc *SendContact) APIURL(url g.String) *SendContact {
 	return sc
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sc *SendContact) Retry(policy *retry.Policy) *SendContact {
+	sc.retry = policy
+	return sc
+}
+
 // Business sets the business connection ID for the contact message.
 func (sc *SendContact) Business(id g.String) *SendContact {
 	sc.opts.BusinessConnectionId = id.Std()
@@ -139,8 +152,25 @@ func (sc *SendContact) DirectMessagesTopic(topicID int64) *SendContact {
 
 // Send sends the contact message to Telegram and returns the result.
 func (sc *SendContact) Send() g.Result[*gotgbot.Message] {
-	return sc.ctx.timers(sc.after, sc.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := sc.chatID.UnwrapOr(sc.ctx.EffectiveChat.Id)
-		return g.ResultOf(sc.ctx.Bot.Raw().SendContact(chatID, sc.phoneNumber.Std(), sc.firstName.Std(), sc.opts))
-	})
+	return sc.ctx.timers(sc.after, sc.deleteAfter, sc.send)
+}
+
+// Schedule schedules the contact message to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (sc *SendContact) Schedule() g.Result[scheduler.JobID] {
+	return schedule(sc.ctx, sc.after, sc.deleteAfter, sc.send)
+}
+
+// send sends the contact message through raw.
+func (sc *SendContact) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if sc.markupErr != nil {
+		return g.Err[*gotgbot.Message](sc.markupErr)
+	}
+
+	chatID, err := sc.ctx.chat(sc.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	return g.ResultOf(raw.SendContactWithContext(retry.WithPolicy(std, sc.retry), chatID, sc.phoneNumber.Std(), sc.firstName.Std(), sc.opts))
 }
@@ -1,12 +1,15 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/suggested"
 	"github.com/enetx/tg/types/effects"
 )
@@ -17,6 +20,8 @@ type SendDice struct {
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
 	opts        *gotgbot.SendDiceOpts
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // After schedules the dice to be sent after the specified duration.
@@ -102,6 +107,8 @@ func (sd *SendDice) Reply(params *reply.Parameters) *SendDice {
 // Markup sets the reply markup keyboard for the dice message.
 func (sd *SendDice) Markup(kb keyboard.Keyboard) *SendDice {
 	sd.opts.ReplyMarkup = kb.Markup()
+	sd.markupErr = keyboardErr(kb)
+
 	return sd
 }
 
@@ -153,6 +160,12 @@ func (sd *SendDice) APIURL(url g.String) *SendDice {
 	return sd
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sd *SendDice) Retry(policy *retry.Policy) *SendDice {
+	sd.retry = policy
+	return sd
+}
+
 // DirectMessagesTopic sets the direct messages topic ID for the message.
 func (sd *SendDice) DirectMessagesTopic(topicID int64) *SendDice {
 	sd.opts.DirectMessagesTopicId = topicID
@@ -161,8 +174,25 @@ func (sd *SendDice) DirectMessagesTopic(topicID int64) *SendDice {
 
 // Send sends the dice message to Telegram and returns the result.
 func (sd *SendDice) Send() g.Result[*gotgbot.Message] {
-	return sd.ctx.timers(sd.after, sd.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := sd.chatID.UnwrapOr(sd.ctx.EffectiveChat.Id)
-		return g.ResultOf(sd.ctx.Bot.Raw().SendDice(chatID, sd.opts))
-	})
+	return sd.ctx.timers(sd.after, sd.deleteAfter, sd.send)
+}
+
+// Schedule schedules the dice message to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (sd *SendDice) Schedule() g.Result[scheduler.JobID] {
+	return schedule(sd.ctx, sd.after, sd.deleteAfter, sd.send)
+}
+
+// send sends the dice message through raw.
+func (sd *SendDice) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if sd.markupErr != nil {
+		return g.Err[*gotgbot.Message](sd.markupErr)
+	}
+
+	chatID, err := sd.ctx.chat(sd.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	return g.ResultOf(raw.SendDiceWithContext(retry.WithPolicy(std, sd.retry), chatID, sd.opts))
 }
@@ -1,6 +1,7 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
@@ -8,6 +9,8 @@ import (
 	"github.com/enetx/tg/entities"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/suggested"
 	"github.com/enetx/tg/types/effects"
 )
@@ -22,6 +25,8 @@ type SendDocument struct {
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
 	err         error
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // CaptionEntities sets custom entities for the document caption.
@@ -87,6 +92,8 @@ func (sd *SendDocument) Effect(effect effects.EffectType) *SendDocument {
 // Markup sets the reply markup keyboard for the document message.
 func (sd *SendDocument) Markup(kb keyboard.Keyboard) *SendDocument {
 	sd.opts.ReplyMarkup = kb.Markup()
+	sd.markupErr = keyboardErr(kb)
+
 	return sd
 }
 
@@ -134,6 +141,12 @@ func (sd *SendDocument) APIURL(url g.String) *SendDocument {
 	return sd
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sd *SendDocument) Retry(policy *retry.Policy) *SendDocument {
+	sd.retry = policy
+	return sd
+}
+
 // Business sets the business connection ID for the document message.
 func (sd *SendDocument) Business(id g.String) *SendDocument {
 	sd.opts.BusinessConnectionId = id.Std()
@@ -174,6 +187,21 @@ func (sd *SendDocument) DirectMessagesTopic(topicID int64) *SendDocument {
 
 // Send sends the document message to Telegram and returns the result.
 func (sd *SendDocument) Send() g.Result[*gotgbot.Message] {
+	return sd.ctx.timers(sd.after, sd.deleteAfter, sd.send)
+}
+
+// Schedule schedules the document message to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (sd *SendDocument) Schedule() g.Result[scheduler.JobID] {
+	return schedule(sd.ctx, sd.after, sd.deleteAfter, sd.send)
+}
+
+// send sends the document message through raw.
+func (sd *SendDocument) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if sd.markupErr != nil {
+		return g.Err[*gotgbot.Message](sd.markupErr)
+	}
+
 	if sd.err != nil {
 		return g.Err[*gotgbot.Message](sd.err)
 	}
@@ -186,8 +214,10 @@ func (sd *SendDocument) Send() g.Result[*gotgbot.Message] {
 		defer sd.thumb.Close()
 	}
 
-	return sd.ctx.timers(sd.after, sd.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := sd.chatID.UnwrapOr(sd.ctx.EffectiveChat.Id)
-		return g.ResultOf(sd.ctx.Bot.Raw().SendDocument(chatID, sd.doc, sd.opts))
-	})
+	chatID, err := sd.ctx.chat(sd.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	return g.ResultOf(raw.SendDocumentWithContext(retry.WithPolicy(std, sd.retry), chatID, sd.doc, sd.opts))
 }
@@ -1,12 +1,15 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/types/effects"
 )
 
@@ -17,6 +20,8 @@ type SendGame struct {
 	chatID        g.Option[int64]
 	after         g.Option[time.Duration]
 	deleteAfter   g.Option[time.Duration]
+	retry         *retry.Policy
+	markupErr     error
 }
 
 // After schedules the game to be sent after the specified duration.
@@ -75,6 +80,8 @@ func (sg *SendGame) Markup(kb keyboard.Keyboard) *SendGame {
 		sg.opts.ReplyMarkup = markup
 	}
 
+	sg.markupErr = keyboardErr(kb)
+
 	return sg
 }
 
@@ -112,10 +119,33 @@ func (sg *SendGame) APIURL(url g.String) *SendGame {
 	return sg
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sg *SendGame) Retry(policy *retry.Policy) *SendGame {
+	sg.retry = policy
+	return sg
+}
+
 // Send sends the game message to Telegram and returns the result.
 func (sg *SendGame) Send() g.Result[*gotgbot.Message] {
-	return sg.ctx.timers(sg.after, sg.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := sg.chatID.UnwrapOr(sg.ctx.EffectiveChat.Id)
-		return g.ResultOf(sg.ctx.Bot.Raw().SendGame(chatID, sg.gameShortName.Std(), sg.opts))
-	})
+	return sg.ctx.timers(sg.after, sg.deleteAfter, sg.send)
+}
+
+// Schedule schedules the game message to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (sg *SendGame) Schedule() g.Result[scheduler.JobID] {
+	return schedule(sg.ctx, sg.after, sg.deleteAfter, sg.send)
+}
+
+// send sends the game message through raw.
+func (sg *SendGame) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if sg.markupErr != nil {
+		return g.Err[*gotgbot.Message](sg.markupErr)
+	}
+
+	chatID, err := sg.ctx.chat(sg.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	return g.ResultOf(raw.SendGameWithContext(retry.WithPolicy(std, sg.retry), chatID, sg.gameShortName.Std(), sg.opts))
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/entities"
+	"github.com/enetx/tg/retry"
 )
 
 // SendGift is a request builder for sending gifts.
@@ -15,6 +16,7 @@ type SendGift struct {
 	userID g.Option[int64]
 	chatID g.Option[int64]
 	opts   *gotgbot.SendGiftOpts
+	retry  *retry.Policy
 }
 
 // To sets the target user ID for the gift.
@@ -81,15 +83,23 @@ func (sg *SendGift) APIURL(url g.String) *SendGift {
 	return sg
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sg *SendGift) Retry(policy *retry.Policy) *SendGift {
+	sg.retry = policy
+	return sg
+}
+
 // Send executes the SendGift request.
 func (sg *SendGift) Send() g.Result[bool] {
 	if sg.userID.IsSome() {
 		sg.opts.UserId = sg.userID.Some()
 	} else if sg.chatID.IsSome() {
 		sg.opts.ChatId = sg.chatID.Some()
-	} else {
+	} else if sg.ctx.EffectiveUser != nil {
 		sg.opts.UserId = sg.ctx.EffectiveUser.Id
+	} else {
+		return g.Err[bool](ErrNoUser)
 	}
 
-	return g.ResultOf(sg.ctx.Bot.Raw().SendGift(sg.giftID.Std(), sg.opts))
+	return g.ResultOf(sg.ctx.Bot.Raw().SendGiftWithContext(retry.WithPolicy(sg.ctx.Std(), sg.retry), sg.giftID.Std(), sg.opts))
 }
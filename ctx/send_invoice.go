@@ -1,12 +1,15 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/suggested"
 	"github.com/enetx/tg/types/effects"
 )
@@ -22,6 +25,8 @@ type SendInvoice struct {
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
 	opts        *gotgbot.SendInvoiceOpts
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // SuggestedPost sets suggested post parameters for direct messages chats.
@@ -182,6 +187,8 @@ func (si *SendInvoice) Markup(kb keyboard.Keyboard) *SendInvoice {
 		si.opts.ReplyMarkup = markup
 	}
 
+	si.markupErr = keyboardErr(kb)
+
 	return si
 }
 
@@ -207,6 +214,12 @@ func (si *SendInvoice) APIURL(url g.String) *SendInvoice {
 	return si
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (si *SendInvoice) Retry(policy *retry.Policy) *SendInvoice {
+	si.retry = policy
+	return si
+}
+
 // DirectMessagesTopic sets the direct messages topic ID for the message.
 func (si *SendInvoice) DirectMessagesTopic(topicID int64) *SendInvoice {
 	si.opts.DirectMessagesTopicId = topicID
@@ -215,15 +228,33 @@ func (si *SendInvoice) DirectMessagesTopic(topicID int64) *SendInvoice {
 
 // Send sends the invoice to Telegram and returns the result.
 func (si *SendInvoice) Send() g.Result[*gotgbot.Message] {
-	return si.ctx.timers(si.after, si.deleteAfter, func() g.Result[*gotgbot.Message] {
-		return g.ResultOf(si.ctx.Bot.Raw().SendInvoice(
-			si.chatID.UnwrapOr(si.ctx.EffectiveChat.Id),
-			si.title.Std(),
-			si.desc.Std(),
-			si.payload.Std(),
-			si.currency.Std(),
-			si.prices,
-			si.opts,
-		))
-	})
+	return si.ctx.timers(si.after, si.deleteAfter, si.send)
+}
+
+// Schedule schedules the invoice to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (si *SendInvoice) Schedule() g.Result[scheduler.JobID] {
+	return schedule(si.ctx, si.after, si.deleteAfter, si.send)
+}
+
+// send sends the invoice through raw.
+func (si *SendInvoice) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if si.markupErr != nil {
+		return g.Err[*gotgbot.Message](si.markupErr)
+	}
+
+	chatID, err := si.ctx.chat(si.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	return g.ResultOf(raw.SendInvoiceWithContext(retry.WithPolicy(std, si.retry),
+		chatID,
+		si.title.Std(),
+		si.desc.Std(),
+		si.payload.Std(),
+		si.currency.Std(),
+		si.prices,
+		si.opts,
+	))
 }
@@ -1,6 +1,7 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
@@ -8,6 +9,8 @@ import (
 	"github.com/enetx/tg/entities"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/suggested"
 	"github.com/enetx/tg/types/effects"
 )
@@ -25,6 +28,8 @@ type SendLivePhoto struct {
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
 	err         error
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // CaptionEntities sets custom entities for the live photo caption.
@@ -96,6 +101,8 @@ func (slp *SendLivePhoto) Effect(effect effects.EffectType) *SendLivePhoto {
 // Markup sets the reply markup keyboard for the live photo message.
 func (slp *SendLivePhoto) Markup(kb keyboard.Keyboard) *SendLivePhoto {
 	slp.opts.ReplyMarkup = kb.Markup()
+	slp.markupErr = keyboardErr(kb)
+
 	return slp
 }
 
@@ -129,6 +136,12 @@ func (slp *SendLivePhoto) APIURL(url g.String) *SendLivePhoto {
 	return slp
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (slp *SendLivePhoto) Retry(policy *retry.Policy) *SendLivePhoto {
+	slp.retry = policy
+	return slp
+}
+
 // Business sets the business connection ID for the live photo message.
 func (slp *SendLivePhoto) Business(id g.String) *SendLivePhoto {
 	slp.opts.BusinessConnectionId = id.Std()
@@ -170,6 +183,21 @@ func (slp *SendLivePhoto) DirectMessagesTopic(topicID int64) *SendLivePhoto {
 
 // Send sends the live photo message to Telegram and returns the result.
 func (slp *SendLivePhoto) Send() g.Result[*gotgbot.Message] {
+	return slp.ctx.timers(slp.after, slp.deleteAfter, slp.send)
+}
+
+// Schedule schedules the live photo message to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (slp *SendLivePhoto) Schedule() g.Result[scheduler.JobID] {
+	return schedule(slp.ctx, slp.after, slp.deleteAfter, slp.send)
+}
+
+// send sends the live photo message through raw.
+func (slp *SendLivePhoto) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if slp.markupErr != nil {
+		return g.Err[*gotgbot.Message](slp.markupErr)
+	}
+
 	if slp.err != nil {
 		return g.Err[*gotgbot.Message](slp.err)
 	}
@@ -182,8 +210,10 @@ func (slp *SendLivePhoto) Send() g.Result[*gotgbot.Message] {
 		defer slp.photoFD.Close()
 	}
 
-	return slp.ctx.timers(slp.after, slp.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := slp.chatID.UnwrapOr(slp.ctx.EffectiveChat.Id)
-		return g.ResultOf(slp.ctx.Bot.Raw().SendLivePhoto(chatID, slp.livePhoto, slp.photo, slp.opts))
-	})
+	chatID, err := slp.ctx.chat(slp.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	return g.ResultOf(raw.SendLivePhotoWithContext(retry.WithPolicy(std, slp.retry), chatID, slp.livePhoto, slp.photo, slp.opts))
 }
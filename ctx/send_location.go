@@ -1,12 +1,15 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/suggested"
 	"github.com/enetx/tg/types/effects"
 )
@@ -19,6 +22,8 @@ type SendLocation struct {
 	chatID      g.Option[int64]
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // After schedules the location to be sent after the specified duration.
@@ -60,6 +65,8 @@ func (sl *SendLocation) Effect(effect effects.EffectType) *SendLocation {
 // Markup sets the reply markup keyboard for the location message.
 func (sl *SendLocation) Markup(kb keyboard.Keyboard) *SendLocation {
 	sl.opts.ReplyMarkup = kb.Markup()
+	sl.markupErr = keyboardErr(kb)
+
 	return sl
 }
 
@@ -117,6 +124,12 @@ func (sl *SendLocation) APIURL(url g.String) *SendLocation {
 	return sl
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sl *SendLocation) Retry(policy *retry.Policy) *SendLocation {
+	sl.retry = policy
+	return sl
+}
+
 // Business sets the business connection ID for the location message.
 func (sl *SendLocation) Business(id g.String) *SendLocation {
 	sl.opts.BusinessConnectionId = id.Std()
@@ -151,8 +164,25 @@ func (sl *SendLocation) DirectMessagesTopic(topicID int64) *SendLocation {
 
 // Send sends the location message to Telegram and returns the result.
 func (sl *SendLocation) Send() g.Result[*gotgbot.Message] {
-	return sl.ctx.timers(sl.after, sl.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := sl.chatID.UnwrapOr(sl.ctx.EffectiveChat.Id)
-		return g.ResultOf(sl.ctx.Bot.Raw().SendLocation(chatID, sl.latitude, sl.longitude, sl.opts))
-	})
+	return sl.ctx.timers(sl.after, sl.deleteAfter, sl.send)
+}
+
+// Schedule schedules the location message to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (sl *SendLocation) Schedule() g.Result[scheduler.JobID] {
+	return schedule(sl.ctx, sl.after, sl.deleteAfter, sl.send)
+}
+
+// send sends the location message through raw.
+func (sl *SendLocation) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if sl.markupErr != nil {
+		return g.Err[*gotgbot.Message](sl.markupErr)
+	}
+
+	chatID, err := sl.ctx.chat(sl.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	return g.ResultOf(raw.SendLocationWithContext(retry.WithPolicy(std, sl.retry), chatID, sl.latitude, sl.longitude, sl.opts))
 }
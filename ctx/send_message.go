@@ -1,6 +1,7 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
@@ -9,6 +10,8 @@ import (
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/preview"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/suggested"
 	"github.com/enetx/tg/types/effects"
 )
@@ -20,6 +23,8 @@ type SendMessage struct {
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
 	opts        *gotgbot.SendMessageOpts
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // Entities sets special entities in the message text using Entities builder.
@@ -81,6 +86,8 @@ func (sm *SendMessage) Reply(params *reply.Parameters) *SendMessage {
 // Markup sets the reply markup keyboard for the message.
 func (sm *SendMessage) Markup(kb keyboard.Keyboard) *SendMessage {
 	sm.opts.ReplyMarkup = kb.Markup()
+	sm.markupErr = keyboardErr(kb)
+
 	return sm
 }
 
@@ -156,16 +163,50 @@ func (sm *SendMessage) APIURL(url g.String) *SendMessage {
 	return sm
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sm *SendMessage) Retry(policy *retry.Policy) *SendMessage {
+	sm.retry = policy
+	return sm
+}
+
 // DirectMessagesTopic sets the direct messages topic ID for the message.
 func (sm *SendMessage) DirectMessagesTopic(topicID int64) *SendMessage {
 	sm.opts.DirectMessagesTopicId = topicID
 	return sm
 }
 
+// Split sends a text too long for one message as several messages, cut at paragraph, line or word
+// boundaries with its formatting carried across the cuts. The reply parameters and effect apply to
+// the first message and the markup to the last:
+//
+//	msgs := c.SendMessage(report).HTML().Split().Send()
+func (sm *SendMessage) Split() *Split {
+	return splitText(sm.ctx, sm.text, sm.opts, func() g.Result[int64] {
+		return sm.ctx.chat(sm.chatID)
+	}, sm.after, sm.deleteAfter, sm.retry)
+}
+
 // Send sends the message to Telegram and returns the result.
 func (sm *SendMessage) Send() g.Result[*gotgbot.Message] {
-	return sm.ctx.timers(sm.after, sm.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := sm.chatID.UnwrapOr(sm.ctx.EffectiveChat.Id)
-		return g.ResultOf(sm.ctx.Bot.Raw().SendMessage(chatID, sm.text.Std(), sm.opts))
-	})
+	return sm.ctx.timers(sm.after, sm.deleteAfter, sm.send)
+}
+
+// Schedule schedules the message to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (sm *SendMessage) Schedule() g.Result[scheduler.JobID] {
+	return schedule(sm.ctx, sm.after, sm.deleteAfter, sm.send)
+}
+
+// send sends the message through raw.
+func (sm *SendMessage) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if sm.markupErr != nil {
+		return g.Err[*gotgbot.Message](sm.markupErr)
+	}
+
+	chatID, err := sm.ctx.chat(sm.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	return g.ResultOf(raw.SendMessageWithContext(retry.WithPolicy(std, sm.retry), chatID, sm.text.Std(), sm.opts))
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/entities"
+	"github.com/enetx/tg/retry"
 )
 
 // SendMessageDraft represents a request to send a message draft.
@@ -15,6 +16,7 @@ type SendMessageDraft struct {
 	chatID  g.Option[int64]
 	draftID int64
 	opts    *gotgbot.SendMessageDraftOpts
+	retry   *retry.Policy
 }
 
 // Text sets the text of the draft message; pass empty text to show a "Thinking..." placeholder.
@@ -75,10 +77,20 @@ func (smd *SendMessageDraft) APIURL(url g.String) *SendMessageDraft {
 	return smd
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (smd *SendMessageDraft) Retry(policy *retry.Policy) *SendMessageDraft {
+	smd.retry = policy
+	return smd
+}
+
 // Send sends the message draft to Telegram and returns the result.
 func (smd *SendMessageDraft) Send() g.Result[bool] {
-	chatID := smd.chatID.UnwrapOr(smd.ctx.EffectiveChat.Id)
-	return g.ResultOf(smd.ctx.Bot.Raw().SendMessageDraft(
+	chatID, err := smd.ctx.chat(smd.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(smd.ctx.Bot.Raw().SendMessageDraftWithContext(retry.WithPolicy(smd.ctx.Std(), smd.retry),
 		chatID,
 		smd.draftID,
 		smd.opts,
@@ -1,6 +1,7 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
@@ -8,6 +9,8 @@ import (
 	"github.com/enetx/tg/input"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/suggested"
 )
 
@@ -20,6 +23,8 @@ type SendPaidMedia struct {
 	media       g.Slice[input.PaidMedia]
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // SuggestedPost sets suggested post parameters for direct messages chats.
@@ -143,6 +148,8 @@ func (spm *SendPaidMedia) Markup(kb keyboard.Keyboard) *SendPaidMedia {
 		spm.opts.ReplyMarkup = markup
 	}
 
+	spm.markupErr = keyboardErr(kb)
+
 	return spm
 }
 
@@ -168,6 +175,12 @@ func (spm *SendPaidMedia) APIURL(url g.String) *SendPaidMedia {
 	return spm
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (spm *SendPaidMedia) Retry(policy *retry.Policy) *SendPaidMedia {
+	spm.retry = policy
+	return spm
+}
+
 // DirectMessagesTopic sets the direct messages topic ID for the message.
 func (spm *SendPaidMedia) DirectMessagesTopic(topicID int64) *SendPaidMedia {
 	spm.opts.DirectMessagesTopicId = topicID
@@ -176,6 +189,21 @@ func (spm *SendPaidMedia) DirectMessagesTopic(topicID int64) *SendPaidMedia {
 
 // Send sends the paid media and returns the message.
 func (spm *SendPaidMedia) Send() g.Result[*gotgbot.Message] {
+	return spm.ctx.timers(spm.after, spm.deleteAfter, spm.send)
+}
+
+// Schedule schedules the paid media to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (spm *SendPaidMedia) Schedule() g.Result[scheduler.JobID] {
+	return schedule(spm.ctx, spm.after, spm.deleteAfter, spm.send)
+}
+
+// send sends the paid media through raw.
+func (spm *SendPaidMedia) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if spm.markupErr != nil {
+		return g.Err[*gotgbot.Message](spm.markupErr)
+	}
+
 	if spm.media.IsEmpty() {
 		return g.Err[*gotgbot.Message](g.Errorf("no paid media specified"))
 	}
@@ -188,10 +216,12 @@ func (spm *SendPaidMedia) Send() g.Result[*gotgbot.Message] {
 		return g.Err[*gotgbot.Message](g.Errorf("star count must be between 1-10000, got {}", spm.starCount))
 	}
 
-	return spm.ctx.timers(spm.after, spm.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := spm.chatID.UnwrapOr(spm.ctx.EffectiveChat.Id)
-		media := g.TransformSlice(spm.media, input.PaidMedia.Build)
+	chatID, err := spm.ctx.chat(spm.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	media := g.TransformSlice(spm.media, input.PaidMedia.Build)
 
-		return g.ResultOf(spm.ctx.Bot.Raw().SendPaidMedia(chatID, spm.starCount, gotgbot.InputPaidMedias(media), spm.opts))
-	})
+	return g.ResultOf(raw.SendPaidMediaWithContext(retry.WithPolicy(std, spm.retry), chatID, spm.starCount, gotgbot.InputPaidMedias(media), spm.opts))
 }
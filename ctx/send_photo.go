@@ -1,6 +1,7 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
@@ -8,6 +9,8 @@ import (
 	"github.com/enetx/tg/entities"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/suggested"
 	"github.com/enetx/tg/types/effects"
 )
@@ -21,6 +24,8 @@ type SendPhoto struct {
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
 	err         error
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // CaptionEntities sets custom entities for the photo caption.
@@ -92,6 +97,8 @@ func (sp *SendPhoto) Effect(effect effects.EffectType) *SendPhoto {
 // Markup sets the reply markup keyboard for the photo message.
 func (sp *SendPhoto) Markup(kb keyboard.Keyboard) *SendPhoto {
 	sp.opts.ReplyMarkup = kb.Markup()
+	sp.markupErr = keyboardErr(kb)
+
 	return sp
 }
 
@@ -125,6 +132,12 @@ func (sp *SendPhoto) APIURL(url g.String) *SendPhoto {
 	return sp
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sp *SendPhoto) Retry(policy *retry.Policy) *SendPhoto {
+	sp.retry = policy
+	return sp
+}
+
 // Business sets the business connection ID for the photo message.
 func (sp *SendPhoto) Business(id g.String) *SendPhoto {
 	sp.opts.BusinessConnectionId = id.Std()
@@ -163,8 +176,46 @@ func (sp *SendPhoto) DirectMessagesTopic(topicID int64) *SendPhoto {
 	return sp
 }
 
+// Split sends a caption too long for the photo in messages that follow it. The photo carries
+// as much of the caption as fits, cut at paragraph, line or word boundaries, and the rest is sent
+// in replies to it, see SendMessage.Split.
+func (sp *SendPhoto) Split() *Split {
+	return splitCaption(sp.ctx, captionOpts{
+		text:        g.String(sp.opts.Caption),
+		entities:    sp.opts.CaptionEntities,
+		parseMode:   sp.opts.ParseMode,
+		thread:      sp.opts.MessageThreadId,
+		business:    sp.opts.BusinessConnectionId,
+		silent:      sp.opts.DisableNotification,
+		protect:     sp.opts.ProtectContent,
+		after:       sp.after,
+		deleteAfter: sp.deleteAfter,
+		retry:       sp.retry,
+	}, func(std context.Context, raw *gotgbot.Bot, text g.String, ents []gotgbot.MessageEntity, _ bool) g.Result[*gotgbot.Message] {
+		sp.opts.Caption = text.Std()
+		sp.opts.CaptionEntities = ents
+
+		return sp.send(std, raw)
+	})
+}
+
 // Send sends the photo message to Telegram and returns the result.
 func (sp *SendPhoto) Send() g.Result[*gotgbot.Message] {
+	return sp.ctx.timers(sp.after, sp.deleteAfter, sp.send)
+}
+
+// Schedule schedules the photo message to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (sp *SendPhoto) Schedule() g.Result[scheduler.JobID] {
+	return schedule(sp.ctx, sp.after, sp.deleteAfter, sp.send)
+}
+
+// send sends the photo message through raw.
+func (sp *SendPhoto) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if sp.markupErr != nil {
+		return g.Err[*gotgbot.Message](sp.markupErr)
+	}
+
 	if sp.err != nil {
 		return g.Err[*gotgbot.Message](sp.err)
 	}
@@ -173,8 +224,10 @@ func (sp *SendPhoto) Send() g.Result[*gotgbot.Message] {
 		defer sp.file.Close()
 	}
 
-	return sp.ctx.timers(sp.after, sp.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := sp.chatID.UnwrapOr(sp.ctx.EffectiveChat.Id)
-		return g.ResultOf(sp.ctx.Bot.Raw().SendPhoto(chatID, sp.doc, sp.opts))
-	})
+	chatID, err := sp.ctx.chat(sp.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	return g.ResultOf(raw.SendPhotoWithContext(retry.WithPolicy(std, sp.retry), chatID, sp.doc, sp.opts))
 }
@@ -1,6 +1,7 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
@@ -10,6 +11,8 @@ import (
 	"github.com/enetx/tg/input"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/types/effects"
 )
 
@@ -22,6 +25,8 @@ type SendPoll struct {
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
 	opts        *gotgbot.SendPollOpts
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // QuestionHTML sets the question parse mode to HTML.
@@ -242,6 +247,8 @@ func (sp *SendPoll) Closed() *SendPoll {
 // Markup sets the reply markup keyboard for the poll.
 func (sp *SendPoll) Markup(kb keyboard.Keyboard) *SendPoll {
 	sp.opts.ReplyMarkup = kb.Markup()
+	sp.markupErr = keyboardErr(kb)
+
 	return sp
 }
 
@@ -275,12 +282,35 @@ func (sp *SendPoll) APIURL(url g.String) *SendPoll {
 	return sp
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sp *SendPoll) Retry(policy *retry.Policy) *SendPoll {
+	sp.retry = policy
+	return sp
+}
+
 // Send sends the poll to Telegram and returns the result.
 func (sp *SendPoll) Send() g.Result[*gotgbot.Message] {
-	return sp.ctx.timers(sp.after, sp.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := sp.chatID.UnwrapOr(sp.ctx.EffectiveChat.Id)
-		options := g.TransformSlice(sp.options, input.PollOption.Build)
+	return sp.ctx.timers(sp.after, sp.deleteAfter, sp.send)
+}
+
+// Schedule schedules the poll to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (sp *SendPoll) Schedule() g.Result[scheduler.JobID] {
+	return schedule(sp.ctx, sp.after, sp.deleteAfter, sp.send)
+}
+
+// send sends the poll through raw.
+func (sp *SendPoll) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if sp.markupErr != nil {
+		return g.Err[*gotgbot.Message](sp.markupErr)
+	}
+
+	chatID, err := sp.ctx.chat(sp.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	options := g.TransformSlice(sp.options, input.PollOption.Build)
 
-		return g.ResultOf(sp.ctx.Bot.Raw().SendPoll(chatID, sp.question.Std(), options, sp.opts))
-	})
+	return g.ResultOf(raw.SendPollWithContext(retry.WithPolicy(std, sp.retry), chatID, sp.question.Std(), options, sp.opts))
 }
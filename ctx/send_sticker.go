@@ -1,12 +1,15 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/suggested"
 	"github.com/enetx/tg/types/effects"
 )
@@ -20,6 +23,8 @@ type SendSticker struct {
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
 	err         error
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // After schedules the sticker to be sent after the specified duration.
@@ -61,6 +66,8 @@ func (ss *SendSticker) Effect(effect effects.EffectType) *SendSticker {
 // Markup sets the reply markup keyboard for the sticker message.
 func (ss *SendSticker) Markup(kb keyboard.Keyboard) *SendSticker {
 	ss.opts.ReplyMarkup = kb.Markup()
+	ss.markupErr = keyboardErr(kb)
+
 	return ss
 }
 
@@ -100,6 +107,12 @@ func (ss *SendSticker) APIURL(url g.String) *SendSticker {
 	return ss
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ss *SendSticker) Retry(policy *retry.Policy) *SendSticker {
+	ss.retry = policy
+	return ss
+}
+
 // Business sets the business connection ID for the sticker message.
 func (ss *SendSticker) Business(id g.String) *SendSticker {
 	ss.opts.BusinessConnectionId = id.Std()
@@ -134,6 +147,21 @@ func (ss *SendSticker) DirectMessagesTopic(topicID int64) *SendSticker {
 
 // Send sends the sticker message to Telegram and returns the result.
 func (ss *SendSticker) Send() g.Result[*gotgbot.Message] {
+	return ss.ctx.timers(ss.after, ss.deleteAfter, ss.send)
+}
+
+// Schedule schedules the sticker message to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (ss *SendSticker) Schedule() g.Result[scheduler.JobID] {
+	return schedule(ss.ctx, ss.after, ss.deleteAfter, ss.send)
+}
+
+// send sends the sticker message through raw.
+func (ss *SendSticker) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if ss.markupErr != nil {
+		return g.Err[*gotgbot.Message](ss.markupErr)
+	}
+
 	if ss.err != nil {
 		return g.Err[*gotgbot.Message](ss.err)
 	}
@@ -142,8 +170,10 @@ func (ss *SendSticker) Send() g.Result[*gotgbot.Message] {
 		defer ss.file.Close()
 	}
 
-	return ss.ctx.timers(ss.after, ss.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := ss.chatID.UnwrapOr(ss.ctx.EffectiveChat.Id)
-		return g.ResultOf(ss.ctx.Bot.Raw().SendSticker(chatID, ss.doc, ss.opts))
-	})
+	chatID, err := ss.ctx.chat(ss.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	return g.ResultOf(raw.SendStickerWithContext(retry.WithPolicy(std, ss.retry), chatID, ss.doc, ss.opts))
 }
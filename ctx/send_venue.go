@@ -1,12 +1,15 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/suggested"
 	"github.com/enetx/tg/types/effects"
 )
@@ -21,6 +24,8 @@ type SendVenue struct {
 	chatID      g.Option[int64]
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // After schedules the venue to be sent after the specified duration.
@@ -62,6 +67,8 @@ func (sv *SendVenue) Effect(effect effects.EffectType) *SendVenue {
 // Markup sets the reply markup keyboard for the venue message.
 func (sv *SendVenue) Markup(kb keyboard.Keyboard) *SendVenue {
 	sv.opts.ReplyMarkup = kb.Markup()
+	sv.markupErr = keyboardErr(kb)
+
 	return sv
 }
 
@@ -119,6 +126,12 @@ func (sv *SendVenue) APIURL(url g.String) *SendVenue {
 	return sv
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sv *SendVenue) Retry(policy *retry.Policy) *SendVenue {
+	sv.retry = policy
+	return sv
+}
+
 // Business sets the business connection ID for the venue message.
 func (sv *SendVenue) Business(id g.String) *SendVenue {
 	sv.opts.BusinessConnectionId = id.Std()
@@ -153,10 +166,27 @@ func (sv *SendVenue) DirectMessagesTopic(topicID int64) *SendVenue {
 
 // Send sends the venue message to Telegram and returns the result.
 func (sv *SendVenue) Send() g.Result[*gotgbot.Message] {
-	return sv.ctx.timers(sv.after, sv.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := sv.chatID.UnwrapOr(sv.ctx.EffectiveChat.Id)
-		return g.ResultOf(
-			sv.ctx.Bot.Raw().SendVenue(chatID, sv.latitude, sv.longitude, sv.title.Std(), sv.address.Std(), sv.opts),
-		)
-	})
+	return sv.ctx.timers(sv.after, sv.deleteAfter, sv.send)
+}
+
+// Schedule schedules the venue message to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (sv *SendVenue) Schedule() g.Result[scheduler.JobID] {
+	return schedule(sv.ctx, sv.after, sv.deleteAfter, sv.send)
+}
+
+// send sends the venue message through raw.
+func (sv *SendVenue) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if sv.markupErr != nil {
+		return g.Err[*gotgbot.Message](sv.markupErr)
+	}
+
+	chatID, err := sv.ctx.chat(sv.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	return g.ResultOf(
+		raw.SendVenueWithContext(retry.WithPolicy(std, sv.retry), chatID, sv.latitude, sv.longitude, sv.title.Std(), sv.address.Std(), sv.opts),
+	)
 }
@@ -1,6 +1,7 @@
 package ctx
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -10,6 +11,8 @@ import (
 	"github.com/enetx/tg/internal/pkg/ffmpeg"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/suggested"
 	"github.com/enetx/tg/types/effects"
 )
@@ -27,6 +30,8 @@ type SendVideo struct {
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
 	err         error
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // CaptionEntities sets custom entities for the video caption.
@@ -138,6 +143,8 @@ func (sv *SendVideo) Effect(effect effects.EffectType) *SendVideo {
 // Markup sets the reply markup keyboard for the video message.
 func (sv *SendVideo) Markup(kb keyboard.Keyboard) *SendVideo {
 	sv.opts.ReplyMarkup = kb.Markup()
+	sv.markupErr = keyboardErr(kb)
+
 	return sv
 }
 
@@ -171,6 +178,12 @@ func (sv *SendVideo) APIURL(url g.String) *SendVideo {
 	return sv
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sv *SendVideo) Retry(policy *retry.Policy) *SendVideo {
+	sv.retry = policy
+	return sv
+}
+
 // Business sets the business connection ID for the video message.
 func (sv *SendVideo) Business(id g.String) *SendVideo {
 	sv.opts.BusinessConnectionId = id.Std()
@@ -307,8 +320,46 @@ func (sv *SendVideo) DirectMessagesTopic(topicID int64) *SendVideo {
 	return sv
 }
 
+// Split sends a caption too long for the video in messages that follow it. The video carries
+// as much of the caption as fits, cut at paragraph, line or word boundaries, and the rest is sent
+// in replies to it, see SendMessage.Split.
+func (sv *SendVideo) Split() *Split {
+	return splitCaption(sv.ctx, captionOpts{
+		text:        g.String(sv.opts.Caption),
+		entities:    sv.opts.CaptionEntities,
+		parseMode:   sv.opts.ParseMode,
+		thread:      sv.opts.MessageThreadId,
+		business:    sv.opts.BusinessConnectionId,
+		silent:      sv.opts.DisableNotification,
+		protect:     sv.opts.ProtectContent,
+		after:       sv.after,
+		deleteAfter: sv.deleteAfter,
+		retry:       sv.retry,
+	}, func(std context.Context, raw *gotgbot.Bot, text g.String, ents []gotgbot.MessageEntity, _ bool) g.Result[*gotgbot.Message] {
+		sv.opts.Caption = text.Std()
+		sv.opts.CaptionEntities = ents
+
+		return sv.send(std, raw)
+	})
+}
+
 // Send sends the video message to Telegram and returns the result.
 func (sv *SendVideo) Send() g.Result[*gotgbot.Message] {
+	return sv.ctx.timers(sv.after, sv.deleteAfter, sv.send)
+}
+
+// Schedule schedules the video message to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (sv *SendVideo) Schedule() g.Result[scheduler.JobID] {
+	return schedule(sv.ctx, sv.after, sv.deleteAfter, sv.send)
+}
+
+// send sends the video message through raw.
+func (sv *SendVideo) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if sv.markupErr != nil {
+		return g.Err[*gotgbot.Message](sv.markupErr)
+	}
+
 	if sv.err != nil {
 		return g.Err[*gotgbot.Message](sv.err)
 	}
@@ -330,8 +381,10 @@ func (sv *SendVideo) Send() g.Result[*gotgbot.Message] {
 		})
 	}()
 
-	return sv.ctx.timers(sv.after, sv.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := sv.chatID.UnwrapOr(sv.ctx.EffectiveChat.Id)
-		return g.ResultOf(sv.ctx.Bot.Raw().SendVideo(chatID, sv.doc, sv.opts))
-	})
+	chatID, err := sv.ctx.chat(sv.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	return g.ResultOf(raw.SendVideoWithContext(retry.WithPolicy(std, sv.retry), chatID, sv.doc, sv.opts))
 }
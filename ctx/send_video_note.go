@@ -1,12 +1,15 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/suggested"
 	"github.com/enetx/tg/types/effects"
 )
@@ -21,6 +24,8 @@ type SendVideoNote struct {
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
 	err         error
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // After schedules the video note to be sent after the specified duration.
@@ -62,6 +67,8 @@ func (svn *SendVideoNote) Effect(effect effects.EffectType) *SendVideoNote {
 // Markup sets the reply markup keyboard for the video note message.
 func (svn *SendVideoNote) Markup(kb keyboard.Keyboard) *SendVideoNote {
 	svn.opts.ReplyMarkup = kb.Markup()
+	svn.markupErr = keyboardErr(kb)
+
 	return svn
 }
 
@@ -121,6 +128,12 @@ func (svn *SendVideoNote) APIURL(url g.String) *SendVideoNote {
 	return svn
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (svn *SendVideoNote) Retry(policy *retry.Policy) *SendVideoNote {
+	svn.retry = policy
+	return svn
+}
+
 // Business sets the business connection ID for the video note message.
 func (svn *SendVideoNote) Business(id g.String) *SendVideoNote {
 	svn.opts.BusinessConnectionId = id.Std()
@@ -155,6 +168,21 @@ func (svn *SendVideoNote) DirectMessagesTopic(topicID int64) *SendVideoNote {
 
 // Send sends the video note message to Telegram and returns the result.
 func (svn *SendVideoNote) Send() g.Result[*gotgbot.Message] {
+	return svn.ctx.timers(svn.after, svn.deleteAfter, svn.send)
+}
+
+// Schedule schedules the video note message to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (svn *SendVideoNote) Schedule() g.Result[scheduler.JobID] {
+	return schedule(svn.ctx, svn.after, svn.deleteAfter, svn.send)
+}
+
+// send sends the video note message through raw.
+func (svn *SendVideoNote) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if svn.markupErr != nil {
+		return g.Err[*gotgbot.Message](svn.markupErr)
+	}
+
 	if svn.err != nil {
 		return g.Err[*gotgbot.Message](svn.err)
 	}
@@ -167,8 +195,10 @@ func (svn *SendVideoNote) Send() g.Result[*gotgbot.Message] {
 		defer svn.thumb.Close()
 	}
 
-	return svn.ctx.timers(svn.after, svn.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := svn.chatID.UnwrapOr(svn.ctx.EffectiveChat.Id)
-		return g.ResultOf(svn.ctx.Bot.Raw().SendVideoNote(chatID, svn.doc, svn.opts))
-	})
+	chatID, err := svn.ctx.chat(svn.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	return g.ResultOf(raw.SendVideoNoteWithContext(retry.WithPolicy(std, svn.retry), chatID, svn.doc, svn.opts))
 }
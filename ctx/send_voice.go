@@ -1,6 +1,7 @@
 package ctx
 
 import (
+	"context"
 	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
@@ -8,6 +9,8 @@ import (
 	"github.com/enetx/tg/entities"
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
 	"github.com/enetx/tg/suggested"
 	"github.com/enetx/tg/types/effects"
 )
@@ -21,6 +24,8 @@ type SendVoice struct {
 	after       g.Option[time.Duration]
 	deleteAfter g.Option[time.Duration]
 	err         error
+	retry       *retry.Policy
+	markupErr   error
 }
 
 // CaptionEntities sets custom entities for the voice caption.
@@ -86,6 +91,8 @@ func (sv *SendVoice) Effect(effect effects.EffectType) *SendVoice {
 // Markup sets the reply markup keyboard for the voice message.
 func (sv *SendVoice) Markup(kb keyboard.Keyboard) *SendVoice {
 	sv.opts.ReplyMarkup = kb.Markup()
+	sv.markupErr = keyboardErr(kb)
+
 	return sv
 }
 
@@ -125,6 +132,12 @@ func (sv *SendVoice) APIURL(url g.String) *SendVoice {
 	return sv
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sv *SendVoice) Retry(policy *retry.Policy) *SendVoice {
+	sv.retry = policy
+	return sv
+}
+
 // Business sets the business connection ID for the voice message.
 func (sv *SendVoice) Business(id g.String) *SendVoice {
 	sv.opts.BusinessConnectionId = id.Std()
@@ -159,6 +172,21 @@ func (sv *SendVoice) DirectMessagesTopic(topicID int64) *SendVoice {
 
 // Send sends the voice message to Telegram and returns the result.
 func (sv *SendVoice) Send() g.Result[*gotgbot.Message] {
+	return sv.ctx.timers(sv.after, sv.deleteAfter, sv.send)
+}
+
+// Schedule schedules the voice message to be sent after the delay set with After, or right away,
+// and returns the ID of its job in the bot's scheduler.
+func (sv *SendVoice) Schedule() g.Result[scheduler.JobID] {
+	return schedule(sv.ctx, sv.after, sv.deleteAfter, sv.send)
+}
+
+// send sends the voice message through raw.
+func (sv *SendVoice) send(std context.Context, raw *gotgbot.Bot) g.Result[*gotgbot.Message] {
+	if sv.markupErr != nil {
+		return g.Err[*gotgbot.Message](sv.markupErr)
+	}
+
 	if sv.err != nil {
 		return g.Err[*gotgbot.Message](sv.err)
 	}
@@ -167,8 +195,10 @@ func (sv *SendVoice) Send() g.Result[*gotgbot.Message] {
 		defer sv.file.Close()
 	}
 
-	return sv.ctx.timers(sv.after, sv.deleteAfter, func() g.Result[*gotgbot.Message] {
-		chatID := sv.chatID.UnwrapOr(sv.ctx.EffectiveChat.Id)
-		return g.ResultOf(sv.ctx.Bot.Raw().SendVoice(chatID, sv.doc, sv.opts))
-	})
+	chatID, err := sv.ctx.chat(sv.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Message](err)
+	}
+
+	return g.ResultOf(raw.SendVoiceWithContext(retry.WithPolicy(std, sv.retry), chatID, sv.doc, sv.opts))
 }
@@ -0,0 +1,176 @@
+package ctx
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/enetx/g"
+)
+
+// sessionKey is the key under which the session is kept in the value store of the update.
+type sessionKey struct{}
+
+// Session holds data kept between updates of the same user or chat.
+// It is loaded before the handler and saved after it by the session middleware
+// (see the session package); without the middleware it lasts for the current update only.
+//
+// Values must be encodable as JSON. Values set during the update are returned as is,
+// values loaded from the store are decoded on access. Call Set again after modifying
+// a value in place, so that the change is saved.
+type Session struct {
+	mu      sync.RWMutex
+	data    map[g.String]any
+	changed bool
+}
+
+// Session returns the session of the update.
+func (ctx *Context) Session() *Session {
+	ctx.values.mu.Lock()
+	defer ctx.values.mu.Unlock()
+
+	if s, ok := ctx.values.m[sessionKey{}].(*Session); ok {
+		return s
+	}
+
+	s := &Session{data: make(map[g.String]any)}
+	ctx.values.m[sessionKey{}] = s
+
+	return s
+}
+
+// Set stores value under key.
+func (s *Session) Set(key g.String, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data[key] = value
+	s.changed = true
+}
+
+// Get returns the value stored under key. Loaded values are decoded as generic JSON values,
+// use SessionValue to decode them into a specific type.
+func (s *Session) Get(key g.String) g.Option[any] {
+	return SessionValue[any](s, key)
+}
+
+// Has reports whether a value is stored under key.
+func (s *Session) Has(key g.String) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.data[key]
+
+	return ok
+}
+
+// Delete removes the value stored under key.
+func (s *Session) Delete(key g.String) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data[key]; ok {
+		delete(s.data, key)
+		s.changed = true
+	}
+}
+
+// Clear removes all values. A cleared session is deleted from the store.
+func (s *Session) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.data) > 0 {
+		clear(s.data)
+		s.changed = true
+	}
+}
+
+// Keys returns the keys of the stored values.
+func (s *Session) Keys() g.Slice[g.String] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return g.Map[g.String, any](s.data).Keys()
+}
+
+// Len returns the number of stored values.
+func (s *Session) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.data)
+}
+
+// Changed reports whether the session was modified since it was loaded.
+func (s *Session) Changed() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.changed
+}
+
+// MarshalJSON encodes the session data as a JSON object.
+func (s *Session) MarshalJSON() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return json.Marshal(s.data)
+}
+
+// UnmarshalJSON replaces the session data with the JSON object in data and marks the session unchanged.
+func (s *Session) UnmarshalJSON(data []byte) error {
+	var raw map[g.String]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = make(map[g.String]any, len(raw))
+	for k, v := range raw {
+		s.data[k] = v
+	}
+
+	s.changed = false
+
+	return nil
+}
+
+// Reset removes all values and marks the session unchanged.
+func (s *Session) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.data)
+	s.changed = false
+}
+
+// SessionValue returns the value stored under key in s as T. A value loaded from the store is decoded
+// into T; None is returned if the key is missing or the value does not fit T.
+//
+//	cart := ctx.SessionValue[Cart](c.Session(), "cart").UnwrapOrDefault()
+func SessionValue[T any](s *Session, key g.String) g.Option[T] {
+	s.mu.RLock()
+	v, ok := s.data[key]
+	s.mu.RUnlock()
+
+	if !ok {
+		return g.None[T]()
+	}
+
+	if raw, ok := v.(json.RawMessage); ok {
+		var decoded T
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return g.None[T]()
+		}
+
+		return g.Some(decoded)
+	}
+
+	if t, ok := v.(T); ok {
+		return g.Some(t)
+	}
+
+	return g.None[T]()
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetChatAdministratorCustomTitle represents a request to set a custom title for an administrator.
@@ -14,6 +15,7 @@ type SetChatAdministratorCustomTitle struct {
 	customTitle g.String
 	opts        *gotgbot.SetChatAdministratorCustomTitleOpts
 	chatID      g.Option[int64]
+	retry       *retry.Policy
 }
 
 // ChatID sets the target chat ID for this request.
@@ -44,10 +46,20 @@ func (scact *SetChatAdministratorCustomTitle) APIURL(url g.String) *SetChatAdmin
 	return scact
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (scact *SetChatAdministratorCustomTitle) Retry(policy *retry.Policy) *SetChatAdministratorCustomTitle {
+	scact.retry = policy
+	return scact
+}
+
 // Send executes the SetChatAdministratorCustomTitle request.
 func (scact *SetChatAdministratorCustomTitle) Send() g.Result[bool] {
-	chatID := scact.chatID.UnwrapOr(scact.ctx.EffectiveChat.Id)
+	chatID, err := scact.ctx.chat(scact.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
 	return g.ResultOf(
-		scact.ctx.Bot.Raw().SetChatAdministratorCustomTitle(chatID, scact.userID, scact.customTitle.Std(), scact.opts),
+		scact.ctx.Bot.Raw().SetChatAdministratorCustomTitleWithContext(retry.WithPolicy(scact.ctx.Std(), scact.retry), chatID, scact.userID, scact.customTitle.Std(), scact.opts),
 	)
 }
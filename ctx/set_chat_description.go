@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetChatDescription represents a request to set the chat description.
@@ -13,6 +14,7 @@ type SetChatDescription struct {
 	description g.String
 	opts        *gotgbot.SetChatDescriptionOpts
 	chatID      g.Option[int64]
+	retry       *retry.Policy
 }
 
 // ChatID sets the target chat ID for this request.
@@ -43,8 +45,18 @@ func (scd *SetChatDescription) APIURL(url g.String) *SetChatDescription {
 	return scd
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (scd *SetChatDescription) Retry(policy *retry.Policy) *SetChatDescription {
+	scd.retry = policy
+	return scd
+}
+
 // Send executes the SetChatDescription request.
 func (scd *SetChatDescription) Send() g.Result[bool] {
-	chatID := scd.chatID.UnwrapOr(scd.ctx.EffectiveChat.Id)
-	return g.ResultOf(scd.ctx.Bot.Raw().SetChatDescription(chatID, scd.opts))
+	chatID, err := scd.ctx.chat(scd.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(scd.ctx.Bot.Raw().SetChatDescriptionWithContext(retry.WithPolicy(scd.ctx.Std(), scd.retry), chatID, scd.opts))
 }
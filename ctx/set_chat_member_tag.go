@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetChatMemberTag represents a request to set a tag for a regular member in a chat.
@@ -13,6 +14,7 @@ type SetChatMemberTag struct {
 	userID int64
 	opts   *gotgbot.SetChatMemberTagOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID where the member's tag will be updated.
@@ -50,8 +52,18 @@ func (scmt *SetChatMemberTag) APIURL(url g.String) *SetChatMemberTag {
 	return scmt
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (scmt *SetChatMemberTag) Retry(policy *retry.Policy) *SetChatMemberTag {
+	scmt.retry = policy
+	return scmt
+}
+
 // Send executes the set chat member tag action and returns the result.
 func (scmt *SetChatMemberTag) Send() g.Result[bool] {
-	chatID := scmt.chatID.UnwrapOr(scmt.ctx.EffectiveChat.Id)
-	return g.ResultOf(scmt.ctx.Bot.Raw().SetChatMemberTag(chatID, scmt.userID, scmt.opts))
+	chatID, err := scmt.ctx.chat(scmt.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(scmt.ctx.Bot.Raw().SetChatMemberTagWithContext(retry.WithPolicy(scmt.ctx.Std(), scmt.retry), chatID, scmt.userID, scmt.opts))
 }
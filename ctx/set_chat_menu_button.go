@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetChatMenuButton represents a request to set the menu button of a chat.
@@ -13,6 +14,7 @@ type SetChatMenuButton struct {
 	chatID     g.Option[*int64]
 	menuButton gotgbot.MenuButton
 	opts       *gotgbot.SetChatMenuButtonOpts
+	retry      *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -71,10 +73,16 @@ func (scmb *SetChatMenuButton) APIURL(url g.String) *SetChatMenuButton {
 	return scmb
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (scmb *SetChatMenuButton) Retry(policy *retry.Policy) *SetChatMenuButton {
+	scmb.retry = policy
+	return scmb
+}
+
 // Send sets the chat menu button.
 func (scmb *SetChatMenuButton) Send() g.Result[bool] {
 	scmb.opts.ChatId = scmb.chatID.UnwrapOrDefault()
 	scmb.opts.MenuButton = scmb.menuButton
 
-	return g.ResultOf(scmb.ctx.Bot.Raw().SetChatMenuButton(scmb.opts))
+	return g.ResultOf(scmb.ctx.Bot.Raw().SetChatMenuButtonWithContext(retry.WithPolicy(scmb.ctx.Std(), scmb.retry), scmb.opts))
 }
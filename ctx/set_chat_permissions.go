@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 	"github.com/enetx/tg/types/permissions"
 )
 
@@ -15,6 +16,7 @@ type SetChatPermissions struct {
 	autoPermissions bool
 	opts            *gotgbot.SetChatPermissionsOpts
 	chatID          g.Option[int64]
+	retry           *retry.Policy
 }
 
 // ChatID sets the target chat ID for this request.
@@ -51,6 +53,12 @@ func (scp *SetChatPermissions) APIURL(url g.String) *SetChatPermissions {
 	return scp
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (scp *SetChatPermissions) Retry(policy *retry.Policy) *SetChatPermissions {
+	scp.retry = policy
+	return scp
+}
+
 // Permissions sets the allowed permissions for the chat.
 func (scp *SetChatPermissions) Permissions(perms ...permissions.Permission) *SetChatPermissions {
 	scp.permissions = permissions.Permissions(perms...)
@@ -63,8 +71,12 @@ func (scp *SetChatPermissions) Send() g.Result[bool] {
 		return g.Err[bool](g.Errorf("permissions are required"))
 	}
 
-	chatID := scp.chatID.UnwrapOr(scp.ctx.EffectiveChat.Id)
+	chatID, err := scp.ctx.chat(scp.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
 	scp.opts.UseIndependentChatPermissions = !scp.autoPermissions
 
-	return g.ResultOf(scp.ctx.Bot.Raw().SetChatPermissions(chatID, *scp.permissions, scp.opts))
+	return g.ResultOf(scp.ctx.Bot.Raw().SetChatPermissionsWithContext(retry.WithPolicy(scp.ctx.Std(), scp.retry), chatID, *scp.permissions, scp.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetChatPhoto represents a request to set the chat photo.
@@ -15,6 +16,7 @@ type SetChatPhoto struct {
 	file   *g.File
 	chatID g.Option[int64]
 	err    error
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID for this request.
@@ -45,6 +47,12 @@ func (scp *SetChatPhoto) APIURL(url g.String) *SetChatPhoto {
 	return scp
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (scp *SetChatPhoto) Retry(policy *retry.Policy) *SetChatPhoto {
+	scp.retry = policy
+	return scp
+}
+
 // Send executes the SetChatPhoto request.
 func (scp *SetChatPhoto) Send() g.Result[bool] {
 	if scp.err != nil {
@@ -55,7 +63,10 @@ func (scp *SetChatPhoto) Send() g.Result[bool] {
 		defer scp.file.Close()
 	}
 
-	chatID := scp.chatID.UnwrapOr(scp.ctx.EffectiveChat.Id)
+	chatID, err := scp.ctx.chat(scp.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
 
-	return g.ResultOf(scp.ctx.Bot.Raw().SetChatPhoto(chatID, scp.doc, scp.opts))
+	return g.ResultOf(scp.ctx.Bot.Raw().SetChatPhotoWithContext(retry.WithPolicy(scp.ctx.Std(), scp.retry), chatID, scp.doc, scp.opts))
 }
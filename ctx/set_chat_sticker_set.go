@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetChatStickerSet represents a request to set a chat's sticker set.
@@ -13,6 +14,7 @@ type SetChatStickerSet struct {
 	stickerSetName g.String
 	opts           *gotgbot.SetChatStickerSetOpts
 	chatID         g.Option[int64]
+	retry          *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -43,8 +45,18 @@ func (scss *SetChatStickerSet) APIURL(url g.String) *SetChatStickerSet {
 	return scss
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (scss *SetChatStickerSet) Retry(policy *retry.Policy) *SetChatStickerSet {
+	scss.retry = policy
+	return scss
+}
+
 // Send sets the chat sticker set and returns the result.
 func (scss *SetChatStickerSet) Send() g.Result[bool] {
-	chatID := scss.chatID.UnwrapOr(scss.ctx.EffectiveChat.Id)
-	return g.ResultOf(scss.ctx.Bot.Raw().SetChatStickerSet(chatID, scss.stickerSetName.Std(), scss.opts))
+	chatID, err := scss.ctx.chat(scss.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(scss.ctx.Bot.Raw().SetChatStickerSetWithContext(retry.WithPolicy(scss.ctx.Std(), scss.retry), chatID, scss.stickerSetName.Std(), scss.opts))
 }
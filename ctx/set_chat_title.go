@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetChatTitle represents a request to set the chat title.
@@ -13,6 +14,7 @@ type SetChatTitle struct {
 	title  g.String
 	opts   *gotgbot.SetChatTitleOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID for this request.
@@ -43,8 +45,18 @@ func (sat *SetChatTitle) APIURL(url g.String) *SetChatTitle {
 	return sat
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sat *SetChatTitle) Retry(policy *retry.Policy) *SetChatTitle {
+	sat.retry = policy
+	return sat
+}
+
 // Send executes the SetChatTitle request.
 func (sat *SetChatTitle) Send() g.Result[bool] {
-	chatID := sat.chatID.UnwrapOr(sat.ctx.EffectiveChat.Id)
-	return g.ResultOf(sat.ctx.Bot.Raw().SetChatTitle(chatID, sat.title.Std(), sat.opts))
+	chatID, err := sat.ctx.chat(sat.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(sat.ctx.Bot.Raw().SetChatTitleWithContext(retry.WithPolicy(sat.ctx.Std(), sat.retry), chatID, sat.title.Std(), sat.opts))
 }
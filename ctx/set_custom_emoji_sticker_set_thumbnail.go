@@ -5,13 +5,15 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetCustomEmojiStickerSetThumbnail represents a request to set the thumbnail of a custom emoji sticker set.
 type SetCustomEmojiStickerSetThumbnail struct {
-	ctx  *Context
-	name g.String
-	opts *gotgbot.SetCustomEmojiStickerSetThumbnailOpts
+	ctx   *Context
+	name  g.String
+	opts  *gotgbot.SetCustomEmojiStickerSetThumbnailOpts
+	retry *retry.Policy
 }
 
 // CustomEmojiID sets the custom emoji identifier for the thumbnail.
@@ -48,7 +50,13 @@ func (scesst *SetCustomEmojiStickerSetThumbnail) APIURL(url g.String) *SetCustom
 	return scesst
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (scesst *SetCustomEmojiStickerSetThumbnail) Retry(policy *retry.Policy) *SetCustomEmojiStickerSetThumbnail {
+	scesst.retry = policy
+	return scesst
+}
+
 // Send sets the custom emoji sticker set thumbnail.
 func (scesst *SetCustomEmojiStickerSetThumbnail) Send() g.Result[bool] {
-	return g.ResultOf(scesst.ctx.Bot.Raw().SetCustomEmojiStickerSetThumbnail(scesst.name.Std(), scesst.opts))
+	return g.ResultOf(scesst.ctx.Bot.Raw().SetCustomEmojiStickerSetThumbnailWithContext(retry.WithPolicy(scesst.ctx.Std(), scesst.retry), scesst.name.Std(), scesst.opts))
 }
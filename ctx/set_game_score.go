@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetGameScore represents a request to set the score for a game.
@@ -16,6 +17,7 @@ type SetGameScore struct {
 	chatID          g.Option[int64]
 	messageID       g.Option[int64]
 	inlineMessageID g.Option[g.String]
+	retry           *retry.Policy
 }
 
 // UserID sets the user ID for the score.
@@ -82,19 +84,35 @@ func (sgs *SetGameScore) APIURL(url g.String) *SetGameScore {
 	return sgs
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sgs *SetGameScore) Retry(policy *retry.Policy) *SetGameScore {
+	sgs.retry = policy
+	return sgs
+}
+
 // Send sets the game score and returns the result.
 func (sgs *SetGameScore) Send() g.Result[*gotgbot.Message] {
 	if sgs.score < 0 {
 		return g.Err[*gotgbot.Message](g.Errorf("score cannot be negative: {}", sgs.score))
 	}
 
-	sgs.opts.ChatId = sgs.chatID.UnwrapOr(sgs.ctx.EffectiveChat.Id)
-	sgs.opts.MessageId = sgs.messageID.UnwrapOr(sgs.ctx.EffectiveMessage.MessageId)
-
 	if sgs.inlineMessageID.IsSome() {
 		sgs.opts.InlineMessageId = sgs.inlineMessageID.Some().Std()
+	} else {
+		chatID, err := sgs.ctx.chat(sgs.chatID).Result()
+		if err != nil {
+			return g.Err[*gotgbot.Message](err)
+		}
+
+		messageID, err := sgs.ctx.message(sgs.messageID).Result()
+		if err != nil {
+			return g.Err[*gotgbot.Message](err)
+		}
+
+		sgs.opts.ChatId = chatID
+		sgs.opts.MessageId = messageID
 	}
 
-	msg, _, err := sgs.ctx.Bot.Raw().SetGameScore(sgs.userID, sgs.score, sgs.opts)
+	msg, _, err := sgs.ctx.Bot.Raw().SetGameScoreWithContext(retry.WithPolicy(sgs.ctx.Std(), sgs.retry), sgs.userID, sgs.score, sgs.opts)
 	return g.ResultOf(msg, err)
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetManagedBotAccessSettings represents a request to change the access settings of a managed bot.
@@ -13,6 +14,7 @@ type SetManagedBotAccessSettings struct {
 	userID             int64
 	isAccessRestricted bool
 	opts               *gotgbot.SetManagedBotAccessSettingsOpts
+	retry              *retry.Policy
 }
 
 // AddedUserIDs sets the list of up to 10 identifiers of users who will have access to the bot
@@ -44,9 +46,15 @@ func (smbas *SetManagedBotAccessSettings) APIURL(url g.String) *SetManagedBotAcc
 	return smbas
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (smbas *SetManagedBotAccessSettings) Retry(policy *retry.Policy) *SetManagedBotAccessSettings {
+	smbas.retry = policy
+	return smbas
+}
+
 // Send updates the managed bot access settings and returns the result.
 func (smbas *SetManagedBotAccessSettings) Send() g.Result[bool] {
-	return g.ResultOf(smbas.ctx.Bot.Raw().SetManagedBotAccessSettings(
+	return g.ResultOf(smbas.ctx.Bot.Raw().SetManagedBotAccessSettingsWithContext(retry.WithPolicy(smbas.ctx.Std(), smbas.retry),
 		smbas.userID,
 		smbas.isAccessRestricted,
 		smbas.opts,
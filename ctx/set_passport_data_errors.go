@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 	"github.com/enetx/tg/types/passport"
 )
 
@@ -14,6 +15,7 @@ type SetPassportDataErrors struct {
 	userID int64
 	errors g.Slice[gotgbot.PassportElementError]
 	opts   *gotgbot.SetPassportDataErrorsOpts
+	retry  *retry.Policy
 }
 
 // Errors sets the passport element errors using our passport error builders.
@@ -44,7 +46,13 @@ func (spde *SetPassportDataErrors) APIURL(url g.String) *SetPassportDataErrors {
 	return spde
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (spde *SetPassportDataErrors) Retry(policy *retry.Policy) *SetPassportDataErrors {
+	spde.retry = policy
+	return spde
+}
+
 // Send sets the passport data errors.
 func (spde *SetPassportDataErrors) Send() g.Result[bool] {
-	return g.ResultOf(spde.ctx.Bot.Raw().SetPassportDataErrors(spde.userID, spde.errors, spde.opts))
+	return g.ResultOf(spde.ctx.Bot.Raw().SetPassportDataErrorsWithContext(retry.WithPolicy(spde.ctx.Std(), spde.retry), spde.userID, spde.errors, spde.opts))
 }
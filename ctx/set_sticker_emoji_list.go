@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetStickerEmojiList represents a request to set sticker emoji list.
@@ -13,6 +14,7 @@ type SetStickerEmojiList struct {
 	sticker   gotgbot.InputFileOrString
 	emojiList g.Slice[g.String]
 	opts      *gotgbot.SetStickerEmojiListOpts
+	retry     *retry.Policy
 }
 
 // EmojiList sets the emoji list for the sticker.
@@ -43,9 +45,15 @@ func (ssel *SetStickerEmojiList) APIURL(url g.String) *SetStickerEmojiList {
 	return ssel
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ssel *SetStickerEmojiList) Retry(policy *retry.Policy) *SetStickerEmojiList {
+	ssel.retry = policy
+	return ssel
+}
+
 // Send sets the sticker emoji list.
 func (ssel *SetStickerEmojiList) Send() g.Result[bool] {
 	return g.ResultOf(ssel.ctx.Bot.Raw().
-		SetStickerEmojiList(ssel.sticker, g.TransformSlice(ssel.emojiList, g.String.Std), ssel.opts),
+		SetStickerEmojiListWithContext(retry.WithPolicy(ssel.ctx.Std(), ssel.retry), ssel.sticker, g.TransformSlice(ssel.emojiList, g.String.Std), ssel.opts),
 	)
 }
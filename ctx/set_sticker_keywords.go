@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetStickerKeywords represents a request to set sticker keywords.
@@ -13,6 +14,7 @@ type SetStickerKeywords struct {
 	sticker  gotgbot.InputFileOrString
 	keywords g.Slice[g.String]
 	opts     *gotgbot.SetStickerKeywordsOpts
+	retry    *retry.Policy
 }
 
 // Keywords sets the keywords for the sticker.
@@ -43,8 +45,14 @@ func (ssk *SetStickerKeywords) APIURL(url g.String) *SetStickerKeywords {
 	return ssk
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ssk *SetStickerKeywords) Retry(policy *retry.Policy) *SetStickerKeywords {
+	ssk.retry = policy
+	return ssk
+}
+
 // Send sets the sticker keywords.
 func (ssk *SetStickerKeywords) Send() g.Result[bool] {
 	ssk.opts.Keywords = g.TransformSlice(ssk.keywords, g.String.Std)
-	return g.ResultOf(ssk.ctx.Bot.Raw().SetStickerKeywords(ssk.sticker, ssk.opts))
+	return g.ResultOf(ssk.ctx.Bot.Raw().SetStickerKeywordsWithContext(retry.WithPolicy(ssk.ctx.Std(), ssk.retry), ssk.sticker, ssk.opts))
 }
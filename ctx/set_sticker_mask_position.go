@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetStickerMaskPosition represents a request to set sticker mask position.
@@ -13,6 +14,7 @@ type SetStickerMaskPosition struct {
 	sticker      gotgbot.InputFileOrString
 	maskPosition *gotgbot.MaskPosition
 	opts         *gotgbot.SetStickerMaskPositionOpts
+	retry        *retry.Policy
 }
 
 // MaskPosition sets the mask position for the sticker.
@@ -52,8 +54,14 @@ func (ssmp *SetStickerMaskPosition) APIURL(url g.String) *SetStickerMaskPosition
 	return ssmp
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ssmp *SetStickerMaskPosition) Retry(policy *retry.Policy) *SetStickerMaskPosition {
+	ssmp.retry = policy
+	return ssmp
+}
+
 // Send sets the sticker mask position.
 func (ssmp *SetStickerMaskPosition) Send() g.Result[bool] {
 	ssmp.opts.MaskPosition = ssmp.maskPosition
-	return g.ResultOf(ssmp.ctx.Bot.Raw().SetStickerMaskPosition(ssmp.sticker, ssmp.opts))
+	return g.ResultOf(ssmp.ctx.Bot.Raw().SetStickerMaskPositionWithContext(retry.WithPolicy(ssmp.ctx.Std(), ssmp.retry), ssmp.sticker, ssmp.opts))
 }
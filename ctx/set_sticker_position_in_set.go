@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetStickerPositionInSet represents a request to set sticker position in set.
@@ -13,6 +14,7 @@ type SetStickerPositionInSet struct {
 	sticker  gotgbot.InputFileOrString
 	position int64
 	opts     *gotgbot.SetStickerPositionInSetOpts
+	retry    *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -37,7 +39,13 @@ func (sspis *SetStickerPositionInSet) APIURL(url g.String) *SetStickerPositionIn
 	return sspis
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sspis *SetStickerPositionInSet) Retry(policy *retry.Policy) *SetStickerPositionInSet {
+	sspis.retry = policy
+	return sspis
+}
+
 // Send sets the sticker position in the set.
 func (sspis *SetStickerPositionInSet) Send() g.Result[bool] {
-	return g.ResultOf(sspis.ctx.Bot.Raw().SetStickerPositionInSet(sspis.sticker, int64(sspis.position), sspis.opts))
+	return g.ResultOf(sspis.ctx.Bot.Raw().SetStickerPositionInSetWithContext(retry.WithPolicy(sspis.ctx.Std(), sspis.retry), sspis.sticker, int64(sspis.position), sspis.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetStickerSetThumbnail represents a request to set sticker set thumbnail.
@@ -16,6 +17,7 @@ type SetStickerSetThumbnail struct {
 	opts   *gotgbot.SetStickerSetThumbnailOpts
 	thumb  *g.File
 	err    error
+	retry  *retry.Policy
 }
 
 // Thumbnail sets the thumbnail file for the sticker set.
@@ -62,6 +64,12 @@ func (ssst *SetStickerSetThumbnail) APIURL(url g.String) *SetStickerSetThumbnail
 	return ssst
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ssst *SetStickerSetThumbnail) Retry(policy *retry.Policy) *SetStickerSetThumbnail {
+	ssst.retry = policy
+	return ssst
+}
+
 // Send sets the sticker set thumbnail.
 func (ssst *SetStickerSetThumbnail) Send() g.Result[bool] {
 	if ssst.err != nil {
@@ -73,6 +81,6 @@ func (ssst *SetStickerSetThumbnail) Send() g.Result[bool] {
 	}
 
 	return g.ResultOf(ssst.ctx.Bot.Raw().
-		SetStickerSetThumbnail(ssst.name.Std(), ssst.userID, ssst.format.Std(), ssst.opts),
+		SetStickerSetThumbnailWithContext(retry.WithPolicy(ssst.ctx.Std(), ssst.retry), ssst.name.Std(), ssst.userID, ssst.format.Std(), ssst.opts),
 	)
 }
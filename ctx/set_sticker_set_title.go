@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetStickerSetTitle represents a request to set the title of a sticker set.
@@ -13,6 +14,7 @@ type SetStickerSetTitle struct {
 	name  g.String
 	title g.String
 	opts  *gotgbot.SetStickerSetTitleOpts
+	retry *retry.Policy
 }
 
 // Timeout sets a custom timeout for this request.
@@ -37,7 +39,13 @@ func (ssst *SetStickerSetTitle) APIURL(url g.String) *SetStickerSetTitle {
 	return ssst
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ssst *SetStickerSetTitle) Retry(policy *retry.Policy) *SetStickerSetTitle {
+	ssst.retry = policy
+	return ssst
+}
+
 // Send sets the sticker set title.
 func (ssst *SetStickerSetTitle) Send() g.Result[bool] {
-	return g.ResultOf(ssst.ctx.Bot.Raw().SetStickerSetTitle(ssst.name.Std(), ssst.title.Std(), ssst.opts))
+	return g.ResultOf(ssst.ctx.Bot.Raw().SetStickerSetTitleWithContext(retry.WithPolicy(ssst.ctx.Std(), ssst.retry), ssst.name.Std(), ssst.title.Std(), ssst.opts))
 }
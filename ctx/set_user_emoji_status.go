@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // SetUserEmojiStatus represents a request to set user emoji status.
@@ -12,6 +13,7 @@ type SetUserEmojiStatus struct {
 	ctx    *Context
 	userID int64
 	opts   *gotgbot.SetUserEmojiStatusOpts
+	retry  *retry.Policy
 }
 
 // EmojiStatusCustomEmojiID sets the custom emoji identifier for the status.
@@ -66,7 +68,13 @@ func (sues *SetUserEmojiStatus) APIURL(url g.String) *SetUserEmojiStatus {
 	return sues
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sues *SetUserEmojiStatus) Retry(policy *retry.Policy) *SetUserEmojiStatus {
+	sues.retry = policy
+	return sues
+}
+
 // Send sets the user emoji status.
 func (sues *SetUserEmojiStatus) Send() g.Result[bool] {
-	return g.ResultOf(sues.ctx.Bot.Raw().SetUserEmojiStatus(sues.userID, sues.opts))
+	return g.ResultOf(sues.ctx.Bot.Raw().SetUserEmojiStatusWithContext(retry.WithPolicy(sues.ctx.Std(), sues.retry), sues.userID, sues.opts))
 }
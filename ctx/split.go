@@ -0,0 +1,217 @@
+package ctx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/entities"
+	"github.com/enetx/tg/retry"
+)
+
+var (
+	// ErrSplitMarkdown is returned by Split.Send for MarkdownV2 text, which cannot be split
+	// safely. Use HTML or entities instead.
+	ErrSplitMarkdown = errors.New("MarkdownV2 text cannot be split, use HTML or entities")
+
+	// ErrSplitAfter is returned by Split.Send when the request was delayed with After.
+	ErrSplitAfter = errors.New("split messages cannot be delayed with After")
+)
+
+// Split sends a text that may be too long for one message as a series of messages. The text is cut
+// at paragraph, line or word boundaries, see entities.Entities.Cut, and its formatting is carried
+// across the cuts: entities are re-based and HTML tags are re-opened in every part.
+//
+// It is created by the Split method of SendMessage, Reply and the requests sending media with a
+// caption. A caption too long for the media continues in messages that reply to the media.
+type Split struct {
+	ctx         *Context
+	text        g.String
+	entities    []gotgbot.MessageEntity
+	parseMode   string
+	limit       int
+	deleteAfter g.Option[time.Duration]
+	after       g.Option[time.Duration]
+	retry       *retry.Policy
+
+	// first sends the first part; last reports whether it is the only one.
+	first func(std context.Context, raw *gotgbot.Bot, text g.String, ents []gotgbot.MessageEntity, last bool) g.Result[*gotgbot.Message]
+
+	// next returns the options of a following part, given the first message.
+	next func(first *gotgbot.Message, last bool) *gotgbot.SendMessageOpts
+}
+
+// Send sends all parts and returns the sent messages in order. It stops at the first failed part.
+func (s *Split) Send() g.Result[g.Slice[*gotgbot.Message]] {
+	if s.after.IsSome() {
+		return g.Err[g.Slice[*gotgbot.Message]](ErrSplitAfter)
+	}
+
+	parts, err := s.parts().Result()
+	if err != nil {
+		return g.Err[g.Slice[*gotgbot.Message]](err)
+	}
+
+	std, raw := s.ctx.Std(), s.ctx.Bot.Raw()
+	msgs := g.NewSlice[*gotgbot.Message]()
+
+	for i, part := range parts {
+		last := i == len(parts)-1
+		text, ents := s.render(part)
+
+		var msg g.Result[*gotgbot.Message]
+
+		if i == 0 {
+			msg = s.first(std, raw, text, ents, last)
+		} else {
+			opts := s.next(msgs[0], last)
+			opts.ParseMode = s.parseMode
+			opts.Entities = ents
+
+			msg = g.ResultOf(raw.SendMessageWithContext(retry.WithPolicy(std, s.retry), msgs[0].Chat.Id, text.Std(), opts))
+		}
+
+		if msg.IsErr() {
+			return g.Err[g.Slice[*gotgbot.Message]](fmt.Errorf("failed to send part %d of %d: %w", i+1, len(parts), msg.Err()))
+		}
+
+		msgs.Push(msg.Ok())
+
+		if s.deleteAfter.IsSome() {
+			s.ctx.DeleteMessage().ChatID(msg.Ok().Chat.Id).MessageID(msg.Ok().MessageId).After(s.deleteAfter.Some()).Send()
+		}
+	}
+
+	return g.Ok(msgs)
+}
+
+// parts cuts the text into the parts of the messages.
+func (s *Split) parts() g.Result[g.Slice[*entities.Entities]] {
+	var text *entities.Entities
+
+	switch s.parseMode {
+	case "":
+		text = entities.New(s.text).Import(s.entities)
+	case "HTML":
+		parsed, err := entities.ParseHTML(s.text).Result()
+		if err != nil {
+			return g.Err[g.Slice[*entities.Entities]](err)
+		}
+
+		text = parsed
+	default:
+		return g.Err[g.Slice[*entities.Entities]](ErrSplitMarkdown)
+	}
+
+	head, rest := text.Cut(s.limit)
+
+	parts := g.SliceOf(head)
+	if rest != nil {
+		parts.Push(rest.Split(entities.MaxMessageLen)...)
+	}
+
+	return g.Ok(parts)
+}
+
+// render returns the text of a part and its entities in the parse mode of the split.
+func (s *Split) render(part *entities.Entities) (g.String, []gotgbot.MessageEntity) {
+	if s.parseMode == "HTML" {
+		return entities.ToHTML(part.Text(), part.Std()), nil
+	}
+
+	return part.Text(), part.Std()
+}
+
+// splitText returns a split of a text message sent with opts to the chat returned by chat.
+// The reply parameters and effect apply to the first part, and the markup to the last.
+func splitText(
+	c *Context,
+	text g.String,
+	opts *gotgbot.SendMessageOpts,
+	chat func() g.Result[int64],
+	after, deleteAfter g.Option[time.Duration],
+	rp *retry.Policy,
+) *Split {
+	return &Split{
+		ctx:         c,
+		text:        text,
+		entities:    opts.Entities,
+		parseMode:   opts.ParseMode,
+		limit:       entities.MaxMessageLen,
+		after:       after,
+		deleteAfter: deleteAfter,
+		retry:       rp,
+		first: func(std context.Context, raw *gotgbot.Bot, text g.String, ents []gotgbot.MessageEntity, last bool) g.Result[*gotgbot.Message] {
+			chatID, err := chat().Result()
+			if err != nil {
+				return g.Err[*gotgbot.Message](err)
+			}
+
+			first := *opts
+			first.Entities = ents
+
+			if !last {
+				first.ReplyMarkup = nil
+			}
+
+			return g.ResultOf(raw.SendMessageWithContext(retry.WithPolicy(std, rp), chatID, text.Std(), &first))
+		},
+		next: func(_ *gotgbot.Message, last bool) *gotgbot.SendMessageOpts {
+			next := *opts
+			next.ReplyParameters = nil
+			next.MessageEffectId = ""
+
+			if !last {
+				next.ReplyMarkup = nil
+			}
+
+			return &next
+		},
+	}
+}
+
+// captionOpts holds what a split caption shares with the messages following its media.
+type captionOpts struct {
+	text        g.String
+	entities    []gotgbot.MessageEntity
+	parseMode   string
+	thread      int64
+	business    string
+	silent      bool
+	protect     bool
+	after       g.Option[time.Duration]
+	deleteAfter g.Option[time.Duration]
+	retry       *retry.Policy
+}
+
+// splitCaption returns a split of a caption. The first part is sent by first with the media,
+// and the rest in messages that reply to it with the same delivery options.
+func splitCaption(
+	c *Context,
+	cp captionOpts,
+	first func(std context.Context, raw *gotgbot.Bot, text g.String, ents []gotgbot.MessageEntity, last bool) g.Result[*gotgbot.Message],
+) *Split {
+	return &Split{
+		ctx:         c,
+		text:        cp.text,
+		entities:    cp.entities,
+		parseMode:   cp.parseMode,
+		limit:       entities.MaxCaptionLen,
+		after:       cp.after,
+		deleteAfter: cp.deleteAfter,
+		retry:       cp.retry,
+		first:       first,
+		next: func(media *gotgbot.Message, _ bool) *gotgbot.SendMessageOpts {
+			return &gotgbot.SendMessageOpts{
+				MessageThreadId:      cp.thread,
+				BusinessConnectionId: cp.business,
+				DisableNotification:  cp.silent,
+				ProtectContent:       cp.protect,
+				ReplyParameters:      &gotgbot.ReplyParameters{MessageId: media.MessageId},
+			}
+		},
+	}
+}
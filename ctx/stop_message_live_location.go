@@ -6,6 +6,7 @@ import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/keyboard"
+	"github.com/enetx/tg/retry"
 )
 
 // StopMessageLiveLocation represents a request to stop updating a live location message.
@@ -14,6 +15,8 @@ type StopMessageLiveLocation struct {
 	opts      *gotgbot.StopMessageLiveLocationOpts
 	chatID    g.Option[int64]
 	messageID g.Option[int64]
+	retry     *retry.Policy
+	markupErr error
 }
 
 // ChatID sets the target chat ID.
@@ -46,6 +49,8 @@ func (smll *StopMessageLiveLocation) Markup(kb *keyboard.InlineKeyboard) *StopMe
 		smll.opts.ReplyMarkup = markup
 	}
 
+	smll.markupErr = keyboardErr(kb)
+
 	return smll
 }
 
@@ -71,11 +76,34 @@ func (smll *StopMessageLiveLocation) APIURL(url g.String) *StopMessageLiveLocati
 	return smll
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (smll *StopMessageLiveLocation) Retry(policy *retry.Policy) *StopMessageLiveLocation {
+	smll.retry = policy
+	return smll
+}
+
 // Send stops updating the live location message.
 func (smll *StopMessageLiveLocation) Send() g.Result[*gotgbot.Message] {
-	smll.opts.ChatId = smll.chatID.UnwrapOr(smll.ctx.EffectiveChat.Id)
-	smll.opts.MessageId = smll.messageID.UnwrapOr(smll.ctx.EffectiveMessage.MessageId)
-	msg, _, err := smll.ctx.Bot.Raw().StopMessageLiveLocation(smll.opts)
+	if smll.markupErr != nil {
+		return g.Err[*gotgbot.Message](smll.markupErr)
+	}
+
+	if smll.opts.InlineMessageId == "" {
+		chatID, err := smll.ctx.chat(smll.chatID).Result()
+		if err != nil {
+			return g.Err[*gotgbot.Message](err)
+		}
+
+		messageID, err := smll.ctx.message(smll.messageID).Result()
+		if err != nil {
+			return g.Err[*gotgbot.Message](err)
+		}
+
+		smll.opts.ChatId = chatID
+		smll.opts.MessageId = messageID
+	}
+
+	msg, _, err := smll.ctx.Bot.Raw().StopMessageLiveLocationWithContext(retry.WithPolicy(smll.ctx.Std(), smll.retry), smll.opts)
 
 	return g.ResultOf(msg, err)
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/keyboard"
+	"github.com/enetx/tg/retry"
 )
 
 // StopPoll represents a request to stop a poll.
@@ -14,6 +15,8 @@ type StopPoll struct {
 	opts      *gotgbot.StopPollOpts
 	chatID    g.Option[int64]
 	messageID g.Option[int64]
+	retry     *retry.Policy
+	markupErr error
 }
 
 // ChatID sets the target chat ID.
@@ -40,6 +43,8 @@ func (sp *StopPoll) Markup(kb *keyboard.InlineKeyboard) *StopPoll {
 		sp.opts.ReplyMarkup = markup
 	}
 
+	sp.markupErr = keyboardErr(kb)
+
 	return sp
 }
 
@@ -65,11 +70,31 @@ func (sp *StopPoll) APIURL(url g.String) *StopPoll {
 	return sp
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (sp *StopPoll) Retry(policy *retry.Policy) *StopPoll {
+	sp.retry = policy
+	return sp
+}
+
 // Send stops the poll.
 func (sp *StopPoll) Send() g.Result[*gotgbot.Poll] {
-	return g.ResultOf(sp.ctx.Bot.Raw().StopPoll(
-		sp.chatID.UnwrapOr(sp.ctx.EffectiveChat.Id),
-		sp.messageID.UnwrapOr(sp.ctx.EffectiveMessage.MessageId),
+	if sp.markupErr != nil {
+		return g.Err[*gotgbot.Poll](sp.markupErr)
+	}
+
+	chatID, err := sp.ctx.chat(sp.chatID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Poll](err)
+	}
+
+	messageID, err := sp.ctx.message(sp.messageID).Result()
+	if err != nil {
+		return g.Err[*gotgbot.Poll](err)
+	}
+
+	return g.ResultOf(sp.ctx.Bot.Raw().StopPollWithContext(retry.WithPolicy(sp.ctx.Std(), sp.retry),
+		chatID,
+		messageID,
 		sp.opts,
 	))
 }
@@ -0,0 +1,455 @@
+package ctx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"time"
+	"unicode/utf8"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/entities"
+	"github.com/enetx/tg/keyboard"
+	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/types/effects"
+)
+
+// ErrStreamEmpty is returned by Stream when the stream ends without any text to send.
+var ErrStreamEmpty = errors.New("stream is empty")
+
+const (
+	// draftInterval is the default time between draft updates.
+	draftInterval = 500 * time.Millisecond
+
+	// editInterval is the default time between edits of a message, which are rate limited
+	// more strictly than drafts.
+	editInterval = time.Second
+)
+
+// Stream sends text generated over time, e.g. by a language model, as it arrives. Chunks are
+// coalesced and shown as a draft updated at a safe cadence; in chats without drafts the text
+// is sent as a message that is edited instead. When the stream ends, the text is sent as
+// regular messages, split at the message length limit as with SendMessage.Split.
+type Stream struct {
+	ctx       *Context
+	chatID    g.Option[int64]
+	draftID   int64
+	interval  g.Option[time.Duration]
+	html      bool
+	opts      *gotgbot.SendMessageOpts
+	retry     *retry.Policy
+	markupErr error
+}
+
+// To sets the target chat ID for the stream.
+func (s *Stream) To(chatID int64) *Stream {
+	s.chatID = g.Some(chatID)
+	return s
+}
+
+// Thread sets the message thread ID for the stream.
+func (s *Stream) Thread(id int64) *Stream {
+	s.opts.MessageThreadId = id
+	return s
+}
+
+// DraftID sets the identifier of the draft, random by default.
+func (s *Stream) DraftID(id int64) *Stream {
+	s.draftID = id
+	return s
+}
+
+// Interval sets the time between updates of the draft or the edited message.
+func (s *Stream) Interval(duration time.Duration) *Stream {
+	s.interval = g.Some(duration)
+	return s
+}
+
+// HTML sets the stream parse mode to HTML. Tags still open in the text received so far are
+// closed in updates, and markup Telegram would reject is shown as plain text.
+func (s *Stream) HTML() *Stream {
+	s.html = true
+	return s
+}
+
+// Silent disables notification for the messages of the stream.
+func (s *Stream) Silent() *Stream {
+	s.opts.DisableNotification = true
+	return s
+}
+
+// Protect enables content protection for the messages of the stream.
+func (s *Stream) Protect() *Stream {
+	s.opts.ProtectContent = true
+	return s
+}
+
+// Effect sets a message effect for the first message of the stream.
+func (s *Stream) Effect(effect effects.EffectType) *Stream {
+	s.opts.MessageEffectId = effect.String()
+	return s
+}
+
+// Reply sets reply parameters for the first message of the stream using the reply builder.
+func (s *Stream) Reply(params *reply.Parameters) *Stream {
+	if params != nil {
+		s.opts.ReplyParameters = params.Std()
+	}
+	return s
+}
+
+// Markup sets the reply markup keyboard for the last message of the stream. In chats without
+// drafts only inline keyboards can be attached.
+func (s *Stream) Markup(kb keyboard.Keyboard) *Stream {
+	s.opts.ReplyMarkup = kb.Markup()
+	s.markupErr = keyboardErr(kb)
+
+	return s
+}
+
+// Business sets the business connection ID for the messages of the stream.
+func (s *Stream) Business(id g.String) *Stream {
+	s.opts.BusinessConnectionId = id.Std()
+	return s
+}
+
+// Retry sets a retry policy for the requests of the stream, overriding the bot's default policy.
+func (s *Stream) Retry(policy *retry.Policy) *Stream {
+	s.retry = policy
+	return s
+}
+
+// From streams the text read from r until EOF and returns the sent messages. A read error is
+// returned after the text read before it is sent:
+//
+//	resp := llm.Generate(ctx.Std(), prompt) // io.Reader
+//	return ctx.Stream().HTML().From(resp).Err()
+func (s *Stream) From(r io.Reader) g.Result[g.Slice[*gotgbot.Message]] {
+	chunks := make(chan g.String)
+	done := make(chan struct{})
+
+	var err error
+
+	go func() {
+		defer close(chunks)
+
+		buf := make([]byte, 4096)
+
+		var pending []byte
+
+		for {
+			n, rerr := r.Read(buf)
+			pending = append(pending, buf[:n]...)
+
+			end := len(pending)
+			if rerr == nil {
+				end = complete(pending)
+			}
+
+			if end > 0 {
+				select {
+				case chunks <- g.String(pending[:end]):
+				case <-done:
+					return
+				}
+
+				pending = append(pending[:0], pending[end:]...)
+			}
+
+			if rerr != nil {
+				if rerr != io.EOF {
+					err = rerr
+				}
+
+				return
+			}
+		}
+	}()
+
+	defer close(done)
+
+	// The chunks are closed once err is set, so it can be read if the stream ran to its end.
+	msgs := s.FromChan(chunks)
+	if msgs.IsOk() && err != nil {
+		return g.Err[g.Slice[*gotgbot.Message]](fmt.Errorf("failed to read stream: %w", err))
+	}
+
+	return msgs
+}
+
+// FromChan streams the chunks received from ch until it is closed and returns the sent messages.
+func (s *Stream) FromChan(ch <-chan g.String) g.Result[g.Slice[*gotgbot.Message]] {
+	if s.markupErr != nil {
+		return g.Err[g.Slice[*gotgbot.Message]](s.markupErr)
+	}
+
+	chatID, err := s.ctx.chat(s.chatID).Result()
+	if err != nil {
+		return g.Err[g.Slice[*gotgbot.Message]](err)
+	}
+
+	w := &streamWriter{Stream: s, chatID: chatID, std: s.ctx.Std(), raw: s.ctx.Bot.Raw()}
+
+	// Drafts are only supported in private chats; chats of unknown type are detected by the first
+	// draft being rejected.
+	w.drafts = s.chatID.IsSome() || s.ctx.EffectiveChat == nil ||
+		s.ctx.EffectiveChat.Type == "" || s.ctx.EffectiveChat.Type == "private"
+
+	ticker := time.NewTicker(w.cadence())
+	defer ticker.Stop()
+
+	var (
+		text  g.Builder
+		dirty bool
+	)
+
+	for {
+		select {
+		case chunk, ok := <-ch:
+			if !ok {
+				return w.finish(text.String())
+			}
+
+			text.WriteString(chunk)
+			dirty = true
+		case <-ticker.C:
+			if !dirty {
+				continue
+			}
+
+			drafts := w.drafts
+
+			shown, err := w.update(text.String())
+			if err != nil {
+				return g.Err[g.Slice[*gotgbot.Message]](err)
+			}
+
+			if drafts != w.drafts {
+				ticker.Reset(w.cadence())
+			}
+
+			dirty = !shown
+		case <-w.std.Done():
+			return g.Err[g.Slice[*gotgbot.Message]](w.std.Err())
+		}
+	}
+}
+
+// streamWriter shows the text of a stream. Parts of the text before the last are final once
+// the text outgrows them and are sent as soon as they are; the last part is shown in a draft
+// or in a message that is edited until the stream ends.
+type streamWriter struct {
+	*Stream
+	chatID int64
+	std    context.Context
+	raw    *gotgbot.Bot
+	drafts bool
+	msgs   g.Slice[*gotgbot.Message]
+	live   *gotgbot.Message // Message edited with the last part, if drafts are not used
+	shown  g.String         // Last part shown, as HTML
+}
+
+// cadence returns the time between updates.
+func (w *streamWriter) cadence() time.Duration {
+	if w.drafts {
+		return w.interval.UnwrapOr(draftInterval)
+	}
+
+	return w.interval.UnwrapOr(editInterval)
+}
+
+// update shows the text received so far and reports whether it is shown. A draft that failed
+// for a reason other than the chat not supporting drafts, e.g. a rate limit, is retried with
+// the next update.
+func (w *streamWriter) update(text g.String) (bool, error) {
+	parts := w.parts(text, true)
+	if parts.IsEmpty() {
+		return true, nil
+	}
+
+	for len(w.msgs) < len(parts)-1 {
+		if err := w.put(parts[len(w.msgs)], false); err != nil {
+			return false, err
+		}
+	}
+
+	last := parts[len(parts)-1]
+
+	if w.drafts {
+		err := w.draft(last)
+		if !unsupported(err) {
+			return err == nil, nil
+		}
+
+		w.drafts = false
+	}
+
+	if w.live == nil {
+		msg, err := w.send(last, false).Result()
+		if err != nil {
+			return false, err
+		}
+
+		w.live = msg
+	} else if err := w.edit(last, false); err != nil {
+		return false, err
+	}
+
+	w.shown = entities.ToHTML(last.Text(), last.Std())
+
+	return true, nil
+}
+
+// finish sends the parts of the final text that are not final yet.
+func (w *streamWriter) finish(text g.String) g.Result[g.Slice[*gotgbot.Message]] {
+	parts := w.parts(text, false)
+	if parts.IsEmpty() && w.msgs.IsEmpty() && w.live == nil {
+		return g.Err[g.Slice[*gotgbot.Message]](ErrStreamEmpty)
+	}
+
+	for i := len(w.msgs); i < len(parts); i++ {
+		if err := w.put(parts[i], i == len(parts)-1); err != nil {
+			return g.Err[g.Slice[*gotgbot.Message]](err)
+		}
+	}
+
+	if w.live != nil {
+		w.msgs.Push(w.live)
+	}
+
+	return g.Ok(w.msgs)
+}
+
+// put sends a final part, replacing the text of the live message if there is one.
+func (w *streamWriter) put(part *entities.Entities, last bool) error {
+	if w.live == nil {
+		msg, err := w.send(part, last).Result()
+		if err != nil {
+			return err
+		}
+
+		w.msgs.Push(msg)
+
+		return nil
+	}
+
+	if err := w.edit(part, last); err != nil {
+		return err
+	}
+
+	w.msgs.Push(w.live)
+	w.live, w.shown = nil, ""
+
+	return nil
+}
+
+// parts parses the text and cuts it into the parts of the messages. Markup that does not parse
+// is sent as plain text.
+func (w *streamWriter) parts(text g.String, partial bool) g.Slice[*entities.Entities] {
+	e := entities.New(text)
+
+	if w.html {
+		parse := entities.ParseHTML
+		if partial {
+			parse = entities.ParseHTMLPrefix
+		}
+
+		parsed := parse(text)
+		if parsed.IsErr() && !partial {
+			parsed = entities.ParseHTMLPrefix(text)
+		}
+
+		if parsed.IsOk() {
+			e = parsed.Ok()
+		}
+	}
+
+	return e.Split(entities.MaxMessageLen)
+}
+
+// draft shows a part in the draft.
+func (w *streamWriter) draft(part *entities.Entities) error {
+	opts := &gotgbot.SendMessageDraftOpts{
+		MessageThreadId: w.opts.MessageThreadId,
+		Text:            part.Text().Std(),
+		Entities:        part.Std(),
+	}
+
+	_, err := w.raw.SendMessageDraftWithContext(retry.WithPolicy(w.std, w.retry), w.chatID, w.draftID, opts)
+
+	return err
+}
+
+// unsupported reports whether a draft was rejected because the chat does not support drafts.
+// Such requests fail with Bad Request; rate limits and network errors are transient.
+func unsupported(err error) bool {
+	var tgErr *gotgbot.TelegramError
+	return errors.As(err, &tgErr) && tgErr.Code == 400
+}
+
+// send sends a part as a new message. The reply parameters and effect apply to the first
+// message and the markup to the last.
+func (w *streamWriter) send(part *entities.Entities, last bool) g.Result[*gotgbot.Message] {
+	opts := *w.opts
+	opts.Entities = part.Std()
+
+	if !w.msgs.IsEmpty() || w.live != nil {
+		opts.ReplyParameters = nil
+		opts.MessageEffectId = ""
+	}
+
+	if !last {
+		opts.ReplyMarkup = nil
+	}
+
+	return g.ResultOf(w.raw.SendMessageWithContext(retry.WithPolicy(w.std, w.retry), w.chatID, part.Text().Std(), &opts))
+}
+
+// edit replaces the text of the live message with a part, unless it is shown already.
+func (w *streamWriter) edit(part *entities.Entities, last bool) error {
+	opts := &gotgbot.EditMessageTextOpts{
+		ChatId:               w.chatID,
+		MessageId:            w.live.MessageId,
+		BusinessConnectionId: w.opts.BusinessConnectionId,
+		Entities:             part.Std(),
+	}
+
+	markup, inline := w.opts.ReplyMarkup.(gotgbot.InlineKeyboardMarkup)
+	if last && inline {
+		opts.ReplyMarkup = markup
+	}
+
+	if entities.ToHTML(part.Text(), part.Std()) == w.shown && opts.ReplyMarkup.InlineKeyboard == nil {
+		return nil
+	}
+
+	if _, _, err := w.raw.EditMessageTextWithContext(retry.WithPolicy(w.std, w.retry), part.Text().Std(), opts); err != nil {
+		return fmt.Errorf("failed to edit streamed message: %w", err)
+	}
+
+	return nil
+}
+
+// complete returns the length of the longest prefix of p that does not end in an incomplete rune.
+func complete(p []byte) int {
+	for i := len(p) - 1; i >= 0 && i > len(p)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(p[i]) {
+			if utf8.FullRune(p[i:]) {
+				return len(p)
+			}
+
+			return i
+		}
+	}
+
+	return len(p)
+}
+
+// newDraftID returns a random non-zero draft identifier.
+func newDraftID() int64 {
+	return rand.Int64N(1<<31-1) + 1
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // TransferGift is a request builder for transferring gifts.
@@ -14,6 +15,7 @@ type TransferGift struct {
 	ownedGiftID          g.String
 	newOwnerChatID       int64
 	opts                 *gotgbot.TransferGiftOpts
+	retry                *retry.Policy
 }
 
 // StarCount sets the amount of stars to pay for transfer from business balance.
@@ -44,9 +46,15 @@ func (tg *TransferGift) APIURL(url g.String) *TransferGift {
 	return tg
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (tg *TransferGift) Retry(policy *retry.Policy) *TransferGift {
+	tg.retry = policy
+	return tg
+}
+
 // Send executes the TransferGift request.
 func (tg *TransferGift) Send() g.Result[bool] {
-	return g.ResultOf(tg.ctx.Bot.Raw().TransferGift(
+	return g.ResultOf(tg.ctx.Bot.Raw().TransferGiftWithContext(retry.WithPolicy(tg.ctx.Std(), tg.retry),
 		tg.businessConnectionID.Std(),
 		tg.ownedGiftID.Std(),
 		tg.newOwnerChatID,
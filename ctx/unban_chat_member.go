@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 type UnbanChatMember struct {
@@ -12,6 +13,7 @@ type UnbanChatMember struct {
 	opts   *gotgbot.UnbanChatMemberOpts
 	userID int64
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID for the unban action.
@@ -48,8 +50,18 @@ func (u *UnbanChatMember) APIURL(url g.String) *UnbanChatMember {
 	return u
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (u *UnbanChatMember) Retry(policy *retry.Policy) *UnbanChatMember {
+	u.retry = policy
+	return u
+}
+
 // Send executes the unban action and returns the result.
 func (u *UnbanChatMember) Send() g.Result[bool] {
-	chatID := u.chatID.UnwrapOr(u.ctx.EffectiveChat.Id)
-	return g.ResultOf(u.ctx.Bot.Raw().UnbanChatMember(chatID, u.userID, u.opts))
+	chatID, err := u.ctx.chat(u.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(u.ctx.Bot.Raw().UnbanChatMemberWithContext(retry.WithPolicy(u.ctx.Std(), u.retry), chatID, u.userID, u.opts))
 }
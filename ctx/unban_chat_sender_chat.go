@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // UnbanChatSenderChat represents a request to unban a sender chat in a chat.
@@ -13,6 +14,7 @@ type UnbanChatSenderChat struct {
 	senderChatID int64
 	opts         *gotgbot.UnbanChatSenderChatOpts
 	chatID       g.Option[int64]
+	retry        *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -43,10 +45,21 @@ func (ucsc *UnbanChatSenderChat) APIURL(url g.String) *UnbanChatSenderChat {
 	return ucsc
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ucsc *UnbanChatSenderChat) Retry(policy *retry.Policy) *UnbanChatSenderChat {
+	ucsc.retry = policy
+	return ucsc
+}
+
 // Send unbans the sender chat from the target chat.
 func (ucsc *UnbanChatSenderChat) Send() g.Result[bool] {
-	return g.ResultOf(ucsc.ctx.Bot.Raw().UnbanChatSenderChat(
-		ucsc.chatID.UnwrapOr(ucsc.ctx.EffectiveChat.Id),
+	chatID, err := ucsc.ctx.chat(ucsc.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(ucsc.ctx.Bot.Raw().UnbanChatSenderChatWithContext(retry.WithPolicy(ucsc.ctx.Std(), ucsc.retry),
+		chatID,
 		ucsc.senderChatID,
 		ucsc.opts,
 	))
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // UnhideGeneralForumTopic represents a request to unhide the general forum topic.
@@ -12,6 +13,7 @@ type UnhideGeneralForumTopic struct {
 	ctx    *Context
 	opts   *gotgbot.UnhideGeneralForumTopicOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -42,10 +44,21 @@ func (ugft *UnhideGeneralForumTopic) APIURL(url g.String) *UnhideGeneralForumTop
 	return ugft
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ugft *UnhideGeneralForumTopic) Retry(policy *retry.Policy) *UnhideGeneralForumTopic {
+	ugft.retry = policy
+	return ugft
+}
+
 // Send unhides the general forum topic.
 func (ugft *UnhideGeneralForumTopic) Send() g.Result[bool] {
-	return g.ResultOf(ugft.ctx.Bot.Raw().UnhideGeneralForumTopic(
-		ugft.chatID.UnwrapOr(ugft.ctx.EffectiveChat.Id),
+	chatID, err := ugft.ctx.chat(ugft.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(ugft.ctx.Bot.Raw().UnhideGeneralForumTopicWithContext(retry.WithPolicy(ugft.ctx.Std(), ugft.retry),
+		chatID,
 		ugft.opts,
 	))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // UnpinAllChatMessages represents a request to unpin all messages.
@@ -12,6 +13,7 @@ type UnpinAllChatMessages struct {
 	ctx    *Context
 	opts   *gotgbot.UnpinAllChatMessagesOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID for this request.
@@ -42,8 +44,18 @@ func (uacm *UnpinAllChatMessages) APIURL(url g.String) *UnpinAllChatMessages {
 	return uacm
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (uacm *UnpinAllChatMessages) Retry(policy *retry.Policy) *UnpinAllChatMessages {
+	uacm.retry = policy
+	return uacm
+}
+
 // Send executes the UnpinAllChatMessages request.
 func (uacm *UnpinAllChatMessages) Send() g.Result[bool] {
-	chatID := uacm.chatID.UnwrapOr(uacm.ctx.EffectiveChat.Id)
-	return g.ResultOf(uacm.ctx.Bot.Raw().UnpinAllChatMessages(chatID, uacm.opts))
+	chatID, err := uacm.ctx.chat(uacm.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(uacm.ctx.Bot.Raw().UnpinAllChatMessagesWithContext(retry.WithPolicy(uacm.ctx.Std(), uacm.retry), chatID, uacm.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // UnpinAllForumTopicMessages represents a request to unpin all messages in a forum topic.
@@ -13,6 +14,7 @@ type UnpinAllForumTopicMessages struct {
 	messageThreadID int64
 	opts            *gotgbot.UnpinAllForumTopicMessagesOpts
 	chatID          g.Option[int64]
+	retry           *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -43,10 +45,21 @@ func (uaftm *UnpinAllForumTopicMessages) APIURL(url g.String) *UnpinAllForumTopi
 	return uaftm
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (uaftm *UnpinAllForumTopicMessages) Retry(policy *retry.Policy) *UnpinAllForumTopicMessages {
+	uaftm.retry = policy
+	return uaftm
+}
+
 // Send unpins all messages in the forum topic.
 func (uaftm *UnpinAllForumTopicMessages) Send() g.Result[bool] {
-	return g.ResultOf(uaftm.ctx.Bot.Raw().UnpinAllForumTopicMessages(
-		uaftm.chatID.UnwrapOr(uaftm.ctx.EffectiveChat.Id),
+	chatID, err := uaftm.ctx.chat(uaftm.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(uaftm.ctx.Bot.Raw().UnpinAllForumTopicMessagesWithContext(retry.WithPolicy(uaftm.ctx.Std(), uaftm.retry),
+		chatID,
 		uaftm.messageThreadID,
 		uaftm.opts,
 	))
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // UnpinAllGeneralForumTopicMessages represents a request to unpin all messages in the general forum topic.
@@ -12,6 +13,7 @@ type UnpinAllGeneralForumTopicMessages struct {
 	ctx    *Context
 	opts   *gotgbot.UnpinAllGeneralForumTopicMessagesOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID.
@@ -42,10 +44,21 @@ func (uagftm *UnpinAllGeneralForumTopicMessages) APIURL(url g.String) *UnpinAllG
 	return uagftm
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (uagftm *UnpinAllGeneralForumTopicMessages) Retry(policy *retry.Policy) *UnpinAllGeneralForumTopicMessages {
+	uagftm.retry = policy
+	return uagftm
+}
+
 // Send unpins all messages in the general forum topic.
 func (uagftm *UnpinAllGeneralForumTopicMessages) Send() g.Result[bool] {
-	return g.ResultOf(uagftm.ctx.Bot.Raw().UnpinAllGeneralForumTopicMessages(
-		uagftm.chatID.UnwrapOr(uagftm.ctx.EffectiveChat.Id),
+	chatID, err := uagftm.ctx.chat(uagftm.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(uagftm.ctx.Bot.Raw().UnpinAllGeneralForumTopicMessagesWithContext(retry.WithPolicy(uagftm.ctx.Std(), uagftm.retry),
+		chatID,
 		uagftm.opts,
 	))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // UnpinChatMessage represents a request to unpin a message.
@@ -12,6 +13,7 @@ type UnpinChatMessage struct {
 	ctx    *Context
 	opts   *gotgbot.UnpinChatMessageOpts
 	chatID g.Option[int64]
+	retry  *retry.Policy
 }
 
 // ChatID sets the target chat ID for this request.
@@ -54,8 +56,18 @@ func (ucm *UnpinChatMessage) APIURL(url g.String) *UnpinChatMessage {
 	return ucm
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ucm *UnpinChatMessage) Retry(policy *retry.Policy) *UnpinChatMessage {
+	ucm.retry = policy
+	return ucm
+}
+
 // Send executes the UnpinChatMessage request.
 func (ucm *UnpinChatMessage) Send() g.Result[bool] {
-	chatID := ucm.chatID.UnwrapOr(ucm.ctx.EffectiveChat.Id)
-	return g.ResultOf(ucm.ctx.Bot.Raw().UnpinChatMessage(chatID, ucm.opts))
+	chatID, err := ucm.ctx.chat(ucm.chatID).Result()
+	if err != nil {
+		return g.Err[bool](err)
+	}
+
+	return g.ResultOf(ucm.ctx.Bot.Raw().UnpinChatMessageWithContext(retry.WithPolicy(ucm.ctx.Std(), ucm.retry), chatID, ucm.opts))
 }
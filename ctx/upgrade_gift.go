@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // UpgradeGift is a request builder for upgrading gifts.
@@ -13,6 +14,7 @@ type UpgradeGift struct {
 	businessConnectionID g.String
 	ownedGiftID          g.String
 	opts                 *gotgbot.UpgradeGiftOpts
+	retry                *retry.Policy
 }
 
 // KeepOriginalDetails preserves original gift text, sender, and receiver.
@@ -49,9 +51,15 @@ func (ug *UpgradeGift) APIURL(url g.String) *UpgradeGift {
 	return ug
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (ug *UpgradeGift) Retry(policy *retry.Policy) *UpgradeGift {
+	ug.retry = policy
+	return ug
+}
+
 // Send executes the UpgradeGift request.
 func (ug *UpgradeGift) Send() g.Result[bool] {
-	return g.ResultOf(ug.ctx.Bot.Raw().UpgradeGift(
+	return g.ResultOf(ug.ctx.Bot.Raw().UpgradeGiftWithContext(retry.WithPolicy(ug.ctx.Std(), ug.retry),
 		ug.businessConnectionID.Std(),
 		ug.ownedGiftID.Std(),
 		ug.opts,
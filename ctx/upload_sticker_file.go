@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // UploadStickerFile represents a request to upload a sticker file.
@@ -16,6 +17,7 @@ type UploadStickerFile struct {
 	opts    *gotgbot.UploadStickerFileOpts
 	file    *g.File
 	err     error
+	retry   *retry.Policy
 }
 
 // File sets the sticker file to upload.
@@ -60,6 +62,12 @@ func (usf *UploadStickerFile) APIURL(url g.String) *UploadStickerFile {
 	return usf
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (usf *UploadStickerFile) Retry(policy *retry.Policy) *UploadStickerFile {
+	usf.retry = policy
+	return usf
+}
+
 // Send uploads the sticker file.
 func (usf *UploadStickerFile) Send() g.Result[*gotgbot.File] {
 	if usf.err != nil {
@@ -70,5 +78,5 @@ func (usf *UploadStickerFile) Send() g.Result[*gotgbot.File] {
 		defer usf.file.Close()
 	}
 
-	return g.ResultOf(usf.ctx.Bot.Raw().UploadStickerFile(usf.userID, usf.sticker, usf.format.Std(), usf.opts))
+	return g.ResultOf(usf.ctx.Bot.Raw().UploadStickerFileWithContext(retry.WithPolicy(usf.ctx.Std(), usf.retry), usf.userID, usf.sticker, usf.format.Std(), usf.opts))
 }
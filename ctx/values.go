@@ -0,0 +1,75 @@
+package ctx
+
+import (
+	"sync"
+
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/g"
+)
+
+// valuesKey is the key under which the value store is kept in the update data.
+const valuesKey = "tg.values"
+
+// values is a goroutine-safe store of values attached to a single update.
+type values struct {
+	mu sync.RWMutex
+	m  map[any]any
+}
+
+// valuesOf returns the value store of the update, creating it on first use,
+// so that all handlers and middlewares processing the update share it.
+func valuesOf(raw *ext.Context) *values {
+	if raw.Data == nil {
+		raw.Data = make(map[string]any)
+	}
+
+	if v, ok := raw.Data[valuesKey].(*values); ok {
+		return v
+	}
+
+	v := &values{m: make(map[any]any)}
+	raw.Data[valuesKey] = v
+
+	return v
+}
+
+// Set stores value under key for the rest of the update's processing.
+// Values set by a middleware are visible to the handler and to handlers in later groups.
+// It is safe to call from goroutines started by the handler.
+func (ctx *Context) Set(key, value any) {
+	ctx.values.mu.Lock()
+	defer ctx.values.mu.Unlock()
+
+	ctx.values.m[key] = value
+}
+
+// Get returns the value stored under key.
+func (ctx *Context) Get(key any) g.Option[any] {
+	ctx.values.mu.RLock()
+	defer ctx.values.mu.RUnlock()
+
+	if v, ok := ctx.values.m[key]; ok {
+		return g.Some(v)
+	}
+
+	return g.None[any]()
+}
+
+// Delete removes the value stored under key.
+func (ctx *Context) Delete(key any) {
+	ctx.values.mu.Lock()
+	defer ctx.values.mu.Unlock()
+
+	delete(ctx.values.m, key)
+}
+
+// Value returns the value stored under key in c if it has type T.
+//
+//	user := ctx.Value[*User](c, "user")
+func Value[T any](c *Context, key any) g.Option[T] {
+	if v, ok := c.Get(key).UnwrapOr(nil).(T); ok {
+		return g.Some(v)
+	}
+
+	return g.None[T]()
+}
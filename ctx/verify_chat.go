@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // VerifyChat represents a request to verify a chat.
@@ -12,6 +13,7 @@ type VerifyChat struct {
 	ctx    *Context
 	chatID int64
 	opts   *gotgbot.VerifyChatOpts
+	retry  *retry.Policy
 }
 
 // CustomDescription for the verification; 0-70 characters.
@@ -43,7 +45,13 @@ func (vc *VerifyChat) APIURL(url g.String) *VerifyChat {
 	return vc
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (vc *VerifyChat) Retry(policy *retry.Policy) *VerifyChat {
+	vc.retry = policy
+	return vc
+}
+
 // Send verifies the chat.
 func (vc *VerifyChat) Send() g.Result[bool] {
-	return g.ResultOf(vc.ctx.Bot.Raw().VerifyChat(vc.chatID, vc.opts))
+	return g.ResultOf(vc.ctx.Bot.Raw().VerifyChatWithContext(retry.WithPolicy(vc.ctx.Std(), vc.retry), vc.chatID, vc.opts))
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
 )
 
 // VerifyUser represents a request to verify a user.
@@ -12,6 +13,7 @@ type VerifyUser struct {
 	ctx    *Context
 	userID int64
 	opts   *gotgbot.VerifyUserOpts
+	retry  *retry.Policy
 }
 
 // CustomDescription for the verification; 0-70 characters.
@@ -43,7 +45,13 @@ func (vu *VerifyUser) APIURL(url g.String) *VerifyUser {
 	return vu
 }
 
+// Retry sets a retry policy for this request, overriding the bot's default policy.
+func (vu *VerifyUser) Retry(policy *retry.Policy) *VerifyUser {
+	vu.retry = policy
+	return vu
+}
+
 // Send verifies the user.
 func (vu *VerifyUser) Send() g.Result[bool] {
-	return g.ResultOf(vu.ctx.Bot.Raw().VerifyUser(vu.userID, vu.opts))
+	return g.ResultOf(vu.ctx.Bot.Raw().VerifyUserWithContext(retry.WithPolicy(vu.ctx.Std(), vu.retry), vu.userID, vu.opts))
 }
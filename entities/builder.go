@@ -0,0 +1,168 @@
+package entities
+
+import (
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/g"
+)
+
+// Builder builds a text and its entities together by appending formatted pieces,
+// so no substring search is involved:
+//
+//	t := entities.Text().Plain("Hi ").Bold(name).Plain(", see the ").Link("docs", url)
+//
+//	return c.SendMessage(t.String()).Entities(t.Entities()).Send().Err()
+type Builder struct {
+	text     g.Builder
+	length   int64
+	entities g.Slice[gotgbot.MessageEntity]
+}
+
+// Text returns an empty Builder.
+func Text() *Builder {
+	return &Builder{entities: g.NewSlice[gotgbot.MessageEntity]()}
+}
+
+// Plain appends unformatted text.
+func (b *Builder) Plain(text g.String) *Builder {
+	b.text.WriteString(text)
+	b.length += UTF16Len(text)
+
+	return b
+}
+
+// Line appends text followed by a line break.
+func (b *Builder) Line(text g.String) *Builder {
+	return b.Plain(text).Plain("\n")
+}
+
+// Bold appends bold text.
+func (b *Builder) Bold(text g.String) *Builder {
+	return b.add(text, gotgbot.MessageEntity{Type: "bold"})
+}
+
+// Italic appends italic text.
+func (b *Builder) Italic(text g.String) *Builder {
+	return b.add(text, gotgbot.MessageEntity{Type: "italic"})
+}
+
+// Underline appends underlined text.
+func (b *Builder) Underline(text g.String) *Builder {
+	return b.add(text, gotgbot.MessageEntity{Type: "underline"})
+}
+
+// Strikethrough appends strikethrough text.
+func (b *Builder) Strikethrough(text g.String) *Builder {
+	return b.add(text, gotgbot.MessageEntity{Type: "strikethrough"})
+}
+
+// Spoiler appends text hidden as a spoiler.
+func (b *Builder) Spoiler(text g.String) *Builder {
+	return b.add(text, gotgbot.MessageEntity{Type: "spoiler"})
+}
+
+// Code appends inline code.
+func (b *Builder) Code(text g.String) *Builder {
+	return b.add(text, gotgbot.MessageEntity{Type: "code"})
+}
+
+// Pre appends a preformatted code block, optionally with its programming language.
+func (b *Builder) Pre(text g.String, language ...g.String) *Builder {
+	entity := gotgbot.MessageEntity{Type: "pre"}
+	if len(language) > 0 {
+		entity.Language = language[0].Std()
+	}
+
+	return b.add(text, entity)
+}
+
+// Link appends text that opens url.
+func (b *Builder) Link(text, url g.String) *Builder {
+	return b.add(text, gotgbot.MessageEntity{Type: "text_link", Url: url.Std()})
+}
+
+// Mention appends text that mentions the user with the given ID.
+func (b *Builder) Mention(text g.String, userID int64) *Builder {
+	return b.add(text, gotgbot.MessageEntity{Type: "text_mention", User: &gotgbot.User{Id: userID}})
+}
+
+// CustomEmoji appends a custom emoji, with emoji as its fallback for clients that cannot show it.
+func (b *Builder) CustomEmoji(emoji, emojiID g.String) *Builder {
+	return b.add(emoji, gotgbot.MessageEntity{Type: "custom_emoji", CustomEmojiId: emojiID.Std()})
+}
+
+// Blockquote appends a blockquote.
+func (b *Builder) Blockquote(text g.String) *Builder {
+	return b.add(text, gotgbot.MessageEntity{Type: "blockquote"})
+}
+
+// ExpandableBlockquote appends a blockquote that is collapsed by default.
+func (b *Builder) ExpandableBlockquote(text g.String) *Builder {
+	return b.add(text, gotgbot.MessageEntity{Type: "expandable_blockquote"})
+}
+
+// DateTime appends text shown as the date and time of unixTime, formatted as format
+// (e.g. "t", "r", "wDT") or by default.
+func (b *Builder) DateTime(text g.String, unixTime int64, format ...g.String) *Builder {
+	entity := gotgbot.MessageEntity{Type: "date_time", UnixTime: unixTime}
+	if len(format) > 0 {
+		entity.DateTimeFormat = format[0].Std()
+	}
+
+	return b.add(text, entity)
+}
+
+// Append appends the text and entities of another builder, e.g. to apply a style to
+// a part that is itself formatted, see Wrap.
+func (b *Builder) Append(other *Builder) *Builder {
+	for _, entity := range other.entities {
+		entity.Offset += b.length
+		b.entities.Push(entity)
+	}
+
+	return b.Plain(other.String())
+}
+
+// Wrap appends the text and entities of another builder and marks all of it with entity,
+// whose offset and length are set by Wrap, e.g. to nest formatting:
+//
+//	entities.Text().Wrap(entities.Text().Plain("bold ").Italic("and italic"), gotgbot.MessageEntity{Type: "bold"})
+func (b *Builder) Wrap(other *Builder, entity gotgbot.MessageEntity) *Builder {
+	if other.length > 0 {
+		entity.Offset = b.length
+		entity.Length = other.length
+		b.entities.Push(entity)
+	}
+
+	return b.Append(other)
+}
+
+// add appends text marked with entity.
+func (b *Builder) add(text g.String, entity gotgbot.MessageEntity) *Builder {
+	if length := UTF16Len(text); length > 0 {
+		entity.Offset = b.length
+		entity.Length = length
+		b.entities.Push(entity)
+	}
+
+	return b.Plain(text)
+}
+
+// Len returns the length of the text in UTF-16 code units.
+func (b *Builder) Len() int64 {
+	return b.length
+}
+
+// String returns the text.
+func (b *Builder) String() g.String {
+	return b.text.String()
+}
+
+// Entities returns the entities of the text, ready to be sent with it.
+func (b *Builder) Entities() *Entities {
+	return New(b.String()).Import(b.entities.Clone())
+}
+
+// Std returns the underlying MessageEntity slice.
+func (b *Builder) Std() []gotgbot.MessageEntity {
+	return b.entities
+}
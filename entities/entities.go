@@ -1,14 +1,22 @@
 package entities
 
 import (
+	"strings"
+
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 )
 
 // Entities provides a builder for creating MessageEntity slices from text.
+//
+// Each marking method searches text for sub and marks the first occurrence by default; All and Nth
+// change which occurrences the following calls mark. Offsets and lengths are counted in UTF-16
+// code units, as Telegram expects.
 type Entities struct {
 	entities g.Slice[gotgbot.MessageEntity]
 	text     g.String
+	all      bool // Mark every occurrence
+	nth      int  // Occurrence to mark, counting from 1; 0 means the first
 }
 
 // New creates a new Entities builder bound to the given source text.
@@ -19,49 +27,68 @@ func New[T ~string](text T) *Entities {
 	}
 }
 
-// Bold marks the first occurrence of sub as bold.
+// First makes the following calls mark only the first occurrence of their substring. This is the default.
+func (e *Entities) First() *Entities { return e.Nth(1) }
+
+// All makes the following calls mark every non-overlapping occurrence of their substring.
+func (e *Entities) All() *Entities {
+	e.all = true
+	e.nth = 0
+
+	return e
+}
+
+// Nth makes the following calls mark only the n-th occurrence of their substring, counting from 1.
+func (e *Entities) Nth(n int) *Entities {
+	e.all = false
+	e.nth = n
+
+	return e
+}
+
+// Bold marks sub as bold.
 func (e *Entities) Bold(sub g.String) *Entities {
 	return e.match(sub, func(offset, length int64) {
 		e.entities.Push(gotgbot.MessageEntity{Type: "bold", Offset: offset, Length: length})
 	})
 }
 
-// Italic marks the first occurrence of sub as italic.
+// Italic marks sub as italic.
 func (e *Entities) Italic(sub g.String) *Entities {
 	return e.match(sub, func(offset, length int64) {
 		e.entities.Push(gotgbot.MessageEntity{Type: "italic", Offset: offset, Length: length})
 	})
 }
 
-// Underline marks the first occurrence of sub as underlined.
+// Underline marks sub as underlined.
 func (e *Entities) Underline(sub g.String) *Entities {
 	return e.match(sub, func(offset, length int64) {
 		e.entities.Push(gotgbot.MessageEntity{Type: "underline", Offset: offset, Length: length})
 	})
 }
 
-// Strikethrough marks the first occurrence of sub as strikethrough.
+// Strikethrough marks sub as strikethrough.
 func (e *Entities) Strikethrough(sub g.String) *Entities {
 	return e.match(sub, func(offset, length int64) {
 		e.entities.Push(gotgbot.MessageEntity{Type: "strikethrough", Offset: offset, Length: length})
 	})
 }
 
-// Spoiler marks the first occurrence of sub as spoiler.
+// Spoiler marks sub as spoiler.
 func (e *Entities) Spoiler(sub g.String) *Entities {
 	return e.match(sub, func(offset, length int64) {
 		e.entities.Push(gotgbot.MessageEntity{Type: "spoiler", Offset: offset, Length: length})
 	})
 }
 
-// Code marks the first occurrence of sub as inline code.
+// Code marks sub as inline code.
 func (e *Entities) Code(sub g.String) *Entities {
 	return e.match(sub, func(offset, length int64) {
 		e.entities.Push(gotgbot.MessageEntity{Type: "code", Offset: offset, Length: length})
 	})
 }
 
-// Pre marks the first occurrence of sub as preformatted code.
+// Pre marks sub as preformatted code.
 func (e *Entities) Pre(sub g.String, language ...g.String) *Entities {
 	return e.match(sub, func(offset, length int64) {
 		entity := gotgbot.MessageEntity{Type: "pre", Offset: offset, Length: length}
@@ -73,7 +100,7 @@ func (e *Entities) Pre(sub g.String, language ...g.String) *Entities {
 	})
 }
 
-// URL marks the first occurrence of sub as a hyperlink.
+// URL marks sub as a hyperlink.
 func (e *Entities) URL(sub, url g.String) *Entities {
 	return e.match(sub, func(offset, length int64) {
 		e.entities.Push(gotgbot.MessageEntity{
@@ -85,7 +112,7 @@ func (e *Entities) URL(sub, url g.String) *Entities {
 	})
 }
 
-// Mention marks the first occurrence of sub as a user mention.
+// Mention marks sub as a user mention.
 func (e *Entities) Mention(sub g.String, userID int64) *Entities {
 	return e.match(sub, func(offset, length int64) {
 		e.entities.Push(gotgbot.MessageEntity{
@@ -97,7 +124,7 @@ func (e *Entities) Mention(sub g.String, userID int64) *Entities {
 	})
 }
 
-// CustomEmoji marks the first occurrence of sub as a custom emoji.
+// CustomEmoji marks sub as a custom emoji.
 func (e *Entities) CustomEmoji(sub, emojiID g.String) *Entities {
 	return e.match(sub, func(offset, length int64) {
 		e.entities.Push(gotgbot.MessageEntity{
@@ -109,21 +136,21 @@ func (e *Entities) CustomEmoji(sub, emojiID g.String) *Entities {
 	})
 }
 
-// Blockquote marks the first occurrence of sub as a blockquote.
+// Blockquote marks sub as a blockquote.
 func (e *Entities) Blockquote(sub g.String) *Entities {
 	return e.match(sub, func(offset, length int64) {
 		e.entities.Push(gotgbot.MessageEntity{Type: "blockquote", Offset: offset, Length: length})
 	})
 }
 
-// ExpandableBlockquote marks the first occurrence of sub as an expandable blockquote.
+// ExpandableBlockquote marks sub as an expandable blockquote.
 func (e *Entities) ExpandableBlockquote(sub g.String) *Entities {
 	return e.match(sub, func(offset, length int64) {
 		e.entities.Push(gotgbot.MessageEntity{Type: "expandable_blockquote", Offset: offset, Length: length})
 	})
 }
 
-// DateTime marks the first occurrence of sub as a formatted date and time.
+// DateTime marks sub as a formatted date and time.
 // unixTime is the timestamp associated with the entity and format optionally specifies
 // the date-time formatting (e.g. "t", "r", "wDT"); leave format empty for the default.
 func (e *Entities) DateTime(sub g.String, unixTime int64, format ...g.String) *Entities {
@@ -143,11 +170,37 @@ func (e *Entities) DateTime(sub g.String, unixTime int64, format ...g.String) *E
 	})
 }
 
-// match finds the first occurrence of sub in text and applies fn with offset and length.
+// match finds the occurrences of sub in text selected by All or Nth and applies fn
+// with their offset and length in UTF-16 code units.
 func (e *Entities) match(sub g.String, fn func(offset, length int64)) *Entities {
-	offset := e.text.Index(sub)
-	if offset >= 0 {
-		fn(int64(offset), int64(len(sub)))
+	if sub.IsEmpty() {
+		return e
+	}
+
+	text, length := e.text.Std(), UTF16Len(sub)
+	nth := max(e.nth, 1)
+
+	var start, units int64
+
+	for n := 1; ; n++ {
+		i := strings.Index(text[start:], sub.Std())
+		if i < 0 {
+			break
+		}
+
+		units += UTF16Len(g.String(text[start : start+int64(i)]))
+		start += int64(i)
+
+		if e.all || n == nth {
+			fn(units, length)
+
+			if !e.all {
+				break
+			}
+		}
+
+		units += length
+		start += int64(len(sub))
 	}
 
 	return e
@@ -179,6 +232,11 @@ func (e *Entities) Count() g.Int {
 	return e.entities.Len()
 }
 
+// Text returns the text the entities refer to.
+func (e *Entities) Text() g.String {
+	return e.text
+}
+
 // Std returns the underlying MessageEntity slice.
 func (e *Entities) Std() []gotgbot.MessageEntity {
 	return e.entities
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"strings"
+
+	"github.com/enetx/g"
+)
+
+var (
+	htmlEscaper         = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+	markdownEscaper     = newMarkdownEscaper("\\_*[]()~`>#+-=|{}.!")
+	markdownCodeEscaper = newMarkdownEscaper("\\`")
+	markdownURLEscaper  = newMarkdownEscaper("\\)")
+)
+
+// EscapeHTML escapes s for messages sent with the HTML parse mode, so that user input
+// is shown as is:
+//
+//	c.Reply(g.Format("Hello, <b>{}</b>!", entities.EscapeHTML(name))).HTML()
+func EscapeHTML(s g.String) g.String {
+	return g.String(htmlEscaper.Replace(s.Std()))
+}
+
+// EscapeMarkdownV2 escapes s for messages sent with the MarkdownV2 parse mode, so that user input
+// is shown as is:
+//
+//	c.Reply(g.Format("Hello, *{}*\\!", entities.EscapeMarkdownV2(name))).Markdown()
+func EscapeMarkdownV2(s g.String) g.String {
+	return g.String(markdownEscaper.Replace(s.Std()))
+}
+
+// EscapeMarkdownV2Code escapes s for the inside of MarkdownV2 code and pre blocks,
+// where only backticks and backslashes are special.
+func EscapeMarkdownV2Code(s g.String) g.String {
+	return g.String(markdownCodeEscaper.Replace(s.Std()))
+}
+
+// newMarkdownEscaper returns a replacer that prefixes each of chars with a backslash.
+func newMarkdownEscaper(chars string) *strings.Replacer {
+	pairs := make([]string, 0, 2*len(chars))
+	for _, c := range chars {
+		pairs = append(pairs, string(c), `\`+string(c))
+	}
+
+	return strings.NewReplacer(pairs...)
+}
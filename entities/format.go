@@ -0,0 +1,315 @@
+package entities
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/g"
+)
+
+// ToHTML returns text with its entities as markup for the HTML parse mode. Nested and
+// overlapping entities are supported; entities Telegram detects by itself, such as mentions,
+// hashtags and URLs, are left as plain text:
+//
+//	html := entities.ToHTML(g.String(msg.Text), msg.Entities)
+//	c.SendMessage("Quoted: " + html).HTML().Send()
+func ToHTML(text g.String, entities []gotgbot.MessageEntity) g.String {
+	return render(text, entities, new(htmlFormat))
+}
+
+// ToMarkdownV2 returns text with its entities as markup for the MarkdownV2 parse mode, see ToHTML.
+func ToMarkdownV2(text g.String, entities []gotgbot.MessageEntity) g.String {
+	return render(text, entities, new(markdownFormat))
+}
+
+// format writes the markup of a parse mode.
+type format interface {
+	open(b *strings.Builder, e gotgbot.MessageEntity)
+	close(b *strings.Builder, e gotgbot.MessageEntity)
+	text(b *strings.Builder, s string, open []gotgbot.MessageEntity)
+}
+
+// formatted reports whether the entity type is written as markup.
+func formatted(typ string) bool {
+	switch typ {
+	case "bold", "italic", "underline", "strikethrough", "spoiler", "code", "pre", "text_link",
+		"text_mention", "custom_emoji", "blockquote", "expandable_blockquote", "date_time":
+		return true
+	default:
+		return false
+	}
+}
+
+// render writes text with the markup of its entities. Entities that partially overlap
+// an enclosing one are closed and reopened around its end.
+func render(text g.String, entities []gotgbot.MessageEntity, f format) g.String {
+	units := utf16.Encode([]rune(text.Std()))
+	size := int64(len(units))
+
+	ents := make([]gotgbot.MessageEntity, 0, len(entities))
+
+	for _, e := range entities {
+		if !formatted(e.Type) || e.Offset < 0 || e.Offset >= size || e.Length <= 0 {
+			continue
+		}
+
+		e.Length = min(e.Length, size-e.Offset)
+		ents = append(ents, e)
+	}
+
+	slices.SortStableFunc(ents, func(a, b gotgbot.MessageEntity) int {
+		return cmp.Or(cmp.Compare(a.Offset, b.Offset), cmp.Compare(b.Length, a.Length))
+	})
+
+	var (
+		b     strings.Builder
+		stack []gotgbot.MessageEntity
+		next  int
+	)
+
+	end := func(e gotgbot.MessageEntity) int64 { return e.Offset + e.Length }
+
+	for pos := int64(0); ; {
+		for {
+			i := slices.IndexFunc(stack, func(e gotgbot.MessageEntity) bool { return end(e) <= pos })
+			if i < 0 {
+				break
+			}
+
+			var reopen []gotgbot.MessageEntity
+
+			for j := len(stack) - 1; j >= i; j-- {
+				f.close(&b, stack[j])
+
+				if j > i && end(stack[j]) > pos {
+					reopen = append(reopen, stack[j])
+				}
+			}
+
+			stack = stack[:i]
+
+			for j := len(reopen) - 1; j >= 0; j-- {
+				f.open(&b, reopen[j])
+				stack = append(stack, reopen[j])
+			}
+		}
+
+		for next < len(ents) && ents[next].Offset == pos {
+			f.open(&b, ents[next])
+			stack = append(stack, ents[next])
+			next++
+		}
+
+		if pos == size {
+			break
+		}
+
+		stop := size
+		if next < len(ents) {
+			stop = ents[next].Offset
+		}
+
+		for _, e := range stack {
+			stop = min(stop, end(e))
+		}
+
+		f.text(&b, string(utf16.Decode(units[pos:stop])), stack)
+		pos = stop
+	}
+
+	return g.String(b.String())
+}
+
+// htmlFormat writes markup for the HTML parse mode.
+type htmlFormat struct{}
+
+func (htmlFormat) open(b *strings.Builder, e gotgbot.MessageEntity) {
+	switch e.Type {
+	case "bold":
+		b.WriteString("<b>")
+	case "italic":
+		b.WriteString("<i>")
+	case "underline":
+		b.WriteString("<u>")
+	case "strikethrough":
+		b.WriteString("<s>")
+	case "spoiler":
+		b.WriteString("<tg-spoiler>")
+	case "code":
+		b.WriteString("<code>")
+	case "pre":
+		if e.Language != "" {
+			fmt.Fprintf(b, `<pre><code class="language-%s">`, htmlEscaper.Replace(e.Language))
+		} else {
+			b.WriteString("<pre>")
+		}
+	case "text_link":
+		fmt.Fprintf(b, `<a href="%s">`, htmlEscaper.Replace(e.Url))
+	case "text_mention":
+		fmt.Fprintf(b, `<a href="tg://user?id=%d">`, userID(e))
+	case "custom_emoji":
+		fmt.Fprintf(b, `<tg-emoji emoji-id="%s">`, htmlEscaper.Replace(e.CustomEmojiId))
+	case "blockquote":
+		b.WriteString("<blockquote>")
+	case "expandable_blockquote":
+		b.WriteString("<blockquote expandable>")
+	case "date_time":
+		fmt.Fprintf(b, `<tg-time unix="%d"`, e.UnixTime)
+
+		if e.DateTimeFormat != "" {
+			fmt.Fprintf(b, ` format="%s"`, htmlEscaper.Replace(e.DateTimeFormat))
+		}
+
+		b.WriteString(">")
+	}
+}
+
+func (htmlFormat) close(b *strings.Builder, e gotgbot.MessageEntity) {
+	switch e.Type {
+	case "bold":
+		b.WriteString("</b>")
+	case "italic":
+		b.WriteString("</i>")
+	case "underline":
+		b.WriteString("</u>")
+	case "strikethrough":
+		b.WriteString("</s>")
+	case "spoiler":
+		b.WriteString("</tg-spoiler>")
+	case "code":
+		b.WriteString("</code>")
+	case "pre":
+		if e.Language != "" {
+			b.WriteString("</code></pre>")
+		} else {
+			b.WriteString("</pre>")
+		}
+	case "text_link", "text_mention":
+		b.WriteString("</a>")
+	case "custom_emoji":
+		b.WriteString("</tg-emoji>")
+	case "blockquote", "expandable_blockquote":
+		b.WriteString("</blockquote>")
+	case "date_time":
+		b.WriteString("</tg-time>")
+	}
+}
+
+func (htmlFormat) text(b *strings.Builder, s string, _ []gotgbot.MessageEntity) {
+	htmlEscaper.WriteString(b, s)
+}
+
+// markdownFormat writes markup for the MarkdownV2 parse mode.
+type markdownFormat struct {
+	marker string // Last marker written, if nothing was written after it
+}
+
+func (m *markdownFormat) open(b *strings.Builder, e gotgbot.MessageEntity) {
+	switch e.Type {
+	case "bold":
+		m.write(b, "*")
+	case "italic":
+		m.write(b, "_")
+	case "underline":
+		m.write(b, "__")
+	case "strikethrough":
+		m.write(b, "~")
+	case "spoiler":
+		m.write(b, "||")
+	case "code":
+		m.write(b, "`")
+	case "pre":
+		m.write(b, "```"+e.Language+"\n")
+	case "text_link", "text_mention":
+		m.write(b, "[")
+	case "custom_emoji", "date_time":
+		m.write(b, "![")
+	case "blockquote":
+		m.write(b, ">")
+	case "expandable_blockquote":
+		m.write(b, "**>")
+	}
+}
+
+func (m *markdownFormat) close(b *strings.Builder, e gotgbot.MessageEntity) {
+	switch e.Type {
+	case "bold":
+		m.write(b, "*")
+	case "italic":
+		m.write(b, "_")
+	case "underline":
+		m.write(b, "__")
+	case "strikethrough":
+		m.write(b, "~")
+	case "spoiler":
+		m.write(b, "||")
+	case "code":
+		m.write(b, "`")
+	case "pre":
+		m.write(b, "\n```")
+	case "text_link":
+		m.write(b, "]("+markdownURLEscaper.Replace(e.Url)+")")
+	case "text_mention":
+		m.write(b, fmt.Sprintf("](tg://user?id=%d)", userID(e)))
+	case "custom_emoji":
+		m.write(b, "](tg://emoji?id="+markdownURLEscaper.Replace(e.CustomEmojiId)+")")
+	case "date_time":
+		url := fmt.Sprintf("tg://time?unix=%d", e.UnixTime)
+		if e.DateTimeFormat != "" {
+			url += "&format=" + e.DateTimeFormat
+		}
+
+		m.write(b, "]("+markdownURLEscaper.Replace(url)+")")
+	case "expandable_blockquote":
+		m.write(b, "||")
+	}
+}
+
+func (m *markdownFormat) text(b *strings.Builder, s string, open []gotgbot.MessageEntity) {
+	m.marker = ""
+
+	quoted := slices.ContainsFunc(open, func(e gotgbot.MessageEntity) bool {
+		return e.Type == "blockquote" || e.Type == "expandable_blockquote"
+	})
+
+	code := slices.ContainsFunc(open, func(e gotgbot.MessageEntity) bool {
+		return e.Type == "code" || e.Type == "pre"
+	})
+
+	if code {
+		s = markdownCodeEscaper.Replace(s)
+	} else {
+		s = markdownEscaper.Replace(s)
+	}
+
+	if quoted {
+		s = strings.ReplaceAll(s, "\n", "\n>")
+	}
+
+	b.WriteString(s)
+}
+
+// write writes a marker. Underscores of adjacent markers are separated by a carriage return,
+// which Telegram ignores, so that e.g. the end of an italic inside an underline is not read
+// as the start of another underline.
+func (m *markdownFormat) write(b *strings.Builder, marker string) {
+	if strings.HasSuffix(m.marker, "_") && strings.HasPrefix(marker, "_") {
+		b.WriteByte('\r')
+	}
+
+	b.WriteString(marker)
+	m.marker = marker
+}
+
+// userID returns the ID of the user mentioned by a text_mention entity.
+func userID(e gotgbot.MessageEntity) int64 {
+	if e.User == nil {
+		return 0
+	}
+
+	return e.User.Id
+}
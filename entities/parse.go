@@ -0,0 +1,317 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/g"
+)
+
+// ErrInvalidHTML is returned by ParseHTML for markup Telegram would reject.
+var ErrInvalidHTML = errors.New("invalid HTML")
+
+// tagTypes maps the supported HTML tags to entity types.
+var tagTypes = map[string]string{
+	"b":          "bold",
+	"strong":     "bold",
+	"i":          "italic",
+	"em":         "italic",
+	"u":          "underline",
+	"ins":        "underline",
+	"s":          "strikethrough",
+	"strike":     "strikethrough",
+	"del":        "strikethrough",
+	"tg-spoiler": "spoiler",
+	"span":       "spoiler",
+	"code":       "code",
+	"pre":        "pre",
+	"a":          "text_link",
+	"tg-emoji":   "custom_emoji",
+	"blockquote": "blockquote",
+	"tg-time":    "date_time",
+}
+
+// ParseHTML parses text formatted for the HTML parse mode into plain text and entities,
+// following the rules of the Bot API:
+//
+//	e := entities.ParseHTML(`Hello, <b>world</b>!`).Unwrap()
+//	c.SendMessage(e.Text()).Entities(e).Send()
+func ParseHTML(s g.String) g.Result[*Entities] {
+	return parseHTML(s, false)
+}
+
+// ParseHTMLPrefix parses the beginning of HTML markup, such as a message still being generated,
+// see ParseHTML. Tags still open are closed at its end, and an incomplete tag or character
+// reference at its end is left out, so every prefix of valid markup parses.
+func ParseHTMLPrefix(s g.String) g.Result[*Entities] {
+	return parseHTML(s, true)
+}
+
+// parseHTML parses s, as a prefix of the markup if partial is set.
+func parseHTML(s g.String, partial bool) g.Result[*Entities] {
+	p := parser{entities: g.NewSlice[gotgbot.MessageEntity](), partial: partial}
+
+	if err := p.parse(s.Std()); err != nil {
+		return g.Err[*Entities](fmt.Errorf("%w: %w", ErrInvalidHTML, err))
+	}
+
+	ents := g.NewSlice[gotgbot.MessageEntity]()
+
+	for _, e := range p.entities {
+		if e.Length > 0 {
+			ents.Push(e)
+		}
+	}
+
+	return g.Ok(New(p.text.String()).Import(ents))
+}
+
+// parser collects the text and entities of HTML markup.
+type parser struct {
+	text     g.Builder
+	length   int64
+	entities g.Slice[gotgbot.MessageEntity]
+	stack    []openTag
+	partial  bool
+}
+
+// openTag is a tag waiting for its end tag.
+type openTag struct {
+	name   string
+	entity int // Index of the entity in parser.entities, -1 for a <code> merged into its <pre>
+}
+
+// parse parses s.
+func (p *parser) parse(s string) error {
+	for s != "" {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			p.write(p.complete(s))
+			break
+		}
+
+		p.write(s[:i])
+		s = s[i:]
+
+		j := tagEnd(s)
+		if j < 0 && p.partial {
+			break
+		}
+
+		if j < 0 {
+			return errors.New("unclosed tag")
+		}
+
+		if err := p.tag(s[1:j]); err != nil {
+			return err
+		}
+
+		s = s[j+1:]
+	}
+
+	if p.partial {
+		for _, open := range p.stack {
+			if open.entity >= 0 {
+				p.entities[open.entity].Length = p.length - p.entities[open.entity].Offset
+			}
+		}
+
+		p.stack = nil
+	}
+
+	if len(p.stack) > 0 {
+		return fmt.Errorf("tag <%s> is not closed", p.stack[len(p.stack)-1].name)
+	}
+
+	return nil
+}
+
+// complete returns the text at the end of the markup without an incomplete character
+// reference, if the markup is partial.
+func (p *parser) complete(s string) string {
+	if !p.partial {
+		return s
+	}
+
+	i := strings.LastIndexByte(s, '&')
+	if i < 0 || strings.ContainsAny(s[i:], "; \t\r\n") {
+		return s
+	}
+
+	return s[:i]
+}
+
+// write appends text with its character references decoded.
+func (p *parser) write(s string) {
+	s = html.UnescapeString(s)
+	p.text.WriteString(g.String(s))
+	p.length += UTF16Len(g.String(s))
+}
+
+// tag handles the inside of a start or end tag.
+func (p *parser) tag(s string) error {
+	if name, ok := strings.CutPrefix(s, "/"); ok {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		if len(p.stack) == 0 || p.stack[len(p.stack)-1].name != name {
+			return fmt.Errorf("unexpected end tag </%s>", name)
+		}
+
+		top := p.stack[len(p.stack)-1]
+		p.stack = p.stack[:len(p.stack)-1]
+
+		if top.entity >= 0 {
+			e := &p.entities[top.entity]
+			e.Length = p.length - e.Offset
+		}
+
+		return nil
+	}
+
+	name, attrs := parseTag(s)
+
+	typ, ok := tagTypes[name]
+	if !ok {
+		return fmt.Errorf("unsupported tag <%s>", name)
+	}
+
+	entity := gotgbot.MessageEntity{Type: typ, Offset: p.length}
+
+	switch name {
+	case "span":
+		if attrs["class"] != "tg-spoiler" {
+			return errors.New(`tag <span> must have class "tg-spoiler"`)
+		}
+	case "code":
+		if n := len(p.stack); n > 0 && p.stack[n-1].name == "pre" {
+			pre := &p.entities[p.stack[n-1].entity]
+			if language, ok := strings.CutPrefix(attrs["class"], "language-"); ok && pre.Offset == p.length {
+				pre.Language = language
+				p.stack = append(p.stack, openTag{name: name, entity: -1})
+
+				return nil
+			}
+		}
+	case "a":
+		href, ok := attrs["href"]
+		if !ok {
+			return errors.New("tag <a> has no href")
+		}
+
+		if id, ok := strings.CutPrefix(href, "tg://user?id="); ok {
+			n, err := strconv.ParseInt(id, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid user ID in %q", href)
+			}
+
+			entity.Type = "text_mention"
+			entity.User = &gotgbot.User{Id: n}
+		} else {
+			entity.Url = href
+		}
+	case "tg-emoji":
+		entity.CustomEmojiId = attrs["emoji-id"]
+		if entity.CustomEmojiId == "" {
+			return errors.New("tag <tg-emoji> has no emoji-id")
+		}
+	case "blockquote":
+		if _, ok := attrs["expandable"]; ok {
+			entity.Type = "expandable_blockquote"
+		}
+	case "tg-time":
+		unix, err := strconv.ParseInt(attrs["unix"], 10, 64)
+		if err != nil {
+			return errors.New("tag <tg-time> has no valid unix attribute")
+		}
+
+		entity.UnixTime = unix
+		entity.DateTimeFormat = attrs["format"]
+	}
+
+	p.stack = append(p.stack, openTag{name: name, entity: len(p.entities)})
+	p.entities.Push(entity)
+
+	return nil
+}
+
+// tagEnd returns the index of the '>' that ends the tag at the start of s,
+// skipping quoted attribute values, or -1 if there is none.
+func tagEnd(s string) int {
+	var quote byte
+
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i
+		}
+	}
+
+	return -1
+}
+
+// parseTag splits the inside of a start tag into its lowercase name and its attributes,
+// with character references in values decoded.
+func parseTag(s string) (string, map[string]string) {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "/")
+
+	end := strings.IndexAny(s, " \t\r\n")
+	if end < 0 {
+		return strings.ToLower(s), nil
+	}
+
+	name, rest := strings.ToLower(s[:end]), s[end:]
+	attrs := make(map[string]string)
+
+	for {
+		rest = strings.TrimLeft(rest, " \t\r\n")
+		if rest == "" {
+			return name, attrs
+		}
+
+		i := strings.IndexAny(rest, "= \t\r\n")
+		if i < 0 {
+			attrs[strings.ToLower(rest)] = ""
+			return name, attrs
+		}
+
+		key := strings.ToLower(rest[:i])
+		rest = strings.TrimLeft(rest[i:], " \t\r\n")
+
+		if !strings.HasPrefix(rest, "=") {
+			attrs[key] = ""
+			continue
+		}
+
+		rest = strings.TrimLeft(rest[1:], " \t\r\n")
+
+		var value string
+
+		if rest != "" && (rest[0] == '"' || rest[0] == '\'') {
+			j := strings.IndexByte(rest[1:], rest[0])
+			if j < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:j+1], rest[j+2:]
+			}
+		} else {
+			j := strings.IndexAny(rest, " \t\r\n")
+			if j < 0 {
+				j = len(rest)
+			}
+
+			value, rest = rest[:j], rest[j:]
+		}
+
+		attrs[key] = html.UnescapeString(value)
+	}
+}
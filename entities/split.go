@@ -0,0 +1,95 @@
+package entities
+
+import (
+	"unicode/utf16"
+
+	"github.com/enetx/g"
+)
+
+const (
+	// MaxMessageLen is the maximum length of a message text, in UTF-16 code units after entity parsing.
+	MaxMessageLen = 4096
+
+	// MaxCaptionLen is the maximum length of a media caption, in UTF-16 code units after entity parsing.
+	MaxCaptionLen = 1024
+)
+
+// Split splits the text into parts of at most limit UTF-16 code units, see Cut.
+func (e *Entities) Split(limit int) g.Slice[*Entities] {
+	parts := g.NewSlice[*Entities]()
+
+	for part, rest := e.Cut(limit); ; part, rest = rest.Cut(limit) {
+		if !part.text.IsEmpty() {
+			parts.Push(part)
+		}
+
+		if rest == nil {
+			return parts
+		}
+	}
+}
+
+// Cut splits the text into a head of at most limit UTF-16 code units and the rest, which is nil
+// if the whole text fits. It cuts at the last paragraph or line break in the second half of the
+// head, or else at its last space, dropping the break or space; a text without any is cut at
+// the limit. Entities are cut to the parts they cover and re-based, so formatting carries
+// across the cut.
+func (e *Entities) Cut(limit int) (*Entities, *Entities) {
+	units := utf16.Encode([]rune(e.text.Std()))
+	size := len(units)
+
+	if size <= limit || limit <= 0 {
+		return e.slice(units, 0, size), nil
+	}
+
+	end, next := cut(units, limit)
+
+	return e.slice(units, 0, end), e.slice(units, next, size)
+}
+
+// cut returns where the head of units ends and where the rest starts.
+func cut(units []uint16, limit int) (int, int) {
+	for i := limit; i > limit/2; i-- {
+		if i+1 < len(units) && units[i] == '\n' && units[i+1] == '\n' {
+			return i, i + 2
+		}
+	}
+
+	for i := limit; i > limit/2; i-- {
+		if units[i] == '\n' {
+			return i, i + 1
+		}
+	}
+
+	for i := limit; i > 0; i-- {
+		if units[i] == ' ' {
+			return i, i + 1
+		}
+	}
+
+	if high := units[limit-1]; high >= 0xD800 && high < 0xDC00 && limit > 1 {
+		return limit - 1, limit - 1
+	}
+
+	return limit, limit
+}
+
+// slice returns the text between the UTF-16 offsets start and end with the entities it covers.
+func (e *Entities) slice(units []uint16, start, end int) *Entities {
+	part := New(string(utf16.Decode(units[start:end])))
+
+	for _, entity := range e.entities {
+		from := max(entity.Offset, int64(start))
+		to := min(entity.Offset+entity.Length, int64(end))
+
+		if from >= to {
+			continue
+		}
+
+		entity.Offset = from - int64(start)
+		entity.Length = to - from
+		part.entities.Push(entity)
+	}
+
+	return part
+}
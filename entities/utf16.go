@@ -0,0 +1,19 @@
+package entities
+
+import "github.com/enetx/g"
+
+// UTF16Len returns the length of s in UTF-16 code units, the unit of entity offsets and lengths.
+// Characters outside the Basic Multilingual Plane, such as most emoji, take two units.
+func UTF16Len(s g.String) int64 {
+	var n int64
+
+	for _, r := range s.Std() {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+
+	return n
+}
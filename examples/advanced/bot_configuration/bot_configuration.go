@@ -3,6 +3,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"strconv"
@@ -51,7 +52,7 @@ func main() {
 
 	// Start the bot
 	log.Println("🚀 Bot Configuration Example started...")
-	botInstance.Polling().AllowedUpdates().Start()
+	botInstance.Polling().AllowedUpdates().Start(context.Background())
 }
 
 // handleStart provides main configuration menu
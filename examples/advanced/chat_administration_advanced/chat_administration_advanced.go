@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"time"
@@ -61,7 +62,7 @@ func main() {
 
 	// Start the bot
 	log.Println("🚀 Advanced Chat Administration Example started...")
-	botInstance.Polling().AllowedUpdates().Start()
+	botInstance.Polling().AllowedUpdates().Start(context.Background())
 }
 
 // handleAdminPanel provides main administration menu
@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 
@@ -41,7 +42,7 @@ func main() {
 
 	// Start the bot
 	log.Println("🚀 Advanced Input Builders Example Bot started...")
-	b.Polling().AllowedUpdates().Start()
+	b.Polling().AllowedUpdates().Start(context.Background())
 }
 
 // handleStart provides main menu for input builder demonstrations
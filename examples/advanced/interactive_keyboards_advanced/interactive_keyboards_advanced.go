@@ -4,6 +4,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/g/cmp"
 	"github.com/enetx/tg/bot"
@@ -203,7 +205,7 @@ func main() {
 
 	// Start the bot
 	g.Println("🚀 Interactive Keyboards Example started...")
-	botInstance.Polling().AllowedUpdates().Start()
+	botInstance.Polling().AllowedUpdates().Start(context.Background())
 }
 
 // handleKeyboardDemo provides main interactive keyboards menu
@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"time"
@@ -64,7 +65,7 @@ func main() {
 
 	// Start the bot
 	log.Println("🚀 Advanced Payment Processing Example started...")
-	botInstance.Polling().AllowedUpdates().Start()
+	botInstance.Polling().AllowedUpdates().Start(context.Background())
 }
 
 // handlePaymentPanel provides main payment processing menu
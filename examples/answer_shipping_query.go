@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -144,5 +146,5 @@ func main() {
 		}
 	})
 
-	b.Polling().Start()
+	b.Polling().Start(context.Background())
 }
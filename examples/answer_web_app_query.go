@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"time"
 
@@ -137,5 +138,5 @@ func main() {
 			"📤 Inline message sent on your behalf.").Send().Err()
 	})
 
-	b.Polling().Start()
+	b.Polling().Start(context.Background())
 }
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/bot"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/keyboard"
+)
+
+func main() {
+	token := g.NewFile("../.env").Read().Ok().Trim().Split("=").Collect().Last().Some()
+	b := bot.New(token).Build().Unwrap()
+
+	b.Command("register", func(c *ctx.Context) error {
+		// Ask suspends the handler until the same user answers in this chat.
+		// Other updates, including those of this user that do not match, are handled as usual.
+		name := c.Ask("What is your name? Send /cancel to stop.").Timeout(5 * time.Minute).Text()
+		if name.IsErr() {
+			return farewell(c, name.Err())
+		}
+
+		// Expect waits for an update accepted by the filter, here a number.
+		age := c.Ask("How old are you?").
+			Timeout(5 * time.Minute).
+			Expect(func(c *ctx.Context) bool {
+				return c.EffectiveMessage != nil && g.String(c.EffectiveMessage.Text).ToInt().IsOk()
+			})
+		if age.IsErr() {
+			return farewell(c, age.Err())
+		}
+
+		// An answer may also be a button press.
+		confirm := c.Ask(g.Format("{}, {} years old. Correct?", name.Ok(), age.Ok().EffectiveMessage.Text)).
+			Markup(keyboard.Inline().Text("Yes", "yes").Text("No", "no")).
+			Timeout(time.Minute).
+			Expect(func(c *ctx.Context) bool { return c.Callback != nil })
+		if confirm.IsErr() {
+			return farewell(c, confirm.Err())
+		}
+
+		answer := confirm.Ok()
+		answer.AnswerCallbackQuery("").Send()
+
+		if answer.Callback.Data != "yes" {
+			return c.SendMessage("Let's try again later.").Send().Err()
+		}
+
+		return c.SendMessage(g.Format("Welcome, {}!", name.Ok())).Send().Err()
+	})
+
+	b.Polling().Start(context.Background())
+}
+
+// farewell tells the user why the conversation ended.
+func farewell(c *ctx.Context, err error) error {
+	var (
+		timeout *ctx.AskTimeoutError
+		cancel  *ctx.AskCancelError
+	)
+
+	switch {
+	case errors.As(err, &timeout):
+		return c.SendMessage("You took too long, send /register to start over.").Send().Err()
+	case errors.As(err, &cancel):
+		return c.SendMessage("Registration cancelled.").Send().Err()
+	default:
+		return err
+	}
+}
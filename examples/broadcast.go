@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/bot"
+	"github.com/enetx/tg/broadcast"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/handlers"
+)
+
+func main() {
+	token := g.NewFile("../.env").Read().Ok().Trim().Split("=").Collect().Last().Some()
+	b := bot.New(token).Build().Unwrap()
+
+	// Chats that talked to the bot; a real bot keeps them in a database.
+	subscribers := g.NewMapSafe[int64, struct{}]()
+
+	// The running broadcast, if any.
+	var run *broadcast.Run
+
+	b.Command("start", func(c *ctx.Context) error {
+		subscribers.Insert(c.EffectiveChat.Id, struct{}{})
+		return c.Reply("Subscribed. /announce sends a message to every subscriber.").Send().Err()
+	})
+
+	b.Command("announce", func(c *ctx.Context) error {
+		admin := c.EffectiveChat.Id
+		text := c.Args().Join(" ")
+
+		if text.IsEmpty() {
+			return c.Reply("Usage: /announce <text>").Send().Err()
+		}
+
+		run = b.Broadcast(slices.Values(subscribers.Iter().Keys().Collect())).
+			Message(func(c *ctx.Context) error {
+				return c.SendMessage("📣 " + text).Send().Err()
+			}).
+			OnInactive(func(chatID int64, reason broadcast.Reason) {
+				log.Printf("removing %d: %s", chatID, reason)
+				subscribers.Remove(chatID)
+			}).
+			Start(b.Context())
+
+		b.Go(func() {
+			report := run.Wait()
+
+			b.SendMessage(admin, g.Format(
+				"Broadcast finished: {} sent, {} blocked, {} deactivated, {} not found, {} other.",
+				report.Sent,
+				report.Count(broadcast.Blocked),
+				report.Count(broadcast.Deactivated),
+				report.Count(broadcast.ChatNotFound),
+				report.Count(broadcast.Other),
+			)).Send()
+		})
+
+		return c.Reply("Broadcast started. /pause, /resume, /cancel or /progress.").Send().Err()
+	})
+
+	// Commands that control the broadcast are ignored while none was started.
+	running := func(c *ctx.Context) error {
+		if run == nil {
+			return errors.New("no broadcast")
+		}
+
+		return nil
+	}
+
+	b.Command("pause", func(c *ctx.Context) error { run.Pause(); return nil }).Use(handlers.Before(running))
+	b.Command("resume", func(c *ctx.Context) error { run.Resume(); return nil }).Use(handlers.Before(running))
+	b.Command("cancel", func(c *ctx.Context) error { run.Cancel(); return nil }).Use(handlers.Before(running))
+
+	b.Command("progress", func(c *ctx.Context) error {
+		p := run.Progress()
+		return c.Reply(g.Format("{} sent, {} failed in {}", p.Sent, p.Failed, p.Elapsed)).Send().Err()
+	}).Use(handlers.Before(running))
+
+	b.Polling().DropPendingUpdates().Start(context.Background())
+}
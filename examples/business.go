@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -128,5 +130,5 @@ func main() {
 			updates.EditedBusinessMessage,
 			updates.DeletedBusinessMessages,
 		).
-		Start()
+		Start(context.Background())
 }
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -68,5 +70,5 @@ func main() {
 		return ctx.EditMessageReplyMarkup(nil).Send().Err()
 	})
 
-	b.Polling().AllowedUpdates().Start()
+	b.Polling().AllowedUpdates().Start(context.Background())
 }
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -44,5 +46,5 @@ func main() {
 		return ctx.Reply("Echo: " + g.String(ctx.EffectiveMessage.Text)).Send().Err()
 	})
 
-	b.Polling().Start()
+	b.Polling().Start(context.Background())
 }
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -55,5 +57,5 @@ func main() {
 		return ctx.EditMessageReplyMarkup(markup).Send().Err()
 	})
 
-	b.Polling().DropPendingUpdates().Start()
+	b.Polling().DropPendingUpdates().Start(context.Background())
 }
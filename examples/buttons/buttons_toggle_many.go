@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
 	"github.com/enetx/tg/keyboard"
@@ -66,5 +68,5 @@ func main() {
 		return ctx.AnswerCallbackQuery("Unknown fruit").Send().Err()
 	})
 
-	b.Polling().Start()
+	b.Polling().Start(context.Background())
 }
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -30,5 +32,5 @@ func main() {
 		return ctx.SendMessage(message).Send().Err()
 	})
 
-	b.Polling().Start()
+	b.Polling().Start(context.Background())
 }
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/bot"
+	"github.com/enetx/tg/callback"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/keyboard"
+)
+
+// Counter is packed into the callback data of the buttons.
+type Counter struct {
+	Value int
+	Step  int
+}
+
+func main() {
+	token := g.NewFile("../.env").Read().Ok().Trim().Split("=").Collect().Last().Some()
+	b := bot.New(token).Build().Unwrap()
+
+	// Signed, so users can't send counters the bot never offered.
+	counters := callback.New[Counter]("counter").Sign([]byte(token))
+
+	markup := func(value int) *keyboard.InlineKeyboard {
+		return keyboard.Inline().
+			Data("-10", counters, Counter{Value: value, Step: -10}).
+			Data("-1", counters, Counter{Value: value, Step: -1}).
+			Data("+1", counters, Counter{Value: value, Step: 1}).
+			Data("+10", counters, Counter{Value: value, Step: 10})
+	}
+
+	b.Command("start", func(ctx *ctx.Context) error {
+		return ctx.Reply("Counter: 0").Markup(markup(0)).Send().Err()
+	})
+
+	b.On.Callback.Data(counters, func(ctx *ctx.Context, c Counter) error {
+		value := c.Value + c.Step
+
+		ctx.AnswerCallbackQuery("").Send()
+		return ctx.EditMessageText(g.Format("Counter: {}", value)).Markup(markup(value)).Send().Err()
+	})
+
+	b.Polling().DropPendingUpdates().Start(context.Background())
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -29,5 +31,5 @@ func main() {
 	})
 
 	// Start polling
-	b.Polling().DropPendingUpdates().Start()
+	b.Polling().DropPendingUpdates().Start(context.Background())
 }
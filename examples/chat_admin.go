@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -220,5 +222,5 @@ func main() {
 			Send().Err()
 	})
 
-	b.Polling().AllowedUpdates().Start()
+	b.Polling().AllowedUpdates().Start(context.Background())
 }
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -98,5 +100,5 @@ func main() {
 		return ctx.Reply("Successfully declined join request").Send().Err()
 	})
 
-	b.Polling().Start()
+	b.Polling().Start(context.Background())
 }
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -37,5 +39,5 @@ func main() {
 		Register()
 
 	// Start polling with allowed updates
-	b.Polling().AllowedUpdates().Start()
+	b.Polling().AllowedUpdates().Start(context.Background())
 }
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
@@ -496,5 +498,5 @@ func main() {
 		return c.Reply("All commands and menu button reset to default!").Send().Err()
 	})
 
-	b.Polling().AllowedUpdates().Start()
+	b.Polling().AllowedUpdates().Start(context.Background())
 }
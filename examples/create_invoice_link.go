@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/enetx/g"
@@ -267,5 +268,5 @@ Try these commands to create different types of invoice links:
 		return ctx.AnswerCallbackQuery("Demo product link created! 🎮").Send().Err()
 	})
 
-	b.Polling().Start()
+	b.Polling().Start(context.Background())
 }
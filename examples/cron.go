@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/bot"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/scheduler"
+)
+
+func main() {
+	token := g.NewFile("../.env").Read().Ok().Trim().Split("=").Collect().Last().Some()
+
+	// The next run of every job is kept in the file, so runs missed during a restart are noticed.
+	b := bot.New(token).JobStore(scheduler.NewFile("jobs.json")).Build().Unwrap()
+
+	b.Scheduler().OnError(func(job scheduler.Job, err error) {
+		log.Printf("job %s failed: %v", job.ID, err)
+	})
+
+	// Chats that subscribed to the morning greeting.
+	subscribers := g.NewMapSafe[int64, struct{}]()
+
+	b.Command("subscribe", func(c *ctx.Context) error {
+		subscribers.Insert(c.EffectiveChat.Id, struct{}{})
+		return c.Reply("You will get a greeting every weekday at 9:00 UTC.").Send().Err()
+	})
+
+	// Every weekday at 9:00 UTC; a greeting missed while the bot was down is sent once after the restart.
+	b.Schedule("0 9 * * MON-FRI", func(c *ctx.Context) error {
+		for id := range subscribers.Iter().Keys() {
+			if err := c.Chat(id).SendMessage("☀️ Good morning!").Send().Err(); err != nil {
+				log.Printf("greeting %d: %v", id, err)
+			}
+		}
+
+		return nil
+	}).
+		In(time.UTC).
+		Name("greeting").
+		Missed(scheduler.MissedRunOnce)
+
+	// Runs that take longer than the interval are skipped rather than piling up.
+	b.Every(time.Minute, func(c *ctx.Context) error {
+		log.Printf("heartbeat due at %s", scheduler.Scheduled(c.Std()).Format(time.TimeOnly))
+		return nil
+	})
+
+	b.Polling().DropPendingUpdates().Start(context.Background())
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"time"
 
 	"github.com/enetx/g"
@@ -28,5 +29,5 @@ func main() {
 		return ctx.Reply(text).Entities(e).Send().Err()
 	})
 
-	b.Polling().Start()
+	b.Polling().Start(context.Background())
 }
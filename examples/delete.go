@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"time"
 
 	"github.com/enetx/g"
@@ -23,5 +24,5 @@ func main() {
 		return ctx.DeleteMessage().Send().Err()
 	})
 
-	b.Polling().DropPendingUpdates().Start()
+	b.Polling().DropPendingUpdates().Start(context.Background())
 }
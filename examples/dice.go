@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -18,5 +20,5 @@ func main() {
 		return ctx.SendDice().Slot().Send().Err()
 	})
 
-	b.Polling().AllowedUpdates().DropPendingUpdates().Start()
+	b.Polling().AllowedUpdates().DropPendingUpdates().Start(context.Background())
 }
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -101,5 +103,5 @@ This is expandable quote`
 	})
 
 	// Start polling for updates
-	b.Polling().Start()
+	b.Polling().Start(context.Background())
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"time"
 
 	"github.com/enetx/g"
@@ -45,5 +46,5 @@ func main() {
 			Err()
 	})
 
-	b.Polling().Start()
+	b.Polling().Start(context.Background())
 }
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/PaulSonOfLars/gotgbot/v2"
 
 	"github.com/enetx/fsm"
@@ -222,5 +224,5 @@ func main() {
 	})
 
 	// Start the bot's polling loop to listen for updates from Telegram.
-	b.Polling().Start()
+	b.Polling().Start(context.Background())
 }
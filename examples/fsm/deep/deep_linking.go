@@ -4,6 +4,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/fsm"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
@@ -72,7 +74,7 @@ func main() {
 	})
 
 	// Start polling for updates
-	b.Polling().DropPendingUpdates().Start()
+	b.Polling().DropPendingUpdates().Start(context.Background())
 }
 
 // Fallback handler for unknown payloads or transitions
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/fsm"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
@@ -167,5 +169,5 @@ func main() {
 	})
 
 	// Start the bot's polling loop.
-	b.Polling().Start()
+	b.Polling().Start(context.Background())
 }
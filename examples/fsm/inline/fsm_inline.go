@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/fsm"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
@@ -159,5 +161,5 @@ func main() {
 
 	// Start the bot's polling loop. DropPendingUpdates clears any old updates
 	// that might have accumulated while the bot was offline.
-	b.Polling().DropPendingUpdates().Start()
+	b.Polling().DropPendingUpdates().Start(context.Background())
 }
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
@@ -41,7 +43,7 @@ func main() {
 		return ctx.Reply(info).HTML().Send().Err()
 	})
 
-	b.Polling().Start()
+	b.Polling().Start(context.Background())
 }
 
 // Helper function to get member status string
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -32,5 +34,5 @@ func main() {
 		return ctx.AnswerGuestQuery(queryID, result).Send().Err()
 	})
 
-	b.Polling().AllowedUpdates(updates.All...).Start()
+	b.Polling().AllowedUpdates(updates.All...).Start(context.Background())
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/enetx/g"
@@ -146,5 +147,5 @@ func main() {
 	})
 
 	// Start polling
-	b.Polling().Start()
+	b.Polling().Start(context.Background())
 }
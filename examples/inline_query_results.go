@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -271,5 +272,5 @@ func main() {
 			Send().Err()
 	})
 
-	b.Polling().AllowedUpdates(updates.All...).Start()
+	b.Polling().AllowedUpdates(updates.All...).Start(context.Background())
 }
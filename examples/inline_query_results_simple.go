@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/enetx/g"
@@ -30,5 +31,5 @@ func main() {
 			Send().Err()
 	})
 
-	b.Polling().AllowedUpdates(updates.All...).Start()
+	b.Polling().AllowedUpdates(updates.All...).Start(context.Background())
 }
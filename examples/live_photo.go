@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -22,5 +24,5 @@ func main() {
 			Send().Err()
 	})
 
-	b.Polling().Start()
+	b.Polling().Start(context.Background())
 }
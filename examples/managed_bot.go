@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -98,5 +100,5 @@ func main() {
 		return ctx.Reply(g.Format("Prepared button id: <code>{}</code>", result.Ok().Id)).HTML().Send().Err()
 	})
 
-	b.Polling().AllowedUpdates(updates.All...).Start()
+	b.Polling().AllowedUpdates(updates.All...).Start(context.Background())
 }
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -34,5 +36,5 @@ func main() {
 		return ctx.SendMessage(g.String("Here is your complete answer!")).Send().Err()
 	})
 
-	b.Polling().Start()
+	b.Polling().Start(context.Background())
 }
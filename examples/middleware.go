@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -56,5 +58,5 @@ func main() {
 	// Simple message handler: replies "Hello" to any text message
 	b.On.Message.Text(func(ctx *ctx.Context) error { return ctx.SendMessage("Hello").Send().Err() })
 
-	b.Polling().DropPendingUpdates().Start()
+	b.Polling().DropPendingUpdates().Start(context.Background())
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"time"
 
 	"github.com/enetx/tg/bot"
@@ -101,5 +102,5 @@ func main() {
 		return ctx.Reply("Paid live photo sent with builder pattern!").Send().Err()
 	})
 
-	b.Polling().AllowedUpdates().Start()
+	b.Polling().AllowedUpdates().Start(context.Background())
 }
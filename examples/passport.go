@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -159,5 +161,5 @@ Use /passport_demo to test all error types.`).Send().Err()
 		return nil
 	})
 
-	b.Polling().Start()
+	b.Polling().Start(context.Background())
 }
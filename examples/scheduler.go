@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/bot"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/scheduler"
+)
+
+func main() {
+	token := g.NewFile("../.env").Read().Ok().Trim().Split("=").Collect().Last().Some()
+
+	// Jobs are kept in a file, so reminders set before a restart are still sent.
+	b := bot.New(token).JobStore(scheduler.NewFile("jobs.json")).Build().Unwrap()
+
+	b.Scheduler().OnError(func(job scheduler.Job, err error) {
+		log.Printf("job %s failed: %v", job.ID, err)
+	})
+
+	// Pending reminder of every chat, to cancel or postpone it.
+	reminders := g.NewMapSafe[int64, scheduler.JobID]()
+
+	b.Command("remind", func(c *ctx.Context) error {
+		id := c.SendMessage("⏰ Time for a break!").
+			After(time.Minute).
+			DeleteAfter(10 * time.Minute).
+			Schedule()
+		if id.IsErr() {
+			return id.Err()
+		}
+
+		reminders.Insert(c.EffectiveChat.Id, id.Ok())
+
+		return c.Reply("Reminder set for one minute. /later postpones it, /cancel drops it.").Send().Err()
+	})
+
+	b.Command("later", func(c *ctx.Context) error {
+		id := reminders.Get(c.EffectiveChat.Id)
+		if id.IsNone() {
+			return c.Reply("No reminder set.").Send().Err()
+		}
+
+		if err := b.Scheduler().Reschedule(id.Some(), time.Now().Add(5*time.Minute)); err != nil {
+			return c.Reply("The reminder was already sent.").Send().Err()
+		}
+
+		return c.Reply("Reminder postponed by five minutes.").Send().Err()
+	})
+
+	b.Command("cancel", func(c *ctx.Context) error {
+		id := reminders.Get(c.EffectiveChat.Id)
+		if id.IsNone() || b.Scheduler().Cancel(id.Some()) != nil {
+			return c.Reply("No reminder to cancel.").Send().Err()
+		}
+
+		reminders.Remove(c.EffectiveChat.Id)
+
+		return c.Reply("Reminder cancelled.").Send().Err()
+	})
+
+	b.Polling().DropPendingUpdates().Start(context.Background())
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/bot"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/session"
+)
+
+func main() {
+	token := g.NewFile("../.env").Read().Ok().Trim().Split("=").Collect().Last().Some()
+	b := bot.New(token).Build().Unwrap()
+
+	// Keep one session per user in a JSON file, so that it survives restarts.
+	// Sessions not changed for a week are dropped.
+	sessions := session.New(session.NewFile("sessions.json")).
+		Key(session.ByUser).
+		TTL(7 * 24 * time.Hour)
+
+	b.Around(sessions.Middleware())
+
+	b.Command("start", func(ctx *ctx.Context) error {
+		return ctx.Reply("Send me words and I will remember them. /list shows them, /forget clears them.").
+			Send().Err()
+	})
+
+	b.Command("list", func(c *ctx.Context) error {
+		words := ctx.SessionValue[g.Slice[g.String]](c.Session(), "words").UnwrapOrDefault()
+		if words.IsEmpty() {
+			return c.Reply("I don't remember anything yet.").Send().Err()
+		}
+
+		return c.Reply(g.Format("I remember: {}", words.Join(", "))).Send().Err()
+	})
+
+	b.Command("forget", func(c *ctx.Context) error {
+		c.Session().Clear()
+		return c.Reply("Forgotten.").Send().Err()
+	})
+
+	b.On.Message.Text(func(c *ctx.Context) error {
+		words := ctx.SessionValue[g.Slice[g.String]](c.Session(), "words").UnwrapOrDefault()
+		words.Push(g.String(c.EffectiveMessage.Text))
+
+		// Values are saved only when set, so store the updated slice again.
+		c.Session().Set("words", words)
+
+		return c.Reply(g.Format("Remembered {} words.", words.Len())).Send().Err()
+	})
+
+	b.Polling().Start(context.Background())
+}
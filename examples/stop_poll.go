@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"time"
 
 	"github.com/enetx/g"
@@ -95,5 +96,5 @@ func main() {
 		return ctx.Reply("❌ Failed to stop poll").Send().Err()
 	})
 
-	b.Polling().DropPendingUpdates().Start()
+	b.Polling().DropPendingUpdates().Start(context.Background())
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"time"
 
 	"github.com/enetx/tg/bot"
@@ -52,5 +53,5 @@ func main() {
 		return ctx.DeleteStory("your_business_connection_id", storyID).Send().Err()
 	})
 
-	b.Polling().AllowedUpdates().Start()
+	b.Polling().AllowedUpdates().Start(context.Background())
 }
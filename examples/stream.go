@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/bot"
+	"github.com/enetx/tg/ctx"
+)
+
+// Streaming: show a reply while it is being generated. Chunks are coalesced into draft
+// updates (or edits of a message in chats without drafts), and the final text is sent as
+// regular messages, split at the 4096-character limit.
+func main() {
+	token := g.NewFile("../.env").Read().Ok().Trim().Split("=").Collect().Last().Some()
+	b := bot.New(token).Build().Unwrap()
+
+	b.Command("stream", func(ctx *ctx.Context) error {
+		chunks := make(chan g.String)
+
+		// Simulate a model producing HTML token by token; tags left open mid-stream are
+		// closed in the updates.
+		go func() {
+			defer close(chunks)
+
+			for _, token := range g.SliceOf[g.String]("<b>Streaming</b> ", "answers ", "<i>token ", "by ", "token</i>.") {
+				chunks <- token
+				time.Sleep(300 * time.Millisecond)
+			}
+		}()
+
+		return ctx.Stream().HTML().FromChan(chunks).Err()
+	})
+
+	b.Polling().Start(context.Background())
+}
@@ -1,9 +1,6 @@
 package main
 
 import (
-	"io"
-	"net/http"
-
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
 	"github.com/enetx/tg/ctx"
@@ -18,7 +15,14 @@ func main() {
 	path := g.String("/bot/" + token)
 	secret := g.String("my-secret-token")
 
-	b.Webhook().
+	b.On.Message.Text(func(ctx *ctx.Context) error { return ctx.SendMessage("pong").Send().Err() })
+
+	// Listen registers the webhook and serves it on the configured path,
+	// verifying the secret token, request method and body size.
+	// It blocks until SIGINT/SIGTERM and then drains running handlers.
+	g.Println("Listening on http://0.0.0.0:8080 at {}", path)
+
+	err := b.Webhook().
 		Domain("https://3b1d-134-19-179-195.ngrok-free.app").
 		Path(path).
 		SecretToken(secret).
@@ -26,46 +30,19 @@ func main() {
 		DropPending(true).
 		AllowedUpdates(updates.Message, updates.CallbackQuery).
 		// Certificate("cert.pem").
-		Register()
-
-	b.On.Message.Text(func(ctx *ctx.Context) error { return ctx.SendMessage("pong").Send().Err() })
-
-	handler := func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost || g.String(r.URL.Path).Ne(path) {
-			http.NotFound(w, r)
-			return
-		}
-
-		if g.String(r.Header.Get("X-Telegram-Bot-Api-Secret-Token")).Ne(secret) {
-			http.Error(w, "unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		body, err := io.ReadAll(r.Body)
-		if err != nil {
-			http.Error(w, "failed to read body", http.StatusBadRequest)
-			return
-		}
-
-		defer r.Body.Close()
-
-		if err := b.HandleWebhook(body); err != nil {
-			http.Error(w, "invalid update: "+err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		w.WriteHeader(http.StatusOK)
-	}
-
-	// g.Println("Listening on https://0.0.0.0:8443 at {}", path)
-	// err := http.ListenAndServeTLS(":8080", "cert.pem", "key.pem", http.HandlerFunc(handler))
-
-	g.Println("Listening on http://0.0.0.0:8080 at {}", path)
-	err := http.ListenAndServe(":8080", http.HandlerFunc(handler))
+		// TLS("cert.pem", "key.pem").
+		Listen(":8080")
 	if err != nil {
 		panic(err)
 	}
 
+	// To mount the bot in your own server, use b.WebhookHandler() after Register():
+	//
+	// http.Handle(path.Std(), b.WebhookHandler())
+	// http.ListenAndServe(":8080", nil)
+
+	// Other HTTP servers can pass the raw body to b.HandleWebhook, checking the secret themselves:
+	//
 	// handler := func(ctx *fasthttp.RequestCtx) {
 	// 	if !ctx.IsPost() || g.String(ctx.Path()).Ne(path) {
 	// 		ctx.SetStatusCode(fasthttp.StatusNotFound)
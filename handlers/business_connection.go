@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters"
 	"github.com/enetx/g"
@@ -9,7 +10,12 @@ import (
 )
 
 // BusinessConnection provides methods to handle business connection events.
-type BusinessConnection struct{ Bot core.BotAPI }
+type BusinessConnection struct {
+	Bot   core.BotAPI
+	group int
+	uses  g.Slice[Middleware]
+	last  *EventHandler // Handler registered by the latest call
+}
 
 // newBusinessConnection creates a new business connection handler with the given filter and response.
 func newBusinessConnection(f filters.BusinessConnection, r handlers.Response) handlers.BusinessConnection {
@@ -20,60 +26,71 @@ func newBusinessConnection(f filters.BusinessConnection, r handlers.Response) ha
 }
 
 // handleBusinessConnection registers a business connection handler with the dispatcher.
-func (h *BusinessConnection) handleBusinessConnection(f filters.BusinessConnection, fn Handler) {
-	h.Bot.Dispatcher().AddHandler(newBusinessConnection(f, wrap(h.Bot, middlewares(h.Bot), fn)))
+func (h *BusinessConnection) handleBusinessConnection(method string, f filters.BusinessConnection, fn Handler, args ...any) {
+	h.last = register(h.Bot, "business_connection", h.group, h.uses, fn, describe(method, args...), func(r handlers.Response) ext.Handler {
+		return newBusinessConnection(f, r)
+	})
 }
 
+// Last returns the handler registered by the latest call on h, to move it to another group or remove it.
+func (h *BusinessConnection) Last() *EventHandler { return h.last }
+
 // Any handles all business connection events.
 func (h *BusinessConnection) Any(fn Handler) *BusinessConnection {
-	h.handleBusinessConnection(nil, fn)
+	h.handleBusinessConnection("Any", nil, fn)
 	return h
 }
 
 // Enabled handles business connection enabled events.
 func (h *BusinessConnection) Enabled(fn Handler) *BusinessConnection {
-	h.handleBusinessConnection(func(bc *gotgbot.BusinessConnection) bool {
+	h.handleBusinessConnection("Enabled", func(bc *gotgbot.BusinessConnection) bool {
 		return bc.IsEnabled
 	}, fn)
+
 	return h
 }
 
 // Disabled handles business connection disabled events.
 func (h *BusinessConnection) Disabled(fn Handler) *BusinessConnection {
-	h.handleBusinessConnection(func(bc *gotgbot.BusinessConnection) bool {
+	h.handleBusinessConnection("Disabled", func(bc *gotgbot.BusinessConnection) bool {
 		return !bc.IsEnabled
 	}, fn)
+
 	return h
 }
 
 // FromUser handles business connections from a specific user.
 func (h *BusinessConnection) FromUser(userID int64, fn Handler) *BusinessConnection {
-	h.handleBusinessConnection(func(bc *gotgbot.BusinessConnection) bool {
+	h.handleBusinessConnection("FromUser", func(bc *gotgbot.BusinessConnection) bool {
 		return bc.User.Id == userID
-	}, fn)
+	}, fn, userID)
+
 	return h
 }
 
 // FromUsername handles business connections from a specific username.
 func (h *BusinessConnection) FromUsername(username g.String, fn Handler) *BusinessConnection {
-	h.handleBusinessConnection(func(bc *gotgbot.BusinessConnection) bool {
+	h.handleBusinessConnection("FromUsername", func(bc *gotgbot.BusinessConnection) bool {
 		return bc.User.Username == username.Std()
-	}, fn)
+	}, fn, username)
+
 	return h
 }
 
 // ConnectionID handles business connections with a specific connection ID.
 func (h *BusinessConnection) ConnectionID(connectionID g.String, fn Handler) *BusinessConnection {
-	h.handleBusinessConnection(func(bc *gotgbot.BusinessConnection) bool {
+	h.handleBusinessConnection("ConnectionID", func(bc *gotgbot.BusinessConnection) bool {
 		return bc.Id == connectionID.Std()
-	}, fn)
+	}, fn, connectionID)
+
 	return h
 }
 
 // CanReply handles business connections where the bot can reply.
 func (h *BusinessConnection) CanReply(fn Handler) *BusinessConnection {
-	h.handleBusinessConnection(func(bc *gotgbot.BusinessConnection) bool {
+	h.handleBusinessConnection("CanReply", func(bc *gotgbot.BusinessConnection) bool {
 		return bc.Rights != nil && bc.Rights.CanReply
 	}, fn)
+
 	return h
 }
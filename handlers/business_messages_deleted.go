@@ -42,47 +42,61 @@ func newBusinessMessagesDeleted(f filters.BusinessMessagesDeleted, r handlers.Re
 }
 
 // BusinessMessagesDeleted provides methods to handle deleted business messages.
-type BusinessMessagesDeleted struct{ Bot core.BotAPI }
+type BusinessMessagesDeleted struct {
+	Bot   core.BotAPI
+	group int
+	uses  g.Slice[Middleware]
+	last  *EventHandler // Handler registered by the latest call
+}
 
 // handleBusinessMessagesDelete registers a deleted business messages handler with the dispatcher.
-func (h *BusinessMessagesDeleted) handleBusinessMessagesDelete(f filters.BusinessMessagesDeleted, fn Handler) {
-	h.Bot.Dispatcher().AddHandler(newBusinessMessagesDeleted(f, wrap(h.Bot, middlewares(h.Bot), fn)))
+func (h *BusinessMessagesDeleted) handleBusinessMessagesDelete(method string, f filters.BusinessMessagesDeleted, fn Handler, args ...any) {
+	h.last = register(h.Bot, "business_messages_deleted", h.group, h.uses, fn, describe(method, args...), func(r handlers.Response) ext.Handler {
+		return newBusinessMessagesDeleted(f, r)
+	})
 }
 
+// Last returns the handler registered by the latest call on h, to move it to another group or remove it.
+func (h *BusinessMessagesDeleted) Last() *EventHandler { return h.last }
+
 // Any handles all deleted business messages.
 func (h *BusinessMessagesDeleted) Any(fn Handler) *BusinessMessagesDeleted {
-	h.handleBusinessMessagesDelete(nil, fn)
+	h.handleBusinessMessagesDelete("Any", nil, fn)
 	return h
 }
 
 // ConnectionID handles deleted messages from a specific connection.
 func (h *BusinessMessagesDeleted) ConnectionID(connectionID g.String, fn Handler) *BusinessMessagesDeleted {
-	h.handleBusinessMessagesDelete(func(d *gotgbot.BusinessMessagesDeleted) bool {
+	h.handleBusinessMessagesDelete("ConnectionID", func(d *gotgbot.BusinessMessagesDeleted) bool {
 		return d.BusinessConnectionId == connectionID.Std()
-	}, fn)
+	}, fn, connectionID)
+
 	return h
 }
 
 // ChatID handles deleted messages from a specific chat.
 func (h *BusinessMessagesDeleted) ChatID(chatID int64, fn Handler) *BusinessMessagesDeleted {
-	h.handleBusinessMessagesDelete(func(d *gotgbot.BusinessMessagesDeleted) bool {
+	h.handleBusinessMessagesDelete("ChatID", func(d *gotgbot.BusinessMessagesDeleted) bool {
 		return d.Chat.Id == chatID
-	}, fn)
+	}, fn, chatID)
+
 	return h
 }
 
 // Private handles deleted messages from private chats only.
 func (h *BusinessMessagesDeleted) Private(fn Handler) *BusinessMessagesDeleted {
-	h.handleBusinessMessagesDelete(func(d *gotgbot.BusinessMessagesDeleted) bool {
+	h.handleBusinessMessagesDelete("Private", func(d *gotgbot.BusinessMessagesDeleted) bool {
 		return d.Chat.Type == chat.Private.String()
 	}, fn)
+
 	return h
 }
 
 // Group handles deleted messages from groups/supergroups only.
 func (h *BusinessMessagesDeleted) Group(fn Handler) *BusinessMessagesDeleted {
-	h.handleBusinessMessagesDelete(func(d *gotgbot.BusinessMessagesDeleted) bool {
+	h.handleBusinessMessagesDelete("Group", func(d *gotgbot.BusinessMessagesDeleted) bool {
 		return d.Chat.Type == chat.Group.String() || d.Chat.Type == chat.Supergroup.String()
 	}, fn)
+
 	return h
 }
@@ -1,13 +1,15 @@
 package handlers
 
 import (
-	"fmt"
-	"reflect"
+	"slices"
+	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters"
+	"github.com/enetx/fsm"
 	"github.com/enetx/g"
+	"github.com/enetx/tg/callback"
 	"github.com/enetx/tg/core"
 )
 
@@ -17,6 +19,11 @@ type CallbackHandler struct {
 	filter       filters.CallbackQuery
 	handler      Handler
 	name         string
+	desc         g.String
+	group        int
+	uses         g.Slice[Middleware]
+	timeout      time.Duration
+	states       g.Slice[fsm.State]
 	allowChannel bool
 }
 
@@ -26,82 +33,141 @@ func (h *CallbackHandler) AllowChannel() *CallbackHandler {
 	return h
 }
 
+// Use adds middlewares that wrap only this handler, inside the global and scope middlewares.
+func (h *CallbackHandler) Use(middlewares ...Middleware) *CallbackHandler {
+	h.uses = slices.Concat(h.uses, middlewares)
+	return h.Register()
+}
+
+// Timeout cancels the standard context of the update, c.Std(), once the handler
+// and its middlewares have been running for d, aborting its pending requests.
+func (h *CallbackHandler) Timeout(d time.Duration) *CallbackHandler {
+	h.timeout = d
+	return h.Register()
+}
+
+// InState restricts the handler to users whose state machine, see Bot.FSM, is in one of states.
+// Updates of other users are left to the next matching handler.
+func (h *CallbackHandler) InState(states ...fsm.State) *CallbackHandler {
+	h.states = states
+	return h.Register()
+}
+
+// Group moves the handler to the given dispatcher group.
+// Groups are processed in ascending order and each group handles an update at most once,
+// so handlers in different groups can all react to the same update. The default group is 0.
+func (h *CallbackHandler) Group(n int) *CallbackHandler {
+	h.bot.Dispatcher().RemoveHandlerFromGroup(h.name, h.group)
+	h.group = n
+
+	return h.Register()
+}
+
 // Register registers the callback handler with the bot dispatcher.
 func (h *CallbackHandler) Register() *CallbackHandler {
-	h.bot.Dispatcher().RemoveHandlerFromGroup(h.name, 0)
+	h.bot.Dispatcher().RemoveHandlerFromGroup(h.name, h.group)
 
 	c := handlers.CallbackQuery{
 		AllowChannel: h.allowChannel,
 		Filter:       h.filter,
-		Response:     wrap(h.bot, middlewares(h.bot), h.handler),
+		Response:     wrap(h.bot, timeout(h.timeout, chain(h.bot, h.uses)), h.handler),
 	}
 
-	h.bot.Dispatcher().AddHandlerToGroup(namedHandler{h.name, c}, 0)
+	h.bot.Dispatcher().AddHandlerToGroup(namedHandler{h.name, withStates(h.bot, c, h.states)}, h.group)
+	registry(h.bot).put(h.Route())
 
 	return h
 }
 
+// Route returns the description of the registered handler.
+func (h *CallbackHandler) Route() Route {
+	return Route{ID: g.String(h.name), Kind: "callback", Filter: describeStates(h.desc, h.states), Group: h.group, bot: h.bot}
+}
+
+// Remove unregisters the handler. It reports whether the handler was registered.
+func (h *CallbackHandler) Remove() bool {
+	return h.Route().Remove()
+}
+
 // CallbackHandlers provides methods to handle callback query events.
-type CallbackHandlers struct{ Bot core.BotAPI }
+type CallbackHandlers struct {
+	Bot   core.BotAPI
+	group int
+	uses  g.Slice[Middleware]
+}
 
 // handleCallback creates and registers a new callback query handler with the specified filter.
-func (h *CallbackHandlers) handleCallback(f filters.CallbackQuery, fn Handler) *CallbackHandler {
+func (h *CallbackHandlers) handleCallback(method string, f filters.CallbackQuery, fn Handler, args ...any) *CallbackHandler {
 	return (&CallbackHandler{
 		bot:     h.Bot,
 		filter:  f,
 		handler: fn,
-		name:    fmt.Sprintf("callback_%x_%x", reflect.ValueOf(f).Pointer(), reflect.ValueOf(fn).Pointer()),
+		group:   h.group,
+		uses:    h.uses,
+		name:    newID("callback"),
+		desc:    describe(method, args...),
 	}).Register()
 }
 
 // Any handles all callback queries.
-func (h *CallbackHandlers) Any(fn Handler) *CallbackHandler { return h.handleCallback(nil, fn) }
+func (h *CallbackHandlers) Any(fn Handler) *CallbackHandler { return h.handleCallback("Any", nil, fn) }
 
 // Equal handles callback queries with exact matching data.
 func (h *CallbackHandlers) Equal(data g.String, fn Handler) *CallbackHandler {
-	return h.handleCallback(func(q *gotgbot.CallbackQuery) bool {
+	return h.handleCallback("Equal", func(q *gotgbot.CallbackQuery) bool {
 		return q != nil && q.Data == data.Std()
-	}, fn)
+	}, fn, data)
 }
 
 // Prefix handles callback queries with data starting with the specified prefix.
 func (h *CallbackHandlers) Prefix(prefix g.String, fn Handler) *CallbackHandler {
-	return h.handleCallback(func(q *gotgbot.CallbackQuery) bool {
+	return h.handleCallback("Prefix", func(q *gotgbot.CallbackQuery) bool {
 		return q != nil && g.String(q.Data).StartsWith(prefix)
-	}, fn)
+	}, fn, prefix)
 }
 
 // Suffix handles callback queries with data ending with the specified suffix.
 func (h *CallbackHandlers) Suffix(suffix g.String, fn Handler) *CallbackHandler {
-	return h.handleCallback(func(q *gotgbot.CallbackQuery) bool {
+	return h.handleCallback("Suffix", func(q *gotgbot.CallbackQuery) bool {
 		return q != nil && g.String(q.Data).EndsWith(suffix)
-	}, fn)
+	}, fn, suffix)
+}
+
+// Data handles callback queries encoded by codec, passing the decoded value to fn, which must be
+// a func(*ctx.Context, T) error for the codec type T. It panics if fn has another type.
+// Data that fails to decode, e.g. because of a forged signature, does not reach fn.
+func (h *CallbackHandlers) Data(codec callback.Binder, fn any) *CallbackHandler {
+	handler := codec.Bind(fn).Unwrap()
+
+	return h.handleCallback("Data", func(q *gotgbot.CallbackQuery) bool {
+		return q != nil && handler.Match(g.String(q.Data))
+	}, handler.Handle)
 }
 
 // FromUserID handles callback queries from a specific user ID.
 func (h *CallbackHandlers) FromUserID(id int64, fn Handler) *CallbackHandler {
-	return h.handleCallback(func(q *gotgbot.CallbackQuery) bool {
+	return h.handleCallback("FromUserID", func(q *gotgbot.CallbackQuery) bool {
 		return q != nil && q.From.Id == id
-	}, fn)
+	}, fn, id)
 }
 
 // GameName handles callback queries from games with the specified short name.
 func (h *CallbackHandlers) GameName(name g.String, fn Handler) *CallbackHandler {
-	return h.handleCallback(func(q *gotgbot.CallbackQuery) bool {
+	return h.handleCallback("GameName", func(q *gotgbot.CallbackQuery) bool {
 		return q != nil && q.GameShortName == name.Std()
-	}, fn)
+	}, fn, name)
 }
 
 // Inline handles callback queries with inline message ID.
 func (h *CallbackHandlers) Inline(fn Handler) *CallbackHandler {
-	return h.handleCallback(func(q *gotgbot.CallbackQuery) bool {
+	return h.handleCallback("Inline", func(q *gotgbot.CallbackQuery) bool {
 		return q != nil && q.InlineMessageId != ""
 	}, fn)
 }
 
 // ChatInstance handles callback queries with the specified chat instance.
 func (h *CallbackHandlers) ChatInstance(inst g.String, fn Handler) *CallbackHandler {
-	return h.handleCallback(func(q *gotgbot.CallbackQuery) bool {
+	return h.handleCallback("ChatInstance", func(q *gotgbot.CallbackQuery) bool {
 		return q != nil && q.ChatInstance == inst.Std()
-	}, fn)
+	}, fn, inst)
 }
@@ -2,45 +2,60 @@ package handlers
 
 import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters"
+	"github.com/enetx/g"
 	"github.com/enetx/tg/core"
 )
 
 // ChatJoinRequestHandlers provides methods to handle chat join request events.
-type ChatJoinRequestHandlers struct{ Bot core.BotAPI }
+type ChatJoinRequestHandlers struct {
+	Bot   core.BotAPI
+	group int
+	uses  g.Slice[Middleware]
+	last  *EventHandler // Handler registered by the latest call
+}
 
 // handleChatJoinRequest registers a chat join request handler with the dispatcher.
-func (h *ChatJoinRequestHandlers) handleChatJoinRequest(f filters.ChatJoinRequest, fn Handler) {
-	h.Bot.Dispatcher().AddHandler(handlers.NewChatJoinRequest(f, wrap(h.Bot, middlewares(h.Bot), fn)))
+func (h *ChatJoinRequestHandlers) handleChatJoinRequest(method string, f filters.ChatJoinRequest, fn Handler, args ...any) {
+	h.last = register(h.Bot, "chat_join_request", h.group, h.uses, fn, describe(method, args...), func(r handlers.Response) ext.Handler {
+		return handlers.NewChatJoinRequest(f, r)
+	})
 }
 
+// Last returns the handler registered by the latest call on h, to move it to another group or remove it.
+func (h *ChatJoinRequestHandlers) Last() *EventHandler { return h.last }
+
 // Any handles all chat join requests.
 func (h *ChatJoinRequestHandlers) Any(fn Handler) *ChatJoinRequestHandlers {
-	h.handleChatJoinRequest(nil, fn)
+	h.handleChatJoinRequest("Any", nil, fn)
 	return h
 }
 
 // ChatID handles chat join requests for a specific chat ID.
 func (h *ChatJoinRequestHandlers) ChatID(id int64, fn Handler) *ChatJoinRequestHandlers {
-	h.handleChatJoinRequest(func(r *gotgbot.ChatJoinRequest) bool {
+	h.handleChatJoinRequest("ChatID", func(r *gotgbot.ChatJoinRequest) bool {
 		return r != nil && r.Chat.Id == id
-	}, fn)
+	}, fn, id)
+
 	return h
 }
 
 // FromUserID handles chat join requests from a specific user ID.
 func (h *ChatJoinRequestHandlers) FromUserID(id int64, fn Handler) *ChatJoinRequestHandlers {
-	h.handleChatJoinRequest(func(r *gotgbot.ChatJoinRequest) bool {
+	h.handleChatJoinRequest("FromUserID", func(r *gotgbot.ChatJoinRequest) bool {
 		return r != nil && r.From.Id == id
-	}, fn)
+	}, fn, id)
+
 	return h
 }
 
 // HasInviteLink handles chat join requests that include an invite link.
 func (h *ChatJoinRequestHandlers) HasInviteLink(fn Handler) *ChatJoinRequestHandlers {
-	h.handleChatJoinRequest(func(r *gotgbot.ChatJoinRequest) bool {
+	h.handleChatJoinRequest("HasInviteLink", func(r *gotgbot.ChatJoinRequest) bool {
 		return r != nil && r.InviteLink != nil
 	}, fn)
+
 	return h
 }
@@ -2,124 +2,153 @@ package handlers
 
 import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters"
+	"github.com/enetx/g"
 	"github.com/enetx/tg/core"
 	"github.com/enetx/tg/types/chatmember"
 )
 
 // ChatMemberHandlers provides methods to handle chat member update events.
-type ChatMemberHandlers struct{ Bot core.BotAPI }
+type ChatMemberHandlers struct {
+	Bot   core.BotAPI
+	group int
+	uses  g.Slice[Middleware]
+	last  *EventHandler // Handler registered by the latest call
+}
 
 // handleChatMember registers a chat member update handler with the dispatcher.
-func (h *ChatMemberHandlers) handleChatMember(f filters.ChatMember, fn Handler) {
-	h.Bot.Dispatcher().AddHandler(handlers.NewChatMember(f, wrap(h.Bot, middlewares(h.Bot), fn)))
+func (h *ChatMemberHandlers) handleChatMember(method string, f filters.ChatMember, fn Handler, args ...any) {
+	h.last = register(h.Bot, "chat_member", h.group, h.uses, fn, describe(method, args...), func(r handlers.Response) ext.Handler {
+		return handlers.NewChatMember(f, r)
+	})
 }
 
+// Last returns the handler registered by the latest call on h, to move it to another group or remove it.
+func (h *ChatMemberHandlers) Last() *EventHandler { return h.last }
+
 // Any handles all chat member updates.
 func (h *ChatMemberHandlers) Any(fn Handler) *ChatMemberHandlers {
-	h.handleChatMember(nil, fn)
+	h.handleChatMember("Any", nil, fn)
 	return h
 }
 
 // StatusChange handles chat member status changes from one status to another.
 func (h *ChatMemberHandlers) StatusChange(from, to chatmember.ChatMemberStatus, fn Handler) *ChatMemberHandlers {
-	filter := func(cm *gotgbot.ChatMemberUpdated) bool {
+	h.handleChatMember("StatusChange", statusChange(from, to), fn, from, to)
+	return h
+}
+
+// statusChange returns a filter for chat member updates from one status to another.
+func statusChange(from, to chatmember.ChatMemberStatus) filters.ChatMember {
+	return func(cm *gotgbot.ChatMemberUpdated) bool {
 		return cm != nil &&
 			cm.OldChatMember != nil &&
 			cm.NewChatMember != nil &&
 			cm.OldChatMember.GetStatus() == from.String() &&
 			cm.NewChatMember.GetStatus() == to.String()
 	}
-
-	h.handleChatMember(filter, fn)
-	return h
 }
 
 // Joined handles when a member joins the chat.
 func (h *ChatMemberHandlers) Joined(fn Handler) *ChatMemberHandlers {
-	return h.StatusChange(chatmember.Left, chatmember.Member, fn)
+	h.handleChatMember("Joined", statusChange(chatmember.Left, chatmember.Member), fn)
+	return h
 }
 
 // Left handles when a member leaves the chat.
 func (h *ChatMemberHandlers) Left(fn Handler) *ChatMemberHandlers {
-	return h.StatusChange(chatmember.Member, chatmember.Left, fn)
+	h.handleChatMember("Left", statusChange(chatmember.Member, chatmember.Left), fn)
+	return h
 }
 
 // Banned handles when a member is banned from the chat.
 func (h *ChatMemberHandlers) Banned(fn Handler) *ChatMemberHandlers {
-	return h.StatusChange(chatmember.Member, chatmember.Kicked, fn)
+	h.handleChatMember("Banned", statusChange(chatmember.Member, chatmember.Kicked), fn)
+	return h
 }
 
 // Unbanned handles when a member is unbanned from the chat.
 func (h *ChatMemberHandlers) Unbanned(fn Handler) *ChatMemberHandlers {
-	return h.StatusChange(chatmember.Kicked, chatmember.Member, fn)
+	h.handleChatMember("Unbanned", statusChange(chatmember.Kicked, chatmember.Member), fn)
+	return h
 }
 
 // Restricted handles when a member is restricted in the chat.
 func (h *ChatMemberHandlers) Restricted(fn Handler) *ChatMemberHandlers {
-	return h.StatusChange(chatmember.Member, chatmember.Restricted, fn)
+	h.handleChatMember("Restricted", statusChange(chatmember.Member, chatmember.Restricted), fn)
+	return h
 }
 
 // Unrestricted handles when a member's restrictions are removed.
 func (h *ChatMemberHandlers) Unrestricted(fn Handler) *ChatMemberHandlers {
-	return h.StatusChange(chatmember.Restricted, chatmember.Member, fn)
+	h.handleChatMember("Unrestricted", statusChange(chatmember.Restricted, chatmember.Member), fn)
+	return h
 }
 
 // Promoted handles when a member is promoted to administrator.
 func (h *ChatMemberHandlers) Promoted(fn Handler) *ChatMemberHandlers {
-	return h.StatusChange(chatmember.Member, chatmember.Administrator, fn)
+	h.handleChatMember("Promoted", statusChange(chatmember.Member, chatmember.Administrator), fn)
+	return h
 }
 
 // Demoted handles when an administrator is demoted to regular member.
 func (h *ChatMemberHandlers) Demoted(fn Handler) *ChatMemberHandlers {
-	return h.StatusChange(chatmember.Administrator, chatmember.Member, fn)
+	h.handleChatMember("Demoted", statusChange(chatmember.Administrator, chatmember.Member), fn)
+	return h
 }
 
 // ChatID handles chat member updates in a specific chat.
 func (h *ChatMemberHandlers) ChatID(id int64, fn Handler) *ChatMemberHandlers {
-	h.handleChatMember(func(cm *gotgbot.ChatMemberUpdated) bool {
+	h.handleChatMember("ChatID", func(cm *gotgbot.ChatMemberUpdated) bool {
 		return cm != nil && cm.Chat.Id == id
-	}, fn)
+	}, fn, id)
+
 	return h
 }
 
 // UserID handles chat member updates for a specific user.
 func (h *ChatMemberHandlers) UserID(id int64, fn Handler) *ChatMemberHandlers {
-	h.handleChatMember(func(cm *gotgbot.ChatMemberUpdated) bool {
+	h.handleChatMember("UserID", func(cm *gotgbot.ChatMemberUpdated) bool {
 		return cm != nil && cm.NewChatMember.GetUser().Id == id
-	}, fn)
+	}, fn, id)
+
 	return h
 }
 
 // FromUserID handles chat member updates initiated by a specific user.
 func (h *ChatMemberHandlers) FromUserID(id int64, fn Handler) *ChatMemberHandlers {
-	h.handleChatMember(func(cm *gotgbot.ChatMemberUpdated) bool {
+	h.handleChatMember("FromUserID", func(cm *gotgbot.ChatMemberUpdated) bool {
 		return cm != nil && cm.From.Id == id
-	}, fn)
+	}, fn, id)
+
 	return h
 }
 
 // NewStatus handles chat member updates where the new status matches the specified status.
 func (h *ChatMemberHandlers) NewStatus(status chatmember.ChatMemberStatus, fn Handler) *ChatMemberHandlers {
-	h.handleChatMember(func(cm *gotgbot.ChatMemberUpdated) bool {
+	h.handleChatMember("NewStatus", func(cm *gotgbot.ChatMemberUpdated) bool {
 		return cm != nil && cm.NewChatMember.GetStatus() == status.String()
-	}, fn)
+	}, fn, status)
+
 	return h
 }
 
 // OldStatus handles chat member updates where the old status matches the specified status.
 func (h *ChatMemberHandlers) OldStatus(status chatmember.ChatMemberStatus, fn Handler) *ChatMemberHandlers {
-	h.handleChatMember(func(cm *gotgbot.ChatMemberUpdated) bool {
+	h.handleChatMember("OldStatus", func(cm *gotgbot.ChatMemberUpdated) bool {
 		return cm != nil && cm.OldChatMember.GetStatus() == status.String()
-	}, fn)
+	}, fn, status)
+
 	return h
 }
 
 // HasInviteLink handles chat member updates that include an invite link.
 func (h *ChatMemberHandlers) HasInviteLink(fn Handler) *ChatMemberHandlers {
-	h.handleChatMember(func(cm *gotgbot.ChatMemberUpdated) bool {
+	h.handleChatMember("HasInviteLink", func(cm *gotgbot.ChatMemberUpdated) bool {
 		return cm != nil && cm.InviteLink != nil
 	}, fn)
+
 	return h
 }
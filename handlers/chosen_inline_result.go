@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters"
 	"github.com/enetx/g"
@@ -9,63 +10,79 @@ import (
 )
 
 // ChosenInlineResultHandlers provides methods to handle chosen inline result events.
-type ChosenInlineResultHandlers struct{ Bot core.BotAPI }
+type ChosenInlineResultHandlers struct {
+	Bot   core.BotAPI
+	group int
+	uses  g.Slice[Middleware]
+	last  *EventHandler // Handler registered by the latest call
+}
 
 // handleChosenInlineResult registers a chosen inline result handler with the dispatcher.
-func (h *ChosenInlineResultHandlers) handleChosenInlineResult(f filters.ChosenInlineResult, fn Handler) {
-	h.Bot.Dispatcher().AddHandler(handlers.NewChosenInlineResult(f, wrap(h.Bot, middlewares(h.Bot), fn)))
+func (h *ChosenInlineResultHandlers) handleChosenInlineResult(method string, f filters.ChosenInlineResult, fn Handler, args ...any) {
+	h.last = register(h.Bot, "chosen_inline_result", h.group, h.uses, fn, describe(method, args...), func(r handlers.Response) ext.Handler {
+		return handlers.NewChosenInlineResult(f, r)
+	})
 }
 
+// Last returns the handler registered by the latest call on h, to move it to another group or remove it.
+func (h *ChosenInlineResultHandlers) Last() *EventHandler { return h.last }
+
 // Any handles all chosen inline results.
 func (h *ChosenInlineResultHandlers) Any(fn Handler) *ChosenInlineResultHandlers {
-	h.handleChosenInlineResult(nil, fn)
+	h.handleChosenInlineResult("Any", nil, fn)
 	return h
 }
 
 // FromUser handles chosen inline results from a specific user.
 func (h *ChosenInlineResultHandlers) FromUser(id int64, fn Handler) *ChosenInlineResultHandlers {
-	h.handleChosenInlineResult(func(cir *gotgbot.ChosenInlineResult) bool {
+	h.handleChosenInlineResult("FromUser", func(cir *gotgbot.ChosenInlineResult) bool {
 		return cir != nil && cir.From.Id == id
-	}, fn)
+	}, fn, id)
+
 	return h
 }
 
 // Query handles chosen inline results with a specific query string.
 func (h *ChosenInlineResultHandlers) Query(query g.String, fn Handler) *ChosenInlineResultHandlers {
-	h.handleChosenInlineResult(func(cir *gotgbot.ChosenInlineResult) bool {
+	h.handleChosenInlineResult("Query", func(cir *gotgbot.ChosenInlineResult) bool {
 		return cir != nil && cir.Query == query.Std()
-	}, fn)
+	}, fn, query)
+
 	return h
 }
 
 // QueryPrefix handles chosen inline results where query starts with the specified prefix.
 func (h *ChosenInlineResultHandlers) QueryPrefix(prefix g.String, fn Handler) *ChosenInlineResultHandlers {
-	h.handleChosenInlineResult(func(cir *gotgbot.ChosenInlineResult) bool {
+	h.handleChosenInlineResult("QueryPrefix", func(cir *gotgbot.ChosenInlineResult) bool {
 		return cir != nil && g.String(cir.Query).StartsWith(prefix)
-	}, fn)
+	}, fn, prefix)
+
 	return h
 }
 
 // QuerySuffix handles chosen inline results where query ends with the specified suffix.
 func (h *ChosenInlineResultHandlers) QuerySuffix(suffix g.String, fn Handler) *ChosenInlineResultHandlers {
-	h.handleChosenInlineResult(func(cir *gotgbot.ChosenInlineResult) bool {
+	h.handleChosenInlineResult("QuerySuffix", func(cir *gotgbot.ChosenInlineResult) bool {
 		return cir != nil && g.String(cir.Query).EndsWith(suffix)
-	}, fn)
+	}, fn, suffix)
+
 	return h
 }
 
 // InlineMessage handles chosen inline results with a specific inline message ID.
 func (h *ChosenInlineResultHandlers) InlineMessage(messageID g.String, fn Handler) *ChosenInlineResultHandlers {
-	h.handleChosenInlineResult(func(cir *gotgbot.ChosenInlineResult) bool {
+	h.handleChosenInlineResult("InlineMessage", func(cir *gotgbot.ChosenInlineResult) bool {
 		return cir != nil && cir.InlineMessageId == messageID.Std()
-	}, fn)
+	}, fn, messageID)
+
 	return h
 }
 
 // Location handles chosen inline results that include location data.
 func (h *ChosenInlineResultHandlers) Location(fn Handler) *ChosenInlineResultHandlers {
-	h.handleChosenInlineResult(func(cir *gotgbot.ChosenInlineResult) bool {
+	h.handleChosenInlineResult("Location", func(cir *gotgbot.ChosenInlineResult) bool {
 		return cir != nil && cir.Location != nil
 	}, fn)
+
 	return h
 }
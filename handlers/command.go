@@ -1,7 +1,11 @@
 package handlers
 
 import (
+	"slices"
+	"time"
+
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/enetx/fsm"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/core"
 )
@@ -12,12 +16,18 @@ type Command struct {
 	command      g.String
 	handler      Handler
 	name         g.String
+	group        int
+	uses         g.Slice[Middleware]
+	timeout      time.Duration
+	states       g.Slice[fsm.State]
 	triggers     []rune
 	allowEdited  bool
 	allowChannel bool
 }
 
 // NewCommand creates a new command handler for the specified command.
+// The handler is named after the command, so registering the same command again
+// in the same group replaces the earlier handler instead of adding a second one.
 func NewCommand(bot core.BotAPI, cmd g.String, handler Handler) *Command {
 	return &Command{
 		bot:          bot,
@@ -48,20 +58,81 @@ func (c *Command) Triggers(r ...rune) *Command {
 	return c
 }
 
+// Use adds middlewares that wrap only this command, inside the global and scope middlewares,
+// and registers the command.
+func (c *Command) Use(middlewares ...Middleware) *Command {
+	c.uses = slices.Concat(c.uses, middlewares)
+	c.Register()
+
+	return c
+}
+
+// Timeout cancels the standard context of the update, c.Std(), once the command
+// and its middlewares have been running for d, aborting its pending requests. It registers the command.
+func (c *Command) Timeout(d time.Duration) *Command {
+	c.timeout = d
+	c.Register()
+
+	return c
+}
+
+// InState restricts the command to users whose state machine, see Bot.FSM, is in one of states,
+// and registers the command. Updates of other users are left to the next matching handler.
+func (c *Command) InState(states ...fsm.State) *Command {
+	c.states = states
+	c.Register()
+
+	return c
+}
+
+// Group moves the command to the given dispatcher group and registers it there.
+// Groups are processed in ascending order and each group handles an update at most once,
+// so handlers in different groups can all react to the same update. The default group is 0.
+func (c *Command) Group(n int) *Command {
+	c.bot.Dispatcher().RemoveHandlerFromGroup(c.name.Std(), c.group)
+	c.group = n
+	c.Register()
+
+	return c
+}
+
 // Register registers the command handler with the bot dispatcher.
 func (c *Command) Register() {
-	c.bot.Dispatcher().RemoveHandlerFromGroup(c.name.Std(), 0)
+	c.bot.Dispatcher().RemoveHandlerFromGroup(c.name.Std(), c.group)
 
 	cmd := handlers.Command{
 		Triggers:     c.triggers,
 		AllowEdited:  c.allowEdited,
 		AllowChannel: c.allowChannel,
 		Command:      c.command.Std(),
-		Response:     wrap(c.bot, middlewares(c.bot), c.handler),
+		Response:     wrap(c.bot, timeout(c.timeout, chain(c.bot, c.uses)), c.handler),
 	}
 
 	c.bot.Dispatcher().AddHandlerToGroup(namedHandler{
 		name:    c.name.Std(),
-		Handler: cmd,
-	}, 0)
+		Handler: withStates(c.bot, cmd, c.states),
+	}, c.group)
+
+	registry(c.bot).put(c.Route())
+}
+
+// Route returns the description of the registered command.
+func (c *Command) Route() Route {
+	filter := c.command
+	if len(c.triggers) > 0 {
+		filter = g.String(c.triggers[:1]) + filter
+	}
+
+	return Route{
+		ID:     c.name,
+		Kind:   "command",
+		Filter: describeStates(filter, c.states),
+		Group:  c.group,
+		bot:    c.bot,
+	}
+}
+
+// Remove unregisters the command. It reports whether the command was registered.
+func (c *Command) Remove() bool {
+	return c.Route().Remove()
 }
@@ -1,6 +1,10 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 	"github.com/enetx/g"
@@ -11,32 +15,110 @@ import (
 // Handler is a function type that handles bot events and returns an error if processing fails.
 type Handler func(*ctx.Context) error
 
-// wrap creates a wrapped handler function that applies middlewares and creates a context.
-func wrap(bot core.BotAPI, middlewares g.Slice[Handler], handler Handler) func(*gotgbot.Bot, *ext.Context) error {
-	return func(_ *gotgbot.Bot, ectx *ext.Context) error {
-		c := ctx.New(bot, ectx)
+// Errors a handler can return to control which other handlers see the update.
+// They are not passed to the bot's error hook.
+var (
+	// Continue passes the update on to the next matching handler in the same group,
+	// as if the current handler had not matched.
+	Continue = ext.ContinueGroups
+
+	// Stop ends processing of the update, so handlers in later groups do not see it.
+	Stop = ext.EndGroups
+)
+
+// ErrorHandler handles an error returned by a handler or one of its middlewares.
+type ErrorHandler func(*ctx.Context, error)
+
+// PanicHandler handles a panic recovered from a handler or one of its middlewares.
+type PanicHandler func(*ctx.Context, *PanicError)
 
-		final := handler
-		for i := len(middlewares) - 1; i >= 0; i-- {
-			mw := middlewares[i]
-			next := final
-			final = func(c *ctx.Context) error {
-				if err := mw(c); err != nil {
-					return err
-				}
-				return next(c)
+// PanicError describes a panic recovered from a handler.
+type PanicError struct {
+	Value any    // Value passed to panic
+	Stack []byte // Stack trace of the panicking goroutine
+}
+
+// Error implements the error interface.
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("handler panic: %v", p.Value)
+}
+
+// ReplyOnError returns an ErrorHandler that answers the pending callback query, if any,
+// and replies with text to the chat in which the failed update happened.
+func ReplyOnError(text g.String) ErrorHandler {
+	return func(c *ctx.Context, _ error) {
+		if c.Update.CallbackQuery != nil {
+			if c.EffectiveChat == nil {
+				c.AnswerCallbackQuery(text).Send()
+				return
 			}
+
+			c.AnswerCallbackQuery("").Send()
 		}
 
-		return final(c)
+		switch {
+		case c.EffectiveMessage != nil:
+			c.Reply(text).Send()
+		case c.EffectiveChat != nil:
+			c.SendMessage(text).Send()
+		}
 	}
 }
 
-// middlewares extracts middleware handlers from the bot API if available.
-func middlewares(api core.BotAPI) g.Slice[Handler] {
-	if b, ok := api.(interface{ Middlewares() g.Slice[Handler] }); ok {
-		return b.Middlewares()
+// wrap creates a wrapped handler function that applies middlewares and creates a context.
+// The standard context of the update is derived from the bot's serving context, which is cancelled
+// when shutdown begins, so goroutines started by the handler can keep sending through it after the
+// handler returns.
+func wrap(bot core.BotAPI, middlewares g.Slice[Middleware], handler Handler) func(*gotgbot.Bot, *ext.Context) error {
+	return func(_ *gotgbot.Bot, ectx *ext.Context) error {
+		c := ctx.New(bot, ectx)
+
+		return run(bot, c, func(c *ctx.Context) error {
+			return call(c, middlewares, handler)
+		})
+	}
+}
+
+// run calls handler and passes its error or panic to the bot's error and panic hooks, if set.
+// A panic goes to the error hook as *PanicError when no panic hook is set.
+// Without hooks errors are returned and panics propagate to the dispatcher as before.
+func run(bot core.BotAPI, c *ctx.Context, handler Handler) (err error) {
+	h, ok := bot.(interface {
+		ErrorHandler() ErrorHandler
+		PanicHandler() PanicHandler
+	})
+	if !ok {
+		return handler(c)
 	}
 
+	onError, onPanic := h.ErrorHandler(), h.PanicHandler()
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		perr := &PanicError{Value: r, Stack: debug.Stack()}
+
+		switch {
+		case onPanic != nil:
+			onPanic(c, perr)
+		case onError != nil:
+			onError(c, perr)
+		default:
+			panic(r)
+		}
+
+		err = nil
+	}()
+
+	err = handler(c)
+	if err == nil || onError == nil || errors.Is(err, Continue) || errors.Is(err, Stop) {
+		return err
+	}
+
+	onError(c, err)
+
 	return nil
 }
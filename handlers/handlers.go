@@ -1,10 +1,17 @@
 package handlers
 
-import "github.com/enetx/tg/core"
+import (
+	"slices"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/core"
+)
 
 // Handlers provides a collection of all available event handlers for the bot.
 type Handlers struct {
 	bot                     core.BotAPI
+	group                   int
+	uses                    g.Slice[Middleware]
 	Message                 *MessageHandlers
 	Callback                *CallbackHandlers
 	Inline                  *InlineQueryHandlers
@@ -25,24 +32,62 @@ type Handlers struct {
 
 // NewHandlers creates a new instance of Handlers with all handler types initialized.
 func NewHandlers(bot core.BotAPI) *Handlers {
+	return newHandlers(bot, 0, nil)
+}
+
+// Group returns handlers that register into the given dispatcher group instead of group 0.
+// Groups are processed in ascending order and each group handles an update at most once,
+// so a logging handler in group -1 does not prevent the regular handlers in group 0 from running.
+//
+//	b.On.Group(-1).Message.Any(logUpdate)
+func (h *Handlers) Group(n int) *Handlers {
+	return newHandlers(h.bot, n, h.uses)
+}
+
+// Use returns handlers whose registrations are wrapped in the given middlewares,
+// after the bot's global middlewares and those already added to h.
+// Handlers registered through h itself are not affected.
+//
+//	admin := b.Scope().Use(requireAdmin)
+//	admin.Command("ban", ban)
+//	admin.Callback.Prefix("admin:", adminMenu)
+func (h *Handlers) Use(middlewares ...Middleware) *Handlers {
+	return newHandlers(h.bot, h.group, slices.Concat(h.uses, middlewares))
+}
+
+// Command registers a command handler in the group and with the middlewares of h.
+func (h *Handlers) Command(cmd g.String, fn Handler) *Command {
+	c := NewCommand(h.bot, cmd, fn)
+	c.group = h.group
+	c.uses = h.uses
+	c.Register()
+
+	return c
+}
+
+// newHandlers creates a new instance of Handlers that registers into the given dispatcher group
+// with the given scope middlewares.
+func newHandlers(bot core.BotAPI, group int, uses g.Slice[Middleware]) *Handlers {
 	return &Handlers{
 		bot:                     bot,
-		Message:                 &MessageHandlers{bot},
-		Callback:                &CallbackHandlers{bot},
-		Inline:                  &InlineQueryHandlers{bot},
-		Poll:                    &PollHandlers{bot},
-		PollAnswer:              &PollAnswerHandlers{bot},
-		ChatMember:              &ChatMemberHandlers{bot},
-		MyChatMember:            &MyChatMemberHandlers{bot},
-		ChatJoinRequest:         &ChatJoinRequestHandlers{bot},
-		ChosenInlineResult:      &ChosenInlineResultHandlers{bot},
-		Shipping:                &ShippingHandlers{bot},
-		PreCheckout:             &PreCheckoutHandlers{bot},
-		Reaction:                &ReactionHandlers{bot},
-		PaidMedia:               &PaidMediaHandlers{bot},
-		BusinessConnection:      &BusinessConnection{bot},
-		BusinessMessagesDeleted: &BusinessMessagesDeleted{bot},
-		ManagedBot:              &ManagedBot{bot},
+		group:                   group,
+		uses:                    uses,
+		Message:                 &MessageHandlers{bot, group, uses},
+		Callback:                &CallbackHandlers{bot, group, uses},
+		Inline:                  &InlineQueryHandlers{Bot: bot, group: group, uses: uses},
+		Poll:                    &PollHandlers{Bot: bot, group: group, uses: uses},
+		PollAnswer:              &PollAnswerHandlers{Bot: bot, group: group, uses: uses},
+		ChatMember:              &ChatMemberHandlers{Bot: bot, group: group, uses: uses},
+		MyChatMember:            &MyChatMemberHandlers{Bot: bot, group: group, uses: uses},
+		ChatJoinRequest:         &ChatJoinRequestHandlers{Bot: bot, group: group, uses: uses},
+		ChosenInlineResult:      &ChosenInlineResultHandlers{Bot: bot, group: group, uses: uses},
+		Shipping:                &ShippingHandlers{Bot: bot, group: group, uses: uses},
+		PreCheckout:             &PreCheckoutHandlers{Bot: bot, group: group, uses: uses},
+		Reaction:                &ReactionHandlers{Bot: bot, group: group, uses: uses},
+		PaidMedia:               &PaidMediaHandlers{Bot: bot, group: group, uses: uses},
+		BusinessConnection:      &BusinessConnection{Bot: bot, group: group, uses: uses},
+		BusinessMessagesDeleted: &BusinessMessagesDeleted{Bot: bot, group: group, uses: uses},
+		ManagedBot:              &ManagedBot{Bot: bot, group: group, uses: uses},
 	}
 }
 
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters"
 	"github.com/enetx/g"
@@ -10,95 +11,115 @@ import (
 )
 
 // InlineQueryHandlers provides methods to handle inline query events.
-type InlineQueryHandlers struct{ Bot core.BotAPI }
+type InlineQueryHandlers struct {
+	Bot   core.BotAPI
+	group int
+	uses  g.Slice[Middleware]
+	last  *EventHandler // Handler registered by the latest call
+}
 
 // handleInlineQuery registers an inline query handler with the dispatcher.
-func (h *InlineQueryHandlers) handleInlineQuery(f filters.InlineQuery, fn Handler) {
-	h.Bot.Dispatcher().AddHandler(handlers.NewInlineQuery(f, wrap(h.Bot, middlewares(h.Bot), fn)))
+func (h *InlineQueryHandlers) handleInlineQuery(method string, f filters.InlineQuery, fn Handler, args ...any) {
+	h.last = register(h.Bot, "inline_query", h.group, h.uses, fn, describe(method, args...), func(r handlers.Response) ext.Handler {
+		return handlers.NewInlineQuery(f, r)
+	})
 }
 
+// Last returns the handler registered by the latest call on h, to move it to another group or remove it.
+func (h *InlineQueryHandlers) Last() *EventHandler { return h.last }
+
 // Any handles all inline queries.
 func (h *InlineQueryHandlers) Any(fn Handler) *InlineQueryHandlers {
-	h.handleInlineQuery(nil, fn)
+	h.handleInlineQuery("Any", nil, fn)
 	return h
 }
 
 // FromUser handles inline queries from a specific user.
 func (h *InlineQueryHandlers) FromUser(id int64, fn Handler) *InlineQueryHandlers {
-	h.handleInlineQuery(func(iq *gotgbot.InlineQuery) bool {
+	h.handleInlineQuery("FromUser", func(iq *gotgbot.InlineQuery) bool {
 		return iq != nil && iq.From.Id == id
-	}, fn)
+	}, fn, id)
+
 	return h
 }
 
 // Query handles inline queries with a specific query string.
 func (h *InlineQueryHandlers) Query(query g.String, fn Handler) *InlineQueryHandlers {
-	h.handleInlineQuery(func(iq *gotgbot.InlineQuery) bool {
+	h.handleInlineQuery("Query", func(iq *gotgbot.InlineQuery) bool {
 		return iq != nil && iq.Query == query.Std()
-	}, fn)
+	}, fn, query)
+
 	return h
 }
 
 // QueryPrefix handles inline queries where query starts with the specified prefix.
 func (h *InlineQueryHandlers) QueryPrefix(prefix g.String, fn Handler) *InlineQueryHandlers {
-	h.handleInlineQuery(func(iq *gotgbot.InlineQuery) bool {
+	h.handleInlineQuery("QueryPrefix", func(iq *gotgbot.InlineQuery) bool {
 		return iq != nil && g.String(iq.Query).StartsWith(prefix)
-	}, fn)
+	}, fn, prefix)
+
 	return h
 }
 
 // QuerySuffix handles inline queries where query ends with the specified suffix.
 func (h *InlineQueryHandlers) QuerySuffix(suffix g.String, fn Handler) *InlineQueryHandlers {
-	h.handleInlineQuery(func(iq *gotgbot.InlineQuery) bool {
+	h.handleInlineQuery("QuerySuffix", func(iq *gotgbot.InlineQuery) bool {
 		return iq != nil && g.String(iq.Query).EndsWith(suffix)
-	}, fn)
+	}, fn, suffix)
+
 	return h
 }
 
 // Location handles inline queries that include location data.
 func (h *InlineQueryHandlers) Location(fn Handler) *InlineQueryHandlers {
-	h.handleInlineQuery(func(iq *gotgbot.InlineQuery) bool {
+	h.handleInlineQuery("Location", func(iq *gotgbot.InlineQuery) bool {
 		return iq != nil && iq.Location != nil
 	}, fn)
+
 	return h
 }
 
 // Sender handles inline queries from sender chat type.
 func (h *InlineQueryHandlers) Sender(fn Handler) *InlineQueryHandlers {
-	h.handleInlineQuery(func(iq *gotgbot.InlineQuery) bool {
+	h.handleInlineQuery("Sender", func(iq *gotgbot.InlineQuery) bool {
 		return iq != nil && iq.ChatType == chat.Sender.String()
 	}, fn)
+
 	return h
 }
 
 // Private handles inline queries from private chats.
 func (h *InlineQueryHandlers) Private(fn Handler) *InlineQueryHandlers {
-	h.handleInlineQuery(func(iq *gotgbot.InlineQuery) bool {
+	h.handleInlineQuery("Private", func(iq *gotgbot.InlineQuery) bool {
 		return iq != nil && iq.ChatType == chat.Private.String()
 	}, fn)
+
 	return h
 }
 
 // Group handles inline queries from group chats.
 func (h *InlineQueryHandlers) Group(fn Handler) *InlineQueryHandlers {
-	h.handleInlineQuery(func(iq *gotgbot.InlineQuery) bool {
+	h.handleInlineQuery("Group", func(iq *gotgbot.InlineQuery) bool {
 		return iq != nil && iq.ChatType == chat.Group.String()
 	}, fn)
+
 	return h
 }
 
 // Supergroup handles inline queries from supergroup chats.
 func (h *InlineQueryHandlers) Supergroup(fn Handler) *InlineQueryHandlers {
-	h.handleInlineQuery(func(iq *gotgbot.InlineQuery) bool {
+	h.handleInlineQuery("Supergroup", func(iq *gotgbot.InlineQuery) bool {
 		return iq != nil && iq.ChatType == chat.Supergroup.String()
 	}, fn)
+
 	return h
 }
 
 // Channel handles inline queries from channels.
 func (h *InlineQueryHandlers) Channel(fn Handler) *InlineQueryHandlers {
-	h.handleInlineQuery(func(iq *gotgbot.InlineQuery) bool {
+	h.handleInlineQuery("Channel", func(iq *gotgbot.InlineQuery) bool {
 		return iq != nil && iq.ChatType == chat.Channel.String()
 	}, fn)
+
 	return h
 }
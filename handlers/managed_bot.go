@@ -2,13 +2,20 @@ package handlers
 
 import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters"
+	"github.com/enetx/g"
 	"github.com/enetx/tg/core"
 )
 
 // ManagedBot provides methods to handle managed-bot creation, token update, or owner update events.
-type ManagedBot struct{ Bot core.BotAPI }
+type ManagedBot struct {
+	Bot   core.BotAPI
+	group int
+	uses  g.Slice[Middleware]
+	last  *EventHandler // Handler registered by the latest call
+}
 
 // newManagedBot creates a new managed-bot handler with the given filter and response.
 func newManagedBot(f filters.ManagedBot, r handlers.Response) handlers.ManagedBot {
@@ -19,28 +26,35 @@ func newManagedBot(f filters.ManagedBot, r handlers.Response) handlers.ManagedBo
 }
 
 // handleManagedBot registers a managed-bot handler with the dispatcher.
-func (h *ManagedBot) handleManagedBot(f filters.ManagedBot, fn Handler) {
-	h.Bot.Dispatcher().AddHandler(newManagedBot(f, wrap(h.Bot, middlewares(h.Bot), fn)))
+func (h *ManagedBot) handleManagedBot(method string, f filters.ManagedBot, fn Handler, args ...any) {
+	h.last = register(h.Bot, "managed_bot", h.group, h.uses, fn, describe(method, args...), func(r handlers.Response) ext.Handler {
+		return newManagedBot(f, r)
+	})
 }
 
+// Last returns the handler registered by the latest call on h, to move it to another group or remove it.
+func (h *ManagedBot) Last() *EventHandler { return h.last }
+
 // Any handles all managed-bot updates.
 func (h *ManagedBot) Any(fn Handler) *ManagedBot {
-	h.handleManagedBot(nil, fn)
+	h.handleManagedBot("Any", nil, fn)
 	return h
 }
 
 // OwnedByUserID handles updates only for managed bots created by the given owner user ID.
 func (h *ManagedBot) OwnedByUserID(userID int64, fn Handler) *ManagedBot {
-	h.handleManagedBot(func(mbu *gotgbot.ManagedBotUpdated) bool {
+	h.handleManagedBot("OwnedByUserID", func(mbu *gotgbot.ManagedBotUpdated) bool {
 		return mbu.User.Id == userID
-	}, fn)
+	}, fn, userID)
+
 	return h
 }
 
 // AboutBotID handles updates only for the managed bot identified by the given bot ID.
 func (h *ManagedBot) AboutBotID(botID int64, fn Handler) *ManagedBot {
-	h.handleManagedBot(func(mbu *gotgbot.ManagedBotUpdated) bool {
+	h.handleManagedBot("AboutBotID", func(mbu *gotgbot.ManagedBotUpdated) bool {
 		return mbu.Bot.Id == botID
-	}, fn)
+	}, fn, botID)
+
 	return h
 }
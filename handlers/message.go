@@ -1,13 +1,14 @@
 package handlers
 
 import (
-	"fmt"
-	"reflect"
 	"regexp"
+	"slices"
+	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters"
+	"github.com/enetx/fsm"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/core"
 	"github.com/enetx/tg/types/chat"
@@ -20,6 +21,11 @@ type MessageHandler struct {
 	filter        filters.Message
 	handler       Handler
 	name          string
+	desc          g.String
+	group         int
+	uses          g.Slice[Middleware]
+	timeout       time.Duration
+	states        g.Slice[fsm.State]
 	allowEdited   bool
 	allowChannel  bool
 	allowBusiness bool
@@ -43,251 +49,300 @@ func (h *MessageHandler) AllowBusiness() *MessageHandler {
 	return h
 }
 
+// Use adds middlewares that wrap only this handler, inside the global and scope middlewares.
+func (h *MessageHandler) Use(middlewares ...Middleware) *MessageHandler {
+	h.uses = slices.Concat(h.uses, middlewares)
+	return h.Register()
+}
+
+// Timeout cancels the standard context of the update, c.Std(), once the handler
+// and its middlewares have been running for d, aborting its pending requests.
+func (h *MessageHandler) Timeout(d time.Duration) *MessageHandler {
+	h.timeout = d
+	return h.Register()
+}
+
+// InState restricts the handler to users whose state machine, see Bot.FSM, is in one of states.
+// Updates of other users are left to the next matching handler.
+func (h *MessageHandler) InState(states ...fsm.State) *MessageHandler {
+	h.states = states
+	return h.Register()
+}
+
+// Group moves the handler to the given dispatcher group.
+// Groups are processed in ascending order and each group handles an update at most once,
+// so handlers in different groups can all react to the same update. The default group is 0.
+func (h *MessageHandler) Group(n int) *MessageHandler {
+	h.bot.Dispatcher().RemoveHandlerFromGroup(h.name, h.group)
+	h.group = n
+
+	return h.Register()
+}
+
 // Register registers the message handler with the bot dispatcher.
 func (h *MessageHandler) Register() *MessageHandler {
-	h.bot.Dispatcher().RemoveHandlerFromGroup(h.name, 0)
+	h.bot.Dispatcher().RemoveHandlerFromGroup(h.name, h.group)
 
 	m := handlers.Message{
 		AllowEdited:   h.allowEdited,
 		AllowChannel:  h.allowChannel,
 		AllowBusiness: h.allowBusiness,
 		Filter:        h.filter,
-		Response:      wrap(h.bot, middlewares(h.bot), h.handler),
+		Response:      wrap(h.bot, timeout(h.timeout, chain(h.bot, h.uses)), h.handler),
 	}
 
-	h.bot.Dispatcher().AddHandlerToGroup(namedHandler{h.name, m}, 0)
+	h.bot.Dispatcher().AddHandlerToGroup(namedHandler{h.name, withStates(h.bot, m, h.states)}, h.group)
+	registry(h.bot).put(h.Route())
 
 	return h
 }
 
+// Route returns the description of the registered handler.
+func (h *MessageHandler) Route() Route {
+	return Route{ID: g.String(h.name), Kind: "message", Filter: describeStates(h.desc, h.states), Group: h.group, bot: h.bot}
+}
+
+// Remove unregisters the handler. It reports whether the handler was registered.
+func (h *MessageHandler) Remove() bool {
+	return h.Route().Remove()
+}
+
 // MessageHandlers provides methods to handle message events with various filters.
-type MessageHandlers struct{ Bot core.BotAPI }
+type MessageHandlers struct {
+	Bot   core.BotAPI
+	group int
+	uses  g.Slice[Middleware]
+}
 
 // handleMessage creates and registers a new message handler with the specified filter.
-func (h *MessageHandlers) handleMessage(f filters.Message, fn Handler) *MessageHandler {
+func (h *MessageHandlers) handleMessage(method string, f filters.Message, fn Handler, args ...any) *MessageHandler {
 	return (&MessageHandler{
 		bot:     h.Bot,
 		filter:  f,
 		handler: fn,
-		name:    fmt.Sprintf("message_%x_%x", reflect.ValueOf(f).Pointer(), reflect.ValueOf(fn).Pointer()),
+		group:   h.group,
+		uses:    h.uses,
+		name:    newID("message"),
+		desc:    describe(method, args...),
 	}).Register()
 }
 
 // Any handles all messages.
-func (h *MessageHandlers) Any(fn Handler) *MessageHandler { return h.handleMessage(nil, fn) }
+func (h *MessageHandlers) Any(fn Handler) *MessageHandler { return h.handleMessage("Any", nil, fn) }
 
 // Text handles messages that contain text.
 func (h *MessageHandlers) Text(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Text != "" }, fn)
+	return h.handleMessage("Text", func(msg *gotgbot.Message) bool { return msg.Text != "" }, fn)
 }
 
 // Location handles messages that contain location data.
 func (h *MessageHandlers) Location(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Location != nil }, fn)
+	return h.handleMessage("Location", func(msg *gotgbot.Message) bool { return msg.Location != nil }, fn)
 }
 
 // Contact handles messages that contain contact information.
 func (h *MessageHandlers) Contact(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Contact != nil }, fn)
+	return h.handleMessage("Contact", func(msg *gotgbot.Message) bool { return msg.Contact != nil }, fn)
 }
 
 // Poll handles messages that contain polls.
 func (h *MessageHandlers) Poll(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Poll != nil }, fn)
+	return h.handleMessage("Poll", func(msg *gotgbot.Message) bool { return msg.Poll != nil }, fn)
 }
 
 // Photo handles messages that contain photos.
 func (h *MessageHandlers) Photo(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return len(msg.Photo) > 0 }, fn)
+	return h.handleMessage("Photo", func(msg *gotgbot.Message) bool { return len(msg.Photo) > 0 }, fn)
 }
 
 // Voice handles messages that contain voice recordings.
 func (h *MessageHandlers) Voice(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Voice != nil }, fn)
+	return h.handleMessage("Voice", func(msg *gotgbot.Message) bool { return msg.Voice != nil }, fn)
 }
 
 // Video handles messages that contain videos.
 func (h *MessageHandlers) Video(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Video != nil }, fn)
+	return h.handleMessage("Video", func(msg *gotgbot.Message) bool { return msg.Video != nil }, fn)
 }
 
 // Audio handles messages that contain audio files.
 func (h *MessageHandlers) Audio(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Audio != nil }, fn)
+	return h.handleMessage("Audio", func(msg *gotgbot.Message) bool { return msg.Audio != nil }, fn)
 }
 
 // Sticker handles messages that contain stickers.
 func (h *MessageHandlers) Sticker(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Sticker != nil }, fn)
+	return h.handleMessage("Sticker", func(msg *gotgbot.Message) bool { return msg.Sticker != nil }, fn)
 }
 
 // Document handles messages that contain documents.
 func (h *MessageHandlers) Document(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Document != nil }, fn)
+	return h.handleMessage("Document", func(msg *gotgbot.Message) bool { return msg.Document != nil }, fn)
 }
 
 // Reply handles messages that are replies to other messages.
 func (h *MessageHandlers) Reply(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.ReplyToMessage != nil }, fn)
+	return h.handleMessage("Reply", func(msg *gotgbot.Message) bool { return msg.ReplyToMessage != nil }, fn)
 }
 
 // Animation handles messages that contain animations (GIFs).
 func (h *MessageHandlers) Animation(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Animation != nil }, fn)
+	return h.handleMessage("Animation", func(msg *gotgbot.Message) bool { return msg.Animation != nil }, fn)
 }
 
 // VideoNote handles messages that contain video notes (voice messages).
 func (h *MessageHandlers) VideoNote(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.VideoNote != nil }, fn)
+	return h.handleMessage("VideoNote", func(msg *gotgbot.Message) bool { return msg.VideoNote != nil }, fn)
 }
 
 // Dice handles messages that contain dice.
 func (h *MessageHandlers) Dice(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Dice != nil }, fn)
+	return h.handleMessage("Dice", func(msg *gotgbot.Message) bool { return msg.Dice != nil }, fn)
 }
 
 // Game handles messages that contain games.
 func (h *MessageHandlers) Game(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Game != nil }, fn)
+	return h.handleMessage("Game", func(msg *gotgbot.Message) bool { return msg.Game != nil }, fn)
 }
 
 // Venue handles messages that contain venue information.
 func (h *MessageHandlers) Venue(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Venue != nil }, fn)
+	return h.handleMessage("Venue", func(msg *gotgbot.Message) bool { return msg.Venue != nil }, fn)
 }
 
 // NewChatMembers handles messages about new chat members joining.
 func (h *MessageHandlers) NewChatMembers(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.NewChatMembers != nil }, fn)
+	return h.handleMessage("NewChatMembers", func(msg *gotgbot.Message) bool { return msg.NewChatMembers != nil }, fn)
 }
 
 // LeftChatMember handles messages about chat members leaving.
 func (h *MessageHandlers) LeftChatMember(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.LeftChatMember != nil }, fn)
+	return h.handleMessage("LeftChatMember", func(msg *gotgbot.Message) bool { return msg.LeftChatMember != nil }, fn)
 }
 
 // PinnedMessage handles messages about pinned messages.
 func (h *MessageHandlers) PinnedMessage(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.PinnedMessage != nil }, fn)
+	return h.handleMessage("PinnedMessage", func(msg *gotgbot.Message) bool { return msg.PinnedMessage != nil }, fn)
 }
 
 // ViaBot handles messages sent via bots.
 func (h *MessageHandlers) ViaBot(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.ViaBot != nil }, fn)
+	return h.handleMessage("ViaBot", func(msg *gotgbot.Message) bool { return msg.ViaBot != nil }, fn)
 }
 
 // Entities handles messages that contain text entities.
 func (h *MessageHandlers) Entities(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return len(msg.Entities) > 0 }, fn)
+	return h.handleMessage("Entities", func(msg *gotgbot.Message) bool { return len(msg.Entities) > 0 }, fn)
 }
 
 // Caption handles messages that contain captions.
 func (h *MessageHandlers) Caption(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Caption != "" }, fn)
+	return h.handleMessage("Caption", func(msg *gotgbot.Message) bool { return msg.Caption != "" }, fn)
 }
 
 // CaptionEntities handles messages that contain caption entities.
 func (h *MessageHandlers) CaptionEntities(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return len(msg.CaptionEntities) > 0 }, fn)
+	return h.handleMessage("CaptionEntities", func(msg *gotgbot.Message) bool { return len(msg.CaptionEntities) > 0 }, fn)
 }
 
 // Migrate handles messages about chat migrations.
 func (h *MessageHandlers) Migrate(fn Handler) *MessageHandler {
-	return h.handleMessage(
+	return h.handleMessage("Migrate",
 		func(msg *gotgbot.Message) bool { return msg.MigrateFromChatId != 0 || msg.MigrateToChatId != 0 }, fn)
 }
 
 // MediaGroup handles messages that are part of a media group.
 func (h *MessageHandlers) MediaGroup(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.MediaGroupId != "" }, fn)
+	return h.handleMessage("MediaGroup", func(msg *gotgbot.Message) bool { return msg.MediaGroupId != "" }, fn)
 }
 
 // IsAutomaticForward handles messages that are automatic forwards.
 func (h *MessageHandlers) IsAutomaticForward(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.IsAutomaticForward }, fn)
+	return h.handleMessage("IsAutomaticForward", func(msg *gotgbot.Message) bool { return msg.IsAutomaticForward }, fn)
 }
 
 // UsersShared handles messages about shared users.
 func (h *MessageHandlers) UsersShared(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.UsersShared != nil }, fn)
+	return h.handleMessage("UsersShared", func(msg *gotgbot.Message) bool { return msg.UsersShared != nil }, fn)
 }
 
 // ChatShared handles messages about shared chats.
 func (h *MessageHandlers) ChatShared(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.ChatShared != nil }, fn)
+	return h.handleMessage("ChatShared", func(msg *gotgbot.Message) bool { return msg.ChatShared != nil }, fn)
 }
 
 // Story handles messages that contain stories.
 func (h *MessageHandlers) Story(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Story != nil }, fn)
+	return h.handleMessage("Story", func(msg *gotgbot.Message) bool { return msg.Story != nil }, fn)
 }
 
 // TopicCreated handles messages about forum topic creation.
 func (h *MessageHandlers) TopicCreated(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.ForumTopicCreated != nil }, fn)
+	return h.handleMessage("TopicCreated", func(msg *gotgbot.Message) bool { return msg.ForumTopicCreated != nil }, fn)
 }
 
 // TopicEdited handles messages about forum topic edits.
 func (h *MessageHandlers) TopicEdited(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.ForumTopicEdited != nil }, fn)
+	return h.handleMessage("TopicEdited", func(msg *gotgbot.Message) bool { return msg.ForumTopicEdited != nil }, fn)
 }
 
 // TopicClosed handles messages about forum topic closure.
 func (h *MessageHandlers) TopicClosed(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.ForumTopicClosed != nil }, fn)
+	return h.handleMessage("TopicClosed", func(msg *gotgbot.Message) bool { return msg.ForumTopicClosed != nil }, fn)
 }
 
 // TopicReopened handles messages about forum topic reopening.
 func (h *MessageHandlers) TopicReopened(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.ForumTopicReopened != nil }, fn)
+	return h.handleMessage("TopicReopened", func(msg *gotgbot.Message) bool { return msg.ForumTopicReopened != nil }, fn)
 }
 
 // SuccessfulPayment handles messages about successful payments.
 func (h *MessageHandlers) SuccessfulPayment(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.SuccessfulPayment != nil }, fn)
+	return h.handleMessage("SuccessfulPayment", func(msg *gotgbot.Message) bool { return msg.SuccessfulPayment != nil }, fn)
 }
 
 // RefundedPayment handles messages about refunded payments.
 func (h *MessageHandlers) RefundedPayment(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.RefundedPayment != nil }, fn)
+	return h.handleMessage("RefundedPayment", func(msg *gotgbot.Message) bool { return msg.RefundedPayment != nil }, fn)
 }
 
 // Checklist handles messages that contain checklists.
 func (h *MessageHandlers) Checklist(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Checklist != nil }, fn)
+	return h.handleMessage("Checklist", func(msg *gotgbot.Message) bool { return msg.Checklist != nil }, fn)
 }
 
 // ChatOwnerLeft handles service messages indicating the chat owner has left.
 func (h *MessageHandlers) ChatOwnerLeft(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.ChatOwnerLeft != nil }, fn)
+	return h.handleMessage("ChatOwnerLeft", func(msg *gotgbot.Message) bool { return msg.ChatOwnerLeft != nil }, fn)
 }
 
 // ChatOwnerChanged handles service messages indicating a chat ownership transfer.
 func (h *MessageHandlers) ChatOwnerChanged(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.ChatOwnerChanged != nil }, fn)
+	return h.handleMessage("ChatOwnerChanged", func(msg *gotgbot.Message) bool { return msg.ChatOwnerChanged != nil }, fn)
 }
 
 // FromUser handles messages from a specific user ID.
 func (h *MessageHandlers) FromUser(id int64, fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.From != nil && msg.From.Id == id }, fn)
+	return h.handleMessage("FromUser", func(msg *gotgbot.Message) bool { return msg.From != nil && msg.From.Id == id }, fn, id)
 }
 
 // FromUsername handles messages from a specific username.
 func (h *MessageHandlers) FromUsername(name g.String, fn Handler) *MessageHandler {
-	return h.handleMessage(
+	return h.handleMessage("FromUsername",
 		func(msg *gotgbot.Message) bool { return msg.From != nil && msg.From.Username == name.Std() },
 		fn,
+		name,
 	)
 }
 
 // Forwarded handles messages that are forwarded from other chats.
 func (h *MessageHandlers) Forwarded(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.ForwardOrigin != nil }, fn)
+	return h.handleMessage("Forwarded", func(msg *gotgbot.Message) bool { return msg.ForwardOrigin != nil }, fn)
 }
 
 // ForwardFromUser handles messages forwarded from a specific user.
 func (h *MessageHandlers) ForwardFromUser(id int64, fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool {
+	return h.handleMessage("ForwardFromUser", func(msg *gotgbot.Message) bool {
 		if msg.ForwardOrigin == nil {
 			return false
 		}
@@ -295,12 +350,12 @@ func (h *MessageHandlers) ForwardFromUser(id int64, fn Handler) *MessageHandler
 		u := msg.ForwardOrigin.MergeMessageOrigin().SenderUser
 
 		return u != nil && u.Id == id
-	}, fn)
+	}, fn, id)
 }
 
 // ForwardFromChat handles messages forwarded from a specific chat.
 func (h *MessageHandlers) ForwardFromChat(id int64, fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool {
+	return h.handleMessage("ForwardFromChat", func(msg *gotgbot.Message) bool {
 		if msg.ForwardOrigin == nil {
 			return false
 		}
@@ -308,174 +363,179 @@ func (h *MessageHandlers) ForwardFromChat(id int64, fn Handler) *MessageHandler
 		c := msg.ForwardOrigin.MergeMessageOrigin().Chat
 
 		return c != nil && c.Id == id
-	}, fn)
+	}, fn, id)
 }
 
 // Prefix handles messages where text starts with the specified prefix.
 func (h *MessageHandlers) Prefix(prefix g.String, fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return g.String(msg.GetText()).StartsWith(prefix) }, fn)
+	return h.handleMessage("Prefix", func(msg *gotgbot.Message) bool { return g.String(msg.GetText()).StartsWith(prefix) }, fn, prefix)
 }
 
 // Suffix handles messages where text ends with the specified suffix.
 func (h *MessageHandlers) Suffix(suffix g.String, fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return g.String(msg.GetText()).EndsWith(suffix) }, fn)
+	return h.handleMessage("Suffix", func(msg *gotgbot.Message) bool { return g.String(msg.GetText()).EndsWith(suffix) }, fn, suffix)
 }
 
 // Contains handles messages where text contains the specified substring.
 func (h *MessageHandlers) Contains(substr g.String, fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return g.String(msg.GetText()).Contains(substr) }, fn)
+	return h.handleMessage("Contains", func(msg *gotgbot.Message) bool { return g.String(msg.GetText()).Contains(substr) }, fn, substr)
 }
 
 // Equal handles messages where text exactly matches the specified string.
 func (h *MessageHandlers) Equal(str g.String, fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return g.String(msg.GetText()).Eq(str) }, fn)
+	return h.handleMessage("Equal", func(msg *gotgbot.Message) bool { return g.String(msg.GetText()).Eq(str) }, fn, str)
 }
 
 // MatchRegex handles messages where text matches the specified regular expression.
 func (h *MessageHandlers) MatchRegex(pattern *regexp.Regexp, fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return pattern.MatchString(msg.GetText()) }, fn)
+	return h.handleMessage("MatchRegex", func(msg *gotgbot.Message) bool { return pattern.MatchString(msg.GetText()) }, fn, pattern)
 }
 
 // ChatUsername handles messages from a chat with the specified username.
 func (h *MessageHandlers) ChatUsername(name g.String, fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Chat.Username == name.Std() }, fn)
+	return h.handleMessage("ChatUsername", func(msg *gotgbot.Message) bool { return msg.Chat.Username == name.Std() }, fn, name)
 }
 
 // ChatID handles messages from a specific chat ID.
 func (h *MessageHandlers) ChatID(id int64, fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Chat.Id == id }, fn)
+	return h.handleMessage("ChatID", func(msg *gotgbot.Message) bool { return msg.Chat.Id == id }, fn, id)
 }
 
 // ChatType handles messages from chats of the specified type.
 func (h *MessageHandlers) ChatType(chattype chat.ChatType, fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Chat.Type == chattype.String() }, fn)
+	return h.handleMessage("ChatType", chatType(chattype), fn, chattype)
 }
 
 // Private handles messages from private chats.
 func (h *MessageHandlers) Private(fn Handler) *MessageHandler {
-	return h.ChatType(chat.Private, fn)
+	return h.handleMessage("Private", chatType(chat.Private), fn)
 }
 
 // Group handles messages from group chats.
 func (h *MessageHandlers) Group(fn Handler) *MessageHandler {
-	return h.ChatType(chat.Group, fn)
+	return h.handleMessage("Group", chatType(chat.Group), fn)
 }
 
 // Supergroup handles messages from supergroup chats.
 func (h *MessageHandlers) Supergroup(fn Handler) *MessageHandler {
-	return h.ChatType(chat.Supergroup, fn)
+	return h.handleMessage("Supergroup", chatType(chat.Supergroup), fn)
 }
 
 // Channel handles messages from channels.
 func (h *MessageHandlers) Channel(fn Handler) *MessageHandler {
-	return h.ChatType(chat.Channel, fn)
+	return h.handleMessage("Channel", chatType(chat.Channel), fn)
+}
+
+// chatType returns a filter for messages from chats of the given type.
+func chatType(chattype chat.ChatType) filters.Message {
+	return func(msg *gotgbot.Message) bool { return msg.Chat.Type == chattype.String() }
 }
 
 // Business handles messages from business connections.
 func (h *MessageHandlers) Business(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.BusinessConnectionId != "" }, fn)
+	return h.handleMessage("Business", func(msg *gotgbot.Message) bool { return msg.BusinessConnectionId != "" }, fn)
 }
 
 // WebAppData handles messages that contain web app data.
 func (h *MessageHandlers) WebAppData(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.WebAppData != nil }, fn)
+	return h.handleMessage("WebAppData", func(msg *gotgbot.Message) bool { return msg.WebAppData != nil }, fn)
 }
 
 // Entity handles messages that contain the specified entity type.
 func (h *MessageHandlers) Entity(entType entity.EntityType, fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool {
+	return h.handleMessage("Entity", func(msg *gotgbot.Message) bool {
 		return g.Slice[gotgbot.MessageEntity](msg.Entities).Iter().
 			Any(func(ent gotgbot.MessageEntity) bool { return ent.Type == entType.String() })
-	}, fn)
+	}, fn, entType)
 }
 
 // CaptionEntity handles messages that contain the specified entity type in captions.
 func (h *MessageHandlers) CaptionEntity(entType entity.EntityType, fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool {
+	return h.handleMessage("CaptionEntity", func(msg *gotgbot.Message) bool {
 		return g.Slice[gotgbot.MessageEntity](msg.CaptionEntities).Iter().
 			Any(func(ent gotgbot.MessageEntity) bool { return ent.Type == entType.String() })
-	}, fn)
+	}, fn, entType)
 }
 
 // DiceValue handles messages with dice that have the specified value.
 func (h *MessageHandlers) DiceValue(val int64, fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.Dice != nil && msg.Dice.Value == val }, fn)
+	return h.handleMessage("DiceValue", func(msg *gotgbot.Message) bool { return msg.Dice != nil && msg.Dice.Value == val }, fn, val)
 }
 
 // SuccessfulPaymentPrefix handles successful payment messages with payload starting with the specified prefix.
 func (h *MessageHandlers) SuccessfulPaymentPrefix(prefix g.String, fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool {
+	return h.handleMessage("SuccessfulPaymentPrefix", func(msg *gotgbot.Message) bool {
 		return msg.SuccessfulPayment != nil && g.String(msg.SuccessfulPayment.InvoicePayload).StartsWith(prefix)
-	}, fn)
+	}, fn, prefix)
 }
 
 // RefundedPaymentPrefix handles refunded payment messages with payload starting with the specified prefix.
 func (h *MessageHandlers) RefundedPaymentPrefix(prefix g.String, fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool {
+	return h.handleMessage("RefundedPaymentPrefix", func(msg *gotgbot.Message) bool {
 		return msg.RefundedPayment != nil && g.String(msg.RefundedPayment.InvoicePayload).StartsWith(prefix)
-	}, fn)
+	}, fn, prefix)
 }
 
 // ChecklistTitleContains handles checklist messages where title contains the specified substring.
 func (h *MessageHandlers) ChecklistTitleContains(substr g.String, fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool {
+	return h.handleMessage("ChecklistTitleContains", func(msg *gotgbot.Message) bool {
 		return msg.Checklist != nil && g.String(msg.Checklist.Title).Contains(substr)
-	}, fn)
+	}, fn, substr)
 }
 
 // ChecklistTitleEquals handles checklist messages where title exactly matches the specified string.
 func (h *MessageHandlers) ChecklistTitleEquals(title g.String, fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool {
+	return h.handleMessage("ChecklistTitleEquals", func(msg *gotgbot.Message) bool {
 		return msg.Checklist != nil && msg.Checklist.Title == title.Std()
-	}, fn)
+	}, fn, title)
 }
 
 // DirectMessagesTopic handles messages with direct messages topic information.
 func (h *MessageHandlers) DirectMessagesTopic(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.DirectMessagesTopic != nil }, fn)
+	return h.handleMessage("DirectMessagesTopic", func(msg *gotgbot.Message) bool { return msg.DirectMessagesTopic != nil }, fn)
 }
 
 // SuggestedPostInfo handles messages with suggested post information.
 func (h *MessageHandlers) SuggestedPostInfo(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.SuggestedPostInfo != nil }, fn)
+	return h.handleMessage("SuggestedPostInfo", func(msg *gotgbot.Message) bool { return msg.SuggestedPostInfo != nil }, fn)
 }
 
 // SuggestedPostApproved handles service messages about approved suggested posts.
 func (h *MessageHandlers) SuggestedPostApproved(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.SuggestedPostApproved != nil }, fn)
+	return h.handleMessage("SuggestedPostApproved", func(msg *gotgbot.Message) bool { return msg.SuggestedPostApproved != nil }, fn)
 }
 
 // SuggestedPostApprovalFailed handles service messages about failed suggested post approvals.
 func (h *MessageHandlers) SuggestedPostApprovalFailed(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.SuggestedPostApprovalFailed != nil }, fn)
+	return h.handleMessage("SuggestedPostApprovalFailed", func(msg *gotgbot.Message) bool { return msg.SuggestedPostApprovalFailed != nil }, fn)
 }
 
 // SuggestedPostDeclined handles service messages about declined suggested posts.
 func (h *MessageHandlers) SuggestedPostDeclined(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.SuggestedPostDeclined != nil }, fn)
+	return h.handleMessage("SuggestedPostDeclined", func(msg *gotgbot.Message) bool { return msg.SuggestedPostDeclined != nil }, fn)
 }
 
 // SuggestedPostPaid handles service messages about paid suggested posts.
 func (h *MessageHandlers) SuggestedPostPaid(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.SuggestedPostPaid != nil }, fn)
+	return h.handleMessage("SuggestedPostPaid", func(msg *gotgbot.Message) bool { return msg.SuggestedPostPaid != nil }, fn)
 }
 
 // SuggestedPostRefunded handles service messages about refunded suggested posts.
 func (h *MessageHandlers) SuggestedPostRefunded(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.SuggestedPostRefunded != nil }, fn)
+	return h.handleMessage("SuggestedPostRefunded", func(msg *gotgbot.Message) bool { return msg.SuggestedPostRefunded != nil }, fn)
 }
 
 // PaidPost handles messages that are paid posts.
 func (h *MessageHandlers) PaidPost(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.IsPaidPost }, fn)
+	return h.handleMessage("PaidPost", func(msg *gotgbot.Message) bool { return msg.IsPaidPost }, fn)
 }
 
 // ChecklistTasksDone handles service messages about completed checklist tasks.
 func (h *MessageHandlers) ChecklistTasksDone(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.ChecklistTasksDone != nil }, fn)
+	return h.handleMessage("ChecklistTasksDone", func(msg *gotgbot.Message) bool { return msg.ChecklistTasksDone != nil }, fn)
 }
 
 // ChecklistTasksAdded handles service messages about added checklist tasks.
 func (h *MessageHandlers) ChecklistTasksAdded(fn Handler) *MessageHandler {
-	return h.handleMessage(func(msg *gotgbot.Message) bool { return msg.ChecklistTasksAdded != nil }, fn)
+	return h.handleMessage("ChecklistTasksAdded", func(msg *gotgbot.Message) bool { return msg.ChecklistTasksAdded != nil }, fn)
 }
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/core"
+	"github.com/enetx/tg/ctx"
+)
+
+// Middleware wraps a handler. It runs code before and after the rest of the chain by calling next,
+// or skips the handler by not calling it. The error returned by next comes from the inner
+// middlewares and the handler, and can be inspected, replaced or passed on.
+//
+//	func Timing(c *ctx.Context, next func() error) error {
+//		start := time.Now()
+//		err := next()
+//		log.Printf("update %d handled in %s", c.Update.UpdateId, time.Since(start))
+//		return err
+//	}
+type Middleware func(c *ctx.Context, next func() error) error
+
+// Before adapts a Handler used as middleware to the Middleware signature.
+// The handler runs before the rest of the chain, which is skipped if it returns an error.
+func Before(h Handler) Middleware {
+	return func(c *ctx.Context, next func() error) error {
+		if err := h(c); err != nil {
+			return err
+		}
+
+		return next()
+	}
+}
+
+// Timeout returns a middleware that cancels the standard context of the update after d.
+// Requests sent through the context fail once it expires; long-running handlers should watch c.Std().Done().
+// The context is not cancelled when the handler returns earlier, so goroutines it started keep it until d.
+func Timeout(d time.Duration) Middleware {
+	return func(c *ctx.Context, next func() error) error {
+		prev := c.Std()
+		// The timer of the context releases it at the deadline, so cancel is not needed.
+		std, cancel := context.WithTimeout(prev, d)
+		_ = cancel
+
+		c.SetStd(std)
+		defer c.SetStd(prev)
+
+		return next()
+	}
+}
+
+// timeout puts a Timeout middleware in front of middlewares if d is positive,
+// so that the deadline covers the whole chain.
+func timeout(d time.Duration, middlewares g.Slice[Middleware]) g.Slice[Middleware] {
+	if d <= 0 {
+		return middlewares
+	}
+
+	return slices.Concat(g.Slice[Middleware]{Timeout(d)}, middlewares)
+}
+
+// call runs middlewares in order around handler.
+func call(c *ctx.Context, middlewares g.Slice[Middleware], handler Handler) error {
+	if len(middlewares) == 0 {
+		return handler(c)
+	}
+
+	return middlewares[0](c, func() error { return call(c, middlewares[1:], handler) })
+}
+
+// chain returns the global middlewares of the bot followed by the scoped ones.
+func chain(api core.BotAPI, scoped g.Slice[Middleware]) g.Slice[Middleware] {
+	return slices.Concat(middlewares(api), scoped)
+}
+
+// middlewares extracts the global middlewares from the bot API if available.
+// Bots that only provide Handler middlewares have them adapted with Before.
+func middlewares(api core.BotAPI) g.Slice[Middleware] {
+	if b, ok := api.(interface{ Chain() g.Slice[Middleware] }); ok {
+		return b.Chain()
+	}
+
+	if b, ok := api.(interface{ Middlewares() g.Slice[Handler] }); ok {
+		return g.TransformSlice(b.Middlewares(), Before)
+	}
+
+	return nil
+}
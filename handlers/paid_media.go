@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters"
 	"github.com/enetx/g"
@@ -9,39 +10,52 @@ import (
 )
 
 // PaidMediaHandlers provides methods to handle paid media purchase events.
-type PaidMediaHandlers struct{ Bot core.BotAPI }
+type PaidMediaHandlers struct {
+	Bot   core.BotAPI
+	group int
+	uses  g.Slice[Middleware]
+	last  *EventHandler // Handler registered by the latest call
+}
 
 // handlePurchasedPaidMedia registers a paid media purchase handler with the dispatcher.
-func (h *PaidMediaHandlers) handlePurchasedPaidMedia(f filters.PurchasedPaidMedia, fn Handler) {
-	h.Bot.Dispatcher().AddHandler(handlers.NewPurchasedPaidMedia(f, wrap(h.Bot, middlewares(h.Bot), fn)))
+func (h *PaidMediaHandlers) handlePurchasedPaidMedia(method string, f filters.PurchasedPaidMedia, fn Handler, args ...any) {
+	h.last = register(h.Bot, "paid_media", h.group, h.uses, fn, describe(method, args...), func(r handlers.Response) ext.Handler {
+		return handlers.NewPurchasedPaidMedia(f, r)
+	})
 }
 
+// Last returns the handler registered by the latest call on h, to move it to another group or remove it.
+func (h *PaidMediaHandlers) Last() *EventHandler { return h.last }
+
 // Any handles all paid media purchases.
 func (h *PaidMediaHandlers) Any(fn Handler) *PaidMediaHandlers {
-	h.handlePurchasedPaidMedia(nil, fn)
+	h.handlePurchasedPaidMedia("Any", nil, fn)
 	return h
 }
 
 // FromUserID handles paid media purchases from a specific user.
 func (h *PaidMediaHandlers) FromUserID(id int64, fn Handler) *PaidMediaHandlers {
-	h.handlePurchasedPaidMedia(func(pm *gotgbot.PaidMediaPurchased) bool {
+	h.handlePurchasedPaidMedia("FromUserID", func(pm *gotgbot.PaidMediaPurchased) bool {
 		return pm != nil && pm.From.Id == id
-	}, fn)
+	}, fn, id)
+
 	return h
 }
 
 // Payload handles paid media purchases with a specific payload.
 func (h *PaidMediaHandlers) Payload(payload g.String, fn Handler) *PaidMediaHandlers {
-	h.handlePurchasedPaidMedia(func(pm *gotgbot.PaidMediaPurchased) bool {
+	h.handlePurchasedPaidMedia("Payload", func(pm *gotgbot.PaidMediaPurchased) bool {
 		return pm != nil && pm.PaidMediaPayload == payload.Std()
-	}, fn)
+	}, fn, payload)
+
 	return h
 }
 
 // PayloadPrefix handles paid media purchases where payload starts with the specified prefix.
 func (h *PaidMediaHandlers) PayloadPrefix(prefix g.String, fn Handler) *PaidMediaHandlers {
-	h.handlePurchasedPaidMedia(func(pm *gotgbot.PaidMediaPurchased) bool {
+	h.handlePurchasedPaidMedia("PayloadPrefix", func(pm *gotgbot.PaidMediaPurchased) bool {
 		return pm != nil && g.String(pm.PaidMediaPayload).StartsWith(prefix)
-	}, fn)
+	}, fn, prefix)
+
 	return h
 }
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters"
 	"github.com/enetx/g"
@@ -10,33 +11,54 @@ import (
 )
 
 // PollHandlers provides methods to handle poll events.
-type PollHandlers struct{ Bot core.BotAPI }
+type PollHandlers struct {
+	Bot   core.BotAPI
+	group int
+	uses  g.Slice[Middleware]
+	last  *EventHandler // Handler registered by the latest call
+}
 
 // handlePoll registers a poll handler with the dispatcher.
-func (h *PollHandlers) handlePoll(f filters.Poll, fn Handler) {
-	h.Bot.Dispatcher().AddHandler(handlers.NewPoll(f, wrap(h.Bot, middlewares(h.Bot), fn)))
+func (h *PollHandlers) handlePoll(method string, f filters.Poll, fn Handler, args ...any) {
+	h.last = register(h.Bot, "poll", h.group, h.uses, fn, describe(method, args...), func(r handlers.Response) ext.Handler {
+		return handlers.NewPoll(f, r)
+	})
 }
 
+// Last returns the handler registered by the latest call on h, to move it to another group or remove it.
+func (h *PollHandlers) Last() *EventHandler { return h.last }
+
 // Any handles all polls.
 func (h *PollHandlers) Any(fn Handler) *PollHandlers {
-	h.handlePoll(nil, fn)
+	h.handlePoll("Any", nil, fn)
 	return h
 }
 
 // ID handles polls with a specific ID.
 func (h *PollHandlers) ID(id g.String, fn Handler) *PollHandlers {
-	h.handlePoll(func(p *gotgbot.Poll) bool { return p != nil && p.Id == id.Std() }, fn)
+	h.handlePoll("ID", func(p *gotgbot.Poll) bool { return p != nil && p.Id == id.Std() }, fn, id)
 	return h
 }
 
 // Type handles polls of the specified type.
 func (h *PollHandlers) Type(t poll.PollType, fn Handler) *PollHandlers {
-	h.handlePoll(func(p *gotgbot.Poll) bool { return p != nil && p.Type == t.String() }, fn)
+	h.handlePoll("Type", pollType(t), fn, t)
 	return h
 }
 
 // Regular handles regular polls.
-func (h *PollHandlers) Regular(fn Handler) *PollHandlers { return h.Type(poll.Regular, fn) }
+func (h *PollHandlers) Regular(fn Handler) *PollHandlers {
+	h.handlePoll("Regular", pollType(poll.Regular), fn)
+	return h
+}
 
 // Quiz handles quiz polls.
-func (h *PollHandlers) Quiz(fn Handler) *PollHandlers { return h.Type(poll.Quiz, fn) }
+func (h *PollHandlers) Quiz(fn Handler) *PollHandlers {
+	h.handlePoll("Quiz", pollType(poll.Quiz), fn)
+	return h
+}
+
+// pollType returns a filter for polls of the given type.
+func pollType(t poll.PollType) filters.Poll {
+	return func(p *gotgbot.Poll) bool { return p != nil && p.Type == t.String() }
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters"
 	"github.com/enetx/g"
@@ -9,31 +10,43 @@ import (
 )
 
 // PollAnswerHandlers provides methods to handle poll answer events.
-type PollAnswerHandlers struct{ Bot core.BotAPI }
+type PollAnswerHandlers struct {
+	Bot   core.BotAPI
+	group int
+	uses  g.Slice[Middleware]
+	last  *EventHandler // Handler registered by the latest call
+}
 
 // handlePollAnswer registers a poll answer handler with the dispatcher.
-func (h *PollAnswerHandlers) handlePollAnswer(f filters.PollAnswer, fn Handler) {
-	h.Bot.Dispatcher().AddHandler(handlers.NewPollAnswer(f, wrap(h.Bot, middlewares(h.Bot), fn)))
+func (h *PollAnswerHandlers) handlePollAnswer(method string, f filters.PollAnswer, fn Handler, args ...any) {
+	h.last = register(h.Bot, "poll_answer", h.group, h.uses, fn, describe(method, args...), func(r handlers.Response) ext.Handler {
+		return handlers.NewPollAnswer(f, r)
+	})
 }
 
+// Last returns the handler registered by the latest call on h, to move it to another group or remove it.
+func (h *PollAnswerHandlers) Last() *EventHandler { return h.last }
+
 // Any handles all poll answers.
 func (h *PollAnswerHandlers) Any(fn Handler) *PollAnswerHandlers {
-	h.handlePollAnswer(nil, fn)
+	h.handlePollAnswer("Any", nil, fn)
 	return h
 }
 
 // ID handles poll answers for a specific poll ID.
 func (h *PollAnswerHandlers) ID(id g.String, fn Handler) *PollAnswerHandlers {
-	h.handlePollAnswer(func(p *gotgbot.PollAnswer) bool {
+	h.handlePollAnswer("ID", func(p *gotgbot.PollAnswer) bool {
 		return p != nil && p.PollId == id.Std()
-	}, fn)
+	}, fn, id)
+
 	return h
 }
 
 // FromUserID handles poll answers from a specific user.
 func (h *PollAnswerHandlers) FromUserID(id int64, fn Handler) *PollAnswerHandlers {
-	h.handlePollAnswer(func(p *gotgbot.PollAnswer) bool {
+	h.handlePollAnswer("FromUserID", func(p *gotgbot.PollAnswer) bool {
 		return p != nil && p.User.Id == id
-	}, fn)
+	}, fn, id)
+
 	return h
 }
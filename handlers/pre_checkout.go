@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters"
 	"github.com/enetx/g"
@@ -9,39 +10,52 @@ import (
 )
 
 // PreCheckoutHandlers provides methods to handle pre-checkout query events.
-type PreCheckoutHandlers struct{ Bot core.BotAPI }
+type PreCheckoutHandlers struct {
+	Bot   core.BotAPI
+	group int
+	uses  g.Slice[Middleware]
+	last  *EventHandler // Handler registered by the latest call
+}
 
 // handlePreCheckoutQuery registers a pre-checkout query handler with the dispatcher.
-func (h *PreCheckoutHandlers) handlePreCheckoutQuery(f filters.PreCheckoutQuery, fn Handler) {
-	h.Bot.Dispatcher().AddHandler(handlers.NewPreCheckoutQuery(f, wrap(h.Bot, middlewares(h.Bot), fn)))
+func (h *PreCheckoutHandlers) handlePreCheckoutQuery(method string, f filters.PreCheckoutQuery, fn Handler, args ...any) {
+	h.last = register(h.Bot, "pre_checkout", h.group, h.uses, fn, describe(method, args...), func(r handlers.Response) ext.Handler {
+		return handlers.NewPreCheckoutQuery(f, r)
+	})
 }
 
+// Last returns the handler registered by the latest call on h, to move it to another group or remove it.
+func (h *PreCheckoutHandlers) Last() *EventHandler { return h.last }
+
 // Any handles all pre-checkout queries.
 func (h *PreCheckoutHandlers) Any(fn Handler) *PreCheckoutHandlers {
-	h.handlePreCheckoutQuery(nil, fn)
+	h.handlePreCheckoutQuery("Any", nil, fn)
 	return h
 }
 
 // FromUserID handles pre-checkout queries from a specific user.
 func (h *PreCheckoutHandlers) FromUserID(id int64, fn Handler) *PreCheckoutHandlers {
-	h.handlePreCheckoutQuery(func(p *gotgbot.PreCheckoutQuery) bool {
+	h.handlePreCheckoutQuery("FromUserID", func(p *gotgbot.PreCheckoutQuery) bool {
 		return p != nil && p.From.Id == id
-	}, fn)
+	}, fn, id)
+
 	return h
 }
 
 // HasPayloadPrefix handles pre-checkout queries where invoice payload starts with the specified prefix.
 func (h *PreCheckoutHandlers) HasPayloadPrefix(prefix g.String, fn Handler) *PreCheckoutHandlers {
-	h.handlePreCheckoutQuery(func(p *gotgbot.PreCheckoutQuery) bool {
+	h.handlePreCheckoutQuery("HasPayloadPrefix", func(p *gotgbot.PreCheckoutQuery) bool {
 		return p != nil && g.String(p.InvoicePayload).StartsWith(prefix)
-	}, fn)
+	}, fn, prefix)
+
 	return h
 }
 
 // Currency handles pre-checkout queries that match the given currency.
 func (h *PreCheckoutHandlers) Currency(currency g.String, fn Handler) *PreCheckoutHandlers {
-	h.handlePreCheckoutQuery(func(p *gotgbot.PreCheckoutQuery) bool {
+	h.handlePreCheckoutQuery("Currency", func(p *gotgbot.PreCheckoutQuery) bool {
 		return p != nil && g.String(p.Currency).Eq(currency)
-	}, fn)
+	}, fn, currency)
+
 	return h
 }
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters"
 	"github.com/enetx/g"
@@ -9,22 +10,32 @@ import (
 )
 
 // ReactionHandlers provides methods to handle message reaction events.
-type ReactionHandlers struct{ Bot core.BotAPI }
+type ReactionHandlers struct {
+	Bot   core.BotAPI
+	group int
+	uses  g.Slice[Middleware]
+	last  *EventHandler // Handler registered by the latest call
+}
 
 // handleReaction registers a message reaction handler with the dispatcher.
-func (h *ReactionHandlers) handleReaction(f filters.Reaction, fn Handler) {
-	h.Bot.Dispatcher().AddHandler(handlers.NewReaction(f, wrap(h.Bot, middlewares(h.Bot), fn)))
+func (h *ReactionHandlers) handleReaction(method string, f filters.Reaction, fn Handler, args ...any) {
+	h.last = register(h.Bot, "reaction", h.group, h.uses, fn, describe(method, args...), func(r handlers.Response) ext.Handler {
+		return handlers.NewReaction(f, r)
+	})
 }
 
+// Last returns the handler registered by the latest call on h, to move it to another group or remove it.
+func (h *ReactionHandlers) Last() *EventHandler { return h.last }
+
 // Any handles all message reaction updates.
 func (h *ReactionHandlers) Any(fn Handler) *ReactionHandlers {
-	h.handleReaction(nil, fn)
+	h.handleReaction("Any", nil, fn)
 	return h
 }
 
 // FromPeer handles reactions from a specific user or chat.
 func (h *ReactionHandlers) FromPeer(id int64, fn Handler) *ReactionHandlers {
-	h.handleReaction(func(mru *gotgbot.MessageReactionUpdated) bool {
+	h.handleReaction("FromPeer", func(mru *gotgbot.MessageReactionUpdated) bool {
 		if mru == nil {
 			return false
 		}
@@ -38,30 +49,32 @@ func (h *ReactionHandlers) FromPeer(id int64, fn Handler) *ReactionHandlers {
 		}
 
 		return false
-	}, fn)
+	}, fn, id)
 
 	return h
 }
 
 // ChatID handles reactions in a specific chat.
 func (h *ReactionHandlers) ChatID(id int64, fn Handler) *ReactionHandlers {
-	h.handleReaction(func(mru *gotgbot.MessageReactionUpdated) bool {
+	h.handleReaction("ChatID", func(mru *gotgbot.MessageReactionUpdated) bool {
 		return mru != nil && mru.Chat.Id == id
-	}, fn)
+	}, fn, id)
+
 	return h
 }
 
 // MessageID handles reactions for a specific message.
 func (h *ReactionHandlers) MessageID(id int64, fn Handler) *ReactionHandlers {
-	h.handleReaction(func(mru *gotgbot.MessageReactionUpdated) bool {
+	h.handleReaction("MessageID", func(mru *gotgbot.MessageReactionUpdated) bool {
 		return mru != nil && mru.MessageId == id
-	}, fn)
+	}, fn, id)
+
 	return h
 }
 
 // NewReactionEmoji handles reactions where the specified emoji was added.
 func (h *ReactionHandlers) NewReactionEmoji(emoji g.String, fn Handler) *ReactionHandlers {
-	h.handleReaction(func(mru *gotgbot.MessageReactionUpdated) bool {
+	h.handleReaction("NewReactionEmoji", func(mru *gotgbot.MessageReactionUpdated) bool {
 		if mru == nil {
 			return false
 		}
@@ -73,14 +86,14 @@ func (h *ReactionHandlers) NewReactionEmoji(emoji g.String, fn Handler) *Reactio
 		}
 
 		return false
-	}, fn)
+	}, fn, emoji)
 
 	return h
 }
 
 // OldReactionEmoji handles reactions where the specified emoji was removed.
 func (h *ReactionHandlers) OldReactionEmoji(emoji g.String, fn Handler) *ReactionHandlers {
-	h.handleReaction(func(mru *gotgbot.MessageReactionUpdated) bool {
+	h.handleReaction("OldReactionEmoji", func(mru *gotgbot.MessageReactionUpdated) bool {
 		if mru == nil {
 			return false
 		}
@@ -92,7 +105,7 @@ func (h *ReactionHandlers) OldReactionEmoji(emoji g.String, fn Handler) *Reactio
 		}
 
 		return false
-	}, fn)
+	}, fn, emoji)
 
 	return h
 }
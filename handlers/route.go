@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/core"
+)
+
+// seq numbers handlers so that every registration gets a unique name.
+var seq atomic.Uint64
+
+// Route describes a handler registered with the dispatcher.
+type Route struct {
+	ID     g.String // Unique handler name in the dispatcher
+	Kind   g.String // Update kind, e.g. "message", "callback" or "command"
+	Filter g.String // Registration method that selected the updates, e.g. "Text" or "/start"
+	Group  int      // Dispatcher group the handler runs in
+
+	bot core.BotAPI
+}
+
+// Remove unregisters the handler. It reports whether the handler was still registered.
+func (r Route) Remove() bool {
+	group := r.Group
+	if current := registry(r.bot).take(r.ID); current.IsSome() {
+		group = current.Some().Group
+	}
+
+	return r.bot.Dispatcher().RemoveHandlerFromGroup(r.ID.Std(), group)
+}
+
+// String returns a one-line description of the route.
+func (r Route) String() string {
+	return fmt.Sprintf("group %d: %s %s [%s]", r.Group, r.Kind, r.Filter, r.ID)
+}
+
+// Registry keeps track of the handlers registered on a bot.
+// A nil Registry records nothing.
+type Registry struct {
+	mu     sync.RWMutex
+	routes []Route
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry { return new(Registry) }
+
+// Routes returns all registered handlers ordered by group and registration order.
+func (r *Registry) Routes() g.Slice[Route] {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	routes := slices.Clone(r.routes)
+	r.mu.RUnlock()
+
+	slices.SortStableFunc(routes, func(a, b Route) int { return cmp.Compare(a.Group, b.Group) })
+
+	return routes
+}
+
+// put records route after all others, replacing a previous route with the same ID
+// the same way re-registering moves a handler to the end of its dispatcher group.
+func (r *Registry) put(route Route) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.routes = slices.DeleteFunc(r.routes, func(e Route) bool { return e.ID == route.ID })
+	r.routes = append(r.routes, route)
+}
+
+// take removes the route with the given ID and returns it.
+func (r *Registry) take(id g.String) g.Option[Route] {
+	if r == nil {
+		return g.None[Route]()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := slices.IndexFunc(r.routes, func(e Route) bool { return e.ID == id })
+	if i < 0 {
+		return g.None[Route]()
+	}
+
+	route := r.routes[i]
+	r.routes = slices.Delete(r.routes, i, i+1)
+
+	return g.Some(route)
+}
+
+// registry returns the route registry of the bot, or nil if it does not keep one.
+func registry(bot core.BotAPI) *Registry {
+	if b, ok := bot.(interface{ Registry() *Registry }); ok {
+		return b.Registry()
+	}
+
+	return nil
+}
+
+// newID returns a unique handler name for the given update kind.
+func newID(kind string) string {
+	return fmt.Sprintf("%s_%d", kind, seq.Add(1))
+}
+
+// EventHandler is a handler registered for updates other than messages, callback queries and commands,
+// such as polls or inline queries. Last returns it after registration to move or remove the handler:
+//
+//	quiz := b.On.Poll.Quiz(onQuiz).Last()
+//	quiz.Group(-1)
+type EventHandler struct {
+	bot     core.BotAPI
+	kind    string
+	build   func(handlers.Response) ext.Handler
+	handler Handler
+	name    string
+	desc    g.String
+	group   int
+	uses    g.Slice[Middleware]
+}
+
+// register creates a handler of kind that build makes from the wrapped response,
+// and registers it in the dispatcher group.
+func register(
+	bot core.BotAPI,
+	kind string,
+	group int,
+	uses g.Slice[Middleware],
+	fn Handler,
+	desc g.String,
+	build func(handlers.Response) ext.Handler,
+) *EventHandler {
+	return (&EventHandler{
+		bot:     bot,
+		kind:    kind,
+		build:   build,
+		handler: fn,
+		name:    newID(kind),
+		desc:    desc,
+		group:   group,
+		uses:    uses,
+	}).Register()
+}
+
+// Group moves the handler to the given dispatcher group.
+// Groups are processed in ascending order and each group handles an update at most once,
+// so handlers in different groups can all react to the same update. The default group is 0.
+func (h *EventHandler) Group(n int) *EventHandler {
+	h.bot.Dispatcher().RemoveHandlerFromGroup(h.name, h.group)
+	h.group = n
+
+	return h.Register()
+}
+
+// Register registers the handler with the bot dispatcher.
+func (h *EventHandler) Register() *EventHandler {
+	h.bot.Dispatcher().RemoveHandlerFromGroup(h.name, h.group)
+
+	handler := h.build(wrap(h.bot, chain(h.bot, h.uses), h.handler))
+
+	h.bot.Dispatcher().AddHandlerToGroup(namedHandler{h.name, handler}, h.group)
+	registry(h.bot).put(h.Route())
+
+	return h
+}
+
+// Route returns the description of the registered handler.
+func (h *EventHandler) Route() Route {
+	return Route{ID: g.String(h.name), Kind: g.String(h.kind), Filter: h.desc, Group: h.group, bot: h.bot}
+}
+
+// Remove unregisters the handler. It reports whether the handler was registered.
+func (h *EventHandler) Remove() bool {
+	return h.Route().Remove()
+}
+
+// describe returns the description of a registration: the name of the registration method,
+// followed by the arguments that select the updates if it took any, such as `Equal("a")`.
+func describe(method string, args ...any) g.String {
+	if len(args) == 0 {
+		return g.String(method)
+	}
+
+	values := make([]string, len(args))
+	for i, arg := range args {
+		values[i] = formatArg(arg)
+	}
+
+	return g.Format("{}({})", method, strings.Join(values, ", "))
+}
+
+// formatArg formats a filter argument, quoting text.
+func formatArg(arg any) string {
+	if s, ok := arg.(fmt.Stringer); ok {
+		return strconv.Quote(s.String())
+	}
+
+	if v := reflect.ValueOf(arg); v.Kind() == reflect.String {
+		return strconv.Quote(v.String())
+	}
+
+	return fmt.Sprint(arg)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters"
 	"github.com/enetx/g"
@@ -9,39 +10,52 @@ import (
 )
 
 // ShippingHandlers provides methods to handle shipping query events.
-type ShippingHandlers struct{ Bot core.BotAPI }
+type ShippingHandlers struct {
+	Bot   core.BotAPI
+	group int
+	uses  g.Slice[Middleware]
+	last  *EventHandler // Handler registered by the latest call
+}
 
 // handleShippingQuery registers a shipping query handler with the dispatcher.
-func (h *ShippingHandlers) handleShippingQuery(f filters.ShippingQuery, fn Handler) {
-	h.Bot.Dispatcher().AddHandler(handlers.NewShippingQuery(f, wrap(h.Bot, middlewares(h.Bot), fn)))
+func (h *ShippingHandlers) handleShippingQuery(method string, f filters.ShippingQuery, fn Handler, args ...any) {
+	h.last = register(h.Bot, "shipping", h.group, h.uses, fn, describe(method, args...), func(r handlers.Response) ext.Handler {
+		return handlers.NewShippingQuery(f, r)
+	})
 }
 
+// Last returns the handler registered by the latest call on h, to move it to another group or remove it.
+func (h *ShippingHandlers) Last() *EventHandler { return h.last }
+
 // Any handles all shipping queries.
 func (h *ShippingHandlers) Any(fn Handler) *ShippingHandlers {
-	h.handleShippingQuery(nil, fn)
+	h.handleShippingQuery("Any", nil, fn)
 	return h
 }
 
 // FromUserID handles shipping queries from a specific user.
 func (h *ShippingHandlers) FromUserID(id int64, fn Handler) *ShippingHandlers {
-	h.handleShippingQuery(func(s *gotgbot.ShippingQuery) bool {
+	h.handleShippingQuery("FromUserID", func(s *gotgbot.ShippingQuery) bool {
 		return s != nil && s.From.Id == id
-	}, fn)
+	}, fn, id)
+
 	return h
 }
 
 // HasPayloadPrefix handles shipping queries where invoice payload starts with the specified prefix.
 func (h *ShippingHandlers) HasPayloadPrefix(prefix g.String, fn Handler) *ShippingHandlers {
-	h.handleShippingQuery(func(s *gotgbot.ShippingQuery) bool {
+	h.handleShippingQuery("HasPayloadPrefix", func(s *gotgbot.ShippingQuery) bool {
 		return s != nil && g.String(s.InvoicePayload).StartsWith(prefix)
-	}, fn)
+	}, fn, prefix)
+
 	return h
 }
 
 // InvoicePayload handles shipping queries with a specific invoice payload.
 func (h *ShippingHandlers) InvoicePayload(payload g.String, fn Handler) *ShippingHandlers {
-	h.handleShippingQuery(func(s *gotgbot.ShippingQuery) bool {
+	h.handleShippingQuery("InvoicePayload", func(s *gotgbot.ShippingQuery) bool {
 		return s != nil && g.String(s.InvoicePayload) == payload
-	}, fn)
+	}, fn, payload)
+
 	return h
 }
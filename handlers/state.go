@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/fsm"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/core"
+	"github.com/enetx/tg/ctx"
+)
+
+// stater is implemented by bots that keep a state machine per user, see Bot.FSM.
+type stater interface {
+	State(c *ctx.Context) g.Option[fsm.State]
+}
+
+// inState restricts a handler to updates whose user is in one of the given states.
+type inState struct {
+	ext.Handler
+	bot    core.BotAPI
+	states g.Slice[fsm.State]
+}
+
+// CheckUpdate matches the update if the wrapped handler matches it and the user's
+// current state is one of the handler's states. Users without a stored machine are in the
+// template's initial state. Without a state machine on the bot nothing matches.
+func (s inState) CheckUpdate(b *gotgbot.Bot, ectx *ext.Context) bool {
+	if !s.Handler.CheckUpdate(b, ectx) {
+		return false
+	}
+
+	st, ok := s.bot.(stater)
+	if !ok {
+		return false
+	}
+
+	state := st.State(ctx.New(s.bot, ectx))
+
+	return state.IsSome() && s.states.Contains(state.Some())
+}
+
+// withStates restricts handler to the given states, if any.
+func withStates(bot core.BotAPI, handler ext.Handler, states g.Slice[fsm.State]) ext.Handler {
+	if len(states) == 0 {
+		return handler
+	}
+
+	return inState{Handler: handler, bot: bot, states: states}
+}
+
+// describeStates appends the states a handler is restricted to to its filter description.
+func describeStates(filter g.String, states g.Slice[fsm.State]) g.String {
+	if len(states) == 0 {
+		return filter
+	}
+
+	names := g.TransformSlice(states, func(s fsm.State) g.String { return g.String(s) })
+
+	return g.Format("{} in {}", filter, names.Join(", ")).Trim()
+}
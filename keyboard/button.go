@@ -47,6 +47,8 @@ func (b *Button) Text(text g.String) *Button {
 }
 
 // Callback sets the callback data that will be sent when the button is pressed.
+// Telegram accepts at most MaxCallbackLen bytes; the keyboard the button is added to
+// reports longer data with Err, or keeps it in its Storage.
 func (b *Button) Callback(callback g.String) *Button {
 	b.raw.CallbackData = callback.Std()
 	return b
@@ -1,6 +1,8 @@
 package keyboard
 
 import (
+	"errors"
+	"fmt"
 	"unsafe"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
@@ -8,9 +10,61 @@ import (
 	"github.com/enetx/g/ref"
 )
 
+// MaxCallbackLen is the maximum length of callback data accepted by Telegram, in bytes.
+const MaxCallbackLen = 64
+
+// ErrTooLong is reported by InlineKeyboard.Err for buttons with callback data over MaxCallbackLen
+// when the keyboard has no Storage. Telegram rejects such keyboards.
+var ErrTooLong = errors.New("callback data exceeds 64 bytes")
+
+// Storage keeps callback data that does not fit into a button, see callback.Storage.
+type Storage interface {
+	// Shorten returns data unchanged if it fits into a button, or a short token that stands for it.
+	Shorten(data g.String) g.Result[g.String]
+}
+
+// Codec encodes values into callback data for Data, see callback.Codec.
+type Codec interface {
+	// EncodeValue encodes v, which must be of the type of the codec, into callback data.
+	EncodeValue(v any) g.Result[g.String]
+}
+
 // InlineKeyboard helps build Telegram inline keyboard markup using a fluent API.
 type InlineKeyboard struct {
-	rows g.Slice[g.Slice[gotgbot.InlineKeyboardButton]]
+	rows    g.Slice[g.Slice[gotgbot.InlineKeyboardButton]]
+	storage Storage
+	err     error
+}
+
+// Storage makes the keyboard put callback data over MaxCallbackLen of buttons added afterwards
+// into storage, and send its short token instead. The storage must resolve the tokens when the
+// buttons are pressed, see Bot.CallbackStorage.
+func (b *InlineKeyboard) Storage(storage Storage) *InlineKeyboard {
+	b.storage = storage
+	return b
+}
+
+// check replaces callback data over MaxCallbackLen with a token of the storage,
+// or reports ErrTooLong if the keyboard has none.
+func (b *InlineKeyboard) check(btn gotgbot.InlineKeyboardButton) gotgbot.InlineKeyboardButton {
+	if len(btn.CallbackData) <= MaxCallbackLen {
+		return btn
+	}
+
+	if b.storage == nil {
+		b.err = errors.Join(b.err, fmt.Errorf("%w: button %q has %d bytes", ErrTooLong, btn.Text, len(btn.CallbackData)))
+		return btn
+	}
+
+	token, err := b.storage.Shorten(g.String(btn.CallbackData)).Result()
+	if err != nil {
+		b.err = errors.Join(b.err, fmt.Errorf("failed to shorten callback data of button %q: %w", btn.Text, err))
+		return btn
+	}
+
+	btn.CallbackData = token.Std()
+
+	return btn
 }
 
 // Row starts a new row for subsequent buttons.
@@ -19,8 +73,13 @@ func (b *InlineKeyboard) Row() *InlineKeyboard {
 	return b
 }
 
-// addToLastRow adds a button to the last row, creating a new row if needed.
+// addToLastRow checks a button and adds it to the last row.
 func (b *InlineKeyboard) addToLastRow(btn gotgbot.InlineKeyboardButton) *InlineKeyboard {
+	return b.push(b.check(btn))
+}
+
+// push adds a button to the last row, creating a new row if needed.
+func (b *InlineKeyboard) push(btn gotgbot.InlineKeyboardButton) *InlineKeyboard {
 	if b.rows.IsEmpty() {
 		b.rows.Push([]gotgbot.InlineKeyboardButton{btn})
 	} else {
@@ -41,22 +100,23 @@ func (b *InlineKeyboard) Button(btn *Button) *InlineKeyboard {
 		return b.addToLastRow(btn.Build())
 	}
 
-	cb := btn.raw.CallbackData
-	if cb == "" {
+	if btn.raw.CallbackData == "" {
 		return b
 	}
 
+	built := b.check(btn.Build())
+
 	for i, row := range b.rows {
 		for j := range row {
-			if b.rows[i][j].CallbackData == cb {
-				b.rows[i][j] = btn.Build()
+			if b.rows[i][j].CallbackData == built.CallbackData {
+				b.rows[i][j] = built
 				return b
 			}
 		}
 	}
 
 	btn.attach(b)
-	return b.addToLastRow(btn.Build())
+	return b.push(built)
 }
 
 // update refreshes an existing button in the keyboard based on its callback data.
@@ -65,18 +125,18 @@ func (b *InlineKeyboard) update(btn *Button) *InlineKeyboard {
 		return b
 	}
 
-	cb := btn.raw.CallbackData
+	built := b.check(btn.Build())
 
 	for i, row := range b.rows {
 		for j := range row {
-			if b.rows[i][j].CallbackData == cb {
-				b.rows[i][j] = btn.Build()
+			if b.rows[i][j].CallbackData == built.CallbackData {
+				b.rows[i][j] = built
 				return b
 			}
 		}
 	}
 
-	return b.addToLastRow(btn.Build())
+	return b.push(built)
 }
 
 // Text adds a text button with callback data to the current row.
@@ -87,6 +147,21 @@ func (b *InlineKeyboard) Text(text, callback g.String) *InlineKeyboard {
 	})
 }
 
+// Data adds a button with value encoded into its callback data by codec, see callback.Codec.
+// If encoding fails, the button is left out and the error is reported by Err.
+func (b *InlineKeyboard) Data(text g.String, codec Codec, value any) *InlineKeyboard {
+	callback, err := codec.EncodeValue(value).Result()
+	if err != nil {
+		b.err = errors.Join(b.err, fmt.Errorf("failed to encode callback data of button %q: %w", text, err))
+		return b
+	}
+
+	return b.Text(text, callback)
+}
+
+// Err returns the errors of buttons that could not be built, such as ErrTooLong.
+func (b *InlineKeyboard) Err() error { return b.err }
+
 // URL adds a button that opens a given URL.
 func (b *InlineKeyboard) URL(text, url g.String) *InlineKeyboard {
 	return b.addToLastRow(gotgbot.InlineKeyboardButton{
@@ -0,0 +1,247 @@
+// Package ratelimit keeps outgoing Bot API requests within Telegram's flood limits.
+//
+// Limits are applied per chat, so a busy or flood-waiting chat never delays requests to other chats.
+// Requests that post or edit messages in a chat are limited; all other requests pass through unchanged.
+// The limiter does not resend requests rejected with "Too Many Requests": it pauses the chat and returns
+// the error, leaving retries to the retry policy of the bot, see retry.Wrap. Bots built with rate limits
+// and no retry policy resend such requests once after the pause, see retry.Flood.
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// sweepInterval is how often idle per-chat state is released.
+const sweepInterval = time.Minute
+
+// Limits describes the flood limits applied to outgoing requests.
+type Limits struct {
+	global  window
+	private window
+	group   window
+}
+
+// New returns limits matching Telegram's documented flood limits:
+// 30 messages per second overall, 1 message per second in a private chat
+// and 20 messages per minute in a group or channel. A chat that gets a
+// "Too Many Requests" answer is paused for the advised delay.
+func New() *Limits {
+	return &Limits{
+		global:  window{n: 30, per: time.Second},
+		private: window{n: 1, per: time.Second},
+		group:   window{n: 20, per: time.Minute},
+	}
+}
+
+// Global sets how many messages may be sent per interval across all chats.
+// A non-positive n disables the limit.
+func (l *Limits) Global(n int, per time.Duration) *Limits {
+	l.global = window{n: n, per: per}
+	return l
+}
+
+// Private sets how many messages may be sent per interval to a single private chat.
+// A non-positive n disables the limit.
+func (l *Limits) Private(n int, per time.Duration) *Limits {
+	l.private = window{n: n, per: per}
+	return l
+}
+
+// Group sets how many messages may be sent per interval to a single group or channel.
+// A non-positive n disables the limit.
+func (l *Limits) Group(n int, per time.Duration) *Limits {
+	l.group = window{n: n, per: per}
+	return l
+}
+
+// Wrap returns a BotClient that applies the limits to requests sent through c.
+func (l *Limits) Wrap(c gotgbot.BotClient) gotgbot.BotClient {
+	return &client{
+		BotClient: c,
+		limits:    *l,
+		global:    &window{n: l.global.n, per: l.global.per},
+		chats:     make(map[string]*chat),
+		swept:     time.Now(),
+	}
+}
+
+// chat holds the limiter state of a single chat.
+type chat struct {
+	window *window
+	paused time.Time
+}
+
+// client is a gotgbot.BotClient that delays requests to stay within the limits.
+type client struct {
+	gotgbot.BotClient
+	limits Limits
+	mu     sync.Mutex
+	global *window
+	chats  map[string]*chat
+	swept  time.Time
+}
+
+// RequestWithContext waits for a free slot in the target chat and globally and sends the request.
+// When Telegram answers with "Too Many Requests", later requests to the chat wait for the advised delay.
+func (c *client) RequestWithContext(
+	ctx context.Context,
+	token string,
+	method string,
+	params map[string]string,
+	data map[string]gotgbot.FileReader,
+	opts *gotgbot.RequestOpts,
+) (json.RawMessage, error) {
+	id, ok := chatID(method, params)
+	if !ok {
+		return c.BotClient.RequestWithContext(ctx, token, method, params, data, opts)
+	}
+
+	if err := c.wait(ctx, id); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.BotClient.RequestWithContext(ctx, token, method, params, data, opts)
+
+	if delay, flood := retryAfter(err); flood {
+		c.pause(id, delay)
+	}
+
+	return resp, err
+}
+
+// wait blocks until the request may be sent to the chat with the given ID.
+func (c *client) wait(ctx context.Context, id string) error {
+	c.mu.Lock()
+
+	now := time.Now()
+	ch := c.chat(id, now)
+
+	start := now
+	if ch.paused.After(start) {
+		start = ch.paused
+	}
+
+	at := ch.window.reserve(start)
+	c.mu.Unlock()
+
+	if err := sleep(ctx, at); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	at = c.global.reserve(time.Now())
+	c.mu.Unlock()
+
+	return sleep(ctx, at)
+}
+
+// pause stops sending to the chat with the given ID for the given delay.
+func (c *client) pause(id string, delay time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	ch := c.chat(id, now)
+
+	if until := now.Add(delay); until.After(ch.paused) {
+		ch.paused = until
+	}
+}
+
+// chat returns the state of the chat with the given ID, creating it if needed.
+// It also releases the state of idle chats. The caller must hold c.mu.
+func (c *client) chat(id string, now time.Time) *chat {
+	if now.Sub(c.swept) > sweepInterval {
+		for key, ch := range c.chats {
+			if ch.window.idle(now) && now.After(ch.paused) {
+				delete(c.chats, key)
+			}
+		}
+
+		c.swept = now
+	}
+
+	ch, ok := c.chats[id]
+	if !ok {
+		w := c.limits.group
+		if private(id) {
+			w = c.limits.private
+		}
+
+		ch = &chat{window: &window{n: w.n, per: w.per}}
+		c.chats[id] = ch
+	}
+
+	return ch
+}
+
+// retryAfter reports whether err is a "Too Many Requests" error from Telegram
+// and returns the delay it advises before sending again.
+func retryAfter(err error) (time.Duration, bool) {
+	var tgErr *gotgbot.TelegramError
+	if !errors.As(err, &tgErr) || tgErr.Code != 429 {
+		return 0, false
+	}
+
+	if tgErr.ResponseParams == nil || tgErr.ResponseParams.RetryAfter <= 0 {
+		return time.Second, true
+	}
+
+	return time.Duration(tgErr.ResponseParams.RetryAfter) * time.Second, true
+}
+
+// chatID returns the target chat of a request that posts or edits messages.
+func chatID(method string, params map[string]string) (string, bool) {
+	if method == "sendChatAction" {
+		return "", false
+	}
+
+	limited := false
+	for _, prefix := range []string{"send", "copy", "forward", "edit"} {
+		if strings.HasPrefix(method, prefix) {
+			limited = true
+			break
+		}
+	}
+
+	if !limited {
+		return "", false
+	}
+
+	id, ok := params["chat_id"]
+
+	return id, ok && id != ""
+}
+
+// private reports whether the chat ID belongs to a private chat.
+// Group, supergroup and channel IDs are negative; channel usernames start with '@'.
+func private(id string) bool {
+	n, err := strconv.ParseInt(id, 10, 64)
+	return err == nil && n > 0
+}
+
+// sleep waits until t or until ctx is done.
+func sleep(ctx context.Context, t time.Time) error {
+	d := time.Until(t)
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
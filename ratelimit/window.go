@@ -0,0 +1,40 @@
+package ratelimit
+
+import "time"
+
+// window is a sliding window that allows at most n events per interval.
+// Events are booked in advance, so callers know when their slot starts.
+type window struct {
+	n     int
+	per   time.Duration
+	times []time.Time
+}
+
+// reserve books the earliest slot not before t and returns its start time.
+// Slots are handed out in booking order.
+func (w *window) reserve(t time.Time) time.Time {
+	if w.n <= 0 || w.per <= 0 {
+		return t
+	}
+
+	if n := len(w.times); n > 0 && w.times[n-1].After(t) {
+		t = w.times[n-1]
+	}
+
+	if len(w.times) >= w.n {
+		if earliest := w.times[len(w.times)-w.n].Add(w.per); earliest.After(t) {
+			t = earliest
+		}
+
+		w.times = append(w.times[:0], w.times[len(w.times)-w.n+1:]...)
+	}
+
+	w.times = append(w.times, t)
+
+	return t
+}
+
+// idle reports whether the window has no bookings that still affect future slots.
+func (w *window) idle(now time.Time) bool {
+	return len(w.times) == 0 || now.Sub(w.times[len(w.times)-1]) > w.per
+}
@@ -0,0 +1,228 @@
+// Package retry retries Bot API requests that failed for transient reasons.
+//
+// Requests rejected with "Too Many Requests" were not executed by Telegram, so they are retried
+// for every method after the advised delay. Network failures and server errors are retried only
+// for idempotent methods, or for any method when the policy is forced.
+package retry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/g"
+)
+
+// idempotent lists method prefixes that are safe to repeat after an unknown outcome.
+var idempotent = []string{"get", "set", "delete", "pin", "unpin", "ban", "unban", "restrict", "promote"}
+
+// Policy describes how failed requests are retried.
+type Policy struct {
+	attempts int
+	base     time.Duration
+	max      time.Duration
+	jitter   float64
+	force    bool
+	flood    bool
+}
+
+// New returns a policy that makes up to 3 attempts with exponential backoff
+// starting at 500ms, capped at 30s, with 50% jitter.
+func New() *Policy {
+	return &Policy{
+		attempts: 3,
+		base:     500 * time.Millisecond,
+		max:      30 * time.Second,
+		jitter:   0.5,
+	}
+}
+
+// Flood returns a policy that retries only requests rejected with "Too Many Requests", once,
+// after the advised delay. It is the default of bots built with rate limits and no retry policy.
+func Flood() *Policy {
+	return &Policy{attempts: 2, base: time.Second, max: 30 * time.Second, flood: true}
+}
+
+// Attempts sets the maximum number of attempts, including the first one.
+func (p *Policy) Attempts(n int) *Policy {
+	p.attempts = n
+	return p
+}
+
+// Backoff sets the delay before the first retry and the upper bound for later delays.
+// The delay doubles after each attempt.
+func (p *Policy) Backoff(base, limit time.Duration) *Policy {
+	p.base = base
+	p.max = limit
+
+	return p
+}
+
+// Jitter sets the fraction of each delay that is randomized, from 0 (none) to 1 (full jitter).
+func (p *Policy) Jitter(fraction float64) *Policy {
+	p.jitter = min(max(fraction, 0), 1)
+	return p
+}
+
+// Force allows retrying non-idempotent methods such as sendMessage after network failures
+// and server errors. A retried send may then be delivered twice.
+func (p *Policy) Force() *Policy {
+	p.force = true
+	return p
+}
+
+// delay returns the backoff before the given retry, starting from 0.
+func (p *Policy) delay(retry int) time.Duration {
+	d := p.base << retry
+	if d <= 0 || d > p.max {
+		d = p.max
+	}
+
+	if p.jitter > 0 {
+		d -= time.Duration(rand.Float64() * p.jitter * float64(d))
+	}
+
+	return d
+}
+
+type policyKey struct{}
+
+// WithPolicy returns a copy of ctx that makes requests use policy instead of the bot's default.
+// A nil policy leaves ctx unchanged.
+func WithPolicy(ctx context.Context, policy *Policy) context.Context {
+	if policy == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, policyKey{}, policy)
+}
+
+// PolicyOf returns the policy attached to ctx with WithPolicy, if any.
+func PolicyOf(ctx context.Context) g.Option[*Policy] {
+	if policy, ok := ctx.Value(policyKey{}).(*Policy); ok {
+		return g.Some(policy)
+	}
+
+	return g.None[*Policy]()
+}
+
+// policy is the JSON form of a Policy.
+type policy struct {
+	Attempts int           `json:"attempts"`
+	Base     time.Duration `json:"base"`
+	Max      time.Duration `json:"max"`
+	Jitter   float64       `json:"jitter,omitzero"`
+	Force    bool          `json:"force,omitzero"`
+	Flood    bool          `json:"flood,omitzero"`
+}
+
+// MarshalJSON encodes the policy, so that it can be stored with a scheduled request.
+func (p *Policy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(policy{p.attempts, p.base, p.max, p.jitter, p.force, p.flood})
+}
+
+// UnmarshalJSON decodes a policy encoded with MarshalJSON.
+func (p *Policy) UnmarshalJSON(data []byte) error {
+	var v policy
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*p = Policy{attempts: v.Attempts, base: v.Base, max: v.Max, jitter: v.Jitter, force: v.Force, flood: v.Flood}
+
+	return nil
+}
+
+// Wrap returns a BotClient that retries requests sent through c.
+// Requests use the policy attached with WithPolicy, falling back to def.
+// A nil def disables retries for requests without their own policy.
+func Wrap(c gotgbot.BotClient, def *Policy) gotgbot.BotClient {
+	return &client{BotClient: c, def: def}
+}
+
+// client is a gotgbot.BotClient that retries failed requests.
+type client struct {
+	gotgbot.BotClient
+	def *Policy
+}
+
+// RequestWithContext sends the request and retries it according to the active policy.
+// Requests that upload files are never retried, since their readers are already consumed.
+func (c *client) RequestWithContext(
+	ctx context.Context,
+	token string,
+	method string,
+	params map[string]string,
+	data map[string]gotgbot.FileReader,
+	opts *gotgbot.RequestOpts,
+) (json.RawMessage, error) {
+	policy, ok := ctx.Value(policyKey{}).(*Policy)
+	if !ok {
+		policy = c.def
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.BotClient.RequestWithContext(ctx, token, method, params, data, opts)
+		if err == nil || policy == nil || attempt >= policy.attempts || len(data) != 0 || ctx.Err() != nil {
+			return resp, err
+		}
+
+		delay, ok := policy.next(method, attempt, err)
+		if !ok {
+			return resp, err
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, err
+		}
+	}
+}
+
+// next reports whether a request that failed with err should be retried and after what delay.
+func (p *Policy) next(method string, attempt int, err error) (time.Duration, bool) {
+	var tgErr *gotgbot.TelegramError
+	if errors.As(err, &tgErr) {
+		switch {
+		case tgErr.Code == 429:
+			if tgErr.ResponseParams != nil && tgErr.ResponseParams.RetryAfter > 0 {
+				return time.Duration(tgErr.ResponseParams.RetryAfter) * time.Second, true
+			}
+
+			return p.delay(attempt - 1), true
+		case tgErr.Code >= 500:
+			return p.delay(attempt - 1), p.safe(method)
+		default:
+			return 0, false
+		}
+	}
+
+	return p.delay(attempt - 1), p.safe(method)
+}
+
+// safe reports whether method may be repeated after an unknown outcome.
+func (p *Policy) safe(method string) bool {
+	if p.flood {
+		return false
+	}
+
+	if p.force {
+		return true
+	}
+
+	for _, prefix := range idempotent {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,28 @@
+package scheduler
+
+import "time"
+
+// Clock tells the time and runs functions after a delay.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// AfterFunc calls f in its own goroutine after d has elapsed.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a pending call started by Clock.AfterFunc.
+type Timer interface {
+	// Stop prevents the call. It reports whether the call was still pending.
+	Stop() bool
+}
+
+// system is the clock of the operating system.
+type system struct{}
+
+// System returns the clock of the operating system.
+func System() Clock { return system{} }
+
+func (system) Now() time.Time { return time.Now() }
+
+func (system) AfterFunc(d time.Duration, f func()) Timer { return time.AfterFunc(d, f) }
@@ -0,0 +1,306 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/enetx/g"
+)
+
+// ErrInvalidSpec is returned for cron expressions and intervals that cannot be parsed.
+var ErrInvalidSpec = errors.New("invalid schedule")
+
+// Spec computes the run times of a recurring job.
+type Spec interface {
+	// Next returns the first run time after the given time, or the zero time if there is none.
+	Next(after time.Time) time.Time
+}
+
+// Interval is a Spec that runs at a fixed interval.
+type Interval time.Duration
+
+// Next returns after plus the interval.
+func (i Interval) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(i))
+}
+
+func (i Interval) String() string {
+	return "@every " + time.Duration(i).String()
+}
+
+// cron is a Spec parsed from a cron expression. Every field is a bit set of the allowed values.
+type cron struct {
+	expr   string
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	loc    *time.Location
+}
+
+// field describes the range and names of a cron field.
+type field struct {
+	name  string
+	min   int
+	max   int
+	names []string
+}
+
+var (
+	minutes = field{name: "minute", min: 0, max: 59}
+	hours   = field{name: "hour", min: 0, max: 23}
+	days    = field{name: "day of month", min: 1, max: 31}
+	months  = field{
+		name:  "month",
+		min:   1,
+		max:   12,
+		names: []string{"", "JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"},
+	}
+	weekdays = field{
+		name:  "day of week",
+		min:   0,
+		max:   7,
+		names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"},
+	}
+)
+
+// descriptors are the predefined schedules accepted by Parse.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a schedule in the standard five-field cron format: minute, hour, day of month,
+// month and day of week, e.g. "0 9 * * MON-FRI" or "*/15 * * * *". Fields accept lists, ranges,
+// steps and the names of months and weekdays; Sunday is 0 or 7. When both days are restricted,
+// a day matches either of them, as in Vixie cron.
+//
+// The descriptors @yearly, @monthly, @weekly, @daily, @hourly and "@every <duration>" are accepted
+// as well. A "CRON_TZ=<zone>" prefix evaluates the expression in that time zone, e.g.
+// "CRON_TZ=Europe/Berlin 0 9 * * *"; otherwise the time zone of the job is used.
+func Parse(expr g.String) g.Result[Spec] {
+	spec, err := parse(expr.Trim().Std())
+	if err != nil {
+		return g.Err[Spec](fmt.Errorf("%w %q: %w", ErrInvalidSpec, expr, err))
+	}
+
+	return g.Ok(spec)
+}
+
+func parse(expr string) (Spec, error) {
+	var loc *time.Location
+
+	if rest, ok := cutPrefix(expr, "CRON_TZ=", "TZ="); ok {
+		zone, tail, _ := strings.Cut(rest, " ")
+
+		l, err := time.LoadLocation(zone)
+		if err != nil {
+			return nil, err
+		}
+
+		loc, expr = l, strings.TrimSpace(tail)
+	}
+
+	if rest, ok := strings.CutPrefix(expr, "@every "); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, err
+		}
+
+		if d <= 0 {
+			return nil, errors.New("interval must be positive")
+		}
+
+		return Interval(d), nil
+	}
+
+	source := expr
+	if std, ok := descriptors[expr]; ok {
+		expr = std
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, got %d", len(parts))
+	}
+
+	c := &cron{expr: source, loc: loc}
+
+	for i, dst := range []struct {
+		bits *uint64
+		f    field
+	}{{&c.minute, minutes}, {&c.hour, hours}, {&c.dom, days}, {&c.month, months}, {&c.dow, weekdays}} {
+		set, err := parseField(parts[i], dst.f)
+		if err != nil {
+			return nil, err
+		}
+
+		*dst.bits = set
+	}
+
+	// Sunday may be written as 7.
+	if c.dow&(1<<7) != 0 {
+		c.dow = c.dow&^(1<<7) | 1
+	}
+
+	return c, nil
+}
+
+// cutPrefix removes the first of prefixes found at the start of s.
+func cutPrefix(s string, prefixes ...string) (string, bool) {
+	for _, prefix := range prefixes {
+		if rest, ok := strings.CutPrefix(s, prefix); ok {
+			return rest, true
+		}
+	}
+
+	return s, false
+}
+
+// starBit marks a field written as * or ?, which matters for the day of month and day of week.
+const starBit = 1 << 63
+
+// parseField parses a comma-separated list of values, ranges and steps into a bit set.
+func parseField(s string, f field) (uint64, error) {
+	var set uint64
+
+	for part := range strings.SplitSeq(s, ",") {
+		rng, step, hasStep := strings.Cut(part, "/")
+
+		var lo, hi int
+
+		switch {
+		case rng == "*" || rng == "?":
+			lo, hi = f.min, f.max
+			if !hasStep {
+				set |= starBit
+			}
+		default:
+			from, to, isRange := strings.Cut(rng, "-")
+
+			var err error
+			if lo, err = f.value(from); err != nil {
+				return 0, err
+			}
+
+			hi = lo
+
+			switch {
+			case isRange:
+				if hi, err = f.value(to); err != nil {
+					return 0, err
+				}
+			case hasStep:
+				hi = f.max
+			}
+		}
+
+		if lo > hi {
+			return 0, fmt.Errorf("%s range %s is reversed", f.name, rng)
+		}
+
+		n := 1
+		if hasStep {
+			var err error
+			if n, err = strconv.Atoi(step); err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid %s step %q", f.name, step)
+			}
+		}
+
+		for v := lo; v <= hi; v += n {
+			set |= 1 << v
+		}
+	}
+
+	return set, nil
+}
+
+// value parses a number or a name of the field.
+func (f field) value(s string) (int, error) {
+	for i, name := range f.names {
+		if name != "" && strings.EqualFold(s, name) {
+			return i, nil
+		}
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid %s %q", f.name, s)
+	}
+
+	return v, nil
+}
+
+// Next returns the first minute after the given time that matches the expression, in the time zone
+// of the expression or, without one, in the time zone of after. It returns the zero time if no such
+// minute exists within five years.
+func (c *cron) Next(after time.Time) time.Time {
+	loc := after.Location()
+	if c.loc != nil {
+		loc = c.loc
+	}
+
+	after = after.In(loc)
+	t := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute()+1, 0, 0, loc)
+	limit := t.Year() + 5
+
+	for t.Year() <= limit {
+		if !has(c.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !c.day(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !has(c.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+
+		if !has(c.minute, t.Minute()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// day reports whether the day of t matches the day of month and day of week fields.
+func (c *cron) day(t time.Time) bool {
+	dom := has(c.dom, t.Day())
+	dow := has(c.dow, int(t.Weekday()))
+
+	if c.dom&starBit != 0 || c.dow&starBit != 0 {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+// has reports whether v is in the bit set.
+func has(set uint64, v int) bool {
+	return set&(1<<v) != 0
+}
+
+func (c *cron) String() string {
+	if c.loc != nil {
+		return "CRON_TZ=" + c.loc.String() + " " + c.expr
+	}
+
+	return c.expr
+}
@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FakeClock is a Clock that only moves when told to, for testing jobs without waiting.
+// Due calls run synchronously in Advance and Set.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock   *FakeClock
+	at      time.Time
+	f       func()
+	stopped bool
+}
+
+// NewFakeClock returns a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// AfterFunc calls f when the clock is moved d or more forward.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{clock: c, at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, t)
+
+	return t
+}
+
+// Stop prevents the call. It reports whether the call was still pending.
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	pending := !t.stopped
+	t.stopped = true
+
+	return pending
+}
+
+// Advance moves the clock d forward. See Set.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set moves the clock to t, running the calls that become due in order of their time, including
+// the calls started by them. Before each call the clock is set to the time the call was due.
+func (c *FakeClock) Set(t time.Time) {
+	for {
+		c.mu.Lock()
+
+		c.timers = slices.DeleteFunc(c.timers, func(timer *fakeTimer) bool { return timer.stopped })
+		sort.SliceStable(c.timers, func(i, j int) bool { return c.timers[i].at.Before(c.timers[j].at) })
+
+		var due *fakeTimer
+
+		if len(c.timers) > 0 && !c.timers[0].at.After(t) {
+			due = c.timers[0]
+			due.stopped = true
+			c.timers = c.timers[1:]
+		}
+
+		if due == nil {
+			if t.After(c.now) {
+				c.now = t
+			}
+
+			c.mu.Unlock()
+
+			return
+		}
+
+		if due.at.After(c.now) {
+			c.now = due.at
+		}
+
+		c.mu.Unlock()
+
+		due.f()
+	}
+}
@@ -0,0 +1,331 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/enetx/g"
+)
+
+// KindRecurring is the kind of the records that keep the next run of recurring jobs in the store.
+// They are not run as jobs.
+const KindRecurring g.String = "recurring"
+
+// ErrOverlap is passed to the error hook when a run of a recurring job is skipped
+// because the previous run is still in progress.
+var ErrOverlap = errors.New("previous run still in progress")
+
+// maxCatchUp bounds the number of missed runs made up with MissedRunAll.
+const maxCatchUp = 1000
+
+// Missed is the policy for runs of a recurring job that were missed, because the bot was not
+// running when they were due or because a run came so late that later runs were due as well.
+type Missed int
+
+const (
+	// MissedSkip drops missed runs; the job waits for its next run time. It is the default.
+	MissedSkip Missed = iota
+
+	// MissedRunOnce makes up all missed runs with a single run.
+	MissedRunOnce
+
+	// MissedRunAll makes up every missed run, one after another, up to 1000 runs.
+	MissedRunAll
+)
+
+// scheduledKey is the context key of the time a run was scheduled for.
+type scheduledKey struct{}
+
+// Scheduled returns the time the run of a recurring job was scheduled for, which is earlier
+// than the current time for late and made-up runs. It returns the zero time outside of a run.
+func Scheduled(std context.Context) time.Time {
+	at, _ := std.Value(scheduledKey{}).(time.Time)
+	return at
+}
+
+// Recurring is a job that runs on a schedule. It is created with Every or Cron and configured
+// with its methods, which take effect right away.
+type Recurring struct {
+	s        *Scheduler
+	spec     Spec
+	fn       func(std context.Context) error
+	err      error
+	name     g.String
+	loc      *time.Location
+	overlap  bool
+	missed   Missed
+	next     time.Time
+	restored bool
+	fresh    bool
+	active   bool
+	running  int
+	gen      int
+	timer    Timer
+}
+
+// Every adds a job that calls fn every interval, first one interval from now.
+func (s *Scheduler) Every(interval time.Duration, fn func(std context.Context) error) *Recurring {
+	if interval <= 0 {
+		return s.recur(nil, fn, g.String(Interval(interval).String()), ErrInvalidSpec)
+	}
+
+	return s.recur(Interval(interval), fn, g.String(Interval(interval).String()), nil)
+}
+
+// Cron adds a job that calls fn at the times of a cron expression, see Parse. An invalid
+// expression is reported by Err and the job never runs.
+func (s *Scheduler) Cron(expr g.String, fn func(std context.Context) error) *Recurring {
+	spec := Parse(expr)
+	if spec.IsErr() {
+		return s.recur(nil, fn, expr, spec.Err())
+	}
+
+	return s.recur(spec.Ok(), fn, expr, nil)
+}
+
+// recur registers a recurring job and arms it if the scheduler is running.
+func (s *Scheduler) recur(spec Spec, fn func(std context.Context) error, name g.String, err error) *Recurring {
+	r := &Recurring{s: s, spec: spec, fn: fn, err: err, name: name, loc: time.Local, fresh: true, active: err == nil}
+
+	if err != nil {
+		return r
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.recurring[r] = struct{}{}
+
+	if s.started {
+		s.armRecurring(r)
+	}
+
+	return r
+}
+
+// Err returns the error of an invalid schedule.
+func (r *Recurring) Err() error {
+	return r.err
+}
+
+// Name sets the name under which the next run is stored, so that runs missed while the bot was
+// not running are detected after a restart. The default is the schedule expression; set a name
+// when several jobs share an expression.
+func (r *Recurring) Name(name g.String) *Recurring {
+	return r.update(func() {
+		if r.name != name {
+			r.s.forget(r.name)
+			r.name = name
+		}
+	})
+}
+
+// In sets the time zone of cron expressions without a CRON_TZ prefix. The default is the local time zone.
+func (r *Recurring) In(loc *time.Location) *Recurring {
+	return r.update(func() { r.loc = loc })
+}
+
+// AllowOverlap lets a run start while the previous one is still in progress.
+// By default such runs are skipped and reported to the error hook with ErrOverlap.
+func (r *Recurring) AllowOverlap() *Recurring {
+	return r.update(func() { r.overlap = true })
+}
+
+// Missed sets the policy for missed runs. The default is MissedSkip.
+func (r *Recurring) Missed(policy Missed) *Recurring {
+	return r.update(func() { r.missed = policy })
+}
+
+// Next returns the time of the next run, or None if the job is not scheduled.
+func (r *Recurring) Next() g.Option[time.Time] {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if !r.active || r.timer == nil {
+		return g.None[time.Time]()
+	}
+
+	return g.Some(r.next)
+}
+
+// Cancel stops the job and removes its stored next run. A run in progress is not interrupted.
+func (r *Recurring) Cancel() error {
+	s := r.s
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !r.active {
+		return ErrNotFound
+	}
+
+	r.active = false
+	r.disarm()
+	delete(s.recurring, r)
+
+	return s.forget(r.name)
+}
+
+// update applies fn and re-arms the job. Until its first run, the job is re-armed as if it was
+// just added, so that options set right after Every or Cron apply to the runs missed since a restart.
+func (r *Recurring) update(fn func()) *Recurring {
+	s := r.s
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fn()
+
+	if r.active && s.started {
+		s.armRecurring(r)
+	}
+
+	return r
+}
+
+// disarm stops the timer of the job. It must be called with s.mu held.
+func (r *Recurring) disarm() {
+	r.gen++
+
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+}
+
+// recordID returns the ID of the store record that keeps the next run of the job named name.
+func recordID(name g.String) JobID {
+	return JobID(KindRecurring + ":" + name)
+}
+
+// forget removes the next run of the job named name loaded from the store, and the stored record.
+// It must be called with s.mu held.
+func (s *Scheduler) forget(name g.String) error {
+	delete(s.marks, name)
+	return s.store.Delete(s.std, recordID(name))
+}
+
+// armRecurring computes the next run of r and starts its timer. A job that has not run since it was
+// added or since the scheduler started continues from the next run loaded from the store, which is
+// made up according to its policy if it has passed. It must be called with s.mu held.
+func (s *Scheduler) armRecurring(r *Recurring) {
+	r.disarm()
+
+	now := s.clock.Now()
+	r.restored = false
+
+	if mark, ok := s.marks[r.name]; ok && r.fresh {
+		r.next = mark
+		r.restored = !mark.After(now)
+	} else {
+		r.next = r.spec.Next(now.In(r.loc))
+	}
+
+	if r.next.IsZero() {
+		return
+	}
+
+	if !r.restored {
+		s.mark(r)
+	}
+
+	gen := r.gen
+	r.timer = s.clock.AfterFunc(max(r.next.Sub(now), 0), func() { s.fireRecurring(r, gen) })
+}
+
+// mark stores the next run of r. A failure is reported to the error hook, which is called
+// in its own goroutine because s.mu is held.
+func (s *Scheduler) mark(r *Recurring) {
+	job := Job{ID: recordID(r.name), Kind: KindRecurring, At: r.next}
+	if err := s.store.Save(s.std, job); err != nil && s.onError != nil {
+		go s.onError(job, fmt.Errorf("failed to store next run: %w", err))
+	}
+}
+
+// fireRecurring runs the due runs of r and arms its next run.
+func (s *Scheduler) fireRecurring(r *Recurring, gen int) {
+	s.mu.Lock()
+
+	if r.gen != gen || !r.active || !s.started {
+		s.mu.Unlock()
+		return
+	}
+
+	now := s.clock.Now()
+
+	due := g.SliceOf(r.next)
+	for t := r.spec.Next(r.next.In(r.loc)); !t.IsZero() && !t.After(now) && due.Len() < maxCatchUp; t = r.spec.Next(t) {
+		due.Push(t)
+	}
+
+	switch {
+	case r.restored && r.missed == MissedSkip:
+		due = nil
+	case r.missed == MissedRunAll:
+	case r.restored:
+		due = due[due.Len()-1:]
+	default:
+		due = due[:1]
+	}
+
+	r.fresh = false
+	r.next = r.spec.Next(maxTime(now, r.next).In(r.loc))
+
+	s.armNext(r)
+
+	if due.IsEmpty() {
+		s.mu.Unlock()
+		return
+	}
+
+	if r.running > 0 && !r.overlap {
+		s.mu.Unlock()
+		s.report(Job{ID: recordID(r.name), Kind: KindRecurring, At: due[0]}, ErrOverlap)
+
+		return
+	}
+
+	r.running++
+	s.running.Add(1)
+	std := s.std
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		r.running--
+		s.mu.Unlock()
+		s.running.Done()
+	}()
+
+	for _, at := range due {
+		if err := call(context.WithValue(std, scheduledKey{}, at), r.fn); err != nil {
+			s.report(Job{ID: recordID(r.name), Kind: KindRecurring, At: at}, err)
+		}
+	}
+}
+
+// armNext stores and arms the next run of r computed by a run. It must be called with s.mu held.
+func (s *Scheduler) armNext(r *Recurring) {
+	r.restored = false
+
+	if r.next.IsZero() {
+		r.timer = nil
+		return
+	}
+
+	s.mark(r)
+
+	gen := r.gen
+	r.timer = s.clock.AfterFunc(max(r.next.Sub(s.clock.Now()), 0), func() { s.fireRecurring(r, gen) })
+}
+
+// maxTime returns the later of a and b.
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
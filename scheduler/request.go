@@ -0,0 +1,206 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"strconv"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/retry"
+)
+
+// KindRequest is the kind of jobs that send a stored Bot API request.
+const KindRequest g.String = "request"
+
+// Request is a Bot API request sent by a job.
+type Request struct {
+	Method      g.String          `json:"method"`                // Bot API method, e.g. sendMessage
+	Params      map[string]string `json:"params,omitempty"`      // Encoded parameters of the method
+	Timeout     time.Duration     `json:"timeout,omitzero"`      // Request timeout, zero for the bot's default
+	APIURL      g.String          `json:"api_url,omitempty"`     // Bot API server, empty for the bot's default
+	DeleteAfter time.Duration     `json:"delete_after,omitzero"` // Delay before deleting the sent messages, zero to keep them
+	Retry       *retry.Policy     `json:"retry,omitempty"`       // Retry policy of the request, nil for the bot's default
+	files       map[string]upload // Files uploaded with the request, which cannot be stored
+}
+
+// upload is a file read into memory when its request was recorded.
+type upload struct {
+	name string
+	data []byte
+}
+
+// Request adds a job that sends req at at and returns its ID. Requests that upload files
+// are kept in memory only; all others are stored as jobs of kind KindRequest.
+func (s *Scheduler) Request(at time.Time, req Request) g.Result[JobID] {
+	if len(req.files) == 0 {
+		return s.Add(KindRequest, at, req)
+	}
+
+	return g.Ok(s.AddFunc(at, func(std context.Context) error { return s.send(std, req) }))
+}
+
+// errRecorded aborts a request captured by Record.
+var errRecorded = errors.New("request recorded")
+
+// errRecordCount is returned by Record when send does not make exactly one request.
+var errRecordCount = errors.New("send must make exactly one request")
+
+// recorder is a client that captures requests instead of sending them.
+type recorder struct {
+	gotgbot.BotClient
+	reqs g.Slice[Request]
+	err  error
+}
+
+func (r *recorder) RequestWithContext(
+	std context.Context,
+	_ string,
+	method string,
+	params map[string]string,
+	data map[string]gotgbot.FileReader,
+	opts *gotgbot.RequestOpts,
+) (json.RawMessage, error) {
+	req := Request{Method: g.String(method), Params: maps.Clone(params), Retry: retry.PolicyOf(std).UnwrapOrDefault()}
+	if opts != nil {
+		req.Timeout = opts.Timeout
+		req.APIURL = g.String(opts.APIURL)
+	}
+
+	if len(data) > 0 {
+		req.files = make(map[string]upload, len(data))
+
+		for field, file := range data {
+			content, err := io.ReadAll(file.Data)
+			if err != nil {
+				r.err = fmt.Errorf("failed to read file %s: %w", file.Name, err)
+				return nil, r.err
+			}
+
+			req.files[field] = upload{name: file.Name, data: content}
+		}
+	}
+
+	r.reqs.Push(req)
+
+	return nil, errRecorded
+}
+
+// Record calls send with a copy of raw that captures the Bot API request instead of sending it.
+// Files uploaded by the request are read into memory, and the retry policy set on the context of
+// the request with retry.WithPolicy is kept. It returns the error of send if no request
+// was made, e.g. when the builder is invalid, and an error if send made more than one request.
+func Record(raw *gotgbot.Bot, send func(raw *gotgbot.Bot) error) g.Result[Request] {
+	rec := &recorder{BotClient: raw.BotClient}
+	err := send(&gotgbot.Bot{Token: raw.Token, User: raw.User, BotClient: rec})
+
+	switch {
+	case rec.err != nil:
+		return g.Err[Request](rec.err)
+	case rec.reqs.IsEmpty() && err != nil && !errors.Is(err, errRecorded):
+		return g.Err[Request](err)
+	case rec.reqs.Len() != 1:
+		return g.Err[Request](fmt.Errorf("%w, got %d", errRecordCount, rec.reqs.Len()))
+	}
+
+	return g.Ok(rec.reqs[0])
+}
+
+// request runs a job of kind KindRequest.
+func (s *Scheduler) request(std context.Context, job Job) error {
+	var req Request
+	if err := json.Unmarshal(job.Payload, &req); err != nil {
+		return fmt.Errorf("failed to decode request: %w", err)
+	}
+
+	return s.send(std, req)
+}
+
+// send sends req through the bot and schedules deletion of the sent messages if requested.
+func (s *Scheduler) send(std context.Context, req Request) error {
+	var opts *gotgbot.RequestOpts
+	if req.Timeout > 0 || !req.APIURL.IsEmpty() {
+		opts = &gotgbot.RequestOpts{Timeout: req.Timeout, APIURL: req.APIURL.Std()}
+	}
+
+	var data map[string]gotgbot.FileReader
+	if len(req.files) > 0 {
+		data = make(map[string]gotgbot.FileReader, len(req.files))
+		for field, file := range req.files {
+			data[field] = gotgbot.FileReader{Name: file.name, Data: bytes.NewReader(file.data)}
+		}
+	}
+
+	raw := s.bot.Raw()
+
+	resp, err := raw.RequestWithContext(retry.WithPolicy(std, req.Retry), req.Method.Std(), req.Params, data, opts)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", req.Method, err)
+	}
+
+	if req.DeleteAfter <= 0 {
+		return nil
+	}
+
+	ids := messageIDs(resp)
+	if ids.IsEmpty() {
+		return nil
+	}
+
+	del := DeleteMessages(req.Params["chat_id"], ids...)
+	del.Timeout, del.APIURL, del.Retry = req.Timeout, req.APIURL, req.Retry
+
+	if r := s.Request(s.Now().Add(req.DeleteAfter), del); r.IsErr() {
+		return fmt.Errorf("failed to schedule deletion: %w", r.Err())
+	}
+
+	return nil
+}
+
+// DeleteMessages returns a request that deletes messages in the chat, given as an ID or @username.
+func DeleteMessages(chatID string, messageIDs ...int64) Request {
+	if len(messageIDs) == 1 {
+		return Request{
+			Method: "deleteMessage",
+			Params: map[string]string{"chat_id": chatID, "message_id": strconv.FormatInt(messageIDs[0], 10)},
+		}
+	}
+
+	ids, _ := json.Marshal(messageIDs)
+
+	return Request{
+		Method: "deleteMessages",
+		Params: map[string]string{"chat_id": chatID, "message_ids": string(ids)},
+	}
+}
+
+// messageIDs returns the IDs of the messages in the result of a send request:
+// a message or a list of messages.
+func messageIDs(resp json.RawMessage) g.Slice[int64] {
+	type sent struct {
+		MessageID int64 `json:"message_id"`
+	}
+
+	var ids g.Slice[int64]
+
+	var one sent
+	if json.Unmarshal(resp, &one) == nil && one.MessageID != 0 {
+		ids.Push(one.MessageID)
+		return ids
+	}
+
+	var many []sent
+	if json.Unmarshal(resp, &many) == nil {
+		for _, m := range many {
+			ids.Push(m.MessageID)
+		}
+	}
+
+	return ids
+}
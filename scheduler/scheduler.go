@@ -0,0 +1,535 @@
+// Package scheduler runs jobs at a given time and keeps them in a store, so that pending jobs
+// survive restarts.
+//
+// Delayed sends and deletions of the ctx builders, After and DeleteAfter, are jobs of the bot's
+// scheduler. Their Bot API request is recorded and sent when the job is due; sends that upload
+// files are kept in memory only, all others are stored. Schedule returns the ID of the job:
+//
+//	id := c.SendMessage("Reminder").After(time.Hour).Schedule().Unwrap()
+//	b.Scheduler().Reschedule(id, time.Now().Add(2*time.Hour))
+//	b.Scheduler().Cancel(id)
+//
+// Applications can add jobs of their own kinds, stored with a JSON payload:
+//
+//	b.Scheduler().Handle("digest", func(std context.Context, job scheduler.Job) error { ... })
+//	b.Scheduler().Add("digest", time.Now().Add(24*time.Hour), digest)
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/enetx/g"
+	"github.com/enetx/g/cmp"
+	"github.com/enetx/tg/core"
+)
+
+// KindFunc is the kind of jobs added with AddFunc. They are kept in memory only.
+const KindFunc g.String = "func"
+
+var (
+	// ErrNotFound is returned by Cancel and Reschedule for jobs that already ran, were cancelled or never existed.
+	ErrNotFound = errors.New("job not found")
+
+	// ErrUnknownKind is passed to the error hook for jobs that are due while their kind has no handler.
+	// The job stays pending and runs as soon as a handler for its kind is set.
+	ErrUnknownKind = errors.New("no handler for job kind")
+
+	// ErrStopped is passed to the error hook for in-memory jobs dropped when the scheduler stops.
+	ErrStopped = errors.New("scheduler stopped")
+)
+
+// JobID identifies a job.
+type JobID string
+
+// Job is a unit of work due at a given time.
+type Job struct {
+	ID      JobID           `json:"id"`                // Unique ID of the job
+	Kind    g.String        `json:"kind"`              // Kind that selects the handler running the job
+	At      time.Time       `json:"at"`                // Time the job is due
+	Payload json.RawMessage `json:"payload,omitempty"` // JSON-encoded input of the handler
+}
+
+// Handler runs a job of a kind. The context is not cancelled on shutdown, so that a running job can finish.
+type Handler func(std context.Context, job Job) error
+
+// ErrorHandler is called with jobs that failed or could not run.
+type ErrorHandler func(job Job, err error)
+
+// entry is a job known to the scheduler with its timer and, for in-memory jobs, its function.
+type entry struct {
+	job   Job
+	fn    func(std context.Context) error
+	timer Timer
+}
+
+// Scheduler runs jobs when they are due.
+type Scheduler struct {
+	bot     core.BotAPI
+	mu      sync.Mutex
+	store   Store
+	clock   Clock
+	kinds   map[g.String]Handler
+	entries map[JobID]*entry
+	onError ErrorHandler
+	std     context.Context
+	started bool
+	running sync.WaitGroup
+	active  int           // Number of running one-off jobs
+	changed chan struct{} // Closed and replaced when a job finishes or pending jobs change
+
+	recurring map[*Recurring]struct{}
+	marks     map[g.String]time.Time // Next runs of recurring jobs, by name, loaded from the store
+}
+
+// New returns a scheduler for bot that keeps jobs in memory. Requests of jobs are sent through bot.
+func New(bot core.BotAPI) *Scheduler {
+	s := &Scheduler{
+		bot:     bot,
+		store:   NewMemory(),
+		clock:   System(),
+		kinds:   make(map[g.String]Handler),
+		entries: make(map[JobID]*entry),
+		std:     context.Background(),
+		changed: make(chan struct{}),
+
+		recurring: make(map[*Recurring]struct{}),
+		marks:     make(map[g.String]time.Time),
+	}
+
+	s.kinds[KindRequest] = s.request
+
+	return s
+}
+
+// Store sets where jobs are kept, e.g. NewFile to run pending jobs after a restart.
+// It must be set before the scheduler starts.
+func (s *Scheduler) Store(store Store) *Scheduler {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.store = store
+
+	return s
+}
+
+// Clock sets the clock used to read the time and wait for jobs, for tests.
+func (s *Scheduler) Clock(clock Clock) *Scheduler {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.clock = clock
+
+	return s
+}
+
+// OnError sets a hook called with jobs that failed, and with jobs that could not run.
+func (s *Scheduler) OnError(fn ErrorHandler) *Scheduler {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onError = fn
+
+	return s
+}
+
+// Handle sets the handler that runs jobs of kind. Jobs of kind that were due before are run right away.
+func (s *Scheduler) Handle(kind g.String, fn Handler) *Scheduler {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.kinds[kind] = fn
+
+	if s.started {
+		for _, e := range s.entries {
+			if e.job.Kind == kind && e.timer == nil {
+				s.arm(e)
+			}
+		}
+	}
+
+	return s
+}
+
+// Now returns the current time of the scheduler's clock.
+func (s *Scheduler) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.clock.Now()
+}
+
+// Start loads the pending jobs from the store and runs every job when it is due; overdue jobs run
+// right away. Jobs added before Start wait for it. The bot starts its scheduler when it is built.
+// Calling Start again has no effect.
+func (s *Scheduler) Start(std context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return nil
+	}
+
+	jobs := s.store.Load(std)
+	if jobs.IsErr() {
+		return fmt.Errorf("failed to load jobs: %w", jobs.Err())
+	}
+
+	for _, job := range jobs.Ok() {
+		if job.Kind == KindRecurring {
+			name := g.String(job.ID).StripPrefix(KindRecurring + ":")
+			s.marks[name] = job.At
+
+			continue
+		}
+
+		if _, ok := s.entries[job.ID]; !ok {
+			s.entries[job.ID] = &entry{job: job}
+		}
+	}
+
+	s.std = context.WithoutCancel(std)
+	s.started = true
+
+	for _, e := range s.entries {
+		s.arm(e)
+	}
+
+	for r := range s.recurring {
+		s.armRecurring(r)
+	}
+
+	return nil
+}
+
+// Stop stops running jobs when they are due and waits for the running ones to finish.
+// Stored jobs stay in the store and run after the next Start; in-memory jobs are dropped
+// and passed to the error hook with ErrStopped. Recurring jobs continue after the next Start,
+// where their missed runs are handled according to their policy.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+
+	var dropped g.Slice[Job]
+
+	for id, e := range s.entries {
+		if e.timer != nil {
+			e.timer.Stop()
+			e.timer = nil
+		}
+
+		if e.fn != nil {
+			dropped.Push(e.job)
+			delete(s.entries, id)
+		}
+	}
+
+	for r := range s.recurring {
+		if r.timer != nil {
+			s.marks[r.name] = r.next
+		}
+
+		r.disarm()
+		r.fresh = true
+	}
+
+	s.started = false
+	onError := s.onError
+
+	s.mu.Unlock()
+
+	if onError != nil {
+		for _, job := range dropped {
+			onError(job, ErrStopped)
+		}
+	}
+
+	s.running.Wait()
+}
+
+// Drain runs the jobs due before deadline when they are due, including jobs they add, such as
+// deletions of sent messages, and then stops the scheduler like Stop. The bot drains its scheduler
+// on graceful shutdown, with the drain timeout as the deadline.
+func (s *Scheduler) Drain(deadline time.Time) {
+	s.wait(deadline)
+	s.Stop()
+}
+
+// wait waits until no job due before deadline is pending or running, or until deadline.
+func (s *Scheduler) wait(deadline time.Time) {
+	s.mu.Lock()
+	expired := make(chan struct{})
+	timer := s.clock.AfterFunc(max(deadline.Sub(s.clock.Now()), 0), func() { close(expired) })
+	s.mu.Unlock()
+
+	defer timer.Stop()
+
+	for {
+		s.mu.Lock()
+		pending, changed := s.pending(deadline), s.changed
+		s.mu.Unlock()
+
+		if !pending {
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-expired:
+			return
+		}
+	}
+}
+
+// pending reports whether a job is running, or armed and due before deadline.
+// It must be called with s.mu held.
+func (s *Scheduler) pending(deadline time.Time) bool {
+	if !s.started {
+		return false
+	}
+
+	if s.active > 0 {
+		return true
+	}
+
+	for _, e := range s.entries {
+		if e.timer != nil && !e.job.At.After(deadline) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// notify wakes up Drain to check the pending jobs again. It must be called with s.mu held.
+func (s *Scheduler) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// Add stores a job of kind due at at, with payload encoded as JSON, and returns its ID.
+func (s *Scheduler) Add(kind g.String, at time.Time, payload any) g.Result[JobID] {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return g.Err[JobID](fmt.Errorf("failed to encode job payload: %w", err))
+	}
+
+	job := Job{ID: newID(), Kind: kind, At: at, Payload: data}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.store.Save(s.std, job); err != nil {
+		return g.Err[JobID](fmt.Errorf("failed to store job: %w", err))
+	}
+
+	e := &entry{job: job}
+	s.entries[job.ID] = e
+
+	if s.started {
+		s.arm(e)
+	}
+
+	return g.Ok(job.ID)
+}
+
+// AddFunc adds a job that calls fn at at and returns its ID. The job is kept in memory only,
+// so it is lost on restart; use Add with a handled kind for jobs that must survive restarts.
+func (s *Scheduler) AddFunc(at time.Time, fn func(std context.Context) error) JobID {
+	job := Job{ID: newID(), Kind: KindFunc, At: at}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := &entry{job: job, fn: fn}
+	s.entries[job.ID] = e
+
+	if s.started {
+		s.arm(e)
+	}
+
+	return job.ID
+}
+
+// Cancel removes a pending job. It returns ErrNotFound if the job already ran or does not exist.
+func (s *Scheduler) Cancel(id JobID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[id]
+	if !ok {
+		return ErrNotFound
+	}
+
+	if e.fn == nil {
+		if err := s.store.Delete(s.std, id); err != nil {
+			return fmt.Errorf("failed to delete job %s: %w", id, err)
+		}
+	}
+
+	if e.timer != nil {
+		e.timer.Stop()
+	}
+
+	delete(s.entries, id)
+	s.notify()
+
+	return nil
+}
+
+// Reschedule moves a pending job to at. It returns ErrNotFound if the job already ran or does not exist.
+func (s *Scheduler) Reschedule(id JobID, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[id]
+	if !ok {
+		return ErrNotFound
+	}
+
+	job := e.job
+	job.At = at
+
+	if e.fn == nil {
+		if err := s.store.Save(s.std, job); err != nil {
+			return fmt.Errorf("failed to store job %s: %w", id, err)
+		}
+	}
+
+	e.job = job
+
+	if e.timer != nil {
+		e.timer.Stop()
+		e.timer = nil
+	}
+
+	if s.started {
+		s.arm(e)
+	}
+
+	s.notify()
+
+	return nil
+}
+
+// Job returns the pending job with the given ID.
+func (s *Scheduler) Job(id JobID) g.Option[Job] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[id]; ok {
+		return g.Some(e.job)
+	}
+
+	return g.None[Job]()
+}
+
+// Jobs returns the pending jobs ordered by the time they are due.
+func (s *Scheduler) Jobs() g.Slice[Job] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := g.NewSlice[Job](0, g.Int(len(s.entries)))
+	for _, e := range s.entries {
+		jobs.Push(e.job)
+	}
+
+	jobs.SortBy(func(a, b Job) cmp.Ordering { return cmp.Cmp(a.At.UnixNano(), b.At.UnixNano()) })
+
+	return jobs
+}
+
+// arm starts the timer of e. It must be called with s.mu held.
+func (s *Scheduler) arm(e *entry) {
+	id := e.job.ID
+	e.timer = s.clock.AfterFunc(max(e.job.At.Sub(s.clock.Now()), 0), func() { s.fire(id) })
+}
+
+// fire runs the job with the given ID if it is still pending. Jobs of a kind without a handler
+// stay pending until Handle sets one.
+func (s *Scheduler) fire(id JobID) {
+	s.mu.Lock()
+
+	e, ok := s.entries[id]
+	if !ok || !s.started {
+		s.mu.Unlock()
+		return
+	}
+
+	handler, known := s.kinds[e.job.Kind]
+	std, store, onError := s.std, s.store, s.onError
+
+	if e.fn == nil && !known {
+		e.timer = nil
+		s.notify()
+		s.mu.Unlock()
+
+		if onError != nil {
+			onError(e.job, fmt.Errorf("%w %s", ErrUnknownKind, e.job.Kind))
+		}
+
+		return
+	}
+
+	delete(s.entries, id)
+
+	s.active++
+	s.running.Add(1)
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.active--
+		s.notify()
+		s.mu.Unlock()
+
+		s.running.Done()
+	}()
+
+	var err error
+
+	if e.fn != nil {
+		err = call(std, e.fn)
+	} else {
+		err = call(std, func(std context.Context) error { return handler(std, e.job) })
+
+		if derr := store.Delete(std, id); derr != nil && err == nil {
+			err = fmt.Errorf("failed to delete job %s: %w", id, derr)
+		}
+	}
+
+	if err != nil && onError != nil {
+		onError(e.job, err)
+	}
+}
+
+// report passes a failed job to the error hook. It must be called without s.mu held.
+func (s *Scheduler) report(job Job, err error) {
+	s.mu.Lock()
+	onError := s.onError
+	s.mu.Unlock()
+
+	if onError != nil {
+		onError(job, err)
+	}
+}
+
+// call runs fn and returns a panic of fn as an error.
+func call(std context.Context, fn func(std context.Context) error) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("job panicked: %v", v)
+		}
+	}()
+
+	return fn(std)
+}
+
+// newID returns a random job ID.
+func newID() JobID {
+	return JobID(rand.Text())
+}
@@ -0,0 +1,191 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/enetx/g"
+)
+
+// Store persists pending jobs. Implementations must be safe for concurrent use.
+type Store interface {
+	// Load returns all stored jobs.
+	Load(std context.Context) g.Result[g.Slice[Job]]
+
+	// Save adds job or replaces the stored job with the same ID.
+	Save(std context.Context, job Job) error
+
+	// Delete removes the job with the given ID. Deleting a missing job is not an error.
+	Delete(std context.Context, id JobID) error
+}
+
+// Memory is a Store that keeps jobs in memory. Jobs are lost on restart.
+type Memory struct {
+	mu   sync.Mutex
+	jobs map[JobID]Job
+}
+
+// NewMemory returns an empty in-memory store.
+func NewMemory() *Memory {
+	return &Memory{jobs: make(map[JobID]Job)}
+}
+
+// Load returns all stored jobs.
+func (m *Memory) Load(context.Context) g.Result[g.Slice[Job]] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return g.Ok(g.Map[JobID, Job](m.jobs).Values())
+}
+
+// Save adds or replaces job.
+func (m *Memory) Save(_ context.Context, job Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.jobs[job.ID] = job
+
+	return nil
+}
+
+// Delete removes the job with the given ID.
+func (m *Memory) Delete(_ context.Context, id JobID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.jobs, id)
+
+	return nil
+}
+
+// File is a Store that keeps jobs in a JSON file, so that they run after a restart.
+// The file is read on first use and rewritten atomically after every change. It must not be
+// shared by several running instances.
+type File struct {
+	mu   sync.Mutex
+	path g.String
+	jobs map[JobID]Job
+}
+
+// NewFile returns a store backed by the JSON file at path. The file is created on the first save.
+func NewFile(path g.String) *File {
+	return &File{path: path}
+}
+
+// Load returns all stored jobs.
+func (f *File) Load(context.Context) g.Result[g.Slice[Job]] {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.open(); err != nil {
+		return g.Err[g.Slice[Job]](err)
+	}
+
+	return g.Ok(g.Map[JobID, Job](f.jobs).Values())
+}
+
+// Save adds or replaces job.
+func (f *File) Save(_ context.Context, job Job) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	prev, existed := f.jobs[job.ID]
+	f.jobs[job.ID] = job
+
+	if err := f.write(); err != nil {
+		if existed {
+			f.jobs[job.ID] = prev
+		} else {
+			delete(f.jobs, job.ID)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes the job with the given ID.
+func (f *File) Delete(_ context.Context, id JobID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	prev, ok := f.jobs[id]
+	if !ok {
+		return nil
+	}
+
+	delete(f.jobs, id)
+
+	if err := f.write(); err != nil {
+		f.jobs[id] = prev
+		return err
+	}
+
+	return nil
+}
+
+// open reads the file on first use. A missing file is an empty store.
+func (f *File) open() error {
+	if f.jobs != nil {
+		return nil
+	}
+
+	jobs := make(map[JobID]Job)
+
+	data, err := os.ReadFile(f.path.Std())
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return err
+	case len(data) > 0:
+		if err := json.Unmarshal(data, &jobs); err != nil {
+			return err
+		}
+	}
+
+	f.jobs = jobs
+
+	return nil
+}
+
+// write replaces the file with the current jobs through a temporary file in the same directory.
+func (f *File) write() error {
+	data, err := json.Marshal(f.jobs)
+	if err != nil {
+		return err
+	}
+
+	path := f.path.Std()
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
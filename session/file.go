@@ -0,0 +1,164 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/enetx/g"
+)
+
+// File is a Store that keeps records in a JSON file, so that sessions survive restarts.
+// The file is read on first use and rewritten atomically after every change. It must not be
+// shared by several running instances; implement Store over a shared database for that.
+type File struct {
+	mu      sync.Mutex
+	path    g.String
+	records map[g.String]Record
+	now     func() time.Time
+}
+
+// NewFile returns a store backed by the JSON file at path. The file is created on the first save.
+func NewFile(path g.String) *File {
+	return &File{path: path, now: time.Now}
+}
+
+// Clock sets the function used to read the current time, for tests.
+func (f *File) Clock(now func() time.Time) *File {
+	f.now = now
+	return f
+}
+
+// Load returns the record stored under key, or None if there is none or it has expired.
+func (f *File) Load(_ context.Context, key g.String) g.Result[g.Option[Record]] {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.open(); err != nil {
+		return g.Err[g.Option[Record]](err)
+	}
+
+	rec, ok := f.records[key]
+	if !ok || rec.expired(f.now()) {
+		return g.Ok(g.None[Record]())
+	}
+
+	return g.Ok(g.Some(rec))
+}
+
+// Save stores rec under key if the stored version equals rec.Version, or returns ErrConflict.
+// Expired records are dropped from the file on every save.
+func (f *File) Save(_ context.Context, key g.String, rec Record) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	now := f.now()
+
+	if err := check(f.records, key, rec, now); err != nil {
+		return err
+	}
+
+	expire(f.records, now)
+
+	prev, existed := f.records[key]
+
+	rec.Version++
+	f.records[key] = rec
+
+	if err := f.write(); err != nil {
+		if existed {
+			f.records[key] = prev
+		} else {
+			delete(f.records, key)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes the record stored under key.
+func (f *File) Delete(_ context.Context, key g.String) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	prev, ok := f.records[key]
+	if !ok {
+		return nil
+	}
+
+	delete(f.records, key)
+
+	if err := f.write(); err != nil {
+		f.records[key] = prev
+		return err
+	}
+
+	return nil
+}
+
+// open reads the file on first use. A missing file is an empty store.
+func (f *File) open() error {
+	if f.records != nil {
+		return nil
+	}
+
+	records := make(map[g.String]Record)
+
+	data, err := os.ReadFile(f.path.Std())
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return err
+	case len(data) > 0:
+		if err := json.Unmarshal(data, &records); err != nil {
+			return err
+		}
+	}
+
+	f.records = records
+
+	return nil
+}
+
+// write replaces the file with the current records through a temporary file in the same directory.
+func (f *File) write() error {
+	data, err := json.Marshal(f.records)
+	if err != nil {
+		return err
+	}
+
+	path := f.path.Std()
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,124 @@
+package session
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/enetx/g"
+)
+
+// sweepInterval is how often expired records are released by the built-in stores.
+const sweepInterval = time.Minute
+
+// Memory is a Store that keeps records in memory. Records are lost on restart
+// and are not shared between instances.
+type Memory struct {
+	mu      sync.Mutex
+	records map[g.String]Record
+	now     func() time.Time
+	swept   time.Time
+}
+
+// NewMemory returns an empty in-memory store.
+func NewMemory() *Memory {
+	return &Memory{records: make(map[g.String]Record), now: time.Now}
+}
+
+// Clock sets the function used to read the current time, for tests.
+func (m *Memory) Clock(now func() time.Time) *Memory {
+	m.now = now
+	return m
+}
+
+// Load returns the record stored under key, or None if there is none or it has expired.
+func (m *Memory) Load(_ context.Context, key g.String) g.Result[g.Option[Record]] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rec, ok := m.records[key]
+	if !ok || rec.expired(m.now()) {
+		return g.Ok(g.None[Record]())
+	}
+
+	return g.Ok(g.Some(rec))
+}
+
+// Save stores rec under key if the stored version equals rec.Version, or returns ErrConflict.
+func (m *Memory) Save(_ context.Context, key g.String, rec Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+
+	if err := check(m.records, key, rec, now); err != nil {
+		return err
+	}
+
+	rec.Version++
+	m.records[key] = rec
+
+	return nil
+}
+
+// Delete removes the record stored under key.
+func (m *Memory) Delete(_ context.Context, key g.String) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.records, key)
+
+	return nil
+}
+
+// Len returns the number of records that have not expired.
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+
+	var n int
+
+	for _, rec := range m.records {
+		if !rec.expired(now) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// sweep removes expired records at most once per sweepInterval.
+func (m *Memory) sweep(now time.Time) {
+	if now.Sub(m.swept) < sweepInterval {
+		return
+	}
+
+	m.swept = now
+	expire(m.records, now)
+}
+
+// check returns ErrConflict unless the version of the live record under key equals rec.Version.
+func check(records map[g.String]Record, key g.String, rec Record, now time.Time) error {
+	var version int64
+	if stored, ok := records[key]; ok && !stored.expired(now) {
+		version = stored.Version
+	}
+
+	if version != rec.Version {
+		return ErrConflict
+	}
+
+	return nil
+}
+
+// expire removes expired records.
+func expire(records map[g.String]Record, now time.Time) {
+	for key, rec := range records {
+		if rec.expired(now) {
+			delete(records, key)
+		}
+	}
+}
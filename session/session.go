@@ -0,0 +1,196 @@
+// Package session keeps ctx.Session data between updates in a pluggable store.
+//
+// Sessions are keyed by user, by chat or by user within a chat. The middleware loads the
+// session before the handler runs and saves it afterwards if it changed. Saves use optimistic
+// concurrency: a session modified by another update or instance since it was loaded is not
+// overwritten, and the handler's update fails with ErrConflict instead.
+//
+//	b.Around(session.New(session.NewFile("sessions.json")).TTL(24 * time.Hour).Middleware())
+//
+//	b.Command("count", func(c *ctx.Context) error {
+//		n := ctx.SessionValue[int](c.Session(), "count").UnwrapOrDefault() + 1
+//		c.Session().Set("count", n)
+//		return c.Reply(g.Format("You called me {} times", n)).Send().Err()
+//	})
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/handlers"
+)
+
+// ErrConflict is returned by Store.Save when the stored session has a different version
+// than the one being replaced, because it was saved by someone else in the meantime.
+var ErrConflict = errors.New("session was modified concurrently")
+
+// Record is a session as kept by a Store.
+type Record struct {
+	Data    json.RawMessage `json:"data"`             // JSON-encoded session data
+	Version int64           `json:"version"`          // Incremented by every save, 0 for a new session
+	Expires time.Time       `json:"expires,omitzero"` // Time after which the record is gone, zero for never
+}
+
+// expired reports whether the record has expired at now.
+func (r Record) expired(now time.Time) bool {
+	return !r.Expires.IsZero() && !now.Before(r.Expires)
+}
+
+// Store persists session records. Implementations must be safe for concurrent use
+// and treat expired records as missing.
+type Store interface {
+	// Load returns the record stored under key, or None if there is none.
+	Load(std context.Context, key g.String) g.Result[g.Option[Record]]
+
+	// Save stores rec under key with its version incremented, if the stored version still equals
+	// rec.Version (0 when there is no record). Otherwise it returns ErrConflict.
+	Save(std context.Context, key g.String, rec Record) error
+
+	// Delete removes the record stored under key regardless of its version.
+	Delete(std context.Context, key g.String) error
+}
+
+// KeyFunc returns the key of the session an update belongs to, or None if the update has no session.
+type KeyFunc func(c *ctx.Context) g.Option[g.String]
+
+// ByUser keys sessions by the user who sent the update, shared across all chats.
+func ByUser(c *ctx.Context) g.Option[g.String] {
+	if c.EffectiveUser == nil {
+		return g.None[g.String]()
+	}
+
+	return g.Some(g.Format("user:{}", c.EffectiveUser.Id))
+}
+
+// ByChat keys sessions by the chat of the update, shared by all its members.
+func ByChat(c *ctx.Context) g.Option[g.String] {
+	if c.EffectiveChat == nil {
+		return g.None[g.String]()
+	}
+
+	return g.Some(g.Format("chat:{}", c.EffectiveChat.Id))
+}
+
+// ByChatUser keys sessions by the user within the chat of the update.
+func ByChatUser(c *ctx.Context) g.Option[g.String] {
+	if c.EffectiveChat == nil || c.EffectiveUser == nil {
+		return g.None[g.String]()
+	}
+
+	return g.Some(g.Format("chat:{}:user:{}", c.EffectiveChat.Id, c.EffectiveUser.Id))
+}
+
+// Manager loads and saves sessions in a store.
+type Manager struct {
+	store Store
+	key   KeyFunc
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+// New returns a Manager that keeps sessions in store, keyed by user and without expiry.
+func New(store Store) *Manager {
+	return &Manager{store: store, key: ByUser, now: time.Now}
+}
+
+// Key sets how updates are mapped to sessions, e.g. ByUser, ByChat, ByChatUser or a custom KeyFunc.
+func (m *Manager) Key(fn KeyFunc) *Manager {
+	m.key = fn
+	return m
+}
+
+// TTL sets how long a session is kept after it was last saved. A non-positive ttl keeps sessions forever.
+func (m *Manager) TTL(ttl time.Duration) *Manager {
+	m.ttl = ttl
+	return m
+}
+
+// Clock sets the function used to read the current time, for tests.
+func (m *Manager) Clock(now func() time.Time) *Manager {
+	m.now = now
+	return m
+}
+
+// Middleware returns a middleware that loads the session of the update into c.Session()
+// before the rest of the chain and saves it afterwards, if it changed and the chain succeeded
+// or returned handlers.Continue or handlers.Stop. A session left empty is deleted from the store.
+func (m *Manager) Middleware() handlers.Middleware {
+	return func(c *ctx.Context, next func() error) error {
+		key := m.key(c)
+		if key.IsNone() {
+			return next()
+		}
+
+		sess := c.Session()
+
+		loaded := m.store.Load(c.Std(), key.Some())
+		if loaded.IsErr() {
+			return fmt.Errorf("failed to load session %s: %w", key.Some(), loaded.Err())
+		}
+
+		var version int64
+
+		if rec := loaded.Ok(); rec.IsSome() {
+			if err := sess.UnmarshalJSON(rec.Some().Data); err != nil {
+				return fmt.Errorf("failed to decode session %s: %w", key.Some(), err)
+			}
+
+			version = rec.Some().Version
+		} else {
+			sess.Reset()
+		}
+
+		err := next()
+		if failed(err) || !sess.Changed() {
+			return err
+		}
+
+		if serr := m.save(c.Std(), key.Some(), sess, version); serr != nil {
+			return serr
+		}
+
+		return err
+	}
+}
+
+// failed reports whether err is a handler failure rather than nil or a group control error.
+func failed(err error) bool {
+	return err != nil && !errors.Is(err, handlers.Continue) && !errors.Is(err, handlers.Stop)
+}
+
+// save stores the session under key, replacing the given version, or deletes it if it is empty.
+func (m *Manager) save(std context.Context, key g.String, sess *ctx.Session, version int64) error {
+	if sess.Len() == 0 {
+		if version == 0 {
+			return nil
+		}
+
+		if err := m.store.Delete(std, key); err != nil {
+			return fmt.Errorf("failed to delete session %s: %w", key, err)
+		}
+
+		return nil
+	}
+
+	data, err := sess.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to encode session %s: %w", key, err)
+	}
+
+	rec := Record{Data: data, Version: version}
+	if m.ttl > 0 {
+		rec.Expires = m.now().Add(m.ttl)
+	}
+
+	if err := m.store.Save(std, key, rec); err != nil {
+		return fmt.Errorf("failed to save session %s: %w", key, err)
+	}
+
+	return nil
+}
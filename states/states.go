@@ -0,0 +1,294 @@
+// Package states runs a finite state machine per user, cloned from a template and kept in a session store.
+//
+// A Manager loads the user's machine before every operation and saves it afterwards, so conversations
+// survive restarts when a persistent session.Store is used. Callbacks of the machine get the Telegram
+// context of the update that triggered them through Ctx:
+//
+//	template := fsm.New("name").Transition("name", "next", "age")
+//	template.OnEnter("age", func(fctx *fsm.Context) error {
+//		return states.Ctx(fctx).Reply("How old are you?").Send().Err()
+//	})
+//
+//	flow := b.FSM(template)
+//	b.Command("start", func(c *ctx.Context) error { return flow.Enter(c, "name") })
+//	b.On.Message.Text(func(c *ctx.Context) error { return flow.Trigger(c, "next", c.EffectiveMessage.Text) }).InState("name")
+package states
+
+import (
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"sync"
+	"time"
+
+	"github.com/enetx/fsm"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/session"
+)
+
+// metaKey is the key of the Telegram context in the Meta of the machine's context.
+const metaKey = "tgctx"
+
+// ErrNoKey is returned when the update does not belong to a user or chat that can have a state machine.
+var ErrNoKey = errors.New("update has no state machine key")
+
+// stripes is the number of locks that serialize operations on machines of the same key.
+const stripes = 64
+
+// loaded is the key under which a loaded machine and its version are kept for the rest of the update.
+type loaded struct {
+	m   *Manager
+	key g.String
+}
+
+// machine is a machine loaded for the update, with the version it was loaded at.
+type machine struct {
+	fsm     *fsm.SyncFSM
+	version int64
+}
+
+// Manager keeps one state machine per user, cloned from a template.
+type Manager struct {
+	template *fsm.FSM
+	store    session.Store
+	key      session.KeyFunc
+	ttl      time.Duration
+	now      func() time.Time
+	seed     maphash.Seed
+	locks    [stripes]sync.Mutex
+}
+
+// New returns a Manager that clones template for every user and keeps the machines in memory.
+func New(template *fsm.FSM) *Manager {
+	return &Manager{
+		template: template,
+		store:    session.NewMemory(),
+		key:      session.ByUser,
+		now:      time.Now,
+		seed:     maphash.MakeSeed(),
+	}
+}
+
+// Store sets where machines are kept between updates, e.g. session.NewFile or a shared database.
+func (m *Manager) Store(store session.Store) *Manager {
+	m.store = store
+	return m
+}
+
+// Key sets how updates are mapped to machines, e.g. session.ByUser or session.ByChatUser.
+func (m *Manager) Key(fn session.KeyFunc) *Manager {
+	m.key = fn
+	return m
+}
+
+// TTL sets how long a machine is kept after it was last saved. A non-positive ttl keeps machines forever.
+func (m *Manager) TTL(ttl time.Duration) *Manager {
+	m.ttl = ttl
+	return m
+}
+
+// Clock sets the function used to read the current time, for tests.
+func (m *Manager) Clock(now func() time.Time) *Manager {
+	m.now = now
+	return m
+}
+
+// Ctx returns the Telegram context of the update being processed by the machine.
+// It is available in callbacks run by Trigger and Enter, and on machines returned by Get.
+func Ctx(fctx *fsm.Context) *ctx.Context {
+	c, _ := fctx.Meta.Get(metaKey).UnwrapOr(nil).(*ctx.Context)
+	return c
+}
+
+// Get returns the machine of the update's user, loaded from the store or cloned from the template.
+// Changes made to it directly, e.g. with SetState, are kept only after Save.
+func (m *Manager) Get(c *ctx.Context) g.Result[*fsm.SyncFSM] {
+	key := m.key(c)
+	if key.IsNone() {
+		return g.Err[*fsm.SyncFSM](ErrNoKey)
+	}
+
+	mc, err := m.load(c, key.Some())
+	if err != nil {
+		return g.Err[*fsm.SyncFSM](err)
+	}
+
+	return g.Ok(mc.fsm)
+}
+
+// Save stores the machine of the update's user. It fails with session.ErrConflict
+// if the machine was saved by another update since it was loaded.
+func (m *Manager) Save(c *ctx.Context, f *fsm.SyncFSM) error {
+	key := m.key(c)
+	if key.IsNone() {
+		return ErrNoKey
+	}
+
+	var version int64
+	if mc := m.cached(c, key.Some()); mc.IsSome() {
+		version = mc.Some().version
+	}
+
+	return m.save(c, key.Some(), machine{f, version})
+}
+
+// Trigger fires event on the machine of the update's user with optional input and saves it.
+// The machine is not saved if the transition fails.
+func (m *Manager) Trigger(c *ctx.Context, event fsm.Event, input ...any) error {
+	return m.update(c, func(f *fsm.SyncFSM) error { return f.Trigger(event, input...) })
+}
+
+// Enter moves the machine of the update's user to state without a transition, runs the state's
+// OnEnter callbacks and saves the machine. It is typically used to start or restart a conversation.
+func (m *Manager) Enter(c *ctx.Context, state fsm.State) error {
+	return m.update(c, func(f *fsm.SyncFSM) error {
+		f.SetState(state)
+		return f.CallEnter(state)
+	})
+}
+
+// State returns the current state of the update's user. A user without a stored machine is in the
+// template's current state. It returns None if the update has no key or the machine cannot be loaded.
+func (m *Manager) State(c *ctx.Context) g.Option[fsm.State] {
+	key := m.key(c)
+	if key.IsNone() {
+		return g.None[fsm.State]()
+	}
+
+	if mc := m.cached(c, key.Some()); mc.IsSome() {
+		return g.Some(mc.Some().fsm.Current())
+	}
+
+	rec := m.store.Load(c.Std(), key.Some())
+	if rec.IsErr() {
+		return g.None[fsm.State]()
+	}
+
+	if rec.Ok().IsNone() {
+		return g.Some(m.template.Current())
+	}
+
+	mc, err := m.decode(c, rec.Ok().Some())
+	if err != nil {
+		return g.None[fsm.State]()
+	}
+
+	c.Set(loaded{m, key.Some()}, mc)
+
+	return g.Some(mc.fsm.Current())
+}
+
+// Reset deletes the machine of the update's user, so that the next access starts from the template.
+func (m *Manager) Reset(c *ctx.Context) error {
+	key := m.key(c)
+	if key.IsNone() {
+		return ErrNoKey
+	}
+
+	c.Delete(loaded{m, key.Some()})
+
+	if err := m.store.Delete(c.Std(), key.Some()); err != nil {
+		return fmt.Errorf("failed to delete state machine %s: %w", key.Some(), err)
+	}
+
+	return nil
+}
+
+// update runs fn on the machine of the update's user and saves it if fn succeeds.
+// Operations on the same key are serialized within the process.
+func (m *Manager) update(c *ctx.Context, fn func(*fsm.SyncFSM) error) error {
+	key := m.key(c)
+	if key.IsNone() {
+		return ErrNoKey
+	}
+
+	mu := &m.locks[maphash.String(m.seed, key.Some().Std())%stripes]
+	mu.Lock()
+	defer mu.Unlock()
+
+	c.Delete(loaded{m, key.Some()})
+
+	mc, err := m.load(c, key.Some())
+	if err != nil {
+		return err
+	}
+
+	if err := fn(mc.fsm); err != nil {
+		return err
+	}
+
+	return m.save(c, key.Some(), mc)
+}
+
+// cached returns the machine already loaded for key during the update.
+func (m *Manager) cached(c *ctx.Context, key g.String) g.Option[machine] {
+	return ctx.Value[machine](c, loaded{m, key})
+}
+
+// load returns the machine cached for the update, the stored machine or a fresh clone of the template.
+func (m *Manager) load(c *ctx.Context, key g.String) (machine, error) {
+	if mc := m.cached(c, key); mc.IsSome() {
+		mc.Some().fsm.Context().Meta.Insert(metaKey, c)
+		return mc.Some(), nil
+	}
+
+	rec := m.store.Load(c.Std(), key)
+	if rec.IsErr() {
+		return machine{}, fmt.Errorf("failed to load state machine %s: %w", key, rec.Err())
+	}
+
+	var mc machine
+
+	if rec.Ok().IsSome() {
+		var err error
+		if mc, err = m.decode(c, rec.Ok().Some()); err != nil {
+			return machine{}, fmt.Errorf("failed to decode state machine %s: %w", key, err)
+		}
+	} else {
+		mc = machine{fsm: m.template.Clone().Sync()}
+		mc.fsm.Context().Meta.Insert(metaKey, c)
+	}
+
+	c.Set(loaded{m, key}, mc)
+
+	return mc, nil
+}
+
+// decode restores a machine from a stored record and attaches the Telegram context.
+func (m *Manager) decode(c *ctx.Context, rec session.Record) (machine, error) {
+	f := m.template.Clone().Sync()
+	if err := f.UnmarshalJSON(rec.Data); err != nil {
+		return machine{}, err
+	}
+
+	f.Context().Meta.Insert(metaKey, c)
+
+	return machine{f, rec.Version}, nil
+}
+
+// save stores the machine without the Telegram context and caches it with its new version.
+func (m *Manager) save(c *ctx.Context, key g.String, mc machine) error {
+	meta := mc.fsm.Context().Meta
+
+	meta.Remove(metaKey)
+	data, err := mc.fsm.MarshalJSON()
+	meta.Insert(metaKey, c)
+
+	if err != nil {
+		return fmt.Errorf("failed to encode state machine %s: %w", key, err)
+	}
+
+	rec := session.Record{Data: data, Version: mc.version}
+	if m.ttl > 0 {
+		rec.Expires = m.now().Add(m.ttl)
+	}
+
+	if err := m.store.Save(c.Std(), key, rec); err != nil {
+		return fmt.Errorf("failed to save state machine %s: %w", key, err)
+	}
+
+	c.Set(loaded{m, key}, machine{mc.fsm, mc.version + 1})
+
+	return nil
+}
@@ -1,11 +1,28 @@
 package bot_test
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/fsm"
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
+	"github.com/enetx/tg/broadcast"
+	"github.com/enetx/tg/callback"
 	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/handlers"
+	"github.com/enetx/tg/keyboard"
+	"github.com/enetx/tg/scheduler"
 )
 
 func TestBot_Dispatcher(t *testing.T) {
@@ -385,3 +402,435 @@ func TestBot_ConcurrentAccess(t *testing.T) {
 		<-done
 	}
 }
+
+func TestBot_Go(t *testing.T) {
+	token := g.String("123456:ABCDEF-test-token-here")
+	result := bot.New(token).DisableTokenCheck().Build()
+
+	if result.IsErr() {
+		t.Errorf("Failed to create bot: %v", result.Err())
+		return
+	}
+
+	bot := result.Ok()
+	done := make(chan struct{})
+
+	bot.Go(func() { close(done) })
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("Expected tracked task to run")
+	}
+}
+
+func TestBot_OnErrorOnPanic(t *testing.T) {
+	token := g.String("123456:ABCDEF-test-token-here")
+	result := bot.New(token).DisableTokenCheck().Build()
+
+	if result.IsErr() {
+		t.Errorf("Failed to create bot: %v", result.Err())
+		return
+	}
+
+	bot := result.Ok()
+
+	if bot.ErrorHandler() != nil || bot.PanicHandler() != nil {
+		t.Error("Expected no hooks by default")
+	}
+
+	if bot.OnError(handlers.ReplyOnError("Something went wrong")) != bot {
+		t.Error("Expected OnError to return the same bot instance")
+	}
+
+	if bot.OnPanic(func(*ctx.Context, *handlers.PanicError) {}) != bot {
+		t.Error("Expected OnPanic to return the same bot instance")
+	}
+
+	if bot.ErrorHandler() == nil || bot.PanicHandler() == nil {
+		t.Error("Expected hooks to be set")
+	}
+}
+
+func TestBot_Routes(t *testing.T) {
+	token := g.String("123456:ABCDEF-test-token-here")
+	result := bot.New(token).DisableTokenCheck().Build()
+
+	if result.IsErr() {
+		t.Errorf("Failed to create bot: %v", result.Err())
+		return
+	}
+
+	bot := result.Ok()
+
+	bot.On.Message.Text(func(*ctx.Context) error { return nil })
+	cmd := bot.Command("start", func(*ctx.Context) error { return nil })
+
+	routes := bot.Routes()
+	if routes.Len() != 2 {
+		t.Fatalf("Expected 2 routes, got %d", routes.Len())
+	}
+
+	if routes[0].Kind != "message" || routes[1].Kind != "command" {
+		t.Errorf("Unexpected routes: %v", routes)
+	}
+
+	cmd.Remove()
+
+	if bot.Routes().Len() != 1 {
+		t.Errorf("Expected 1 route after Remove, got %d", bot.Routes().Len())
+	}
+}
+
+func TestBot_Around(t *testing.T) {
+	token := g.String("123456:ABCDEF-test-token-here")
+	result := bot.New(token).DisableTokenCheck().Build()
+
+	if result.IsErr() {
+		t.Errorf("Failed to create bot: %v", result.Err())
+		return
+	}
+
+	bot := result.Ok()
+
+	bot.Use(func(*ctx.Context) error { return nil })
+
+	if bot.Around(func(_ *ctx.Context, next func() error) error { return next() }) != bot {
+		t.Error("Expected Around to return the same bot instance")
+	}
+
+	if bot.Chain().Len() != 2 {
+		t.Errorf("Expected 2 middlewares in chain, got %d", bot.Chain().Len())
+	}
+
+	if bot.Middlewares().Len() != 1 {
+		t.Errorf("Expected 1 Use middleware, got %d", bot.Middlewares().Len())
+	}
+
+	cmd := bot.Scope().
+		Use(func(_ *ctx.Context, next func() error) error { return next() }).
+		Command("admin", func(*ctx.Context) error { return nil })
+
+	if cmd == nil || bot.Routes().Len() != 1 {
+		t.Error("Expected scoped command to be registered")
+	}
+}
+
+func TestBot_FSM(t *testing.T) {
+	token := g.String("123456:ABCDEF-test-token-here")
+	result := bot.New(token).DisableTokenCheck().Build()
+
+	if result.IsErr() {
+		t.Errorf("Failed to create bot: %v", result.Err())
+		return
+	}
+
+	b := result.Ok()
+
+	update := &gotgbot.Update{
+		UpdateId: 1,
+		Message: &gotgbot.Message{
+			MessageId: 1,
+			Text:      "hello",
+			Chat:      gotgbot.Chat{Id: 42, Type: "private"},
+			From:      &gotgbot.User{Id: 42, FirstName: "Test"},
+		},
+	}
+
+	newContext := func() *ctx.Context {
+		return ctx.New(b, ext.NewContext(b.Raw(), update, nil))
+	}
+
+	if s := b.State(newContext()); s.IsSome() {
+		t.Errorf("Expected no state without FSM, got %s", s.Some())
+	}
+
+	flow := b.FSM(fsm.New("idle").Transition("idle", "ask", "waiting"))
+
+	var calls []string
+
+	b.On.Message.Text(func(*ctx.Context) error {
+		calls = append(calls, "waiting")
+		return nil
+	}).InState("waiting")
+
+	b.On.Message.Text(func(*ctx.Context) error {
+		calls = append(calls, "other")
+		return nil
+	})
+
+	b.Dispatcher().ProcessUpdate(b.Raw(), update, nil)
+
+	if err := flow.Trigger(newContext(), "ask"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if s := b.State(newContext()); s.IsNone() || s.Some() != "waiting" {
+		t.Errorf("Expected state waiting, got %v", s)
+	}
+
+	b.Dispatcher().ProcessUpdate(b.Raw(), update, nil)
+
+	if len(calls) != 2 || calls[0] != "other" || calls[1] != "waiting" {
+		t.Errorf("Expected [other waiting], got %v", calls)
+	}
+}
+
+func TestBot_FSMInitialState(t *testing.T) {
+	b := bot.New(g.String("123456:ABCDEF-test-token-here")).DisableTokenCheck().Build().Unwrap()
+	b.FSM(fsm.New("idle").Transition("idle", "ask", "waiting"))
+
+	var calls []string
+
+	b.On.Message.Text(func(*ctx.Context) error {
+		calls = append(calls, "idle")
+		return nil
+	}).InState("idle")
+
+	update := &gotgbot.Update{
+		UpdateId: 1,
+		Message: &gotgbot.Message{
+			MessageId: 1,
+			Text:      "hello",
+			Chat:      gotgbot.Chat{Id: 7, Type: "private"},
+			From:      &gotgbot.User{Id: 7, FirstName: "New"},
+		},
+	}
+
+	if s := b.State(ctx.New(b, ext.NewContext(b.Raw(), update, nil))); s.IsNone() || s.Some() != "idle" {
+		t.Errorf("Expected a new user in the initial state, got %v", s)
+	}
+
+	b.Dispatcher().ProcessUpdate(b.Raw(), update, nil)
+
+	if len(calls) != 1 {
+		t.Errorf("Expected the initial state handler to run for a new user, got %v", calls)
+	}
+}
+
+func TestBot_Scheduler(t *testing.T) {
+	token := g.String("123456:ABCDEF-test-token-here")
+
+	path := g.String(filepath.Join(t.TempDir(), "jobs.json"))
+	pending := scheduler.Job{ID: "pending", Kind: "digest", At: time.Now().Add(time.Hour)}
+
+	if err := scheduler.NewFile(path).Save(context.Background(), pending); err != nil {
+		t.Fatalf("Failed to store job: %v", err)
+	}
+
+	result := bot.New(token).DisableTokenCheck().JobStore(scheduler.NewFile(path)).Build()
+	if result.IsErr() {
+		t.Fatalf("Failed to create bot: %v", result.Err())
+	}
+
+	b := result.Ok()
+
+	if b.Scheduler() == nil {
+		t.Fatal("Expected the bot to have a scheduler")
+	}
+
+	if job := b.Scheduler().Job("pending"); job.IsNone() || job.Some().Kind != "digest" {
+		t.Errorf("Expected the stored job to be loaded on Build, got %v", job)
+	}
+
+	c := ctx.New(b, &ext.Context{
+		Update:        &gotgbot.Update{UpdateId: 1},
+		EffectiveChat: &gotgbot.Chat{Id: 42, Type: "private"},
+	})
+
+	id := c.SendMessage("later").After(time.Hour).Schedule()
+	if id.IsErr() {
+		t.Fatalf("Schedule failed: %v", id.Err())
+	}
+
+	if b.Scheduler().Job(id.Ok()).IsNone() {
+		t.Error("Expected the delayed send to be a job of the bot's scheduler")
+	}
+}
+
+func TestBot_EveryAndSchedule(t *testing.T) {
+	result := bot.New(g.String("123456:ABCDEF-test-token-here")).DisableTokenCheck().Build()
+	if result.IsErr() {
+		t.Fatalf("Failed to create bot: %v", result.Err())
+	}
+
+	b := result.Ok()
+	runs := make(chan *ctx.Context, 1)
+
+	job := b.Every(5*time.Millisecond, func(c *ctx.Context) error {
+		select {
+		case runs <- c:
+		default:
+		}
+
+		return nil
+	})
+	defer job.Cancel()
+
+	select {
+	case c := <-runs:
+		if c.Bot != b || c.Update == nil || c.EffectiveChat != nil || c.Std() == nil {
+			t.Errorf("Expected a detached context of the bot, got %+v", c)
+		}
+
+		if scheduler.Scheduled(c.Std()).IsZero() {
+			t.Error("Expected the scheduled time in the job's context")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Job did not run")
+	}
+
+	if cron := b.Schedule("0 9 * * MON", func(*ctx.Context) error { return nil }); cron.Err() != nil || cron.Next().IsNone() {
+		t.Errorf("Expected a scheduled cron job, got %v", cron.Err())
+	} else {
+		cron.Cancel()
+	}
+
+	if bad := b.Schedule("every monday", func(*ctx.Context) error { return nil }); !errors.Is(bad.Err(), scheduler.ErrInvalidSpec) {
+		t.Errorf("Expected ErrInvalidSpec, got %v", bad.Err())
+	}
+}
+
+func TestBot_SendWithoutUpdate(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		chats  []string
+		method string
+	)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		chats = append(chats, r.FormValue("chat_id"))
+		method = r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"result":{"message_id":5,"date":0,"chat":{"id":42,"type":"private"}}}`))
+	}))
+	defer api.Close()
+
+	b := bot.New(g.String("123456:ABCDEF-test-token-here")).
+		DisableTokenCheck().
+		DefaultAPIURL(g.String(api.URL)).
+		Build().
+		Unwrap()
+
+	msg := b.SendMessage(42, "hello").Send()
+	if msg.IsErr() {
+		t.Fatalf("SendMessage failed: %v", msg.Err())
+	}
+
+	if msg.Ok().MessageId != 5 || method != "sendMessage" {
+		t.Errorf("Unexpected result %v from %s", msg.Ok(), method)
+	}
+
+	if r := b.Chat(43).Reply("hi").Send(); !errors.Is(r.Err(), ctx.ErrNoMessage) {
+		t.Errorf("Expected ErrNoMessage for a reply without a message, got %v", r.Err())
+	}
+
+	if r := b.Chat(43).SendDice().Send(); r.IsErr() {
+		t.Fatalf("SendDice failed: %v", r.Err())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(chats) != 2 || chats[0] != "42" || chats[1] != "43" {
+		t.Errorf("Expected requests to chats 42 and 43, got %v", chats)
+	}
+}
+
+func TestBot_Broadcast(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.FormValue("chat_id") == "2" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"ok":false,"error_code":403,"description":"Forbidden: bot was blocked by the user"}`))
+
+			return
+		}
+
+		w.Write([]byte(`{"ok":true,"result":{"message_id":5,"date":0,"chat":{"id":1,"type":"private"}}}`))
+	}))
+	defer api.Close()
+
+	b := bot.New(g.String("123456:ABCDEF-test-token-here")).
+		DisableTokenCheck().
+		DefaultAPIURL(g.String(api.URL)).
+		Build().
+		Unwrap()
+
+	report := b.Broadcast(slices.Values([]int64{1, 2, 3})).
+		Rate(0, 0).
+		Message(func(c *ctx.Context) error { return c.SendMessage("news").Send().Err() }).
+		Send(context.Background())
+
+	if report.Sent != 2 || report.Count(broadcast.Blocked) != 1 || report.Chats(broadcast.Blocked)[0] != 2 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+}
+
+func TestBot_CallbackStorage(t *testing.T) {
+	var answered []string
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if strings.HasSuffix(r.URL.Path, "/answerCallbackQuery") {
+			answered = append(answered, r.FormValue("text"))
+		}
+
+		w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer api.Close()
+
+	b := bot.New(g.String("123456:ABCDEF-test-token-here")).
+		DisableTokenCheck().
+		DefaultAPIURL(g.String(api.URL)).
+		Build().
+		Unwrap()
+
+	now := time.Now()
+	clock := func() time.Time { return now }
+
+	payloads := b.CallbackStorage(callback.NewStorage(callback.NewMemory().Clock(clock)).Clock(clock).TTL(time.Hour))
+
+	long := g.String("details:").Append(g.String("x").Repeat(100))
+
+	var got []string
+
+	b.On.Callback.Equal(long, func(c *ctx.Context) error {
+		got = append(got, c.Callback.Data)
+		return nil
+	})
+
+	markup := keyboard.Inline().Storage(payloads).Text("Details", long)
+	token := markup.Markup().(gotgbot.InlineKeyboardMarkup).InlineKeyboard[0][0].CallbackData
+
+	press := func() {
+		update := &gotgbot.Update{
+			UpdateId:      1,
+			CallbackQuery: &gotgbot.CallbackQuery{Id: "cb", Data: token, From: gotgbot.User{Id: 1}},
+		}
+
+		b.Dispatcher().ProcessUpdate(b.Raw(), update, nil)
+	}
+
+	press()
+
+	if len(got) != 1 || got[0] != long.Std() {
+		t.Fatalf("handler got %q, want the stored data", got)
+	}
+
+	now = now.Add(2 * time.Hour)
+	press()
+
+	if len(got) != 1 {
+		t.Error("handler should not run for an expired token")
+	}
+
+	if len(answered) != 1 || answered[0] != "This button has expired" {
+		t.Errorf("expired press answered with %q", answered)
+	}
+}
@@ -2,11 +2,15 @@ package bot_test
 
 import (
 	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
+	"github.com/enetx/tg/ratelimit"
+	"github.com/enetx/tg/retry"
 )
 
 func TestBotBuilder(t *testing.T) {
@@ -289,3 +293,78 @@ func TestBotBuilder_EdgeCasesExtended(t *testing.T) {
 		t.Errorf("Expected success for minimum valid token, got error: %v", result.Err())
 	}
 }
+
+func TestBotBuilder_RateLimit(t *testing.T) {
+	token := g.String("123456:ABCDEF-test-token-here")
+	builder := bot.New(token).DisableTokenCheck()
+
+	if builder.RateLimit(ratelimit.New()) != builder {
+		t.Error("Expected RateLimit to return the same builder")
+	}
+
+	result := builder.Build()
+	if result.IsErr() {
+		t.Fatalf("Failed to create bot: %v", result.Err())
+	}
+
+	if result.Ok().Raw().BotClient == nil {
+		t.Error("Expected bot client to be set")
+	}
+}
+
+func TestBotBuilder_Retry(t *testing.T) {
+	token := g.String("123456:ABCDEF-test-token-here")
+	builder := bot.New(token).DisableTokenCheck()
+
+	if builder.Retry(retry.New()) != builder {
+		t.Error("Expected Retry to return the same builder")
+	}
+
+	result := builder.Build()
+	if result.IsErr() {
+		t.Fatalf("Failed to create bot: %v", result.Err())
+	}
+
+	if result.Ok().Raw().BotClient == nil {
+		t.Error("Expected bot client to be set")
+	}
+}
+
+func TestBotBuilder_RateLimitRetriesFlood(t *testing.T) {
+	var calls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if calls.Add(1) == 1 {
+			w.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 1",` +
+				`"parameters":{"retry_after":1}}`))
+
+			return
+		}
+
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1,"date":0,"chat":{"id":42,"type":"private"}}}`))
+	}))
+	defer srv.Close()
+
+	b := bot.New(g.String("123456:ABCDEF-test-token-here")).
+		DisableTokenCheck().
+		DefaultAPIURL(g.String(srv.URL)).
+		RateLimit(ratelimit.New()).
+		Build().
+		Unwrap()
+
+	start := time.Now()
+
+	if _, err := b.Raw().SendMessage(42, "hi", nil); err != nil {
+		t.Fatalf("Expected the flood-waited send to be retried, got %v", err)
+	}
+
+	if n := calls.Load(); n != 2 {
+		t.Errorf("Expected 2 requests, got %d", n)
+	}
+
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("Expected the retry to wait for the chat's pause, took %v", elapsed)
+	}
+}
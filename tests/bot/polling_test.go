@@ -2,6 +2,7 @@ package bot_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
@@ -198,3 +199,20 @@ func TestPolling_Start(t *testing.T) {
 	// Coverage for this method would need integration tests with a valid bot token
 	t.Skip("Skipping Start() test as it's a blocking method that requires real bot connection")
 }
+
+func TestPolling_DrainTimeout(t *testing.T) {
+	token := g.String("123456:ABCDEF-test-token-here")
+	result := bot.New(token).DisableTokenCheck().Build()
+
+	if result.IsErr() {
+		t.Errorf("Failed to create bot: %v", result.Err())
+		return
+	}
+
+	bot := result.Ok()
+	polling := bot.Polling()
+
+	if polling.DrainTimeout(5*time.Second) != polling {
+		t.Error("Expected DrainTimeout to return the same Polling instance")
+	}
+}
@@ -0,0 +1,153 @@
+package bot_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/bot"
+)
+
+func TestRouter_Path(t *testing.T) {
+	router := bot.NewRouter()
+
+	if path := router.Path(123); path != "/bot/123" {
+		t.Errorf("Expected path /bot/123, got %s", path)
+	}
+
+	router.Prefix("/hooks/")
+	if path := router.Path(123); path != "/hooks/123" {
+		t.Errorf("Expected path /hooks/123, got %s", path)
+	}
+
+	router.Prefix("")
+	if path := router.Path(123); path != "/123" {
+		t.Errorf("Expected path /123, got %s", path)
+	}
+}
+
+func TestRouter_AddRemove(t *testing.T) {
+	b := newWebhookBot(t)
+	router := bot.NewRouter().Domain("https://example.com")
+
+	webhook := router.Add(b)
+	if webhook == nil {
+		t.Fatal("Expected webhook configuration to be non-nil")
+	}
+
+	if webhook.Opts().SecretToken == "" {
+		t.Error("Expected a secret token to be generated")
+	}
+
+	if router.Get(b.Raw().Id).IsNone() {
+		t.Error("Expected bot to be mounted")
+	}
+
+	if router.Bots().Len() != 1 {
+		t.Errorf("Expected 1 bot, got %d", router.Bots().Len())
+	}
+
+	if router.Remove(b.Raw().Id).IsNone() {
+		t.Error("Expected Remove to return the mounted bot")
+	}
+
+	if router.Get(b.Raw().Id).IsSome() {
+		t.Error("Expected bot to be unmounted")
+	}
+}
+
+func TestRouter_ServeHTTP(t *testing.T) {
+	b := newWebhookBot(t)
+	router := bot.NewRouter().Domain("https://example.com")
+
+	webhook := router.Add(b)
+	if result := webhook.Register(); result.IsErr() {
+		t.Fatalf("Failed to register webhook: %v", result.Err())
+	}
+
+	secret := webhook.Opts().SecretToken
+	path := router.Path(b.Raw().Id).Std()
+
+	tests := []struct {
+		name   string
+		path   string
+		secret string
+		code   int
+	}{
+		{"valid", path, secret, http.StatusOK},
+		{"wrong secret", path, "wrong", http.StatusUnauthorized},
+		{"unknown bot", "/bot/42", secret, http.StatusNotFound},
+		{"invalid id", "/bot/abc", secret, http.StatusNotFound},
+		{"outside prefix", "/other/" + g.Int(b.Raw().Id).String().Std(), secret, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(`{"update_id":1}`))
+			req.Header.Set("X-Telegram-Bot-Api-Secret-Token", tt.secret)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.code {
+				t.Errorf("Expected status %d, got %d", tt.code, rec.Code)
+			}
+		})
+	}
+}
+
+func TestRouter_SecretEnforcedBeforeRegister(t *testing.T) {
+	b := newWebhookBot(t)
+	router := bot.NewRouter().Domain("https://example.com")
+
+	secret := router.Add(b).Opts().SecretToken
+	path := router.Path(b.Raw().Id).Std()
+
+	for _, token := range []string{"", "wrong"} {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"update_id":1}`))
+		req.Header.Set("X-Telegram-Bot-Api-Secret-Token", token)
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status %d with secret %q, got %d", http.StatusUnauthorized, token, rec.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"update_id":1}`))
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d with the generated secret, got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestRouter_StartCancelsBotContext(t *testing.T) {
+	b := newWebhookBot(t)
+	router := bot.NewRouter().Domain("https://example.com").DrainTimeout(time.Second)
+	router.Add(b)
+
+	if b.Context().Err() != nil {
+		t.Fatalf("Expected live bot context before shutdown, got %v", b.Context().Err())
+	}
+
+	std, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := router.Start(std, "127.0.0.1:0"); err != nil {
+		t.Fatalf("Expected clean shutdown, got %v", err)
+	}
+
+	if !errors.Is(b.Context().Err(), context.Canceled) {
+		t.Errorf("Expected bot context to be cancelled on shutdown, got %v", b.Context().Err())
+	}
+}
@@ -0,0 +1,249 @@
+package bot_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/bot"
+	"github.com/enetx/tg/ctx"
+)
+
+const shutdownUpdate = `{"update_id":1,"message":{"message_id":1,"date":0,"text":"hi",` +
+	`"chat":{"id":42,"type":"private"},"from":{"id":42,"is_bot":false,"first_name":"Test"}}}`
+
+// shutdownAPI is a fake Bot API that hands out shutdownUpdate once through getUpdates
+// and records the other methods it is called with.
+type shutdownAPI struct {
+	mu      sync.Mutex
+	methods []string
+	served  bool
+}
+
+func (a *shutdownAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if method == "getUpdates" {
+		a.mu.Lock()
+		served := a.served
+		a.served = true
+		a.mu.Unlock()
+
+		if served {
+			time.Sleep(20 * time.Millisecond)
+			w.Write([]byte(`{"ok":true,"result":[]}`))
+
+			return
+		}
+
+		w.Write([]byte(`{"ok":true,"result":[` + shutdownUpdate + `]}`))
+
+		return
+	}
+
+	a.mu.Lock()
+	a.methods = append(a.methods, method)
+	a.mu.Unlock()
+
+	w.Write([]byte(`{"ok":true,"result":true}`))
+}
+
+func (a *shutdownAPI) called(method string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, m := range a.methods {
+		if m == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newShutdownBot returns a bot talking to a shutdownAPI whose message handler schedules a deletion
+// due in 200ms and keeps running for 300ms after closing started. It reports its completion in handled.
+func newShutdownBot(t *testing.T) (*bot.Bot, *shutdownAPI, chan struct{}, *atomic.Bool) {
+	t.Helper()
+
+	api := new(shutdownAPI)
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	b := bot.New(g.String("123456:ABCDEF-test-token-here")).
+		DisableTokenCheck().
+		DefaultAPIURL(g.String(srv.URL)).
+		Build().
+		Unwrap()
+
+	started := make(chan struct{})
+	handled := new(atomic.Bool)
+
+	b.On.Message.Any(func(c *ctx.Context) error {
+		if err := c.DeleteMessage().MessageID(1).After(200 * time.Millisecond).Schedule().Err(); err != nil {
+			return err
+		}
+
+		close(started)
+		time.Sleep(300 * time.Millisecond)
+		handled.Store(true)
+
+		return nil
+	})
+
+	return b, api, started, handled
+}
+
+// freeAddr returns a local address nothing listens on.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to find a free port: %v", err)
+	}
+
+	defer l.Close()
+
+	return l.Addr().String()
+}
+
+// waitStarted waits for the handler to start, failing the test after a second.
+func waitStarted(t *testing.T, started chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("Handler did not start")
+	}
+}
+
+func TestPolling_StartDrainsHandlersAndTimers(t *testing.T) {
+	b, api, started, handled := newShutdownBot(t)
+
+	std, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() { done <- b.Polling().DrainTimeout(5 * time.Second).Start(std) }()
+
+	waitStarted(t, started)
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Fatalf("Expected clean shutdown, got %v", err)
+	}
+
+	if !handled.Load() {
+		t.Error("Expected the running handler to complete before Start returned")
+	}
+
+	if !api.called("deleteMessage") {
+		t.Error("Expected the pending deletion to run before Start returned")
+	}
+}
+
+func TestWebhook_StartDrainsHandlersAndTimers(t *testing.T) {
+	b, api, started, handled := newShutdownBot(t)
+	addr := freeAddr(t)
+
+	std, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() {
+		done <- b.Webhook().
+			Domain("https://example.com").
+			Path("/webhook").
+			DrainTimeout(5*time.Second).
+			Start(std, g.String(addr))
+	}()
+
+	go func() {
+		for {
+			resp, err := http.Post("http://"+addr+"/webhook", "application/json", strings.NewReader(shutdownUpdate))
+			if err == nil {
+				resp.Body.Close()
+				return
+			}
+
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	waitStarted(t, started)
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Fatalf("Expected clean shutdown, got %v", err)
+	}
+
+	if !handled.Load() {
+		t.Error("Expected the running handler to complete before Start returned")
+	}
+
+	if !api.called("deleteMessage") {
+		t.Error("Expected the pending deletion to run before Start returned")
+	}
+}
+
+func TestPolling_StartCancelsHandlerContexts(t *testing.T) {
+	api := new(shutdownAPI)
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	b := bot.New(g.String("123456:ABCDEF-test-token-here")).
+		DisableTokenCheck().
+		DefaultAPIURL(g.String(srv.URL)).
+		Build().
+		Unwrap()
+
+	started := make(chan struct{})
+	cause := make(chan error, 1)
+
+	b.On.Message.Any(func(c *ctx.Context) error {
+		if ctx.UpdateOf(c.Std()).IsNone() {
+			t.Error("Expected the standard context to carry the update")
+		}
+
+		close(started)
+		<-c.Std().Done()
+		cause <- context.Cause(c.Std())
+
+		return nil
+	})
+
+	std, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() { done <- b.Polling().DrainTimeout(5 * time.Second).Start(std) }()
+
+	waitStarted(t, started)
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Expected clean shutdown, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected shutdown to end the handler waiting on its context")
+	}
+
+	if err := <-cause; !errors.Is(err, ctx.ErrShutdown) {
+		t.Errorf("Expected cause ErrShutdown, got %v", err)
+	}
+
+	if b.Context().Err() == nil {
+		t.Error("Expected the lifetime context to be cancelled after Start returned")
+	}
+}
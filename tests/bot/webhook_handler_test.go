@@ -0,0 +1,135 @@
+package bot_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/bot"
+)
+
+// newFakeAPI starts a server that answers every Bot API method with a successful boolean result.
+func newFakeAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func newWebhookBot(t *testing.T) *bot.Bot {
+	t.Helper()
+
+	api := newFakeAPI(t)
+	result := bot.New(g.String("123456:ABCDEF-test-token-here")).
+		DisableTokenCheck().
+		DefaultAPIURL(g.String(api.URL)).
+		Build()
+
+	if result.IsErr() {
+		t.Fatalf("Failed to create bot: %v", result.Err())
+	}
+
+	return result.Ok()
+}
+
+func serveWebhook(b *bot.Bot, method, body, secret string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/webhook", strings.NewReader(body))
+	if secret != "" {
+		req.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+	}
+
+	rec := httptest.NewRecorder()
+	b.WebhookHandler().ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestWebhookHandler_ValidUpdate(t *testing.T) {
+	b := newWebhookBot(t)
+
+	rec := serveWebhook(b, http.MethodPost, `{"update_id":1}`, "")
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestWebhookHandler_RejectsNonPost(t *testing.T) {
+	b := newWebhookBot(t)
+
+	rec := serveWebhook(b, http.MethodGet, "", "")
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status %d, got %d", http.StatusMethodNotAllowed, rec.Code)
+	}
+
+	if rec.Header().Get("Allow") != http.MethodPost {
+		t.Errorf("Expected Allow header %q, got %q", http.MethodPost, rec.Header().Get("Allow"))
+	}
+}
+
+func TestWebhookHandler_RejectsInvalidJSON(t *testing.T) {
+	b := newWebhookBot(t)
+
+	rec := serveWebhook(b, http.MethodPost, "not json", "")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestWebhookHandler_RejectsOversizedBody(t *testing.T) {
+	b := newWebhookBot(t)
+
+	body := `{"update_id":1,"padding":"` + strings.Repeat("x", 2<<20) + `"}`
+
+	rec := serveWebhook(b, http.MethodPost, body, "")
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status %d, got %d", http.StatusRequestEntityTooLarge, rec.Code)
+	}
+}
+
+func TestWebhookHandler_SecretToken(t *testing.T) {
+	b := newWebhookBot(t)
+
+	result := b.Webhook().
+		Domain("https://example.com").
+		Path("/webhook").
+		SecretToken("s3cret").
+		Register()
+
+	if result.IsErr() {
+		t.Fatalf("Failed to register webhook: %v", result.Err())
+	}
+
+	if rec := serveWebhook(b, http.MethodPost, `{"update_id":1}`, ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d without secret, got %d", http.StatusUnauthorized, rec.Code)
+	}
+
+	if rec := serveWebhook(b, http.MethodPost, `{"update_id":1}`, "wrong"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d with wrong secret, got %d", http.StatusUnauthorized, rec.Code)
+	}
+
+	if rec := serveWebhook(b, http.MethodPost, `{"update_id":1}`, "s3cret"); rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d with valid secret, got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestWebhookHandler_SecretTokenBeforeRegister(t *testing.T) {
+	b := newWebhookBot(t)
+
+	b.Webhook().SecretToken("s3cret")
+
+	if rec := serveWebhook(b, http.MethodPost, `{"update_id":1}`, ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d without secret before Register, got %d", http.StatusUnauthorized, rec.Code)
+	}
+
+	if rec := serveWebhook(b, http.MethodPost, `{"update_id":1}`, "s3cret"); rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d with valid secret, got %d", http.StatusOK, rec.Code)
+	}
+}
@@ -1,7 +1,9 @@
 package bot_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/enetx/g"
 	"github.com/enetx/tg/bot"
@@ -286,3 +288,21 @@ func TestWebhook_Certificate(t *testing.T) {
 	// This will panic but we catch it above for coverage
 	botInstance.Webhook().Certificate(g.String("/nonexistent/cert.pem"))
 }
+
+func TestWebhook_Start_InvalidConfiguration(t *testing.T) {
+	token := g.String("123456:ABCDEF-test-token-here")
+	result := bot.New(token).DisableTokenCheck().Build()
+
+	if result.IsErr() {
+		t.Errorf("Failed to create bot: %v", result.Err())
+		return
+	}
+
+	bot := result.Ok()
+
+	// Start must fail before listening when the webhook cannot be registered
+	err := bot.Webhook().Path("/webhook").DrainTimeout(time.Second).Start(context.Background(), ":0")
+	if err == nil {
+		t.Error("Expected error when domain is missing")
+	}
+}
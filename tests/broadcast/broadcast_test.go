@@ -0,0 +1,402 @@
+package broadcast_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/tg/broadcast"
+	"github.com/enetx/tg/ctx"
+)
+
+type mockBot struct{}
+
+func (mockBot) Raw() *gotgbot.Bot           { return &gotgbot.Bot{} }
+func (mockBot) Dispatcher() *ext.Dispatcher { return &ext.Dispatcher{} }
+func (mockBot) Updater() *ext.Updater       { return &ext.Updater{} }
+
+func telegramError(code int, description string) error {
+	return &gotgbot.TelegramError{Code: code, Description: description}
+}
+
+func flood(seconds int64) error {
+	return &gotgbot.TelegramError{
+		Code:           429,
+		Description:    "Too Many Requests: retry after 1",
+		ResponseParams: &gotgbot.ResponseParameters{RetryAfter: seconds},
+	}
+}
+
+func recipients(n int) []int64 {
+	ids := make([]int64, n)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+
+	return ids
+}
+
+func TestBroadcast_SendsToEveryone(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		sent     []int64
+		progress []broadcast.Progress
+	)
+
+	report := broadcast.New(mockBot{}, slices.Values(recipients(10))).
+		Rate(0, 0).
+		Message(func(c *ctx.Context) error {
+			if c.EffectiveChat == nil || c.Std() == nil {
+				return errors.New("context not bound to the recipient")
+			}
+
+			mu.Lock()
+			sent = append(sent, c.EffectiveChat.Id)
+			mu.Unlock()
+
+			return nil
+		}).
+		OnProgress(func(p broadcast.Progress) { progress = append(progress, p) }).
+		Send(context.Background())
+
+	if report.Done != 10 || report.Sent != 10 || report.Failed != 0 || report.Cancelled {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+
+	slices.Sort(sent)
+	if !slices.Equal(sent, recipients(10)) {
+		t.Errorf("Expected every recipient once, got %v", sent)
+	}
+
+	if len(progress) != 10 {
+		t.Fatalf("Expected 10 progress calls, got %d", len(progress))
+	}
+
+	for i, p := range progress {
+		if p.Done != i+1 || p.Sent != i+1 {
+			t.Errorf("Progress %d: %+v", i, p)
+		}
+	}
+}
+
+func TestBroadcast_ClassifiesFailures(t *testing.T) {
+	errs := map[int64]error{
+		1: telegramError(403, "Forbidden: bot was blocked by the user"),
+		2: telegramError(403, "Forbidden: user is deactivated"),
+		3: telegramError(400, "Bad Request: chat not found"),
+		4: telegramError(400, "Bad Request: message is too long"),
+		6: telegramError(403, "Forbidden: bot was kicked from the supergroup chat"),
+	}
+
+	var (
+		mu       sync.Mutex
+		inactive = make(map[int64]broadcast.Reason)
+	)
+
+	report := broadcast.New(mockBot{}, slices.Values(recipients(6))).
+		Rate(0, 0).
+		FloodRetries(0).
+		Message(func(c *ctx.Context) error { return errs[c.EffectiveChat.Id] }).
+		OnInactive(func(chatID int64, reason broadcast.Reason) {
+			mu.Lock()
+			inactive[chatID] = reason
+			mu.Unlock()
+		}).
+		Send(context.Background())
+
+	if report.Sent != 1 || report.Failed != 5 || report.Failures.Len() != 5 {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+
+	for reason, want := range map[broadcast.Reason]int{
+		broadcast.Blocked:      2,
+		broadcast.Deactivated:  1,
+		broadcast.ChatNotFound: 1,
+		broadcast.Other:        1,
+		broadcast.Flood:        0,
+	} {
+		if got := report.Count(reason); got != want {
+			t.Errorf("Count(%s) = %d, want %d", reason, got, want)
+		}
+	}
+
+	blocked := report.Chats(broadcast.Blocked)
+	slices.Sort(blocked)
+
+	if !slices.Equal(blocked, []int64{1, 6}) {
+		t.Errorf("Expected chats 1 and 6 to be blocked, got %v", blocked)
+	}
+
+	want := map[int64]broadcast.Reason{1: broadcast.Blocked, 2: broadcast.Deactivated, 3: broadcast.ChatNotFound, 6: broadcast.Blocked}
+	if len(inactive) != len(want) {
+		t.Fatalf("Expected inactive callbacks for %v, got %v", want, inactive)
+	}
+
+	for id, reason := range want {
+		if inactive[id] != reason {
+			t.Errorf("Inactive chat %d: got %s, want %s", id, inactive[id], reason)
+		}
+	}
+
+	for _, f := range report.Failures {
+		if f.Err == nil || !errors.Is(f.Err, errs[f.ChatID]) {
+			t.Errorf("Failure %d should keep its error, got %v", f.ChatID, f.Err)
+		}
+	}
+}
+
+func TestBroadcast_FloodPausesAndRetries(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts = make(map[int64]int)
+		times    = make(map[int64]time.Time)
+	)
+
+	start := time.Now()
+
+	report := broadcast.New(mockBot{}, slices.Values(recipients(2))).
+		Rate(0, 0).
+		Workers(1).
+		Message(func(c *ctx.Context) error {
+			id := c.EffectiveChat.Id
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			attempts[id]++
+			times[id] = time.Now()
+
+			if id == 1 && attempts[id] == 1 {
+				return flood(1)
+			}
+
+			return nil
+		}).
+		Send(context.Background())
+
+	if report.Sent != 2 || report.Failed != 0 {
+		t.Fatalf("Expected both recipients after the retry, got %+v", report)
+	}
+
+	if attempts[1] != 2 || attempts[2] != 1 {
+		t.Errorf("Expected the flooded recipient to be retried once, got %v", attempts)
+	}
+
+	if times[2].Sub(start) < time.Second {
+		t.Errorf("Expected the broadcast to wait out the flood, next send after %v", times[2].Sub(start))
+	}
+}
+
+func TestBroadcast_FloodWithoutRetries(t *testing.T) {
+	report := broadcast.New(mockBot{}, slices.Values(recipients(1))).
+		Rate(0, 0).
+		FloodRetries(0).
+		Message(func(*ctx.Context) error { return flood(30) }).
+		Send(context.Background())
+
+	if report.Count(broadcast.Flood) != 1 {
+		t.Errorf("Expected a flood failure, got %+v", report)
+	}
+
+	if report.Elapsed > 5*time.Second {
+		t.Errorf("Broadcast should not wait without retries, took %v", report.Elapsed)
+	}
+}
+
+func TestBroadcast_Rate(t *testing.T) {
+	report := broadcast.New(mockBot{}, slices.Values(recipients(5))).
+		Rate(10, 100*time.Millisecond).
+		Message(func(*ctx.Context) error { return nil }).
+		Send(context.Background())
+
+	if report.Sent != 5 {
+		t.Fatalf("Expected 5 sends, got %+v", report)
+	}
+
+	if report.Elapsed < 40*time.Millisecond {
+		t.Errorf("Expected sends to be paced 10ms apart, took %v", report.Elapsed)
+	}
+}
+
+// blocking starts a broadcast to 5 recipients with a single worker whose first send blocks
+// until release is closed. It returns once the first send has started.
+func blocking(t *testing.T) (*broadcast.Run, chan struct{}) {
+	t.Helper()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	run := broadcast.New(mockBot{}, slices.Values(recipients(5))).
+		Rate(0, 0).
+		Workers(1).
+		Message(func(c *ctx.Context) error {
+			if c.EffectiveChat.Id == 1 {
+				close(started)
+				<-release
+			}
+
+			return nil
+		}).
+		Start(context.Background())
+
+	<-started
+
+	return run, release
+}
+
+func TestRun_PauseResume(t *testing.T) {
+	run, release := blocking(t)
+
+	run.Pause()
+
+	if !run.Paused() {
+		t.Fatal("Expected the broadcast to be paused")
+	}
+
+	close(release)
+	time.Sleep(50 * time.Millisecond)
+
+	// The recipient handed to the worker before the pause may still be sent.
+	if p := run.Progress(); p.Done > 2 {
+		t.Fatalf("Expected the broadcast to stop while paused, progress %+v", p)
+	}
+
+	run.Resume()
+
+	if run.Paused() {
+		t.Error("Expected the broadcast to be resumed")
+	}
+
+	select {
+	case <-run.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Broadcast did not finish after Resume")
+	}
+
+	if report := run.Wait(); report.Sent != 5 || report.Cancelled {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+}
+
+func TestRun_Cancel(t *testing.T) {
+	run, release := blocking(t)
+
+	run.Cancel()
+	close(release)
+
+	report := run.Wait()
+
+	if !report.Cancelled {
+		t.Error("Expected the report to be cancelled")
+	}
+
+	if report.Done >= 5 {
+		t.Errorf("Expected the remaining recipients to be skipped, got %+v", report)
+	}
+}
+
+func TestRun_CancelCompletesSendsInFlight(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	var sendErr error
+
+	run := broadcast.New(mockBot{}, slices.Values(recipients(3))).
+		Rate(0, 0).
+		Workers(1).
+		Message(func(c *ctx.Context) error {
+			if c.EffectiveChat.Id != 1 {
+				return nil
+			}
+
+			close(started)
+			<-release
+			sendErr = c.Std().Err()
+
+			return sendErr
+		}).
+		Start(context.Background())
+
+	<-started
+	run.Cancel()
+	close(release)
+
+	report := run.Wait()
+
+	if sendErr != nil {
+		t.Errorf("Expected the send in flight to keep a live context, got %v", sendErr)
+	}
+
+	if !report.Cancelled || report.Sent < 1 {
+		t.Errorf("Expected the send in flight to complete and the rest to be skipped, got %+v", report)
+	}
+}
+
+func TestRun_CancelWhilePaused(t *testing.T) {
+	run, release := blocking(t)
+
+	run.Pause()
+	close(release)
+	run.Cancel()
+
+	select {
+	case <-run.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Cancel did not stop a paused broadcast")
+	}
+
+	if !run.Wait().Cancelled {
+		t.Error("Expected the report to be cancelled")
+	}
+}
+
+func TestBroadcast_ContextCancel(t *testing.T) {
+	std, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report := broadcast.New(mockBot{}, slices.Values(recipients(3))).
+		Message(func(*ctx.Context) error { return nil }).
+		Send(std)
+
+	if !report.Cancelled || report.Done != 0 {
+		t.Errorf("Expected nothing to be sent with a cancelled context, got %+v", report)
+	}
+}
+
+func TestBroadcast_NoMessage(t *testing.T) {
+	report := broadcast.New(mockBot{}, slices.Values(recipients(2))).Rate(0, 0).Send(context.Background())
+
+	if report.Failed != 2 || !errors.Is(report.Failures[0].Err, broadcast.ErrNoMessage) {
+		t.Errorf("Expected ErrNoMessage for every recipient, got %+v", report)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		err  error
+		want broadcast.Reason
+	}{
+		{telegramError(403, "Forbidden: bot was blocked by the user"), broadcast.Blocked},
+		{telegramError(403, "Forbidden: user is deactivated"), broadcast.Deactivated},
+		{telegramError(400, "Bad Request: chat not found"), broadcast.ChatNotFound},
+		{telegramError(400, "Bad Request: user not found"), broadcast.ChatNotFound},
+		{flood(5), broadcast.Flood},
+		{telegramError(500, "Internal Server Error"), broadcast.Other},
+		{errors.New("network down"), broadcast.Other},
+	}
+
+	for _, tt := range tests {
+		if got := broadcast.Classify(tt.err); got != tt.want {
+			t.Errorf("Classify(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+
+	if !broadcast.Blocked.Inactive() || broadcast.Flood.Inactive() || broadcast.Other.Inactive() {
+		t.Error("Unexpected Inactive results")
+	}
+}
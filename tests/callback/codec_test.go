@@ -0,0 +1,221 @@
+package callback_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/callback"
+)
+
+type vote struct {
+	PostID int64
+	Up     bool
+}
+
+type item struct {
+	Name   string
+	Count  uint16
+	Price  float64
+	Offset int8
+	note   string
+	Debug  bool `callback:"-"`
+}
+
+func TestCodec_EncodeDecode(t *testing.T) {
+	votes := callback.New[vote]("vote")
+
+	data := votes.Encode(vote{PostID: 42, Up: true}).Unwrap()
+	if data != "vote:16:1" {
+		t.Fatalf("Encode = %q, want %q", data, "vote:16:1")
+	}
+
+	v := votes.Decode(data).Unwrap()
+	if v.PostID != 42 || !v.Up {
+		t.Errorf("Decode = %+v", v)
+	}
+}
+
+func TestCodec_Fields(t *testing.T) {
+	items := callback.New[item]("item")
+
+	in := item{Name: "a:b%c", Count: 500, Price: 9.99, Offset: -3, note: "skipped", Debug: true}
+
+	data := items.Encode(in).Unwrap()
+	if data != "item:a%3Ab%25c:dw:9.99:-3" {
+		t.Fatalf("Encode = %q", data)
+	}
+
+	out := items.Decode(data).Unwrap()
+	if out.Name != in.Name || out.Count != in.Count || out.Price != in.Price || out.Offset != in.Offset {
+		t.Errorf("Decode = %+v, want %+v", out, in)
+	}
+
+	if out.note != "" || out.Debug {
+		t.Errorf("skipped fields were decoded: %+v", out)
+	}
+}
+
+func TestCodec_EmptyString(t *testing.T) {
+	type page struct{ Query string }
+
+	pages := callback.New[page]("page")
+
+	data := pages.Encode(page{}).Unwrap()
+	if data != "page:" {
+		t.Fatalf("Encode = %q", data)
+	}
+
+	if q := pages.Decode(data).Unwrap().Query; q != "" {
+		t.Errorf("Query = %q", q)
+	}
+}
+
+func TestCodec_Scalar(t *testing.T) {
+	ids := callback.New[int]("id")
+
+	data := ids.Encode(1295).Unwrap()
+	if data != "id:zz" {
+		t.Fatalf("Encode = %q", data)
+	}
+
+	if n := ids.Decode(data).Unwrap(); n != 1295 {
+		t.Errorf("Decode = %d", n)
+	}
+}
+
+func TestCodec_Unsupported(t *testing.T) {
+	type bad struct {
+		Tags []string
+	}
+
+	codec := callback.New[bad]("bad")
+
+	if err := codec.Encode(bad{}).Err(); !errors.Is(err, callback.ErrUnsupported) {
+		t.Errorf("Encode error = %v, want ErrUnsupported", err)
+	}
+
+	if err := codec.Decode("bad:x").Err(); !errors.Is(err, callback.ErrUnsupported) {
+		t.Errorf("Decode error = %v, want ErrUnsupported", err)
+	}
+
+	if err := callback.New[[]int]("s").Encode(nil).Err(); !errors.Is(err, callback.ErrUnsupported) {
+		t.Errorf("Encode error = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestCodec_PrefixWithColon(t *testing.T) {
+	codec := callback.New[int]("page:v2")
+
+	if err := codec.Encode(1).Err(); !errors.Is(err, callback.ErrPrefix) {
+		t.Errorf("Encode error = %v, want ErrPrefix", err)
+	}
+
+	if err := codec.Decode("page:v2:1").Err(); !errors.Is(err, callback.ErrPrefix) {
+		t.Errorf("Decode error = %v, want ErrPrefix", err)
+	}
+}
+
+func TestCodec_TooLong(t *testing.T) {
+	codec := callback.New[string]("text")
+
+	err := codec.Encode(string(g.String("x").Repeat(60))).Err()
+	if !errors.Is(err, callback.ErrTooLong) {
+		t.Errorf("Encode error = %v, want ErrTooLong", err)
+	}
+
+	if codec.Encode(string(g.String("x").Repeat(59))).IsErr() {
+		t.Error("data of exactly 64 bytes should be accepted")
+	}
+}
+
+func TestCodec_Match(t *testing.T) {
+	votes := callback.New[vote]("vote")
+
+	tests := []struct {
+		data g.String
+		want bool
+	}{
+		{"vote:16:1", true},
+		{"vote", true},
+		{"voter:16:1", false},
+		{"vot", false},
+		{"page:1", false},
+	}
+
+	for _, tt := range tests {
+		if got := votes.Match(tt.data); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+
+	if err := votes.Decode("page:1").Err(); !errors.Is(err, callback.ErrMismatch) {
+		t.Errorf("Decode error = %v, want ErrMismatch", err)
+	}
+}
+
+func TestCodec_Malformed(t *testing.T) {
+	votes := callback.New[vote]("vote")
+
+	for _, data := range []g.String{"vote", "vote:16", "vote:16:1:extra", "vote:16:yes", "vote:!:1"} {
+		if err := votes.Decode(data).Err(); !errors.Is(err, callback.ErrMalformed) {
+			t.Errorf("Decode(%q) error = %v, want ErrMalformed", data, err)
+		}
+	}
+}
+
+func TestCodec_Overflow(t *testing.T) {
+	type small struct{ N int8 }
+
+	if err := callback.New[small]("n").Decode("n:zz").Err(); !errors.Is(err, callback.ErrMalformed) {
+		t.Errorf("Decode error = %v, want ErrMalformed", err)
+	}
+}
+
+func TestCodec_Sign(t *testing.T) {
+	votes := callback.New[vote]("vote").Sign([]byte("secret"))
+
+	data := votes.Encode(vote{PostID: 42, Up: true}).Unwrap()
+	if !data.StartsWith("vote:16:1:") || data.Len() != g.Int(len("vote:16:1")+13) {
+		t.Fatalf("Encode = %q", data)
+	}
+
+	if v := votes.Decode(data).Unwrap(); v.PostID != 42 || !v.Up {
+		t.Errorf("Decode = %+v", v)
+	}
+
+	forged := g.String("vote:17:1").Append(data[len("vote:16:1"):])
+	if err := votes.Decode(forged).Err(); !errors.Is(err, callback.ErrSignature) {
+		t.Errorf("forged data error = %v, want ErrSignature", err)
+	}
+
+	if err := votes.Decode("vote:16:1").Err(); !errors.Is(err, callback.ErrSignature) {
+		t.Errorf("unsigned data error = %v, want ErrSignature", err)
+	}
+
+	other := callback.New[vote]("vote").Sign([]byte("other"))
+	if err := other.Decode(data).Err(); !errors.Is(err, callback.ErrSignature) {
+		t.Errorf("data of another key error = %v, want ErrSignature", err)
+	}
+}
+
+func TestCodec_SignNoFields(t *testing.T) {
+	type ping struct{}
+
+	pings := callback.New[ping]("ping").Sign([]byte("secret"))
+
+	data := pings.Encode(ping{}).Unwrap()
+	if pings.Decode(data).IsErr() {
+		t.Errorf("Decode(%q) failed", data)
+	}
+
+	if err := pings.Decode("ping").Err(); !errors.Is(err, callback.ErrSignature) {
+		t.Errorf("unsigned data error = %v, want ErrSignature", err)
+	}
+}
+
+func TestCodec_Prefix(t *testing.T) {
+	if p := callback.New[vote]("vote").Prefix(); p != "vote" {
+		t.Errorf("Prefix = %q", p)
+	}
+}
@@ -0,0 +1,179 @@
+package callback_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/callback"
+)
+
+var long = g.String("report:").Append(g.String("x").Repeat(100))
+
+type failingStore struct{}
+
+func (failingStore) Load(context.Context, g.String) g.Result[g.Option[g.String]] {
+	return g.Err[g.Option[g.String]](errors.New("load failed"))
+}
+
+func (failingStore) Save(context.Context, g.String, g.String, time.Time) error {
+	return errors.New("save failed")
+}
+
+func TestStorage_ShortData(t *testing.T) {
+	store := callback.NewMemory()
+	storage := callback.NewStorage(store)
+
+	if data := storage.Shorten("page:2").Unwrap(); data != "page:2" {
+		t.Errorf("Shorten = %q, want data unchanged", data)
+	}
+
+	if store.Len() != 0 {
+		t.Errorf("short data should not be stored, got %d payloads", store.Len())
+	}
+}
+
+func TestStorage_LongData(t *testing.T) {
+	store := callback.NewMemory()
+	storage := callback.NewStorage(store)
+
+	token := storage.Shorten(long).Unwrap()
+	if !token.StartsWith(callback.TokenPrefix) || token.Len() > callback.MaxLen {
+		t.Fatalf("Shorten = %q, want a short token", token)
+	}
+
+	if again := storage.Shorten(long).Unwrap(); again != token {
+		t.Errorf("same data got another token: %q and %q", token, again)
+	}
+
+	if other := storage.Shorten(long + "y").Unwrap(); other == token {
+		t.Error("different data got the same token")
+	}
+
+	data := storage.Resolve(context.Background(), token).Unwrap()
+	if data.Some() != long {
+		t.Errorf("Resolve = %q, want the stored data", data.Some())
+	}
+
+	if store.Len() != 2 {
+		t.Errorf("Len = %d, want 2", store.Len())
+	}
+}
+
+func TestIsToken(t *testing.T) {
+	storage := callback.NewStorage(callback.NewMemory())
+
+	token := storage.Shorten(long).Unwrap()
+	if !callback.IsToken(token) {
+		t.Errorf("IsToken(%q) = false, want true for an issued token", token)
+	}
+
+	for _, data := range []g.String{
+		"~" + g.String("a").Repeat(22),
+		"~" + token[2:] + "A",
+		token[1:] + "A",
+		"~short",
+		"page:2",
+	} {
+		if callback.IsToken(data) {
+			t.Errorf("IsToken(%q) = true, want false", data)
+		}
+	}
+}
+
+func TestStorage_TTL(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	store := callback.NewMemory().Clock(clock)
+	storage := callback.NewStorage(store).Clock(clock).TTL(time.Hour)
+
+	token := storage.Shorten(long).Unwrap()
+
+	now = now.Add(59 * time.Minute)
+	if storage.Resolve(context.Background(), token).Unwrap().IsNone() {
+		t.Fatal("payload expired too early")
+	}
+
+	// Building the button again extends the expiry.
+	storage.Shorten(long)
+
+	now = now.Add(59 * time.Minute)
+	if storage.Resolve(context.Background(), token).Unwrap().IsNone() {
+		t.Fatal("payload should live an hour after its button was last built")
+	}
+
+	now = now.Add(time.Minute)
+	if storage.Resolve(context.Background(), token).Unwrap().IsSome() {
+		t.Error("payload should have expired")
+	}
+}
+
+func TestStorage_NoTTL(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	storage := callback.NewStorage(callback.NewMemory().Clock(clock)).Clock(clock).TTL(0)
+
+	token := storage.Shorten(long).Unwrap()
+
+	now = now.Add(365 * 24 * time.Hour)
+	if storage.Resolve(context.Background(), token).Unwrap().IsNone() {
+		t.Error("payload without TTL should not expire")
+	}
+}
+
+func TestMemory_Sweep(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	store := callback.NewMemory().Clock(clock)
+	storage := callback.NewStorage(store).Clock(clock).TTL(time.Minute)
+
+	storage.Shorten(long)
+	storage.Shorten(long + "1")
+
+	now = now.Add(2 * time.Minute)
+	storage.Shorten(long + "2")
+
+	if store.Len() != 1 {
+		t.Errorf("Len = %d, want expired payloads released", store.Len())
+	}
+}
+
+func TestStorage_StoreError(t *testing.T) {
+	storage := callback.NewStorage(failingStore{})
+
+	if err := storage.Shorten(long).Err(); err == nil || err.Error() != "failed to store callback data: save failed" {
+		t.Errorf("Shorten error = %v", err)
+	}
+
+	if storage.Shorten("short").IsErr() {
+		t.Error("short data should not reach the store")
+	}
+}
+
+func TestCodec_Storage(t *testing.T) {
+	type search struct{ Query string }
+
+	storage := callback.NewStorage(callback.NewMemory())
+	searches := callback.New[search]("search").Storage(storage)
+
+	query := string(g.String("golang ").Repeat(20))
+
+	token := searches.Encode(search{Query: query}).Unwrap()
+	if !token.StartsWith(callback.TokenPrefix) {
+		t.Fatalf("Encode = %q, want a token", token)
+	}
+
+	data := storage.Resolve(context.Background(), token).Unwrap().Some()
+	if v := searches.Decode(data).Unwrap(); v.Query != query {
+		t.Errorf("Decode = %q, want %q", v.Query, query)
+	}
+
+	if short := searches.Encode(search{Query: "go"}).Unwrap(); short != "search:go" {
+		t.Errorf("short data should stay in the button, got %q", short)
+	}
+}
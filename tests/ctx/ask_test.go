@@ -0,0 +1,432 @@
+package ctx_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/handlers"
+)
+
+// promptClient reports the text of every sent message.
+type promptClient struct {
+	gotgbot.BotClient
+	prompts chan string
+	fail    bool
+}
+
+func (p *promptClient) RequestWithContext(
+	_ context.Context,
+	_ string,
+	method string,
+	params map[string]string,
+	_ map[string]gotgbot.FileReader,
+	_ *gotgbot.RequestOpts,
+) (json.RawMessage, error) {
+	if p.fail {
+		return nil, errors.New("network down")
+	}
+
+	if method == "sendMessage" {
+		p.prompts <- params["text"]
+	}
+
+	return json.RawMessage(`{"message_id":1,"date":0,"chat":{"id":1,"type":"private"}}`), nil
+}
+
+// askBot is a bot with a working dispatcher whose regular handlers record the updates they receive.
+type askBot struct {
+	dispatcher *ext.Dispatcher
+	raw        *gotgbot.Bot
+	client     *promptClient
+	mu         sync.Mutex
+	handled    []string
+}
+
+func newAskBot() *askBot {
+	client := &promptClient{prompts: make(chan string, 10)}
+
+	b := &askBot{
+		dispatcher: ext.NewDispatcher(nil),
+		raw:        &gotgbot.Bot{BotClient: client},
+		client:     client,
+	}
+
+	handlers.NewHandlers(b).Message.Any(func(c *ctx.Context) error {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		b.handled = append(b.handled, c.EffectiveMessage.Text)
+
+		return nil
+	})
+
+	return b
+}
+
+func (b *askBot) Raw() *gotgbot.Bot           { return b.raw }
+func (b *askBot) Dispatcher() *ext.Dispatcher { return b.dispatcher }
+func (b *askBot) Updater() *ext.Updater       { return &ext.Updater{} }
+
+func (b *askBot) Handled() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]string(nil), b.handled...)
+}
+
+// process dispatches update and waits until it is handled.
+func (b *askBot) process(update *gotgbot.Update) {
+	b.dispatcher.ProcessUpdate(b.raw, update, nil)
+}
+
+// context returns the context of a message update of user in chat.
+func (b *askBot) context(chatID, userID int64) *ctx.Context {
+	return ctx.New(b, ext.NewContext(b.raw, message(chatID, userID, "/start"), nil))
+}
+
+// prompt waits for the next sent message.
+func (b *askBot) prompt(t *testing.T) string {
+	t.Helper()
+
+	select {
+	case text := <-b.client.prompts:
+		return text
+	case <-time.After(time.Second):
+		t.Fatal("Expected a prompt to be sent")
+		return ""
+	}
+}
+
+func message(chatID, userID int64, text string) *gotgbot.Update {
+	return &gotgbot.Update{
+		UpdateId: 1,
+		Message: &gotgbot.Message{
+			MessageId: 10,
+			Text:      text,
+			Chat:      gotgbot.Chat{Id: chatID, Type: "private"},
+			From:      &gotgbot.User{Id: userID, FirstName: "Test"},
+		},
+	}
+}
+
+// ask runs fn in the background and returns a channel with its result.
+func ask[T any](fn func() g.Result[T]) <-chan g.Result[T] {
+	done := make(chan g.Result[T], 1)
+	go func() { done <- fn() }()
+
+	return done
+}
+
+func result[T any](t *testing.T, done <-chan g.Result[T]) g.Result[T] {
+	t.Helper()
+
+	select {
+	case r := <-done:
+		return r
+	case <-time.After(time.Second):
+		t.Fatal("Expected the wait to end")
+		return g.Err[T](nil)
+	}
+}
+
+func TestAsk_Text(t *testing.T) {
+	b := newAskBot()
+	c := b.context(1, 42)
+
+	done := ask(func() g.Result[g.String] { return c.Ask("Your name?").Text() })
+
+	if got := b.prompt(t); got != "Your name?" {
+		t.Errorf("Expected prompt 'Your name?', got %q", got)
+	}
+
+	b.process(message(1, 42, "Alice"))
+
+	answer := result(t, done)
+	if answer.IsErr() || answer.Ok() != "Alice" {
+		t.Errorf("Expected answer Alice, got %v", answer)
+	}
+
+	if handled := b.Handled(); len(handled) != 0 {
+		t.Errorf("Expected the answer not to reach other handlers, got %v", handled)
+	}
+}
+
+func TestAsk_OtherUpdatesKeepFlowing(t *testing.T) {
+	b := newAskBot()
+	c := b.context(1, 42)
+
+	done := ask(func() g.Result[g.String] { return c.Ask("Your name?").Text() })
+	b.prompt(t)
+
+	b.process(message(1, 7, "other user"))
+	b.process(message(2, 42, "other chat"))
+	b.process(message(1, 42, "Alice"))
+
+	if answer := result(t, done); answer.IsErr() || answer.Ok() != "Alice" {
+		t.Errorf("Expected answer Alice, got %v", answer)
+	}
+
+	handled := b.Handled()
+	if len(handled) != 2 || handled[0] != "other user" || handled[1] != "other chat" {
+		t.Errorf("Expected updates of other users and chats to be handled, got %v", handled)
+	}
+}
+
+func TestAsk_ExpectFilter(t *testing.T) {
+	b := newAskBot()
+	c := b.context(1, 42)
+
+	digits := func(c *ctx.Context) bool {
+		return c.EffectiveMessage != nil && g.String(c.EffectiveMessage.Text).ToInt().IsOk()
+	}
+
+	done := ask(func() g.Result[*ctx.Context] { return c.Ask("Your age?").Expect(digits) })
+	b.prompt(t)
+
+	b.process(message(1, 42, "thirty"))
+	b.process(message(1, 42, "30"))
+
+	answer := result(t, done)
+	if answer.IsErr() || answer.Ok().EffectiveMessage.Text != "30" {
+		t.Fatalf("Expected answer 30, got %v", answer)
+	}
+
+	if answer.Ok().Std() != c.Std() {
+		t.Error("Expected the answer to use the asking handler's context")
+	}
+
+	if handled := b.Handled(); len(handled) != 1 || handled[0] != "thirty" {
+		t.Errorf("Expected the non-matching update to be handled, got %v", handled)
+	}
+}
+
+func TestAsk_ExpectCallback(t *testing.T) {
+	b := newAskBot()
+	c := b.context(1, 42)
+
+	done := ask(func() g.Result[*ctx.Context] {
+		return c.Ask("Pick one").Expect(func(c *ctx.Context) bool { return c.Callback != nil })
+	})
+	b.prompt(t)
+
+	b.process(&gotgbot.Update{
+		UpdateId: 2,
+		CallbackQuery: &gotgbot.CallbackQuery{
+			Id:      "cb",
+			From:    gotgbot.User{Id: 42, FirstName: "Test"},
+			Data:    "yes",
+			Message: &gotgbot.Message{MessageId: 5, Chat: gotgbot.Chat{Id: 1, Type: "private"}},
+		},
+	})
+
+	answer := result(t, done)
+	if answer.IsErr() || answer.Ok().Callback.Data != "yes" {
+		t.Errorf("Expected callback answer, got %v", answer)
+	}
+}
+
+func TestAsk_Cancel(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		cancel  []g.String
+		command g.String
+	}{
+		{"default", "/cancel", nil, "cancel"},
+		{"with username", "/cancel@test_bot", nil, "cancel"},
+		{"custom", "/stop now", []g.String{"stop"}, "stop"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newAskBot()
+			c := b.context(1, 42)
+
+			done := ask(func() g.Result[g.String] {
+				a := c.Ask("Your name?")
+				if tt.cancel != nil {
+					a.Cancel(tt.cancel...)
+				}
+
+				return a.Text()
+			})
+			b.prompt(t)
+
+			b.process(message(1, 42, tt.text))
+
+			var cerr *ctx.AskCancelError
+			if err := result(t, done).Err(); !errors.As(err, &cerr) {
+				t.Fatalf("Expected AskCancelError, got %v", err)
+			}
+
+			if cerr.Command != tt.command {
+				t.Errorf("Expected command %s, got %s", tt.command, cerr.Command)
+			}
+
+			if cerr.Context == nil || cerr.Context.EffectiveMessage.Text != tt.text {
+				t.Error("Expected the cancelling update in the error")
+			}
+
+			if handled := b.Handled(); len(handled) != 0 {
+				t.Errorf("Expected the cancel command not to reach other handlers, got %v", handled)
+			}
+		})
+	}
+}
+
+func TestAsk_CancelDisabled(t *testing.T) {
+	b := newAskBot()
+	c := b.context(1, 42)
+
+	done := ask(func() g.Result[g.String] { return c.Ask("Command?").Cancel().Text() })
+	b.prompt(t)
+
+	b.process(message(1, 42, "/cancel"))
+
+	if answer := result(t, done); answer.IsErr() || answer.Ok() != "/cancel" {
+		t.Errorf("Expected /cancel as a regular answer, got %v", answer)
+	}
+}
+
+func TestAsk_Timeout(t *testing.T) {
+	b := newAskBot()
+	c := b.context(1, 42)
+
+	done := ask(func() g.Result[g.String] { return c.Ask("Your name?").Timeout(20 * time.Millisecond).Text() })
+	b.prompt(t)
+
+	var terr *ctx.AskTimeoutError
+	if err := result(t, done).Err(); !errors.As(err, &terr) {
+		t.Fatalf("Expected AskTimeoutError, got %v", err)
+	}
+
+	if terr.Timeout != 20*time.Millisecond {
+		t.Errorf("Expected timeout 20ms in the error, got %s", terr.Timeout)
+	}
+
+	b.process(message(1, 42, "late"))
+
+	if handled := b.Handled(); len(handled) != 1 || handled[0] != "late" {
+		t.Errorf("Expected a late answer to be handled as a regular update, got %v", handled)
+	}
+}
+
+func TestAsk_HandlerContextCancelled(t *testing.T) {
+	b := newAskBot()
+	c := b.context(1, 42)
+
+	std, cancel := context.WithCancel(context.Background())
+	c.SetStd(std)
+
+	done := ask(func() g.Result[g.String] { return c.Ask("Your name?").Timeout(time.Minute).Text() })
+	b.prompt(t)
+
+	cancel()
+
+	err := result(t, done).Err()
+
+	var cerr *ctx.AskCancelError
+	if !errors.As(err, &cerr) || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected AskCancelError with context.Canceled, got %v", err)
+	}
+}
+
+func TestAsk_Shutdown(t *testing.T) {
+	b := newAskBot()
+	c := b.context(1, 42)
+
+	std, stop := context.WithCancelCause(context.Background())
+	c.SetStd(std)
+
+	done := ask(func() g.Result[g.String] { return c.Wait().Text() })
+
+	stop(ctx.ErrShutdown)
+
+	var cerr *ctx.AskCancelError
+	if err := result(t, done).Err(); !errors.As(err, &cerr) || !errors.Is(err, ctx.ErrShutdown) {
+		t.Errorf("Expected AskCancelError with ErrShutdown, got %v", err)
+	}
+}
+
+func TestAsk_ReplacedByNewerAsk(t *testing.T) {
+	b := newAskBot()
+
+	first := ask(func() g.Result[g.String] { return b.context(1, 42).Ask("First?").Text() })
+	b.prompt(t)
+
+	second := ask(func() g.Result[g.String] { return b.context(1, 42).Ask("Second?").Text() })
+	b.prompt(t)
+
+	var cerr *ctx.AskCancelError
+	if err := result(t, first).Err(); !errors.As(err, &cerr) || !cerr.Command.IsEmpty() {
+		t.Fatalf("Expected the first wait to be replaced, got %v", err)
+	}
+
+	b.process(message(1, 42, "answer"))
+
+	if answer := result(t, second); answer.IsErr() || answer.Ok() != "answer" {
+		t.Errorf("Expected the second wait to get the answer, got %v", answer)
+	}
+}
+
+func TestAsk_NoUser(t *testing.T) {
+	b := newAskBot()
+	c := ctx.New(b, &ext.Context{Update: &gotgbot.Update{UpdateId: 1}})
+
+	if err := c.Ask("Your name?").Text().Err(); !errors.Is(err, ctx.ErrAskNoUser) {
+		t.Errorf("Expected ErrAskNoUser, got %v", err)
+	}
+}
+
+func TestAsk_PromptFails(t *testing.T) {
+	b := newAskBot()
+	b.client.fail = true
+
+	if err := b.context(1, 42).Ask("Your name?").Text().Err(); err == nil {
+		t.Fatal("Expected the prompt error")
+	}
+
+	b.process(message(1, 42, "hello"))
+
+	if handled := b.Handled(); len(handled) != 1 {
+		t.Errorf("Expected no pending wait after a failed prompt, got %v", handled)
+	}
+}
+
+func TestWait(t *testing.T) {
+	b := newAskBot()
+	c := b.context(1, 42)
+
+	done := ask(func() g.Result[*ctx.Context] { return c.Wait().Expect(nil) })
+
+	for attempt := 0; ; attempt++ {
+		b.process(message(1, 42, "next"))
+
+		select {
+		case answer := <-done:
+			if answer.IsErr() || answer.Ok().EffectiveMessage.Text != "next" {
+				t.Errorf("Expected answer next, got %v", answer)
+			}
+
+			select {
+			case text := <-b.client.prompts:
+				t.Errorf("Expected no prompt, got %q", text)
+			default:
+			}
+
+			return
+		case <-time.After(10 * time.Millisecond):
+			if attempt == 100 {
+				t.Fatal("Expected the wait to end")
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package business_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/enetx/g"
@@ -18,6 +19,15 @@ func TestNewAccount(t *testing.T) {
 	}
 }
 
+func TestAccount_WithContext(t *testing.T) {
+	bot := &mockBot{}
+	account := business.NewAccount(bot, g.String("business_conn_123"))
+
+	if account.WithContext(context.Background()) != account {
+		t.Error("Expected WithContext to return the same account")
+	}
+}
+
 func TestAccount_SetName(t *testing.T) {
 	bot := &mockBot{}
 	connectionID := g.String("business_conn_123")
@@ -54,6 +54,49 @@ func TestNewContext(t *testing.T) {
 	}
 }
 
+func TestDetached(t *testing.T) {
+	bot := &mockBot{}
+	c := ctx.Detached(bot)
+
+	if c.Bot != bot || c.Update == nil || c.Raw == nil || c.Std() == nil {
+		t.Fatal("Expected a context of the bot with an empty update")
+	}
+
+	if c.EffectiveChat != nil || c.EffectiveMessage != nil || c.EffectiveUser != nil || c.Callback != nil {
+		t.Error("Expected no effective chat, message, user or callback")
+	}
+
+	c.Set("key", "value")
+	if v := c.Get("key"); v.IsNone() || v.Some() != "value" {
+		t.Error("Expected values to work on a detached context")
+	}
+}
+
+func TestContext_Chat(t *testing.T) {
+	c := ctx.Detached(&mockBot{})
+	bound := c.Chat(42)
+
+	if bound == c {
+		t.Fatal("Expected a copy of the context")
+	}
+
+	if bound.EffectiveChat == nil || bound.EffectiveChat.Id != 42 {
+		t.Errorf("Expected the effective chat 42, got %v", bound.EffectiveChat)
+	}
+
+	if c.EffectiveChat != nil {
+		t.Error("Chat should not change the original context")
+	}
+
+	if bound.Bot != c.Bot || bound.Update != c.Update {
+		t.Error("Expected the copy to keep the bot and the update")
+	}
+
+	if bound.SendMessage("hello") == nil {
+		t.Error("Expected a SendMessage builder on the bound context")
+	}
+}
+
 func TestContext_Args(t *testing.T) {
 	bot := &mockBot{}
 	rawCtx := &ext.Context{
@@ -0,0 +1,166 @@
+package ctx_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/tg/ctx"
+)
+
+// paramsClient records the requests it receives with their parameters.
+type paramsClient struct {
+	gotgbot.BotClient
+	mu       sync.Mutex
+	requests []sent
+}
+
+type sent struct {
+	method string
+	params map[string]string
+}
+
+func (p *paramsClient) RequestWithContext(
+	_ context.Context,
+	_ string,
+	method string,
+	params map[string]string,
+	_ map[string]gotgbot.FileReader,
+	_ *gotgbot.RequestOpts,
+) (json.RawMessage, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests = append(p.requests, sent{method, params})
+
+	if method == "deleteMessage" {
+		return json.RawMessage(`true`), nil
+	}
+
+	return json.RawMessage(`{"message_id":42,"date":0,"chat":{"id":7,"type":"private"}}`), nil
+}
+
+// rawBot is a bot that sends its requests through a paramsClient.
+type rawBot struct{ raw *gotgbot.Bot }
+
+func (b *rawBot) Raw() *gotgbot.Bot           { return b.raw }
+func (b *rawBot) Dispatcher() *ext.Dispatcher { return &ext.Dispatcher{} }
+func (b *rawBot) Updater() *ext.Updater       { return &ext.Updater{} }
+
+func newDetached() (*ctx.Context, *paramsClient) {
+	client := &paramsClient{}
+	return ctx.Detached(&rawBot{raw: &gotgbot.Bot{Token: "token", BotClient: client}}), client
+}
+
+func TestDetached_NoChat(t *testing.T) {
+	c, client := newDetached()
+
+	if r := c.SendMessage("hello").Send(); !errors.Is(r.Err(), ctx.ErrNoChat) {
+		t.Errorf("Expected ErrNoChat, got %v", r.Err())
+	}
+
+	if r := c.BanChatMember(1).Send(); !errors.Is(r.Err(), ctx.ErrNoChat) {
+		t.Errorf("Expected ErrNoChat, got %v", r.Err())
+	}
+
+	if r := c.IsAdmin(); !errors.Is(r.Err(), ctx.ErrNoChat) {
+		t.Errorf("Expected ErrNoChat from IsAdmin, got %v", r.Err())
+	}
+
+	if len(client.requests) != 0 {
+		t.Errorf("Expected no requests, got %v", client.requests)
+	}
+}
+
+func TestDetached_ExplicitChat(t *testing.T) {
+	c, client := newDetached()
+
+	if r := c.SendMessage("hello").To(9).Send(); r.IsErr() {
+		t.Fatalf("Send failed: %v", r.Err())
+	}
+
+	if len(client.requests) != 1 || client.requests[0].params["chat_id"] != "9" {
+		t.Errorf("Expected a request to chat 9, got %v", client.requests)
+	}
+}
+
+func TestDetached_Chat(t *testing.T) {
+	c, client := newDetached()
+
+	if r := c.Chat(7).SendMessage("hello").Send(); r.IsErr() {
+		t.Fatalf("Send failed: %v", r.Err())
+	}
+
+	if len(client.requests) != 1 {
+		t.Fatalf("Expected one request, got %v", client.requests)
+	}
+
+	if req := client.requests[0]; req.method != "sendMessage" || req.params["chat_id"] != "7" || req.params["text"] != "hello" {
+		t.Errorf("Unexpected request: %v", req)
+	}
+}
+
+func TestDetached_NoMessage(t *testing.T) {
+	c, client := newDetached()
+	chat := c.Chat(7)
+
+	if r := chat.DeleteMessage().Send(); !errors.Is(r.Err(), ctx.ErrNoMessage) {
+		t.Errorf("Expected ErrNoMessage from DeleteMessage, got %v", r.Err())
+	}
+
+	if r := chat.EditMessageText("edited").Send(); !errors.Is(r.Err(), ctx.ErrNoMessage) {
+		t.Errorf("Expected ErrNoMessage from EditMessageText, got %v", r.Err())
+	}
+
+	if r := chat.Reply("hi").Send(); !errors.Is(r.Err(), ctx.ErrNoMessage) {
+		t.Errorf("Expected ErrNoMessage from Reply, got %v", r.Err())
+	}
+
+	if len(client.requests) != 0 {
+		t.Errorf("Expected no requests, got %v", client.requests)
+	}
+
+	if args := c.Args(); !args.IsEmpty() {
+		t.Errorf("Expected no args without a message, got %v", args)
+	}
+}
+
+func TestDetached_ExplicitMessage(t *testing.T) {
+	c, client := newDetached()
+
+	if r := c.Chat(7).DeleteMessage().MessageID(3).Send(); r.IsErr() {
+		t.Fatalf("DeleteMessage failed: %v", r.Err())
+	}
+
+	if r := c.EditMessageText("edited").InlineMessageID("inline").Send(); r.IsErr() {
+		t.Fatalf("EditMessageText with an inline message failed: %v", r.Err())
+	}
+
+	if len(client.requests) != 2 {
+		t.Fatalf("Expected two requests, got %v", client.requests)
+	}
+
+	if req := client.requests[0]; req.params["chat_id"] != "7" || req.params["message_id"] != "3" {
+		t.Errorf("Unexpected delete request: %v", req)
+	}
+
+	if req := client.requests[1]; req.params["inline_message_id"] != "inline" || req.params["chat_id"] != "" {
+		t.Errorf("Unexpected edit request: %v", req)
+	}
+}
+
+func TestDetached_NoUser(t *testing.T) {
+	c, _ := newDetached()
+
+	if r := c.RefundStarPayment("charge").Send(); !errors.Is(r.Err(), ctx.ErrNoUser) {
+		t.Errorf("Expected ErrNoUser from RefundStarPayment, got %v", r.Err())
+	}
+
+	if r := c.Chat(7).IsAdmin(); !errors.Is(r.Err(), ctx.ErrNoUser) {
+		t.Errorf("Expected ErrNoUser from IsAdmin, got %v", r.Err())
+	}
+}
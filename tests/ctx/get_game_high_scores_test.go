@@ -292,3 +292,26 @@ func TestGetGameHighScores_Send(t *testing.T) {
 		t.Logf("GetGameHighScores comprehensive workflow Send failed as expected: %v", comprehensiveResult.Err())
 	}
 }
+
+func TestGetGameHighScores_InlineMessageIDSkipsChat(t *testing.T) {
+	c, client := newDetached()
+
+	c.GetGameHighScores(1).InlineMessageID("inline_123").Send()
+
+	if len(client.requests) != 1 {
+		t.Fatal("Expected an inline message to need no chat")
+	}
+
+	params := client.requests[0].params
+	if params["inline_message_id"] != "inline_123" {
+		t.Errorf("Expected the inline message ID, got %v", params)
+	}
+
+	if _, ok := params["chat_id"]; ok {
+		t.Errorf("Expected no chat_id with an inline message ID, got %v", params)
+	}
+
+	if _, ok := params["message_id"]; ok {
+		t.Errorf("Expected no message_id with an inline message ID, got %v", params)
+	}
+}
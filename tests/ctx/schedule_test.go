@@ -0,0 +1,254 @@
+package ctx_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/scheduler"
+)
+
+// sendClient records the methods of the requests it receives.
+type sendClient struct {
+	gotgbot.BotClient
+	mu      sync.Mutex
+	methods []string
+	sent    chan string
+}
+
+func (s *sendClient) RequestWithContext(
+	_ context.Context,
+	_ string,
+	method string,
+	_ map[string]string,
+	_ map[string]gotgbot.FileReader,
+	_ *gotgbot.RequestOpts,
+) (json.RawMessage, error) {
+	s.mu.Lock()
+	s.methods = append(s.methods, method)
+	s.mu.Unlock()
+
+	if s.sent != nil {
+		s.sent <- method
+	}
+
+	return json.RawMessage(`{"message_id":42,"date":0,"chat":{"id":7,"type":"private"}}`), nil
+}
+
+// schedBot is a bot with its own scheduler.
+type schedBot struct {
+	raw       *gotgbot.Bot
+	client    *sendClient
+	scheduler *scheduler.Scheduler
+}
+
+func newSchedBot(t *testing.T) *schedBot {
+	t.Helper()
+
+	client := &sendClient{sent: make(chan string, 10)}
+	b := &schedBot{raw: &gotgbot.Bot{Token: "token", BotClient: client}, client: client}
+	b.scheduler = scheduler.New(b)
+
+	if err := b.scheduler.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	t.Cleanup(b.scheduler.Stop)
+
+	return b
+}
+
+func (b *schedBot) Raw() *gotgbot.Bot               { return b.raw }
+func (b *schedBot) Dispatcher() *ext.Dispatcher     { return &ext.Dispatcher{} }
+func (b *schedBot) Updater() *ext.Updater           { return &ext.Updater{} }
+func (b *schedBot) Scheduler() *scheduler.Scheduler { return b.scheduler }
+
+func newSchedContext(bot *schedBot) *ctx.Context {
+	chat := gotgbot.Chat{Id: 7, Type: "private"}
+
+	return ctx.New(bot, &ext.Context{
+		Update:           &gotgbot.Update{UpdateId: 1},
+		EffectiveChat:    &chat,
+		EffectiveMessage: &gotgbot.Message{MessageId: 1, Chat: chat},
+	})
+}
+
+// request decodes the request stored by a job.
+func request(t *testing.T, job g.Option[scheduler.Job]) scheduler.Request {
+	t.Helper()
+
+	if job.IsNone() {
+		t.Fatal("Expected a pending job")
+	}
+
+	if job.Some().Kind != scheduler.KindRequest {
+		t.Fatalf("Expected a request job, got kind %s", job.Some().Kind)
+	}
+
+	var req scheduler.Request
+	if err := json.Unmarshal(job.Some().Payload, &req); err != nil {
+		t.Fatalf("Failed to decode request: %v", err)
+	}
+
+	return req
+}
+
+func TestSendMessage_Schedule(t *testing.T) {
+	bot := newSchedBot(t)
+	c := newSchedContext(bot)
+
+	before := time.Now()
+
+	id := c.SendMessage("Reminder").After(time.Hour).DeleteAfter(time.Minute).Schedule()
+	if id.IsErr() {
+		t.Fatalf("Schedule failed: %v", id.Err())
+	}
+
+	job := bot.scheduler.Job(id.Ok())
+
+	req := request(t, job)
+	if req.Method != "sendMessage" || req.Params["text"] != "Reminder" || req.Params["chat_id"] != "7" {
+		t.Errorf("Unexpected request: %+v", req)
+	}
+
+	if req.DeleteAfter != time.Minute {
+		t.Errorf("Expected DeleteAfter of a minute, got %v", req.DeleteAfter)
+	}
+
+	if at := job.Some().At; at.Before(before.Add(time.Hour)) || at.After(time.Now().Add(time.Hour)) {
+		t.Errorf("Expected the job to be due in an hour, got %v", at)
+	}
+
+	if err := bot.scheduler.Cancel(id.Ok()); err != nil {
+		t.Errorf("Cancel failed: %v", err)
+	}
+
+	if len(bot.client.methods) != 0 {
+		t.Errorf("Nothing should be sent, got %v", bot.client.methods)
+	}
+}
+
+func TestSendMessage_AfterUsesScheduler(t *testing.T) {
+	bot := newSchedBot(t)
+	c := newSchedContext(bot)
+
+	result := c.SendMessage("Later").After(time.Hour).Send()
+	if result.IsErr() || result.Ok() != nil {
+		t.Fatalf("Expected Ok(nil) for a delayed send, got %v", result)
+	}
+
+	jobs := bot.scheduler.Jobs()
+	if jobs.Len() != 1 {
+		t.Fatalf("Expected one pending job, got %d", jobs.Len())
+	}
+
+	if req := request(t, g.Some(jobs[0])); req.Method != "sendMessage" || req.Params["text"] != "Later" {
+		t.Errorf("Unexpected request: %+v", req)
+	}
+}
+
+func TestSchedule_RunsRightAwayWithoutAfter(t *testing.T) {
+	bot := newSchedBot(t)
+	c := newSchedContext(bot)
+
+	if id := c.Reply("Now").Schedule(); id.IsErr() {
+		t.Fatalf("Schedule failed: %v", id.Err())
+	}
+
+	select {
+	case method := <-bot.client.sent:
+		if method != "sendMessage" {
+			t.Errorf("Expected sendMessage, got %s", method)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Scheduled reply was not sent")
+	}
+}
+
+func TestSchedule_InvalidBuilderFailsRightAway(t *testing.T) {
+	bot := newSchedBot(t)
+	c := newSchedContext(bot)
+
+	if result := c.DeleteMessages().After(time.Hour).Send(); result.IsOk() {
+		t.Error("Expected an error for a deletion without message IDs")
+	}
+
+	if result := c.MediaGroup().After(time.Hour).Send(); result.IsOk() {
+		t.Error("Expected an error for an empty media group")
+	}
+
+	if !bot.scheduler.Jobs().IsEmpty() {
+		t.Error("Invalid requests must not be scheduled")
+	}
+}
+
+func TestDeleteAfter_SchedulesDeletion(t *testing.T) {
+	bot := newSchedBot(t)
+	c := newSchedContext(bot)
+
+	result := c.SendMessage("Self-destruct").DeleteAfter(time.Hour).Send()
+	if result.IsErr() {
+		t.Fatalf("Send failed: %v", result.Err())
+	}
+
+	jobs := bot.scheduler.Jobs()
+	if jobs.Len() != 1 {
+		t.Fatalf("Expected one deletion job, got %d", jobs.Len())
+	}
+
+	req := request(t, g.Some(jobs[0]))
+	if req.Method != "deleteMessage" || req.Params["chat_id"] != "7" || req.Params["message_id"] != "42" {
+		t.Errorf("Unexpected deletion request: %+v", req)
+	}
+}
+
+func TestDeleteMessage_Schedule(t *testing.T) {
+	bot := newSchedBot(t)
+	c := newSchedContext(bot)
+
+	id := c.DeleteMessage().MessageID(5).After(time.Hour).Schedule()
+	if id.IsErr() {
+		t.Fatalf("Schedule failed: %v", id.Err())
+	}
+
+	req := request(t, bot.scheduler.Job(id.Ok()))
+	if req.Method != "deleteMessage" || req.Params["message_id"] != "5" {
+		t.Errorf("Unexpected request: %+v", req)
+	}
+
+	if result := c.DeleteMessage().After(time.Hour).Send(); result.IsErr() || !result.Ok() {
+		t.Errorf("Expected Ok(true) for a delayed deletion, got %v", result)
+	}
+}
+
+func TestCopyMessage_Schedule(t *testing.T) {
+	bot := newSchedBot(t)
+	c := newSchedContext(bot)
+
+	id := c.CopyMessage(7, 3).To(9).After(time.Hour).Schedule()
+	if id.IsErr() {
+		t.Fatalf("Schedule failed: %v", id.Err())
+	}
+
+	req := request(t, bot.scheduler.Job(id.Ok()))
+	if req.Method != "copyMessage" || req.Params["chat_id"] != "9" || req.Params["message_id"] != "3" {
+		t.Errorf("Unexpected request: %+v", req)
+	}
+}
+
+func TestSchedule_WithoutBotScheduler(t *testing.T) {
+	c := ctx.New(&mockBot{}, &ext.Context{
+		Update:        &gotgbot.Update{UpdateId: 1},
+		EffectiveChat: &gotgbot.Chat{Id: 7, Type: "private"},
+	})
+
+	if id := c.SendMessage("Later").After(time.Hour).Schedule(); id.IsErr() || id.Ok() == "" {
+		t.Errorf("Expected a job of the fallback scheduler, got %v", id)
+	}
+}
@@ -1,6 +1,7 @@
 package ctx_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/enetx/tg/keyboard"
 	"github.com/enetx/tg/preview"
 	"github.com/enetx/tg/reply"
+	"github.com/enetx/tg/retry"
 	"github.com/enetx/tg/suggested"
 	"github.com/enetx/tg/types/effects"
 )
@@ -140,6 +142,12 @@ func TestContextSendMessage(t *testing.T) {
 		t.Error("APIURL method should return SendMessage for chaining")
 	}
 
+	// Test Retry method
+	result = testCtx.SendMessage(text).Retry(retry.New().Force())
+	if result == nil {
+		t.Error("Retry method should return SendMessage for chaining")
+	}
+
 	// Test AllowPaidBroadcast method
 	result = testCtx.SendMessage(text).AllowPaidBroadcast()
 	if result == nil {
@@ -642,3 +650,18 @@ func TestSendMessage_MethodOverrides(t *testing.T) {
 		t.Error("Method overriding should work")
 	}
 }
+
+func TestSendMessage_KeyboardError(t *testing.T) {
+	bot := &mockBot{}
+	rawCtx := &ext.Context{
+		EffectiveChat: &gotgbot.Chat{Id: 456, Type: "private"},
+		Update:        &gotgbot.Update{UpdateId: 1},
+	}
+
+	kb := keyboard.Inline().Text("Long", g.String("x").Repeat(keyboard.MaxCallbackLen+1))
+
+	err := ctx.New(bot, rawCtx).SendMessage("Hello").Markup(kb).Send().Err()
+	if !errors.Is(err, keyboard.ErrTooLong) {
+		t.Errorf("Send error = %v, want ErrTooLong", err)
+	}
+}
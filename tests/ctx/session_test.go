@@ -0,0 +1,110 @@
+package ctx_test
+
+import (
+	"testing"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/ctx"
+)
+
+func TestSession_SetGet(t *testing.T) {
+	s := newValuesCtx().Session()
+
+	if s.Changed() {
+		t.Error("Expected new session to be unchanged")
+	}
+
+	s.Set("name", g.String("alice"))
+
+	if !s.Changed() || !s.Has("name") || s.Len() != 1 {
+		t.Error("Expected session to hold the value and be changed")
+	}
+
+	if got := ctx.SessionValue[g.String](s, "name"); got.IsNone() || got.Some() != "alice" {
+		t.Errorf("Expected alice, got %v", got)
+	}
+
+	if ctx.SessionValue[int](s, "name").IsSome() {
+		t.Error("Expected type mismatch to be None")
+	}
+
+	if s.Get("missing").IsSome() {
+		t.Error("Expected missing key to be None")
+	}
+}
+
+func TestSession_SharedWithinUpdate(t *testing.T) {
+	raw := &ext.Context{Update: &gotgbot.Update{UpdateId: 1}}
+
+	ctx.New(&mockBot{}, raw).Session().Set("step", 1)
+
+	if !ctx.New(&mockBot{}, raw).Session().Has("step") {
+		t.Error("Expected session to be shared by the handlers of an update")
+	}
+}
+
+func TestSession_DeleteClear(t *testing.T) {
+	s := newValuesCtx().Session()
+	s.Set("a", 1)
+	s.Set("b", 2)
+
+	s.Delete("a")
+
+	if s.Has("a") || !s.Keys().Contains("b") {
+		t.Errorf("Expected only b to remain, got %v", s.Keys())
+	}
+
+	s.Clear()
+
+	if s.Len() != 0 {
+		t.Error("Expected cleared session to be empty")
+	}
+}
+
+func TestSession_JSONRoundTrip(t *testing.T) {
+	type profile struct {
+		Age int `json:"age"`
+	}
+
+	s := newValuesCtx().Session()
+	s.Set("profile", profile{Age: 30})
+
+	data, err := s.MarshalJSON()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	loaded := newValuesCtx().Session()
+	if err := loaded.UnmarshalJSON(data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if loaded.Changed() {
+		t.Error("Expected loaded session to be unchanged")
+	}
+
+	if got := ctx.SessionValue[profile](loaded, "profile"); got.IsNone() || got.Some().Age != 30 {
+		t.Errorf("Expected decoded profile, got %v", got)
+	}
+
+	generic := loaded.Get("profile")
+	if m, ok := generic.UnwrapOr(nil).(map[string]any); !ok || m["age"] != float64(30) {
+		t.Errorf("Expected generic JSON value, got %v", generic)
+	}
+
+	if err := loaded.UnmarshalJSON([]byte("[")); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestSession_Reset(t *testing.T) {
+	s := newValuesCtx().Session()
+	s.Set("a", 1)
+	s.Reset()
+
+	if s.Len() != 0 || s.Changed() {
+		t.Error("Expected reset session to be empty and unchanged")
+	}
+}
@@ -131,3 +131,24 @@ func TestSetGameScore_Send(t *testing.T) {
 		t.Logf("SetGameScore Send failed as expected with mock bot: %v", sendResult.Err())
 	}
 }
+
+func TestSetGameScore_InlineMessageIDSkipsChat(t *testing.T) {
+	c, client := newDetached()
+
+	if r := c.SetGameScore(1, 10).InlineMessageID("inline_123").Send(); r.IsErr() {
+		t.Fatalf("Expected an inline message to need no chat, got %v", r.Err())
+	}
+
+	params := client.requests[0].params
+	if params["inline_message_id"] != "inline_123" {
+		t.Errorf("Expected the inline message ID, got %v", params)
+	}
+
+	if _, ok := params["chat_id"]; ok {
+		t.Errorf("Expected no chat_id with an inline message ID, got %v", params)
+	}
+
+	if _, ok := params["message_id"]; ok {
+		t.Errorf("Expected no message_id with an inline message ID, got %v", params)
+	}
+}
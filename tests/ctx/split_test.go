@@ -0,0 +1,288 @@
+package ctx_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/entities"
+	"github.com/enetx/tg/keyboard"
+	"github.com/enetx/tg/types/effects"
+)
+
+// paragraphs returns n paragraphs of size characters each.
+func paragraphs(n, size int) g.String {
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = strings.Repeat(string(rune('a'+i)), size)
+	}
+
+	return g.String(strings.Join(parts, "\n\n"))
+}
+
+func TestSplit_ShortText(t *testing.T) {
+	c, client := newDetached()
+
+	msgs := c.Chat(7).SendMessage("hello").Split().Send()
+	if msgs.IsErr() {
+		t.Fatalf("Send failed: %v", msgs.Err())
+	}
+
+	if len(msgs.Ok()) != 1 || len(client.requests) != 1 {
+		t.Fatalf("Expected one message, got %d messages and requests %v", len(msgs.Ok()), client.requests)
+	}
+
+	if client.requests[0].params["text"] != "hello" {
+		t.Errorf("Unexpected text: %q", client.requests[0].params["text"])
+	}
+}
+
+func TestSplit_LongText(t *testing.T) {
+	c, client := newDetached()
+	kb := keyboard.Inline().Text("OK", "ok")
+
+	msgs := c.Chat(7).
+		SendMessage(paragraphs(3, 3000)).
+		Effect(effects.Fire).
+		Markup(kb).
+		Silent().
+		Split().
+		Send()
+
+	if msgs.IsErr() {
+		t.Fatalf("Send failed: %v", msgs.Err())
+	}
+
+	if len(msgs.Ok()) != 3 || len(client.requests) != 3 {
+		t.Fatalf("Expected three messages, got %d", len(client.requests))
+	}
+
+	for i, req := range client.requests {
+		if req.method != "sendMessage" || req.params["chat_id"] != "7" {
+			t.Errorf("Unexpected request %d: %v", i, req)
+		}
+
+		if want := strings.Repeat(string(rune('a'+i)), 3000); req.params["text"] != want {
+			t.Errorf("Part %d has %d characters, want the %d-th paragraph", i, len(req.params["text"]), i)
+		}
+
+		if req.params["disable_notification"] != "true" {
+			t.Errorf("Part %d is not silent", i)
+		}
+
+		if _, ok := req.params["reply_markup"]; ok != (i == 2) {
+			t.Errorf("Part %d: reply markup present = %t", i, ok)
+		}
+
+		if _, ok := req.params["message_effect_id"]; ok != (i == 0) {
+			t.Errorf("Part %d: effect present = %t", i, ok)
+		}
+	}
+}
+
+func TestSplit_Entities(t *testing.T) {
+	c, client := newDetached()
+
+	text := paragraphs(2, 3000)
+	e := entities.New(text).Bold(text)
+
+	if r := c.Chat(7).SendMessage(text).Entities(e).Split().Send(); r.IsErr() {
+		t.Fatalf("Send failed: %v", r.Err())
+	}
+
+	if len(client.requests) != 2 {
+		t.Fatalf("Expected two messages, got %d", len(client.requests))
+	}
+
+	for i, req := range client.requests {
+		got := req.params["entities"]
+		if !strings.Contains(got, `"type":"bold","offset":0,"length":3000`) {
+			t.Errorf("Part %d entities = %s, want bold over the whole part", i, got)
+		}
+	}
+}
+
+func TestSplit_HTML(t *testing.T) {
+	c, client := newDetached()
+
+	text := "<b>" + paragraphs(2, 3000) + "</b>"
+
+	if r := c.Chat(7).SendMessage(text).HTML().Split().Send(); r.IsErr() {
+		t.Fatalf("Send failed: %v", r.Err())
+	}
+
+	if len(client.requests) != 2 {
+		t.Fatalf("Expected two messages, got %d", len(client.requests))
+	}
+
+	for i, req := range client.requests {
+		got := req.params["text"]
+		if !strings.HasPrefix(got, "<b>") || !strings.HasSuffix(got, "</b>") {
+			t.Errorf("Part %d is not wrapped in <b>: %.20q...", i, got)
+		}
+
+		if req.params["parse_mode"] != "HTML" {
+			t.Errorf("Part %d parse mode = %q", i, req.params["parse_mode"])
+		}
+	}
+}
+
+func TestSplit_InvalidHTML(t *testing.T) {
+	c, client := newDetached()
+
+	r := c.Chat(7).SendMessage("<b>unclosed").HTML().Split().Send()
+	if !errors.Is(r.Err(), entities.ErrInvalidHTML) {
+		t.Errorf("Expected ErrInvalidHTML, got %v", r.Err())
+	}
+
+	if len(client.requests) != 0 {
+		t.Errorf("Expected no requests, got %v", client.requests)
+	}
+}
+
+func TestSplit_Markdown(t *testing.T) {
+	c, client := newDetached()
+
+	r := c.Chat(7).SendMessage("*bold*").Markdown().Split().Send()
+	if !errors.Is(r.Err(), ctx.ErrSplitMarkdown) {
+		t.Errorf("Expected ErrSplitMarkdown, got %v", r.Err())
+	}
+
+	if len(client.requests) != 0 {
+		t.Errorf("Expected no requests, got %v", client.requests)
+	}
+}
+
+func TestSplit_After(t *testing.T) {
+	c, _ := newDetached()
+
+	r := c.Chat(7).SendMessage("hello").After(time.Minute).Split().Send()
+	if !errors.Is(r.Err(), ctx.ErrSplitAfter) {
+		t.Errorf("Expected ErrSplitAfter, got %v", r.Err())
+	}
+}
+
+func TestSplit_NoChat(t *testing.T) {
+	c, _ := newDetached()
+
+	r := c.SendMessage("hello").Split().Send()
+	if !errors.Is(r.Err(), ctx.ErrNoChat) {
+		t.Errorf("Expected ErrNoChat, got %v", r.Err())
+	}
+}
+
+func TestSplit_Reply(t *testing.T) {
+	client := &paramsClient{}
+	bot := &rawBot{raw: &gotgbot.Bot{Token: "token", BotClient: client}}
+
+	c := ctx.New(bot, &ext.Context{
+		Update:           new(gotgbot.Update),
+		EffectiveMessage: &gotgbot.Message{MessageId: 5, Chat: gotgbot.Chat{Id: 7}},
+	})
+
+	if r := c.Reply(paragraphs(2, 3000)).Split().Send(); r.IsErr() {
+		t.Fatalf("Send failed: %v", r.Err())
+	}
+
+	if len(client.requests) != 2 {
+		t.Fatalf("Expected two messages, got %d", len(client.requests))
+	}
+
+	if got := client.requests[0].params["reply_parameters"]; !strings.Contains(got, `"message_id":5`) {
+		t.Errorf("First part should reply to the message, got %q", got)
+	}
+
+	if _, ok := client.requests[1].params["reply_parameters"]; ok {
+		t.Error("Second part should not reply")
+	}
+}
+
+func TestSplit_ReplyNoMessage(t *testing.T) {
+	c, _ := newDetached()
+
+	r := c.Chat(7).Reply("hello").Split().Send()
+	if !errors.Is(r.Err(), ctx.ErrNoMessage) {
+		t.Errorf("Expected ErrNoMessage, got %v", r.Err())
+	}
+}
+
+func TestSplit_Caption(t *testing.T) {
+	c, client := newDetached()
+
+	caption := g.String(strings.Repeat("a", 1000) + "\n\n" + strings.Repeat("b", 500))
+
+	msgs := c.Chat(7).
+		SendPhoto("https://example.com/photo.jpg").
+		Caption(caption).
+		Thread(3).
+		Protect().
+		Split().
+		Send()
+
+	if msgs.IsErr() {
+		t.Fatalf("Send failed: %v", msgs.Err())
+	}
+
+	if len(msgs.Ok()) != 2 || len(client.requests) != 2 {
+		t.Fatalf("Expected two messages, got %v", client.requests)
+	}
+
+	photo, rest := client.requests[0], client.requests[1]
+
+	if photo.method != "sendPhoto" || photo.params["caption"] != strings.Repeat("a", 1000) {
+		t.Errorf("Unexpected photo request: %s with a caption of %d characters", photo.method, len(photo.params["caption"]))
+	}
+
+	if rest.method != "sendMessage" || rest.params["text"] != strings.Repeat("b", 500) {
+		t.Errorf("Unexpected follow-up: %s %.20q", rest.method, rest.params["text"])
+	}
+
+	if !strings.Contains(rest.params["reply_parameters"], `"message_id":42`) {
+		t.Errorf("Follow-up should reply to the photo, got %q", rest.params["reply_parameters"])
+	}
+
+	if rest.params["message_thread_id"] != "3" || rest.params["protect_content"] != "true" {
+		t.Errorf("Follow-up should keep the delivery options, got %v", rest.params)
+	}
+}
+
+func TestSplit_ShortCaption(t *testing.T) {
+	c, client := newDetached()
+
+	if r := c.Chat(7).SendVideo("https://example.com/video.mp4").Caption("short").Split().Send(); r.IsErr() {
+		t.Fatalf("Send failed: %v", r.Err())
+	}
+
+	if len(client.requests) != 1 || client.requests[0].method != "sendVideo" {
+		t.Fatalf("Expected one video, got %v", client.requests)
+	}
+
+	if client.requests[0].params["caption"] != "short" {
+		t.Errorf("Unexpected caption: %q", client.requests[0].params["caption"])
+	}
+}
+
+func TestSplit_DeleteAfter(t *testing.T) {
+	bot := newSchedBot(t)
+	c := newSchedContext(bot)
+
+	if r := c.SendMessage(paragraphs(2, 3000)).DeleteAfter(time.Hour).Split().Send(); r.IsErr() {
+		t.Fatalf("Send failed: %v", r.Err())
+	}
+
+	jobs := bot.scheduler.Jobs()
+	if jobs.Len() != 2 {
+		t.Fatalf("Expected a deletion job for each part, got %d", jobs.Len())
+	}
+
+	for _, job := range jobs {
+		if req := request(t, g.Some(job)); req.Method != "deleteMessage" {
+			t.Errorf("Unexpected job request: %+v", req)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package ctx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/tg/ctx"
+)
+
+// lifetimeBot is a mockBot with a lifetime context.
+type lifetimeBot struct {
+	mockBot
+	std context.Context
+}
+
+func (l *lifetimeBot) Context() context.Context { return l.std }
+
+func TestContext_Std_Default(t *testing.T) {
+	c := ctx.New(&mockBot{}, &ext.Context{Update: &gotgbot.Update{UpdateId: 1}})
+
+	if c.Std() == nil {
+		t.Fatal("Expected Std to return a context")
+	}
+
+	if c.Std().Err() != nil {
+		t.Errorf("Expected live context, got %v", c.Std().Err())
+	}
+}
+
+func TestContext_Std_FromBot(t *testing.T) {
+	std, cancel := context.WithCancel(context.Background())
+	c := ctx.New(&lifetimeBot{std: std}, &ext.Context{Update: &gotgbot.Update{UpdateId: 1}})
+
+	cancel()
+
+	if !errors.Is(c.Std().Err(), context.Canceled) {
+		t.Errorf("Expected context to follow the bot lifetime, got %v", c.Std().Err())
+	}
+}
+
+func TestContext_SetStd(t *testing.T) {
+	c := ctx.New(&mockBot{}, &ext.Context{Update: &gotgbot.Update{UpdateId: 1}})
+
+	type key struct{}
+
+	std := context.WithValue(context.Background(), key{}, 1)
+	c.SetStd(std)
+
+	if c.Std() != std {
+		t.Error("Expected SetStd to replace the context")
+	}
+}
+
+// servingBot is a lifetimeBot whose serving context ends before its lifetime context.
+type servingBot struct {
+	lifetimeBot
+	serving context.Context
+}
+
+func (s *servingBot) Serving() context.Context { return s.serving }
+
+func TestContext_Std_FromServing(t *testing.T) {
+	serving, stop := context.WithCancelCause(context.Background())
+	update := &gotgbot.Update{UpdateId: 1}
+	c := ctx.New(&servingBot{lifetimeBot{std: context.Background()}, serving}, &ext.Context{Update: update})
+
+	if ctx.UpdateOf(c.Std()).UnwrapOrDefault() != update {
+		t.Error("Expected the standard context to carry its update")
+	}
+
+	stop(ctx.ErrShutdown)
+
+	if !errors.Is(context.Cause(c.Std()), ctx.ErrShutdown) {
+		t.Errorf("Expected context to end when serving stops, got %v", context.Cause(c.Std()))
+	}
+}
@@ -0,0 +1,515 @@
+package ctx_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+	"unicode/utf8"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/keyboard"
+)
+
+// streamClient records the requests of a stream, numbering the messages it sends.
+type streamClient struct {
+	gotgbot.BotClient
+	mu         sync.Mutex
+	requests   []sent
+	failDrafts bool
+	draftErrs  []error // Errors returned by the next drafts, in order
+	lastID     int64
+}
+
+func (s *streamClient) RequestWithContext(
+	_ context.Context,
+	_ string,
+	method string,
+	params map[string]string,
+	_ map[string]gotgbot.FileReader,
+	_ *gotgbot.RequestOpts,
+) (json.RawMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, sent{method, params})
+
+	switch method {
+	case "sendMessageDraft":
+		if s.failDrafts {
+			return nil, &gotgbot.TelegramError{Code: 400, Description: "Bad Request: drafts are not supported"}
+		}
+
+		if len(s.draftErrs) > 0 {
+			err := s.draftErrs[0]
+			s.draftErrs = s.draftErrs[1:]
+
+			return nil, err
+		}
+
+		return json.RawMessage(`true`), nil
+	case "sendMessage":
+		s.lastID++
+		return json.RawMessage(fmt.Sprintf(`{"message_id":%d,"date":0,"chat":{"id":7,"type":"private"}}`, s.lastID)), nil
+	default:
+		return json.RawMessage(fmt.Sprintf(`{"message_id":%s,"date":0,"chat":{"id":7,"type":"private"}}`, params["message_id"])), nil
+	}
+}
+
+// sent returns the requests made with method.
+func (s *streamClient) sent(method string) []sent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []sent
+
+	for _, req := range s.requests {
+		if req.method == method {
+			out = append(out, req)
+		}
+	}
+
+	return out
+}
+
+// waitFor waits until a request made with method has the given text.
+func (s *streamClient) waitFor(t *testing.T, method, text string) {
+	t.Helper()
+
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		for _, req := range s.sent(method) {
+			if req.params["text"] == text {
+				return
+			}
+		}
+	}
+
+	t.Fatalf("No %s request with text %q in %v", method, text, s.sent(method))
+}
+
+func newStreamContext(chatType string) (*ctx.Context, *streamClient) {
+	client := &streamClient{}
+	bot := &rawBot{raw: &gotgbot.Bot{Token: "token", BotClient: client}}
+	chat := gotgbot.Chat{Id: 7, Type: chatType}
+
+	return ctx.New(bot, &ext.Context{Update: new(gotgbot.Update), EffectiveChat: &chat}), client
+}
+
+// streamOf starts streaming the chunks sent to the returned channel.
+func streamOf(s *ctx.Stream) (chan<- g.String, <-chan g.Result[g.Slice[*gotgbot.Message]]) {
+	chunks := make(chan g.String)
+	result := make(chan g.Result[g.Slice[*gotgbot.Message]], 1)
+
+	go func() { result <- s.FromChan(chunks) }()
+
+	return chunks, result
+}
+
+func TestStream_Drafts(t *testing.T) {
+	c, client := newStreamContext("private")
+	chunks, result := streamOf(c.Stream().DraftID(3).Interval(time.Millisecond))
+
+	chunks <- "Hello, "
+	client.waitFor(t, "sendMessageDraft", "Hello, ")
+
+	chunks <- "wor"
+	chunks <- "ld"
+	client.waitFor(t, "sendMessageDraft", "Hello, world")
+
+	close(chunks)
+
+	msgs := <-result
+	if msgs.IsErr() {
+		t.Fatalf("Stream failed: %v", msgs.Err())
+	}
+
+	for _, draft := range client.sent("sendMessageDraft") {
+		if draft.params["text"] == "" {
+			t.Error("Drafts must not be empty")
+		}
+
+		if draft.params["draft_id"] != "3" || draft.params["chat_id"] != "7" {
+			t.Errorf("Unexpected draft: %v", draft.params)
+		}
+	}
+
+	final := client.sent("sendMessage")
+	if len(final) != 1 || final[0].params["text"] != "Hello, world" || len(msgs.Ok()) != 1 {
+		t.Errorf("Expected one final message, got %v", final)
+	}
+
+	if edits := client.sent("editMessageText"); len(edits) != 0 {
+		t.Errorf("Expected no edits, got %v", edits)
+	}
+}
+
+func TestStream_CoalescesChunks(t *testing.T) {
+	c, client := newStreamContext("private")
+	chunks, result := streamOf(c.Stream().Interval(time.Hour))
+
+	for range 100 {
+		chunks <- "x"
+	}
+
+	close(chunks)
+
+	if msgs := <-result; msgs.IsErr() {
+		t.Fatalf("Stream failed: %v", msgs.Err())
+	}
+
+	if drafts := client.sent("sendMessageDraft"); len(drafts) != 0 {
+		t.Errorf("Expected no drafts before the first interval, got %d drafts", len(drafts))
+	}
+
+	if final := client.sent("sendMessage"); len(final) != 1 || final[0].params["text"] != strings.Repeat("x", 100) {
+		t.Errorf("Unexpected final messages: %v", final)
+	}
+}
+
+func TestStream_FallsBackToEdits(t *testing.T) {
+	c, client := newStreamContext("private")
+	client.failDrafts = true
+
+	kb := keyboard.Inline().Text("More", "more")
+	chunks, result := streamOf(c.Stream().Interval(time.Millisecond).Markup(kb))
+
+	chunks <- "Hello"
+	client.waitFor(t, "sendMessage", "Hello")
+
+	chunks <- ", world"
+	client.waitFor(t, "editMessageText", "Hello, world")
+
+	close(chunks)
+
+	msgs := <-result
+	if msgs.IsErr() {
+		t.Fatalf("Stream failed: %v", msgs.Err())
+	}
+
+	if drafts := client.sent("sendMessageDraft"); len(drafts) != 1 {
+		t.Errorf("Expected drafts to stop after the first failure, got %d", len(drafts))
+	}
+
+	if sent := client.sent("sendMessage"); len(sent) != 1 {
+		t.Errorf("Expected one message, got %v", sent)
+	} else if _, ok := sent[0].params["reply_markup"]; ok {
+		t.Error("The live message should not carry the markup before the stream ends")
+	}
+
+	edits := client.sent("editMessageText")
+	if last := edits[len(edits)-1]; !strings.Contains(last.params["reply_markup"], "more") {
+		t.Errorf("Expected the final edit to attach the markup, got %v", last.params)
+	}
+
+	if len(msgs.Ok()) != 1 || msgs.Ok()[0].MessageId != 1 {
+		t.Errorf("Expected the edited message, got %v", msgs.Ok())
+	}
+}
+
+func TestStream_KeepsDraftsAfterTransientErrors(t *testing.T) {
+	c, client := newStreamContext("private")
+	client.draftErrs = []error{
+		&gotgbot.TelegramError{Code: 429, Description: "Too Many Requests: retry after 1"},
+		errors.New("connection reset"),
+	}
+
+	chunks, result := streamOf(c.Stream().Interval(time.Millisecond))
+
+	chunks <- "Hello"
+	client.waitFor(t, "sendMessageDraft", "Hello")
+
+	close(chunks)
+
+	if msgs := <-result; msgs.IsErr() {
+		t.Fatalf("Stream failed: %v", msgs.Err())
+	}
+
+	if drafts := client.sent("sendMessageDraft"); len(drafts) < 3 {
+		t.Errorf("Expected drafts to be retried after transient errors, got %d", len(drafts))
+	}
+
+	if final := client.sent("sendMessage"); len(final) != 1 || final[0].params["text"] != "Hello" {
+		t.Errorf("Expected one final message, got %v", final)
+	}
+
+	if edits := client.sent("editMessageText"); len(edits) != 0 {
+		t.Errorf("Expected no fallback to edits, got %v", edits)
+	}
+}
+
+func TestStream_SkipsUnchangedEdits(t *testing.T) {
+	c, client := newStreamContext("group")
+	chunks, result := streamOf(c.Stream().HTML().Interval(time.Millisecond))
+
+	chunks <- "Hello"
+	client.waitFor(t, "sendMessage", "Hello")
+
+	chunks <- "<b"
+	time.Sleep(20 * time.Millisecond)
+
+	close(chunks)
+
+	if msgs := <-result; msgs.IsErr() {
+		t.Fatalf("Stream failed: %v", msgs.Err())
+	}
+
+	if edits := client.sent("editMessageText"); len(edits) != 0 {
+		t.Errorf("Expected no edits of unchanged text, got %v", edits)
+	}
+}
+
+func TestStream_GroupUsesEdits(t *testing.T) {
+	c, client := newStreamContext("supergroup")
+	chunks, result := streamOf(c.Stream().Interval(time.Millisecond))
+
+	chunks <- "one"
+	client.waitFor(t, "sendMessage", "one")
+
+	chunks <- " two"
+	close(chunks)
+
+	if msgs := <-result; msgs.IsErr() {
+		t.Fatalf("Stream failed: %v", msgs.Err())
+	}
+
+	if drafts := client.sent("sendMessageDraft"); len(drafts) != 0 {
+		t.Errorf("Expected no drafts in a group, got %v", drafts)
+	}
+
+	client.waitFor(t, "editMessageText", "one two")
+}
+
+func TestStream_HTML(t *testing.T) {
+	c, client := newStreamContext("private")
+	chunks, result := streamOf(c.Stream().HTML().Interval(time.Millisecond))
+
+	chunks <- "<b>bo"
+	client.waitFor(t, "sendMessageDraft", "bo")
+
+	chunks <- "ld</b> te"
+	chunks <- "xt &amp"
+	client.waitFor(t, "sendMessageDraft", "bold text ")
+
+	chunks <- "; more"
+	close(chunks)
+
+	if msgs := <-result; msgs.IsErr() {
+		t.Fatalf("Stream failed: %v", msgs.Err())
+	}
+
+	for _, draft := range client.sent("sendMessageDraft") {
+		if !strings.Contains(draft.params["entities"], `"type":"bold"`) {
+			t.Errorf("Draft %q lost its formatting: %v", draft.params["text"], draft.params["entities"])
+		}
+	}
+
+	final := client.sent("sendMessage")
+	if len(final) != 1 || final[0].params["text"] != "bold text & more" {
+		t.Fatalf("Unexpected final messages: %v", final)
+	}
+
+	if !strings.Contains(final[0].params["entities"], `"type":"bold","offset":0,"length":4`) {
+		t.Errorf("Unexpected final entities: %s", final[0].params["entities"])
+	}
+
+	if _, ok := final[0].params["parse_mode"]; ok {
+		t.Error("Final message should be sent with entities, not a parse mode")
+	}
+}
+
+func TestStream_InvalidHTML(t *testing.T) {
+	c, client := newStreamContext("private")
+
+	if msgs := c.Stream().HTML().From(strings.NewReader("a <video>b</video>")); msgs.IsErr() {
+		t.Fatalf("Stream failed: %v", msgs.Err())
+	}
+
+	if final := client.sent("sendMessage"); len(final) != 1 || final[0].params["text"] != "a <video>b</video>" {
+		t.Errorf("Expected the markup as plain text, got %v", final)
+	}
+}
+
+func TestStream_UnclosedHTML(t *testing.T) {
+	c, client := newStreamContext("private")
+
+	if msgs := c.Stream().HTML().From(strings.NewReader("<i>never closed")); msgs.IsErr() {
+		t.Fatalf("Stream failed: %v", msgs.Err())
+	}
+
+	final := client.sent("sendMessage")
+	if len(final) != 1 || final[0].params["text"] != "never closed" {
+		t.Fatalf("Unexpected final messages: %v", final)
+	}
+
+	if !strings.Contains(final[0].params["entities"], `"type":"italic"`) {
+		t.Errorf("Expected the open tag to be closed, got %s", final[0].params["entities"])
+	}
+}
+
+func TestStream_LongText(t *testing.T) {
+	c, client := newStreamContext("private")
+
+	msgs := c.Stream().From(strings.NewReader(string(paragraphs(3, 3000))))
+	if msgs.IsErr() {
+		t.Fatalf("Stream failed: %v", msgs.Err())
+	}
+
+	final := client.sent("sendMessage")
+	if len(final) != 3 || len(msgs.Ok()) != 3 {
+		t.Fatalf("Expected three messages, got %d", len(final))
+	}
+
+	for i, req := range final {
+		if req.params["text"] != strings.Repeat(string(rune('a'+i)), 3000) {
+			t.Errorf("Part %d has %d characters", i, len(req.params["text"]))
+		}
+	}
+}
+
+func TestStream_LongTextWithEdits(t *testing.T) {
+	c, client := newStreamContext("group")
+	chunks, result := streamOf(c.Stream().Interval(time.Millisecond))
+
+	first := strings.Repeat("a", 3000)
+
+	chunks <- g.String(first)
+	client.waitFor(t, "sendMessage", first)
+
+	chunks <- "\n\n" + g.String(strings.Repeat("b", 3000))
+	client.waitFor(t, "sendMessage", strings.Repeat("b", 3000))
+
+	close(chunks)
+
+	msgs := <-result
+	if msgs.IsErr() {
+		t.Fatalf("Stream failed: %v", msgs.Err())
+	}
+
+	if len(msgs.Ok()) != 2 || msgs.Ok()[0].MessageId != 1 || msgs.Ok()[1].MessageId != 2 {
+		t.Errorf("Unexpected messages: %v", msgs.Ok())
+	}
+
+	for _, edit := range client.sent("editMessageText") {
+		if utf8.RuneCountInString(edit.params["text"]) > 4096 {
+			t.Errorf("Edit of %d characters exceeds the limit", len(edit.params["text"]))
+		}
+	}
+}
+
+func TestStream_ReplyAndEffectOnFirstMessage(t *testing.T) {
+	c, client := newStreamContext("private")
+
+	stream := c.Stream().Effect(0).Silent()
+	if msgs := stream.From(strings.NewReader(string(paragraphs(2, 3000)))); msgs.IsErr() {
+		t.Fatalf("Stream failed: %v", msgs.Err())
+	}
+
+	final := client.sent("sendMessage")
+	if len(final) != 2 {
+		t.Fatalf("Expected two messages, got %d", len(final))
+	}
+
+	if _, ok := final[0].params["message_effect_id"]; !ok {
+		t.Error("First message should carry the effect")
+	}
+
+	if _, ok := final[1].params["message_effect_id"]; ok {
+		t.Error("Second message should not carry the effect")
+	}
+
+	for i, req := range final {
+		if req.params["disable_notification"] != "true" {
+			t.Errorf("Message %d is not silent", i)
+		}
+	}
+}
+
+func TestStream_ReaderKeepsRunes(t *testing.T) {
+	c, client := newStreamContext("private")
+
+	msgs := c.Stream().Interval(time.Millisecond).From(iotest.OneByteReader(strings.NewReader("Привет, 👋")))
+	if msgs.IsErr() {
+		t.Fatalf("Stream failed: %v", msgs.Err())
+	}
+
+	for _, req := range client.requests {
+		if !utf8.ValidString(req.params["text"]) {
+			t.Errorf("%s with invalid UTF-8: %q", req.method, req.params["text"])
+		}
+	}
+
+	if final := client.sent("sendMessage"); len(final) != 1 || final[0].params["text"] != "Привет, 👋" {
+		t.Errorf("Unexpected final messages: %v", final)
+	}
+}
+
+func TestStream_ReadError(t *testing.T) {
+	c, client := newStreamContext("private")
+	boom := errors.New("boom")
+
+	msgs := c.Stream().From(io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(boom)))
+	if !errors.Is(msgs.Err(), boom) {
+		t.Fatalf("Expected the read error, got %v", msgs.Err())
+	}
+
+	if final := client.sent("sendMessage"); len(final) != 1 || final[0].params["text"] != "partial" {
+		t.Errorf("Expected the text read before the error to be sent, got %v", final)
+	}
+}
+
+func TestStream_Empty(t *testing.T) {
+	c, client := newStreamContext("private")
+
+	if msgs := c.Stream().From(strings.NewReader("")); !errors.Is(msgs.Err(), ctx.ErrStreamEmpty) {
+		t.Errorf("Expected ErrStreamEmpty, got %v", msgs.Err())
+	}
+
+	if final := client.sent("sendMessage"); len(final) != 0 {
+		t.Errorf("Expected no messages, got %v", final)
+	}
+}
+
+func TestStream_NoChat(t *testing.T) {
+	c, client := newDetached()
+
+	if msgs := c.Stream().From(strings.NewReader("hello")); !errors.Is(msgs.Err(), ctx.ErrNoChat) {
+		t.Errorf("Expected ErrNoChat, got %v", msgs.Err())
+	}
+
+	if len(client.requests) != 0 {
+		t.Errorf("Expected no requests, got %v", client.requests)
+	}
+}
+
+// stdBot is a rawBot with its own standard context.
+type stdBot struct {
+	rawBot
+	std context.Context
+}
+
+func (b *stdBot) Context() context.Context { return b.std }
+
+func TestStream_Canceled(t *testing.T) {
+	std, cancel := context.WithCancel(context.Background())
+
+	client := &streamClient{}
+	bot := &stdBot{rawBot: rawBot{raw: &gotgbot.Bot{Token: "token", BotClient: client}}, std: std}
+	c := ctx.New(bot, &ext.Context{Update: new(gotgbot.Update), EffectiveChat: &gotgbot.Chat{Id: 7}})
+
+	chunks, result := streamOf(c.Stream())
+	chunks <- "hello"
+	cancel()
+
+	if msgs := <-result; !errors.Is(msgs.Err(), context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", msgs.Err())
+	}
+}
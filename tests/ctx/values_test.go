@@ -0,0 +1,93 @@
+package ctx_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/ctx"
+)
+
+type account struct{ Name string }
+
+func newValuesCtx() *ctx.Context {
+	return ctx.New(&mockBot{}, &ext.Context{Update: &gotgbot.Update{UpdateId: 1}})
+}
+
+func TestContext_SetGet(t *testing.T) {
+	c := newValuesCtx()
+
+	if c.Get("missing").IsSome() {
+		t.Error("Expected missing key to be None")
+	}
+
+	c.Set("locale", g.String("en"))
+
+	if got := c.Get("locale"); got.IsNone() || got.Some() != g.String("en") {
+		t.Errorf("Expected locale en, got %v", got)
+	}
+
+	c.Delete("locale")
+
+	if c.Get("locale").IsSome() {
+		t.Error("Expected deleted key to be None")
+	}
+}
+
+func TestValue_Typed(t *testing.T) {
+	c := newValuesCtx()
+	c.Set("account", &account{Name: "alice"})
+
+	acc := ctx.Value[*account](c, "account")
+	if acc.IsNone() || acc.Some().Name != "alice" {
+		t.Errorf("Expected account alice, got %v", acc)
+	}
+
+	if ctx.Value[g.String](c, "account").IsSome() {
+		t.Error("Expected type mismatch to be None")
+	}
+
+	if ctx.Value[*account](c, "missing").IsSome() {
+		t.Error("Expected missing key to be None")
+	}
+}
+
+func TestValue_SharedAcrossContextsOfUpdate(t *testing.T) {
+	raw := &ext.Context{Update: &gotgbot.Update{UpdateId: 1}}
+
+	first := ctx.New(&mockBot{}, raw)
+	first.Set("flag", true)
+
+	second := ctx.New(&mockBot{}, raw)
+	if !ctx.Value[bool](second, "flag").UnwrapOr(false) {
+		t.Error("Expected value to be visible to later handlers of the same update")
+	}
+
+	other := newValuesCtx()
+	if other.Get("flag").IsSome() {
+		t.Error("Expected value not to leak into other updates")
+	}
+}
+
+func TestValue_Concurrent(t *testing.T) {
+	c := newValuesCtx()
+
+	var wg sync.WaitGroup
+
+	for i := range 50 {
+		wg.Go(func() {
+			c.Set(i, i)
+			_ = ctx.Value[int](c, i)
+		})
+	}
+
+	wg.Wait()
+
+	for i := range 50 {
+		if ctx.Value[int](c, i).UnwrapOr(-1) != i {
+			t.Errorf("Expected value %d", i)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	. "github.com/enetx/tg/entities"
+)
+
+func TestBuilderPlainAndStyles(t *testing.T) {
+	b := Text().
+		Plain("Hi ").
+		Bold("Олег").
+		Plain(", see the ").
+		Link("docs", "https://example.com")
+
+	if text := b.String(); text != "Hi Олег, see the docs" {
+		t.Fatalf("String = %q", text)
+	}
+
+	result := b.Std()
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 entities, got %d", len(result))
+	}
+
+	if result[0].Type != "bold" || result[0].Offset != 3 || result[0].Length != 4 {
+		t.Errorf("Unexpected bold entity %+v", result[0])
+	}
+
+	if result[1].Type != "text_link" || result[1].Offset != 17 || result[1].Length != 4 || result[1].Url != "https://example.com" {
+		t.Errorf("Unexpected link entity %+v", result[1])
+	}
+}
+
+func TestBuilderEmoji(t *testing.T) {
+	b := Text().Plain("🎉🎉 ").Italic("yay").CustomEmoji("👍", "5368324170671202286")
+
+	result := b.Std()
+	if result[0].Offset != 5 || result[0].Length != 3 {
+		t.Errorf("Unexpected italic entity %+v", result[0])
+	}
+
+	if result[1].Type != "custom_emoji" || result[1].Offset != 8 || result[1].Length != 2 || result[1].CustomEmojiId != "5368324170671202286" {
+		t.Errorf("Unexpected custom emoji entity %+v", result[1])
+	}
+
+	if b.Len() != 10 {
+		t.Errorf("Len = %d, want 10", b.Len())
+	}
+}
+
+func TestBuilderAllTypes(t *testing.T) {
+	b := Text().
+		Bold("b").
+		Italic("i").
+		Underline("u").
+		Strikethrough("s").
+		Spoiler("p").
+		Code("c").
+		Pre("x := 1", "go").
+		Mention("Bob", 42).
+		Blockquote("q").
+		ExpandableBlockquote("e").
+		DateTime("now", 1700000000, "wDT")
+
+	want := []string{
+		"bold", "italic", "underline", "strikethrough", "spoiler", "code",
+		"pre", "text_mention", "blockquote", "expandable_blockquote", "date_time",
+	}
+
+	result := b.Std()
+	if len(result) != len(want) {
+		t.Fatalf("Expected %d entities, got %d", len(want), len(result))
+	}
+
+	for i, typ := range want {
+		if result[i].Type != typ {
+			t.Errorf("Entity %d type = %q, want %q", i, result[i].Type, typ)
+		}
+	}
+
+	if result[6].Language != "go" || result[6].Offset != 6 || result[6].Length != 6 {
+		t.Errorf("Unexpected pre entity %+v", result[6])
+	}
+
+	if result[7].User == nil || result[7].User.Id != 42 {
+		t.Errorf("Unexpected mention entity %+v", result[7])
+	}
+
+	if result[10].UnixTime != 1700000000 || result[10].DateTimeFormat != "wDT" {
+		t.Errorf("Unexpected date_time entity %+v", result[10])
+	}
+}
+
+func TestBuilderLine(t *testing.T) {
+	b := Text().Line("one").Bold("two")
+
+	if b.String() != "one\ntwo" || b.Std()[0].Offset != 4 {
+		t.Errorf("Unexpected text %q and entities %+v", b.String(), b.Std())
+	}
+}
+
+func TestBuilderEmptyPiece(t *testing.T) {
+	b := Text().Bold("").Plain("x")
+
+	if len(b.Std()) != 0 {
+		t.Errorf("Empty styled text should add no entity, got %+v", b.Std())
+	}
+}
+
+func TestBuilderAppend(t *testing.T) {
+	inner := Text().Plain("a ").Bold("b")
+	b := Text().Plain("😀 ").Append(inner)
+
+	if b.String() != "😀 a b" {
+		t.Fatalf("String = %q", b.String())
+	}
+
+	if result := b.Std(); len(result) != 1 || result[0].Offset != 5 || result[0].Length != 1 {
+		t.Errorf("Appended entity should be re-based, got %+v", result)
+	}
+
+	if inner.Std()[0].Offset != 2 {
+		t.Error("Append should not modify the appended builder")
+	}
+}
+
+func TestBuilderWrap(t *testing.T) {
+	b := Text().
+		Plain("> ").
+		Wrap(Text().Plain("bold ").Italic("both"), gotgbot.MessageEntity{Type: "bold"})
+
+	result := b.Std()
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 entities, got %d", len(result))
+	}
+
+	if result[0].Type != "bold" || result[0].Offset != 2 || result[0].Length != 9 {
+		t.Errorf("Unexpected outer entity %+v", result[0])
+	}
+
+	if result[1].Type != "italic" || result[1].Offset != 7 || result[1].Length != 4 {
+		t.Errorf("Unexpected inner entity %+v", result[1])
+	}
+}
+
+func TestBuilderEntities(t *testing.T) {
+	b := Text().Plain("Hello ").Bold("world")
+
+	e := b.Entities()
+	if e.Text() != "Hello world" || e.Count() != 1 {
+		t.Errorf("Entities = %q with %d entities", e.Text(), e.Count())
+	}
+
+	b.Italic("!")
+	if e.Count() != 1 {
+		t.Error("Entities should not change when the builder grows")
+	}
+}
@@ -0,0 +1,43 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/enetx/g"
+	. "github.com/enetx/tg/entities"
+)
+
+func TestEscapeHTML(t *testing.T) {
+	got := EscapeHTML(`<b>Tom & "Jerry"</b>`)
+	want := g.String("&lt;b&gt;Tom &amp; &quot;Jerry&quot;&lt;/b&gt;")
+
+	if got != want {
+		t.Errorf("EscapeHTML = %q, want %q", got, want)
+	}
+}
+
+func TestEscapeMarkdownV2(t *testing.T) {
+	got := EscapeMarkdownV2(`*hi* _x_ [a](b) ~c~ ` + "`d`" + ` >e #f +g -h =i |j| {k} .l !m \n`)
+	want := g.String(`\*hi\* \_x\_ \[a\]\(b\) \~c\~ \` + "`d\\`" + ` \>e \#f \+g \-h \=i \|j\| \{k\} \.l \!m \\n`)
+
+	if got != want {
+		t.Errorf("EscapeMarkdownV2 = %q, want %q", got, want)
+	}
+}
+
+func TestEscapeMarkdownV2Code(t *testing.T) {
+	got := EscapeMarkdownV2Code("a `b` *c* \\d")
+	want := g.String("a \\`b\\` *c* \\\\d")
+
+	if got != want {
+		t.Errorf("EscapeMarkdownV2Code = %q, want %q", got, want)
+	}
+}
+
+func TestEscapePlainText(t *testing.T) {
+	for _, s := range []g.String{"", "hello world", "привет 👋"} {
+		if EscapeHTML(s) != s || EscapeMarkdownV2(s) != s {
+			t.Errorf("%q should not change", s)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/g"
+	. "github.com/enetx/tg/entities"
+)
+
+func entity(typ string, offset, length int64) gotgbot.MessageEntity {
+	return gotgbot.MessageEntity{Type: typ, Offset: offset, Length: length}
+}
+
+func TestToHTMLBasic(t *testing.T) {
+	text := g.String("Hello bold & <world>")
+	ents := []gotgbot.MessageEntity{entity("bold", 6, 4), entity("italic", 13, 7)}
+
+	got := ToHTML(text, ents)
+	want := g.String("Hello <b>bold</b> &amp; <i>&lt;world&gt;</i>")
+
+	if got != want {
+		t.Errorf("ToHTML = %q, want %q", got, want)
+	}
+}
+
+func TestToHTMLUTF16(t *testing.T) {
+	text := g.String("👋 Привет, мир")
+
+	got := ToHTML(text, []gotgbot.MessageEntity{entity("bold", 11, 3)})
+	if got != "👋 Привет, <b>мир</b>" {
+		t.Errorf("ToHTML = %q", got)
+	}
+}
+
+func TestToHTMLNested(t *testing.T) {
+	text := g.String("bold italic end")
+	ents := []gotgbot.MessageEntity{entity("bold", 0, 11), entity("italic", 5, 6)}
+
+	got := ToHTML(text, ents)
+	if got != "<b>bold <i>italic</i></b> end" {
+		t.Errorf("ToHTML = %q", got)
+	}
+}
+
+func TestToHTMLOverlapping(t *testing.T) {
+	text := g.String("abcdef")
+	ents := []gotgbot.MessageEntity{entity("bold", 0, 4), entity("italic", 2, 4)}
+
+	got := ToHTML(text, ents)
+	if got != "<b>ab<i>cd</i></b><i>ef</i>" {
+		t.Errorf("ToHTML = %q", got)
+	}
+}
+
+func TestToHTMLAllTypes(t *testing.T) {
+	tests := []struct {
+		entity gotgbot.MessageEntity
+		want   g.String
+	}{
+		{entity("underline", 0, 1), "<u>x</u>"},
+		{entity("strikethrough", 0, 1), "<s>x</s>"},
+		{entity("spoiler", 0, 1), "<tg-spoiler>x</tg-spoiler>"},
+		{entity("code", 0, 1), "<code>x</code>"},
+		{entity("pre", 0, 1), "<pre>x</pre>"},
+		{gotgbot.MessageEntity{Type: "pre", Length: 1, Language: "go"}, `<pre><code class="language-go">x</code></pre>`},
+		{gotgbot.MessageEntity{Type: "text_link", Length: 1, Url: "https://a.b/?q=1&r=2"}, `<a href="https://a.b/?q=1&amp;r=2">x</a>`},
+		{gotgbot.MessageEntity{Type: "text_mention", Length: 1, User: &gotgbot.User{Id: 42}}, `<a href="tg://user?id=42">x</a>`},
+		{gotgbot.MessageEntity{Type: "custom_emoji", Length: 1, CustomEmojiId: "123"}, `<tg-emoji emoji-id="123">x</tg-emoji>`},
+		{entity("blockquote", 0, 1), "<blockquote>x</blockquote>"},
+		{entity("expandable_blockquote", 0, 1), "<blockquote expandable>x</blockquote>"},
+		{gotgbot.MessageEntity{Type: "date_time", Length: 1, UnixTime: 1700000000, DateTimeFormat: "wDT"}, `<tg-time unix="1700000000" format="wDT">x</tg-time>`},
+		{gotgbot.MessageEntity{Type: "date_time", Length: 1, UnixTime: 1700000000}, `<tg-time unix="1700000000">x</tg-time>`},
+		{entity("hashtag", 0, 1), "x"},
+	}
+
+	for _, tt := range tests {
+		if got := ToHTML("x", []gotgbot.MessageEntity{tt.entity}); got != tt.want {
+			t.Errorf("ToHTML with %s = %q, want %q", tt.entity.Type, got, tt.want)
+		}
+	}
+}
+
+func TestToHTMLInvalidEntities(t *testing.T) {
+	ents := []gotgbot.MessageEntity{entity("bold", 10, 2), entity("italic", 0, 0), entity("code", 2, 10)}
+
+	if got := ToHTML("abcd", ents); got != "ab<code>cd</code>" {
+		t.Errorf("ToHTML = %q", got)
+	}
+}
+
+func TestToMarkdownV2Basic(t *testing.T) {
+	text := g.String("Hello bold. (world)")
+	ents := []gotgbot.MessageEntity{entity("bold", 6, 4), entity("italic", 12, 7)}
+
+	got := ToMarkdownV2(text, ents)
+	want := g.String(`Hello *bold*\. _\(world\)_`)
+
+	if got != want {
+		t.Errorf("ToMarkdownV2 = %q, want %q", got, want)
+	}
+}
+
+func TestToMarkdownV2AllTypes(t *testing.T) {
+	tests := []struct {
+		entity gotgbot.MessageEntity
+		want   g.String
+	}{
+		{entity("underline", 0, 2), "__x\\.__"},
+		{entity("strikethrough", 0, 2), "~x\\.~"},
+		{entity("spoiler", 0, 2), "||x\\.||"},
+		{entity("code", 0, 2), "`x.`"},
+		{gotgbot.MessageEntity{Type: "pre", Length: 2, Language: "go"}, "```go\nx.\n```"},
+		{gotgbot.MessageEntity{Type: "text_link", Length: 2, Url: "https://a.b/(c)"}, "[x\\.](https://a.b/(c\\))"},
+		{gotgbot.MessageEntity{Type: "text_mention", Length: 2, User: &gotgbot.User{Id: 42}}, "[x\\.](tg://user?id=42)"},
+		{gotgbot.MessageEntity{Type: "custom_emoji", Length: 2, CustomEmojiId: "123"}, "![x\\.](tg://emoji?id=123)"},
+		{gotgbot.MessageEntity{Type: "date_time", Length: 2, UnixTime: 1700000000, DateTimeFormat: "r"}, "![x\\.](tg://time?unix=1700000000&format=r)"},
+	}
+
+	for _, tt := range tests {
+		if got := ToMarkdownV2("x.", []gotgbot.MessageEntity{tt.entity}); got != tt.want {
+			t.Errorf("ToMarkdownV2 with %s = %q, want %q", tt.entity.Type, got, tt.want)
+		}
+	}
+}
+
+func TestToMarkdownV2Code(t *testing.T) {
+	got := ToMarkdownV2("run `ls` *now*", []gotgbot.MessageEntity{entity("code", 4, 4)})
+	want := g.String("run `\\`ls\\`` \\*now\\*")
+
+	if got != want {
+		t.Errorf("ToMarkdownV2 = %q, want %q", got, want)
+	}
+}
+
+func TestToMarkdownV2Blockquote(t *testing.T) {
+	text := g.String("one\ntwo\nafter")
+
+	if got := ToMarkdownV2(text, []gotgbot.MessageEntity{entity("blockquote", 0, 7)}); got != ">one\n>two\nafter" {
+		t.Errorf("ToMarkdownV2 = %q", got)
+	}
+
+	if got := ToMarkdownV2(text, []gotgbot.MessageEntity{entity("expandable_blockquote", 0, 7)}); got != "**>one\n>two||\nafter" {
+		t.Errorf("ToMarkdownV2 = %q", got)
+	}
+}
+
+func TestToMarkdownV2UnderlineItalic(t *testing.T) {
+	text := g.String("both")
+	ents := []gotgbot.MessageEntity{entity("underline", 0, 4), entity("italic", 0, 4)}
+
+	if got := ToMarkdownV2(text, ents); got != "__\r_both_\r__" {
+		t.Errorf("ToMarkdownV2 = %q", got)
+	}
+}
+
+func TestToMarkdownV2Nested(t *testing.T) {
+	text := g.String("😀 bold italic")
+	ents := []gotgbot.MessageEntity{entity("bold", 3, 11), entity("italic", 8, 6)}
+
+	if got := ToMarkdownV2(text, ents); got != "😀 *bold _italic_*" {
+		t.Errorf("ToMarkdownV2 = %q", got)
+	}
+}
@@ -0,0 +1,171 @@
+package entities_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/g"
+	. "github.com/enetx/tg/entities"
+)
+
+func TestParseHTMLBasic(t *testing.T) {
+	e := ParseHTML("Hello <b>bold</b> &amp; <i>&lt;world&gt;</i>").Unwrap()
+
+	if e.Text() != "Hello bold & <world>" {
+		t.Fatalf("Text = %q", e.Text())
+	}
+
+	want := []gotgbot.MessageEntity{entity("bold", 6, 4), entity("italic", 13, 7)}
+	if !reflect.DeepEqual(e.Std(), want) {
+		t.Errorf("Entities = %+v, want %+v", e.Std(), want)
+	}
+}
+
+func TestParseHTMLUTF16(t *testing.T) {
+	e := ParseHTML("👋 Привет, <b>мир</b>").Unwrap()
+
+	if got := e.Std()[0]; got.Offset != 11 || got.Length != 3 {
+		t.Errorf("Unexpected entity %+v", got)
+	}
+}
+
+func TestParseHTMLTags(t *testing.T) {
+	tests := []struct {
+		html g.String
+		want gotgbot.MessageEntity
+	}{
+		{"<strong>x</strong>", entity("bold", 0, 1)},
+		{"<em>x</em>", entity("italic", 0, 1)},
+		{"<ins>x</ins>", entity("underline", 0, 1)},
+		{"<del>x</del>", entity("strikethrough", 0, 1)},
+		{"<strike>x</strike>", entity("strikethrough", 0, 1)},
+		{`<span class="tg-spoiler">x</span>`, entity("spoiler", 0, 1)},
+		{"<tg-spoiler>x</tg-spoiler>", entity("spoiler", 0, 1)},
+		{"<B>x</B>", entity("bold", 0, 1)},
+		{`<pre><code class="language-go">x</code></pre>`, gotgbot.MessageEntity{Type: "pre", Length: 1, Language: "go"}},
+		{`<a href="https://a.b/?q=1&amp;r=2">x</a>`, gotgbot.MessageEntity{Type: "text_link", Length: 1, Url: "https://a.b/?q=1&r=2"}},
+		{`<a href='tg://user?id=42'>x</a>`, gotgbot.MessageEntity{Type: "text_mention", Length: 1, User: &gotgbot.User{Id: 42}}},
+		{`<tg-emoji emoji-id="123">x</tg-emoji>`, gotgbot.MessageEntity{Type: "custom_emoji", Length: 1, CustomEmojiId: "123"}},
+		{"<blockquote expandable>x</blockquote>", entity("expandable_blockquote", 0, 1)},
+		{`<tg-time unix="1700000000" format="wDT">x</tg-time>`, gotgbot.MessageEntity{Type: "date_time", Length: 1, UnixTime: 1700000000, DateTimeFormat: "wDT"}},
+	}
+
+	for _, tt := range tests {
+		e := ParseHTML(tt.html).Unwrap()
+
+		if e.Text() != "x" || e.Count() != 1 || !reflect.DeepEqual(e.Std()[0], tt.want) {
+			t.Errorf("ParseHTML(%q) = %q %+v, want %+v", tt.html, e.Text(), e.Std(), tt.want)
+		}
+	}
+}
+
+func TestParseHTMLCodeInPre(t *testing.T) {
+	e := ParseHTML("<pre>a<code>b</code></pre>").Unwrap()
+
+	want := []gotgbot.MessageEntity{entity("pre", 0, 2), entity("code", 1, 1)}
+	if !reflect.DeepEqual(e.Std(), want) {
+		t.Errorf("Entities = %+v, want %+v", e.Std(), want)
+	}
+}
+
+func TestParseHTMLEmptyTag(t *testing.T) {
+	e := ParseHTML("a<b></b>c").Unwrap()
+
+	if e.Text() != "ac" || e.Count() != 0 {
+		t.Errorf("ParseHTML = %q with %d entities", e.Text(), e.Count())
+	}
+}
+
+func TestParseHTMLErrors(t *testing.T) {
+	for _, s := range []g.String{
+		"<b>unclosed",
+		"<b>x</i>",
+		"x</b>",
+		"<div>x</div>",
+		"<span>x</span>",
+		"<a>x</a>",
+		`<a href="tg://user?id=abc">x</a>`,
+		"<tg-emoji>x</tg-emoji>",
+		"<tg-time>x</tg-time>",
+		"<b x",
+	} {
+		if err := ParseHTML(s).Err(); !errors.Is(err, ErrInvalidHTML) {
+			t.Errorf("ParseHTML(%q) error = %v, want ErrInvalidHTML", s, err)
+		}
+	}
+}
+
+func TestParseHTMLRoundTrip(t *testing.T) {
+	text := g.String("👋 Hi <you> & bold italic\nquote line\ncode")
+	ents := []gotgbot.MessageEntity{
+		entity("bold", 14, 11),
+		entity("italic", 19, 6),
+		{Type: "text_link", Offset: 3, Length: 2, Url: "https://example.com/?a=1&b=\"2\""},
+		entity("blockquote", 26, 10),
+		{Type: "pre", Offset: 37, Length: 4, Language: "go"},
+	}
+
+	e := ParseHTML(ToHTML(text, ents)).Unwrap()
+
+	if e.Text() != text {
+		t.Fatalf("Text = %q, want %q", e.Text(), text)
+	}
+
+	want := []gotgbot.MessageEntity{ents[2], ents[0], ents[1], ents[3], ents[4]}
+	if !reflect.DeepEqual(e.Std(), want) {
+		t.Errorf("Entities = %+v, want %+v", e.Std(), want)
+	}
+}
+
+func TestParseHTMLPrefixClosesTags(t *testing.T) {
+	e := ParseHTMLPrefix("Hello <b>wor<i>ld").Unwrap()
+
+	if e.Text() != "Hello world" {
+		t.Fatalf("Text = %q", e.Text())
+	}
+
+	want := []gotgbot.MessageEntity{entity("bold", 6, 5), entity("italic", 9, 2)}
+	if !reflect.DeepEqual(e.Std(), want) {
+		t.Errorf("Entities = %+v, want %+v", e.Std(), want)
+	}
+}
+
+func TestParseHTMLPrefixDropsIncompleteEnd(t *testing.T) {
+	for s, want := range map[g.String]g.String{
+		"Hello <b":              "Hello ",
+		"Hello <a href=\"x>y":   "Hello ",
+		"Hello </b":             "Hello ",
+		"Tom &am":               "Tom ",
+		"Tom &amp; Jerry":       "Tom & Jerry",
+		"a & b":                 "a & b",
+		"<b>bold</b> and <code": "bold and ",
+	} {
+		e := ParseHTMLPrefix(s)
+		if e.IsErr() {
+			t.Errorf("ParseHTMLPrefix(%q) error = %v", s, e.Err())
+			continue
+		}
+
+		if got := e.Ok().Text(); got != want {
+			t.Errorf("ParseHTMLPrefix(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func TestParseHTMLPrefixErrors(t *testing.T) {
+	for _, s := range []g.String{"<b>x</i>", "<video>x"} {
+		if err := ParseHTMLPrefix(s).Err(); !errors.Is(err, ErrInvalidHTML) {
+			t.Errorf("ParseHTMLPrefix(%q) error = %v, want ErrInvalidHTML", s, err)
+		}
+	}
+}
+
+func TestParseHTMLPrefixMatchesParseHTML(t *testing.T) {
+	s := g.String(`<b>bold <i>both</i></b> <a href="https://example.com">link</a> &lt;tag&gt;`)
+
+	if got, want := ParseHTMLPrefix(s).Unwrap(), ParseHTML(s).Unwrap(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseHTMLPrefix = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,175 @@
+package entities_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/g"
+	. "github.com/enetx/tg/entities"
+)
+
+func TestCutFits(t *testing.T) {
+	e := New("short text").Bold("text")
+
+	head, rest := e.Cut(100)
+	if rest != nil {
+		t.Fatalf("rest = %v, want nil", rest)
+	}
+
+	if head.Text() != "short text" || len(head.Std()) != 1 {
+		t.Errorf("head = %q %v", head.Text(), head.Std())
+	}
+}
+
+func TestCutParagraph(t *testing.T) {
+	e := New("first line\nsecond line\n\nthird")
+
+	head, rest := e.Cut(26)
+	if head.Text() != "first line\nsecond line" {
+		t.Errorf("head = %q", head.Text())
+	}
+
+	if rest.Text() != "third" {
+		t.Errorf("rest = %q", rest.Text())
+	}
+}
+
+func TestCutLine(t *testing.T) {
+	e := New("first line\nsecond line\nthird line")
+
+	head, rest := e.Cut(25)
+	if head.Text() != "first line\nsecond line" {
+		t.Errorf("head = %q", head.Text())
+	}
+
+	if rest.Text() != "third line" {
+		t.Errorf("rest = %q", rest.Text())
+	}
+}
+
+func TestCutParagraphInFirstHalfFallsBackToLine(t *testing.T) {
+	e := New("a\n\nbbbbbbbbbbbbbbbb\ncccc")
+
+	head, rest := e.Cut(21)
+	if head.Text() != "a\n\nbbbbbbbbbbbbbbbb" {
+		t.Errorf("head = %q", head.Text())
+	}
+
+	if rest.Text() != "cccc" {
+		t.Errorf("rest = %q", rest.Text())
+	}
+}
+
+func TestCutWord(t *testing.T) {
+	e := New("one two three four")
+
+	head, rest := e.Cut(10)
+	if head.Text() != "one two" {
+		t.Errorf("head = %q", head.Text())
+	}
+
+	if rest.Text() != "three four" {
+		t.Errorf("rest = %q", rest.Text())
+	}
+}
+
+func TestCutHard(t *testing.T) {
+	e := New("abcdefghij")
+
+	head, rest := e.Cut(4)
+	if head.Text() != "abcd" || rest.Text() != "efghij" {
+		t.Errorf("Cut = %q, %q", head.Text(), rest.Text())
+	}
+}
+
+func TestCutKeepsSurrogatePairs(t *testing.T) {
+	e := New("abc😀def")
+
+	head, rest := e.Cut(4)
+	if head.Text() != "abc" || rest.Text() != "😀def" {
+		t.Errorf("Cut = %q, %q", head.Text(), rest.Text())
+	}
+}
+
+func TestCutRebasesEntities(t *testing.T) {
+	e := New("hello brave new world").Bold("brave new")
+
+	head, rest := e.Cut(11)
+	if head.Text() != "hello brave" || rest.Text() != "new world" {
+		t.Fatalf("Cut = %q, %q", head.Text(), rest.Text())
+	}
+
+	if want := []gotgbot.MessageEntity{entity("bold", 6, 5)}; !reflect.DeepEqual(head.Std(), want) {
+		t.Errorf("head entities = %v, want %v", head.Std(), want)
+	}
+
+	if want := []gotgbot.MessageEntity{entity("bold", 0, 3)}; !reflect.DeepEqual(rest.Std(), want) {
+		t.Errorf("rest entities = %v, want %v", rest.Std(), want)
+	}
+}
+
+func TestCutDropsEntitiesOnBreak(t *testing.T) {
+	e := New("aaaa bbbb").Import([]gotgbot.MessageEntity{entity("italic", 4, 1)})
+
+	head, rest := e.Cut(6)
+	if len(head.Std()) != 0 || len(rest.Std()) != 0 {
+		t.Errorf("entities = %v, %v, want none", head.Std(), rest.Std())
+	}
+}
+
+func TestCutKeepsEntityFields(t *testing.T) {
+	e := New("see the docs here").URL("the docs here", "https://example.com")
+
+	_, rest := e.Cut(8)
+	ents := rest.Std()
+
+	if len(ents) != 1 || ents[0].Url != "https://example.com" || ents[0].Offset != 0 || ents[0].Length != 9 {
+		t.Errorf("rest entities = %v", ents)
+	}
+}
+
+func TestSplitLimits(t *testing.T) {
+	words := g.String(strings.Repeat("word ", 3000))
+	parts := New(words).Split(MaxMessageLen)
+
+	if len(parts) != 4 {
+		t.Fatalf("len(parts) = %d, want 4", len(parts))
+	}
+
+	var total int
+
+	for _, part := range parts {
+		n := UTF16Len(part.Text())
+		if n > MaxMessageLen {
+			t.Errorf("part of %d units exceeds the limit", n)
+		}
+
+		total += int(n)
+	}
+
+	if total != 3000*5-(len(parts)-1) {
+		t.Errorf("total = %d", total)
+	}
+}
+
+func TestSplitSkipsEmptyParts(t *testing.T) {
+	parts := New("aaaa\n").Split(4)
+
+	if len(parts) != 1 || parts[0].Text() != "aaaa" {
+		t.Errorf("parts = %v", parts)
+	}
+}
+
+func TestSplitRoundTripsHTML(t *testing.T) {
+	text := g.String("<b>" + strings.Repeat("bold ", 10) + "</b>")
+	e := ParseHTML(text).Unwrap()
+
+	for _, part := range e.Split(20) {
+		html := ToHTML(part.Text(), part.Std())
+		if !html.StartsWith("<b>") || !html.EndsWith("</b>") {
+			t.Errorf("part = %q, want it wrapped in <b>", html)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/enetx/g"
+	. "github.com/enetx/tg/entities"
+)
+
+func TestUTF16Len(t *testing.T) {
+	tests := []struct {
+		text g.String
+		want int64
+	}{
+		{"", 0},
+		{"hello", 5},
+		{"привет", 6},
+		{"日本語", 3},
+		{"👋", 2},
+		{"hi 👋🏽!", 8},
+	}
+
+	for _, tt := range tests {
+		if got := UTF16Len(tt.text); got != tt.want {
+			t.Errorf("UTF16Len(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestEntitiesUTF16Offsets(t *testing.T) {
+	result := New("👋 Привет, мир").Bold("мир").Std()
+
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 entity, got %d", len(result))
+	}
+
+	if result[0].Offset != 11 || result[0].Length != 3 {
+		t.Errorf("Expected offset 11 and length 3, got offset %d length %d", result[0].Offset, result[0].Length)
+	}
+}
+
+func TestEntitiesUTF16Length(t *testing.T) {
+	result := New("Party 🎉🎉 time").Spoiler("🎉🎉").Std()
+
+	if result[0].Offset != 6 || result[0].Length != 4 {
+		t.Errorf("Expected offset 6 and length 4, got offset %d length %d", result[0].Offset, result[0].Length)
+	}
+}
+
+func TestEntitiesFirstByDefault(t *testing.T) {
+	result := New("go go go").Bold("go").Std()
+
+	if len(result) != 1 || result[0].Offset != 0 {
+		t.Errorf("Expected only the first occurrence, got %+v", result)
+	}
+}
+
+func TestEntitiesAll(t *testing.T) {
+	result := New("🍎 apple, 🍎 apple pie").All().Underline("apple").Std()
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 entities, got %d", len(result))
+	}
+
+	if result[0].Offset != 3 || result[1].Offset != 13 {
+		t.Errorf("Expected offsets 3 and 13, got %d and %d", result[0].Offset, result[1].Offset)
+	}
+
+	for _, entity := range result {
+		if entity.Length != 5 || entity.Type != "underline" {
+			t.Errorf("Unexpected entity %+v", entity)
+		}
+	}
+}
+
+func TestEntitiesAllNonOverlapping(t *testing.T) {
+	result := New("aaaa").All().Bold("aa").Std()
+
+	if len(result) != 2 || result[0].Offset != 0 || result[1].Offset != 2 {
+		t.Errorf("Expected occurrences at 0 and 2, got %+v", result)
+	}
+}
+
+func TestEntitiesNth(t *testing.T) {
+	e := New("ёж, ёж и ёж").Nth(2).Italic("ёж")
+
+	result := e.Std()
+	if len(result) != 1 || result[0].Offset != 4 || result[0].Length != 2 {
+		t.Fatalf("Expected the second occurrence at 4, got %+v", result)
+	}
+
+	e.Nth(4).Code("ёж")
+	if e.Count() != 1 {
+		t.Error("Nth past the last occurrence should mark nothing")
+	}
+
+	e.First().Bold("ёж")
+	if last := e.Std()[e.Count()-1]; last.Type != "bold" || last.Offset != 0 {
+		t.Errorf("First should mark the first occurrence, got %+v", last)
+	}
+}
+
+func TestEntitiesModePersists(t *testing.T) {
+	result := New("a b a b").All().Bold("a").Italic("b").Std()
+
+	if len(result) != 4 {
+		t.Errorf("All should apply to every following call, got %d entities", len(result))
+	}
+}
+
+func TestEntitiesEmptySub(t *testing.T) {
+	if n := New("text").All().Bold("").Count(); n != 0 {
+		t.Errorf("Empty substring should mark nothing, got %d entities", n)
+	}
+}
+
+func TestEntitiesText(t *testing.T) {
+	if text := New("hello").Text(); text != "hello" {
+		t.Errorf("Text = %q", text)
+	}
+}
@@ -0,0 +1,97 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/enetx/tg/callback"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/handlers"
+)
+
+type color struct {
+	Name  string
+	Shade int
+}
+
+func TestCallbackHandlers_Data(t *testing.T) {
+	bot := NewMockBot()
+	colors := callback.New[color]("color").Sign([]byte("secret"))
+
+	var got []color
+
+	handlers.NewHandlers(bot).Callback.Data(colors, func(_ *ctx.Context, v color) error {
+		got = append(got, v)
+		return nil
+	})
+
+	data := colors.Encode(color{Name: "red", Shade: 3}).Unwrap()
+	_ = bot.Dispatcher().ProcessUpdate(bot.Raw(), callbackUpdate(data.Std()), map[string]any{})
+
+	if len(got) != 1 || got[0].Name != "red" || got[0].Shade != 3 {
+		t.Fatalf("handler got %+v, want [{red 3}]", got)
+	}
+}
+
+func TestCallbackHandlers_DataNoMatch(t *testing.T) {
+	bot := NewMockBot()
+	colors := callback.New[color]("color")
+
+	var called bool
+
+	handlers.NewHandlers(bot).Callback.Data(colors, func(*ctx.Context, color) error {
+		called = true
+		return nil
+	})
+
+	_ = bot.Dispatcher().ProcessUpdate(bot.Raw(), callbackUpdate("colors:red:3"), map[string]any{})
+
+	if called {
+		t.Error("handler should not run for data of another prefix")
+	}
+}
+
+func TestCallbackHandlers_DataForged(t *testing.T) {
+	bot := NewMockBot()
+	colors := callback.New[color]("color").Sign([]byte("secret"))
+
+	var called bool
+
+	handlers.NewHandlers(bot).Callback.Data(colors, func(*ctx.Context, color) error {
+		called = true
+		return nil
+	})
+
+	_ = bot.Dispatcher().ProcessUpdate(bot.Raw(), callbackUpdate("color:red:3:AAAAAAAAAAAA"), map[string]any{})
+
+	if called {
+		t.Error("handler should not run for data with a forged signature")
+	}
+}
+
+func TestCallbackHandlers_DataRoute(t *testing.T) {
+	bot := NewRegistryBot()
+	colors := callback.New[color]("color")
+
+	h := handlers.NewHandlers(bot).Callback.Data(colors, func(*ctx.Context, color) error { return nil })
+
+	if h.Route().Kind != "callback" {
+		t.Errorf("Route kind = %q, want callback", h.Route().Kind)
+	}
+
+	if !h.Remove() {
+		t.Error("Remove should report the handler as registered")
+	}
+}
+
+func TestCallbackHandlers_DataWrongHandler(t *testing.T) {
+	bot := NewMockBot()
+	colors := callback.New[color]("color")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Data should panic for a handler of another type than the codec")
+		}
+	}()
+
+	handlers.NewHandlers(bot).Callback.Data(colors, func(*ctx.Context, string) error { return nil })
+}
@@ -0,0 +1,201 @@
+package handlers_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/handlers"
+)
+
+func textUpdate(text string) *gotgbot.Update {
+	return &gotgbot.Update{
+		UpdateId: 1,
+		Message: &gotgbot.Message{
+			MessageId: 10,
+			Text:      text,
+			Chat:      gotgbot.Chat{Id: 42, Type: "private"},
+			From:      &gotgbot.User{Id: 42, FirstName: "Test"},
+		},
+	}
+}
+
+func record(calls *[]string, name string, result error) handlers.Handler {
+	return func(*ctx.Context) error {
+		*calls = append(*calls, name)
+		return result
+	}
+}
+
+func TestHandlersGroup_RunsEveryGroup(t *testing.T) {
+	bot := NewMockBot()
+	on := handlers.NewHandlers(bot)
+
+	var calls []string
+
+	on.Message.Text(record(&calls, "logic", nil))
+	on.Group(-1).Message.Any(record(&calls, "log", nil))
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	if !slices.Equal(calls, []string{"log", "logic"}) {
+		t.Errorf("Expected [log logic], got %v", calls)
+	}
+}
+
+func TestHandlersGroup_SameGroupFirstMatchWins(t *testing.T) {
+	bot := NewMockBot()
+	on := handlers.NewHandlers(bot)
+
+	var calls []string
+
+	on.Message.Any(record(&calls, "any", nil))
+	on.Message.Text(record(&calls, "text", nil))
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	if !slices.Equal(calls, []string{"any"}) {
+		t.Errorf("Expected [any], got %v", calls)
+	}
+}
+
+func TestHandlersGroup_Stop(t *testing.T) {
+	bot := NewMockBot()
+	on := handlers.NewHandlers(bot)
+
+	var calls []string
+
+	on.Group(-1).Message.Any(record(&calls, "guard", handlers.Stop))
+	on.Message.Text(record(&calls, "logic", nil))
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	if !slices.Equal(calls, []string{"guard"}) {
+		t.Errorf("Expected [guard], got %v", calls)
+	}
+}
+
+func TestHandlersGroup_Continue(t *testing.T) {
+	bot := NewMockBot()
+	on := handlers.NewHandlers(bot)
+
+	var calls []string
+
+	on.Message.Any(record(&calls, "any", handlers.Continue))
+	on.Message.Text(record(&calls, "text", nil))
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	if !slices.Equal(calls, []string{"any", "text"}) {
+		t.Errorf("Expected [any text], got %v", calls)
+	}
+}
+
+func TestMessageHandler_Group(t *testing.T) {
+	bot := NewMockBot()
+	on := handlers.NewHandlers(bot)
+
+	var calls []string
+
+	on.Message.Any(record(&calls, "any", nil))
+	h := on.Message.Text(record(&calls, "text", nil))
+
+	if h.Group(1) != h {
+		t.Error("Group should return the same MessageHandler for chaining")
+	}
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	if !slices.Equal(calls, []string{"any", "text"}) {
+		t.Errorf("Expected [any text], got %v", calls)
+	}
+}
+
+func TestCallbackHandler_Group(t *testing.T) {
+	bot := NewMockBot()
+	on := handlers.NewHandlers(bot)
+
+	var calls []string
+
+	on.Callback.Any(record(&calls, "any", nil))
+	on.Callback.Equal("data", record(&calls, "equal", nil)).Group(1)
+
+	update := &gotgbot.Update{
+		UpdateId: 1,
+		CallbackQuery: &gotgbot.CallbackQuery{
+			Id:   "cb",
+			From: gotgbot.User{Id: 42, FirstName: "Test"},
+			Data: "data",
+		},
+	}
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), update, nil)
+
+	if !slices.Equal(calls, []string{"any", "equal"}) {
+		t.Errorf("Expected [any equal], got %v", calls)
+	}
+}
+
+func TestCommand_Group(t *testing.T) {
+	bot := NewMockBot()
+	on := handlers.NewHandlers(bot)
+
+	var calls []string
+
+	on.Group(-1).Message.Any(record(&calls, "log", nil))
+
+	cmd := handlers.NewCommand(bot, g.String("start"), record(&calls, "start", nil))
+	if cmd.Group(1) != cmd {
+		t.Error("Group should return the same Command for chaining")
+	}
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("/start"), nil)
+
+	if !slices.Equal(calls, []string{"log", "start"}) {
+		t.Errorf("Expected [log start], got %v", calls)
+	}
+}
+
+func TestEventHandler_Group(t *testing.T) {
+	bot := NewMockBot()
+	on := handlers.NewHandlers(bot)
+
+	var calls []string
+
+	on.Poll.Any(record(&calls, "any", nil))
+
+	h := on.Poll.Regular(record(&calls, "regular", nil)).Last()
+	if h.Group(1) != h {
+		t.Error("Group should return the same EventHandler for chaining")
+	}
+
+	if h.Route().Group != 1 {
+		t.Errorf("Expected the route in group 1, got %d", h.Route().Group)
+	}
+
+	update := &gotgbot.Update{UpdateId: 1, Poll: &gotgbot.Poll{Id: "p", Type: "regular"}}
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), update, nil)
+
+	if !slices.Equal(calls, []string{"any", "regular"}) {
+		t.Errorf("Expected [any regular], got %v", calls)
+	}
+}
+
+func TestHandlersGroup_OtherKinds(t *testing.T) {
+	bot := NewMockBot()
+	on := handlers.NewHandlers(bot)
+
+	var calls []string
+
+	on.Poll.Any(record(&calls, "poll", nil))
+	on.Group(5).Poll.Any(record(&calls, "poll-5", nil))
+
+	update := &gotgbot.Update{UpdateId: 1, Poll: &gotgbot.Poll{Id: "p", Type: "regular"}}
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), update, nil)
+
+	if !slices.Equal(calls, []string{"poll", "poll-5"}) {
+		t.Errorf("Expected [poll poll-5], got %v", calls)
+	}
+}
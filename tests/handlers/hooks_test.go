@@ -0,0 +1,198 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/handlers"
+)
+
+// HookBot is a MockBot with error and panic hooks.
+type HookBot struct {
+	*MockBot
+	onError handlers.ErrorHandler
+	onPanic handlers.PanicHandler
+}
+
+func (h *HookBot) ErrorHandler() handlers.ErrorHandler { return h.onError }
+func (h *HookBot) PanicHandler() handlers.PanicHandler { return h.onPanic }
+
+// recordingClient records the called API methods.
+type recordingClient struct {
+	gotgbot.BotClient
+	methods []string
+}
+
+func (r *recordingClient) RequestWithContext(
+	_ context.Context,
+	_ string,
+	method string,
+	_ map[string]string,
+	_ map[string]gotgbot.FileReader,
+	_ *gotgbot.RequestOpts,
+) (json.RawMessage, error) {
+	r.methods = append(r.methods, method)
+
+	if method == "sendMessage" {
+		return json.RawMessage(`{"message_id":1,"date":0,"chat":{"id":1,"type":"private"}}`), nil
+	}
+
+	return json.RawMessage(`true`), nil
+}
+
+func commandUpdate() *gotgbot.Update {
+	return &gotgbot.Update{
+		UpdateId: 1,
+		Message: &gotgbot.Message{
+			MessageId: 10,
+			Text:      "/test",
+			Chat:      gotgbot.Chat{Id: 42, Type: "private"},
+			From:      &gotgbot.User{Id: 42, FirstName: "Test"},
+		},
+	}
+}
+
+func TestOnError_ReceivesHandlerError(t *testing.T) {
+	bot := &HookBot{MockBot: NewMockBot()}
+
+	var got error
+	var gotCtx *ctx.Context
+
+	bot.onError = func(c *ctx.Context, err error) {
+		gotCtx = c
+		got = err
+	}
+
+	handlerErr := errors.New("boom")
+
+	handlers.NewCommand(bot, g.String("test"), func(*ctx.Context) error { return handlerErr }).Register()
+
+	if err := bot.Dispatcher().ProcessUpdate(bot.Raw(), commandUpdate(), nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !errors.Is(got, handlerErr) {
+		t.Errorf("Expected hook to receive handler error, got %v", got)
+	}
+
+	if gotCtx == nil || gotCtx.EffectiveChat.Id != 42 {
+		t.Error("Expected hook to receive the handler context")
+	}
+}
+
+func TestOnError_ReceivesMiddlewareError(t *testing.T) {
+	bot := &HookBot{MockBot: NewMockBot()}
+	bot.SetMiddlewares(g.Slice[handlers.Handler]{func(*ctx.Context) error { return errors.New("denied") }})
+
+	var got error
+	bot.onError = func(_ *ctx.Context, err error) { got = err }
+
+	handlers.NewCommand(bot, g.String("test"), func(*ctx.Context) error { return nil }).Register()
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), commandUpdate(), nil)
+
+	if got == nil || got.Error() != "denied" {
+		t.Errorf("Expected middleware error, got %v", got)
+	}
+}
+
+func TestOnError_IgnoresGroupControl(t *testing.T) {
+	bot := &HookBot{MockBot: NewMockBot()}
+
+	called := false
+	bot.onError = func(*ctx.Context, error) { called = true }
+
+	handlers.NewCommand(bot, g.String("test"), func(*ctx.Context) error { return ext.EndGroups }).Register()
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), commandUpdate(), nil)
+
+	if called {
+		t.Error("Expected EndGroups not to be reported as an error")
+	}
+}
+
+func TestOnPanic_RecoversWithStack(t *testing.T) {
+	bot := &HookBot{MockBot: NewMockBot()}
+
+	var got *handlers.PanicError
+	bot.onPanic = func(_ *ctx.Context, p *handlers.PanicError) { got = p }
+
+	handlers.NewCommand(bot, g.String("test"), func(*ctx.Context) error { panic("kaboom") }).Register()
+
+	if err := bot.Dispatcher().ProcessUpdate(bot.Raw(), commandUpdate(), nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got == nil {
+		t.Fatal("Expected panic hook to be called")
+	}
+
+	if got.Value != "kaboom" {
+		t.Errorf("Expected panic value kaboom, got %v", got.Value)
+	}
+
+	if !strings.Contains(string(got.Stack), "hooks_test.go") {
+		t.Error("Expected stack trace to include the panicking handler")
+	}
+}
+
+func TestOnPanic_FallsBackToOnError(t *testing.T) {
+	bot := &HookBot{MockBot: NewMockBot()}
+
+	var got error
+	bot.onError = func(_ *ctx.Context, err error) { got = err }
+
+	handlers.NewCommand(bot, g.String("test"), func(*ctx.Context) error { panic("kaboom") }).Register()
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), commandUpdate(), nil)
+
+	var perr *handlers.PanicError
+	if !errors.As(got, &perr) || perr.Value != "kaboom" {
+		t.Errorf("Expected PanicError in error hook, got %v", got)
+	}
+}
+
+func TestReplyOnError_Message(t *testing.T) {
+	bot := &HookBot{MockBot: NewMockBot()}
+	client := new(recordingClient)
+	bot.Raw().BotClient = client
+
+	bot.onError = handlers.ReplyOnError("Something went wrong")
+
+	handlers.NewCommand(bot, g.String("test"), func(*ctx.Context) error { return errors.New("boom") }).Register()
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), commandUpdate(), nil)
+
+	if len(client.methods) != 1 || client.methods[0] != "sendMessage" {
+		t.Errorf("Expected a single sendMessage, got %v", client.methods)
+	}
+}
+
+func TestReplyOnError_CallbackQuery(t *testing.T) {
+	bot := &HookBot{MockBot: NewMockBot()}
+	client := new(recordingClient)
+	bot.Raw().BotClient = client
+
+	update := &gotgbot.Update{
+		UpdateId: 1,
+		CallbackQuery: &gotgbot.CallbackQuery{
+			Id:   "cb",
+			From: gotgbot.User{Id: 42, FirstName: "Test"},
+			Data: "data",
+			Message: &gotgbot.Message{
+				MessageId: 10,
+				Chat:      gotgbot.Chat{Id: 42, Type: "private"},
+			},
+		},
+	}
+
+	handlers.ReplyOnError("Something went wrong")(ctx.New(bot, ext.NewContext(bot.Raw(), update, nil)), errors.New("boom"))
+
+	want := []string{"answerCallbackQuery", "sendMessage"}
+	if strings.Join(client.methods, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected %v, got %v", want, client.methods)
+	}
+}
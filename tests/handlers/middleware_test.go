@@ -0,0 +1,162 @@
+package handlers_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/handlers"
+)
+
+// ChainBot is a MockBot with a global Middleware chain.
+type ChainBot struct {
+	*MockBot
+	chain g.Slice[handlers.Middleware]
+}
+
+func (c *ChainBot) Chain() g.Slice[handlers.Middleware] { return c.chain }
+
+func around(calls *[]string, name string) handlers.Middleware {
+	return func(_ *ctx.Context, next func() error) error {
+		*calls = append(*calls, name+":before")
+		err := next()
+		*calls = append(*calls, name+":after")
+
+		return err
+	}
+}
+
+func TestMiddleware_Onion(t *testing.T) {
+	var calls []string
+
+	bot := &ChainBot{MockBot: NewMockBot()}
+	bot.chain = g.Slice[handlers.Middleware]{around(&calls, "global")}
+
+	on := handlers.NewHandlers(bot).Use(around(&calls, "scope"))
+	on.Message.Text(record(&calls, "handler", nil)).Use(around(&calls, "local"))
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	want := []string{
+		"global:before",
+		"scope:before",
+		"local:before",
+		"handler",
+		"local:after",
+		"scope:after",
+		"global:after",
+	}
+
+	if !slices.Equal(calls, want) {
+		t.Errorf("Expected %v, got %v", want, calls)
+	}
+}
+
+func TestMiddleware_SeesHandlerError(t *testing.T) {
+	handlerErr := errors.New("boom")
+
+	var seen error
+
+	bot := NewMockBot()
+	on := handlers.NewHandlers(bot).Use(func(_ *ctx.Context, next func() error) error {
+		seen = next()
+		return nil
+	})
+
+	on.Message.Text(func(*ctx.Context) error { return handlerErr })
+
+	if err := bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !errors.Is(seen, handlerErr) {
+		t.Errorf("Expected middleware to see handler error, got %v", seen)
+	}
+}
+
+func TestMiddleware_SkipHandler(t *testing.T) {
+	var calls []string
+
+	bot := NewMockBot()
+	on := handlers.NewHandlers(bot)
+
+	on.Message.Text(record(&calls, "handler", nil)).
+		Use(func(*ctx.Context, func() error) error { return nil })
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	if len(calls) != 0 {
+		t.Errorf("Expected handler to be skipped, got %v", calls)
+	}
+}
+
+func TestMiddleware_ScopeDoesNotLeak(t *testing.T) {
+	var calls []string
+
+	bot := NewMockBot()
+	on := handlers.NewHandlers(bot)
+
+	admin := on.Use(around(&calls, "admin"))
+	admin.Command(g.String("ban"), record(&calls, "ban", nil))
+	on.Command(g.String("start"), record(&calls, "start", nil))
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("/start"), nil)
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("/ban"), nil)
+
+	want := []string{"start", "admin:before", "ban", "admin:after"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("Expected %v, got %v", want, calls)
+	}
+}
+
+func TestMiddleware_ScopeKeepsGroup(t *testing.T) {
+	var calls []string
+
+	bot := NewMockBot()
+	on := handlers.NewHandlers(bot)
+
+	on.Message.Any(record(&calls, "any", nil))
+	on.Group(1).Use(around(&calls, "scope")).Message.Text(record(&calls, "text", nil))
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	want := []string{"any", "scope:before", "text", "scope:after"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("Expected %v, got %v", want, calls)
+	}
+}
+
+func TestMiddleware_LegacyHandlers(t *testing.T) {
+	var calls []string
+
+	bot := NewMockBot()
+	bot.SetMiddlewares(g.Slice[handlers.Handler]{record(&calls, "legacy", nil)})
+
+	on := handlers.NewHandlers(bot).Use(around(&calls, "scope"))
+	on.Message.Text(record(&calls, "handler", nil))
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	want := []string{"legacy", "scope:before", "handler", "scope:after"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("Expected %v, got %v", want, calls)
+	}
+}
+
+func TestBefore(t *testing.T) {
+	stop := errors.New("stop")
+	called := false
+
+	mw := handlers.Before(func(*ctx.Context) error { return stop })
+
+	err := mw(NewMockContext(), func() error {
+		called = true
+		return nil
+	})
+
+	if !errors.Is(err, stop) || called {
+		t.Errorf("Expected Before to stop the chain, got err=%v called=%v", err, called)
+	}
+}
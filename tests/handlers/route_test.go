@@ -0,0 +1,272 @@
+package handlers_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/handlers"
+	"github.com/enetx/tg/types/chatmember"
+	"github.com/enetx/tg/types/poll"
+)
+
+// RegistryBot is a MockBot that records its routes.
+type RegistryBot struct {
+	*MockBot
+	registry *handlers.Registry
+}
+
+func NewRegistryBot() *RegistryBot {
+	return &RegistryBot{MockBot: NewMockBot(), registry: handlers.NewRegistry()}
+}
+
+func (r *RegistryBot) Registry() *handlers.Registry { return r.registry }
+
+func callbackUpdate(data string) *gotgbot.Update {
+	return &gotgbot.Update{
+		UpdateId: 1,
+		CallbackQuery: &gotgbot.CallbackQuery{
+			Id:   "cb",
+			From: gotgbot.User{Id: 42, FirstName: "Test"},
+			Data: data,
+		},
+	}
+}
+
+func TestRoutes_SameHandlerDifferentFilters(t *testing.T) {
+	bot := NewRegistryBot()
+	on := handlers.NewHandlers(bot)
+
+	var got []string
+
+	fn := func(c *ctx.Context) error {
+		got = append(got, c.Update.CallbackQuery.Data)
+		return nil
+	}
+
+	a := on.Callback.Equal("a", fn)
+	b := on.Callback.Equal("b", fn)
+
+	if a.Route().ID == b.Route().ID {
+		t.Fatalf("Expected distinct IDs, both got %s", a.Route().ID)
+	}
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), callbackUpdate("a"), nil)
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), callbackUpdate("b"), nil)
+
+	if !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Expected both handlers to run, got %v", got)
+	}
+}
+
+func TestRoutes_Remove(t *testing.T) {
+	bot := NewRegistryBot()
+	on := handlers.NewHandlers(bot)
+
+	var calls []string
+
+	h := on.Message.Text(record(&calls, "text", nil))
+
+	if !h.Remove() {
+		t.Error("Expected Remove to report a registered handler")
+	}
+
+	if h.Remove() {
+		t.Error("Expected second Remove to report nothing removed")
+	}
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	if len(calls) != 0 {
+		t.Errorf("Expected removed handler not to run, got %v", calls)
+	}
+
+	if bot.registry.Routes().Len() != 0 {
+		t.Errorf("Expected no routes, got %v", bot.registry.Routes())
+	}
+}
+
+func TestRoutes_List(t *testing.T) {
+	bot := NewRegistryBot()
+	on := handlers.NewHandlers(bot)
+
+	on.Message.Text(MockHandler)
+	on.Callback.Prefix("menu:", MockHandler).Group(2)
+	on.Group(-1).Poll.Regular(MockHandler)
+	handlers.NewCommand(bot, g.String("Start"), MockHandler).Register()
+
+	routes := bot.registry.Routes()
+
+	want := []struct {
+		kind, filter g.String
+		group        int
+	}{
+		{"poll", "Regular", -1},
+		{"message", "Text", 0},
+		{"command", "/start", 0},
+		{"callback", `Prefix("menu:")`, 2},
+	}
+
+	if len(routes) != len(want) {
+		t.Fatalf("Expected %d routes, got %v", len(want), routes)
+	}
+
+	for i, w := range want {
+		r := routes[i]
+		if r.Kind != w.kind || r.Filter != w.filter || r.Group != w.group {
+			t.Errorf("Route %d: expected %s %s in group %d, got %s", i, w.kind, w.filter, w.group, r)
+		}
+	}
+}
+
+func TestRoutes_FilterArguments(t *testing.T) {
+	bot := NewRegistryBot()
+	on := handlers.NewHandlers(bot)
+
+	tests := []struct {
+		route handlers.Route
+		want  g.String
+	}{
+		{on.Callback.Equal("a", MockHandler).Route(), `Equal("a")`},
+		{on.Callback.Equal("b", MockHandler).Route(), `Equal("b")`},
+		{on.Message.ChatID(42, MockHandler).Route(), "ChatID(42)"},
+		{on.Poll.Type(poll.Quiz, MockHandler).Last().Route(), `Type("quiz")`},
+		{on.ChatMember.StatusChange(chatmember.Left, chatmember.Member, MockHandler).Last().Route(), `StatusChange("left", "member")`},
+		{on.Poll.Quiz(MockHandler).Last().Route(), "Quiz"},
+	}
+
+	for _, tt := range tests {
+		if tt.route.Filter != tt.want {
+			t.Errorf("Expected filter %s, got %s", tt.want, tt.route.Filter)
+		}
+	}
+}
+
+func TestRoutes_RemoveEventHandler(t *testing.T) {
+	bot := NewRegistryBot()
+	on := handlers.NewHandlers(bot)
+
+	var calls []string
+
+	h := on.Inline.Any(record(&calls, "inline", nil)).Last()
+
+	if h.Route().Kind != "inline_query" {
+		t.Errorf("Expected an inline_query route, got %s", h.Route())
+	}
+
+	if !h.Remove() {
+		t.Error("Expected Remove to report a registered handler")
+	}
+
+	if h.Remove() {
+		t.Error("Expected second Remove to report nothing removed")
+	}
+
+	update := &gotgbot.Update{UpdateId: 1, InlineQuery: &gotgbot.InlineQuery{Id: "q", From: gotgbot.User{Id: 1}}}
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), update, nil)
+
+	if len(calls) != 0 {
+		t.Errorf("Expected removed handler not to run, got %v", calls)
+	}
+
+	if bot.registry.Routes().Len() != 0 {
+		t.Errorf("Expected no routes, got %v", bot.registry.Routes())
+	}
+}
+
+func TestRoutes_RemoveFromList(t *testing.T) {
+	bot := NewRegistryBot()
+	on := handlers.NewHandlers(bot)
+
+	var calls []string
+
+	on.Poll.Any(record(&calls, "poll", nil))
+
+	routes := bot.registry.Routes()
+	if routes.Len() != 1 {
+		t.Fatalf("Expected 1 route, got %d", routes.Len())
+	}
+
+	if !routes[0].Remove() {
+		t.Error("Expected Remove to report a registered handler")
+	}
+
+	update := &gotgbot.Update{UpdateId: 1, Poll: &gotgbot.Poll{Id: "p", Type: "regular"}}
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), update, nil)
+
+	if len(calls) != 0 {
+		t.Errorf("Expected removed handler not to run, got %v", calls)
+	}
+}
+
+func TestRoutes_GroupMoveKeepsSingleRoute(t *testing.T) {
+	bot := NewRegistryBot()
+	on := handlers.NewHandlers(bot)
+
+	h := on.Message.Any(MockHandler).Group(3)
+
+	routes := bot.registry.Routes()
+	if routes.Len() != 1 || routes[0].Group != 3 {
+		t.Fatalf("Expected a single route in group 3, got %v", routes)
+	}
+
+	if !h.Remove() {
+		t.Error("Expected Remove to find the handler in its new group")
+	}
+}
+
+func TestRoutes_WithoutRegistry(t *testing.T) {
+	bot := NewMockBot()
+	on := handlers.NewHandlers(bot)
+
+	h := on.Message.Any(MockHandler)
+
+	if !h.Remove() {
+		t.Error("Expected Remove to work without a registry")
+	}
+}
+
+func TestEventHandlers_LastKeepsChaining(t *testing.T) {
+	bot := NewRegistryBot()
+	on := handlers.NewHandlers(bot)
+
+	polls := on.Poll.Any(MockHandler)
+	if polls.Quiz(MockHandler) != polls {
+		t.Error("Expected registrations to return the collection for chaining")
+	}
+
+	last := polls.Last()
+	if last.Route().Filter != "Quiz" {
+		t.Errorf("Expected Last to return the Quiz handler, got %s", last.Route())
+	}
+
+	if !last.Remove() {
+		t.Error("Expected Remove to report a registered handler")
+	}
+
+	if routes := bot.registry.Routes(); routes.Len() != 1 || routes[0].Filter != "Any" {
+		t.Errorf("Expected only the Any route to remain, got %v", routes)
+	}
+}
+
+func TestRoutes_CommandReRegisterReplaces(t *testing.T) {
+	bot := NewRegistryBot()
+	on := handlers.NewHandlers(bot)
+
+	var calls []string
+
+	on.Command("test", record(&calls, "first", nil))
+	on.Command("Test", record(&calls, "second", nil))
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), commandUpdate(), nil)
+
+	if !slices.Equal(calls, []string{"second"}) {
+		t.Errorf("Expected only the second handler to run, got %v", calls)
+	}
+
+	if routes := bot.registry.Routes(); routes.Len() != 1 {
+		t.Errorf("Expected a single command route, got %v", routes)
+	}
+}
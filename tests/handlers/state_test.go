@@ -0,0 +1,164 @@
+package handlers_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/enetx/fsm"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/handlers"
+)
+
+// StateBot is a MockBot that reports a fixed state per user.
+type StateBot struct {
+	*MockBot
+	states map[int64]fsm.State
+	calls  int
+}
+
+func NewStateBot(states map[int64]fsm.State) *StateBot {
+	return &StateBot{MockBot: NewMockBot(), states: states}
+}
+
+func (s *StateBot) State(c *ctx.Context) g.Option[fsm.State] {
+	s.calls++
+
+	if c.EffectiveUser == nil {
+		return g.None[fsm.State]()
+	}
+
+	if state, ok := s.states[c.EffectiveUser.Id]; ok {
+		return g.Some(state)
+	}
+
+	return g.None[fsm.State]()
+}
+
+func TestInState_RoutesByState(t *testing.T) {
+	tests := []struct {
+		name   string
+		states map[int64]fsm.State
+		want   []string
+	}{
+		{"first state", map[int64]fsm.State{42: "name"}, []string{"name"}},
+		{"second state", map[int64]fsm.State{42: "age"}, []string{"age"}},
+		{"other state", map[int64]fsm.State{42: "done"}, []string{"fallback"}},
+		{"no machine", nil, []string{"fallback"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bot := NewStateBot(tt.states)
+			on := handlers.NewHandlers(bot)
+
+			var calls []string
+
+			on.Message.Text(record(&calls, "name", nil)).InState("name")
+			on.Message.Text(record(&calls, "age", nil)).InState("age")
+			on.Message.Text(record(&calls, "fallback", nil))
+
+			bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+			if !slices.Equal(calls, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, calls)
+			}
+		})
+	}
+}
+
+func TestInState_AnyOfStates(t *testing.T) {
+	bot := NewStateBot(map[int64]fsm.State{42: "age"})
+
+	var calls []string
+
+	handlers.NewHandlers(bot).Message.Text(record(&calls, "text", nil)).InState("name", "age")
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	if !slices.Equal(calls, []string{"text"}) {
+		t.Errorf("Expected handler to match one of its states, got %v", calls)
+	}
+}
+
+func TestInState_FilterCheckedFirst(t *testing.T) {
+	bot := NewStateBot(map[int64]fsm.State{42: "name"})
+
+	var calls []string
+
+	handlers.NewHandlers(bot).Callback.Prefix("pick:", record(&calls, "pick", nil)).InState("name")
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	if len(calls) != 0 {
+		t.Errorf("Expected no calls, got %v", calls)
+	}
+
+	if bot.calls != 0 {
+		t.Errorf("Expected state not to be looked up for non-matching updates, got %d lookups", bot.calls)
+	}
+}
+
+func TestInState_Callback(t *testing.T) {
+	bot := NewStateBot(map[int64]fsm.State{42: "name"})
+	on := handlers.NewHandlers(bot)
+
+	var calls []string
+
+	on.Callback.Prefix("pick:", record(&calls, "age", nil)).InState("age")
+	on.Callback.Prefix("pick:", record(&calls, "name", nil)).InState("name")
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), callbackUpdate("pick:1"), nil)
+
+	if !slices.Equal(calls, []string{"name"}) {
+		t.Errorf("Expected [name], got %v", calls)
+	}
+}
+
+func TestInState_Command(t *testing.T) {
+	bot := NewStateBot(map[int64]fsm.State{42: "done"})
+
+	var calls []string
+
+	handlers.NewCommand(bot, "test", record(&calls, "command", nil)).InState("name")
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), commandUpdate(), nil)
+
+	if len(calls) != 0 {
+		t.Errorf("Expected command to be skipped in another state, got %v", calls)
+	}
+
+	bot.states[42] = "name"
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), commandUpdate(), nil)
+
+	if !slices.Equal(calls, []string{"command"}) {
+		t.Errorf("Expected [command], got %v", calls)
+	}
+}
+
+func TestInState_WithoutStateSource(t *testing.T) {
+	bot := NewMockBot()
+
+	var calls []string
+
+	handlers.NewHandlers(bot).Message.Text(record(&calls, "text", nil)).InState("name")
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	if len(calls) != 0 {
+		t.Errorf("Expected no match without a state machine, got %v", calls)
+	}
+}
+
+func TestInState_Route(t *testing.T) {
+	bot := NewStateBot(nil)
+	on := handlers.NewHandlers(bot)
+
+	if got := on.Message.Text(MockHandler).InState("name", "age").Route().Filter; got != "Text in name, age" {
+		t.Errorf("Expected filter with states, got %q", got)
+	}
+
+	if got := on.Message.Text(MockHandler).Route().Filter; got != "Text" {
+		t.Errorf("Expected filter without states, got %q", got)
+	}
+}
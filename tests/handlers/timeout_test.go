@@ -0,0 +1,238 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/handlers"
+)
+
+// LifetimeBot is a MockBot with a lifetime context.
+type LifetimeBot struct {
+	*MockBot
+	std context.Context
+}
+
+func (l *LifetimeBot) Context() context.Context { return l.std }
+
+// contextClient records the context of the last request.
+type contextClient struct {
+	gotgbot.BotClient
+	std context.Context
+}
+
+func (c *contextClient) RequestWithContext(
+	std context.Context,
+	_ string,
+	_ string,
+	_ map[string]string,
+	_ map[string]gotgbot.FileReader,
+	_ *gotgbot.RequestOpts,
+) (json.RawMessage, error) {
+	c.std = std
+
+	if err := std.Err(); err != nil {
+		return nil, err
+	}
+
+	return json.RawMessage(`{"message_id":1,"date":0,"chat":{"id":1,"type":"private"}}`), nil
+}
+
+func TestStd_LiveAfterHandler(t *testing.T) {
+	bot := NewMockBot()
+
+	var std context.Context
+
+	handlers.NewHandlers(bot).Message.Text(func(c *ctx.Context) error {
+		std = c.Std()
+		return nil
+	})
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	if std == nil || std.Err() != nil {
+		t.Errorf("Expected context to stay live after the handler for goroutines it started, got %v", std)
+	}
+}
+
+func TestMessageHandler_TimeoutLiveAfterReturn(t *testing.T) {
+	bot := NewMockBot()
+
+	var std context.Context
+
+	handlers.NewHandlers(bot).Message.Text(func(c *ctx.Context) error {
+		std = c.Std()
+		return nil
+	}).Timeout(20 * time.Millisecond)
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	if std.Err() != nil {
+		t.Errorf("Expected context to stay live until the timeout, got %v", std.Err())
+	}
+
+	select {
+	case <-std.Done():
+	case <-time.After(time.Second):
+		t.Error("Expected context to expire after the timeout")
+	}
+}
+
+func TestStd_DerivedFromBotContext(t *testing.T) {
+	std, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	bot := &LifetimeBot{MockBot: NewMockBot(), std: std}
+
+	var err error
+
+	handlers.NewHandlers(bot).Message.Text(func(c *ctx.Context) error {
+		err = c.Std().Err()
+		return nil
+	})
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected handler context to be cancelled with the bot, got %v", err)
+	}
+}
+
+func TestStd_PassedToRequests(t *testing.T) {
+	bot := NewMockBot()
+	client := new(contextClient)
+	bot.Raw().BotClient = client
+
+	type key struct{}
+
+	handlers.NewHandlers(bot).Message.Text(func(c *ctx.Context) error {
+		c.SetStd(context.WithValue(c.Std(), key{}, "marker"))
+		return c.SendMessage("reply").Send().Err()
+	})
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	if client.std == nil || client.std.Value(key{}) != "marker" {
+		t.Error("Expected request to use the update context")
+	}
+}
+
+func TestMessageHandler_Timeout(t *testing.T) {
+	bot := NewMockBot()
+	client := new(contextClient)
+	bot.Raw().BotClient = client
+
+	var (
+		deadline bool
+		sendErr  error
+	)
+
+	handlers.NewHandlers(bot).Message.Text(func(c *ctx.Context) error {
+		_, deadline = c.Std().Deadline()
+
+		select {
+		case <-c.Std().Done():
+		case <-time.After(time.Second):
+			t.Error("Expected context to expire")
+		}
+
+		sendErr = c.SendMessage("late").Send().Err()
+
+		return nil
+	}).Timeout(10 * time.Millisecond)
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	if !deadline {
+		t.Error("Expected handler context to have a deadline")
+	}
+
+	if !errors.Is(sendErr, context.DeadlineExceeded) {
+		t.Errorf("Expected send to fail with deadline exceeded, got %v", sendErr)
+	}
+}
+
+func TestMessageHandler_TimeoutCoversMiddlewares(t *testing.T) {
+	bot := &ChainBot{MockBot: NewMockBot()}
+
+	var deadline bool
+
+	bot.chain = g.Slice[handlers.Middleware]{func(c *ctx.Context, next func() error) error {
+		_, deadline = c.Std().Deadline()
+		return next()
+	}}
+
+	handlers.NewHandlers(bot).Message.Text(MockHandler).Timeout(time.Minute)
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	if !deadline {
+		t.Error("Expected global middleware to see the handler deadline")
+	}
+}
+
+func TestCallbackHandler_Timeout(t *testing.T) {
+	bot := NewMockBot()
+
+	var deadline bool
+
+	handlers.NewHandlers(bot).Callback.Any(func(c *ctx.Context) error {
+		_, deadline = c.Std().Deadline()
+		return nil
+	}).Timeout(time.Minute)
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), callbackUpdate("data"), nil)
+
+	if !deadline {
+		t.Error("Expected callback handler context to have a deadline")
+	}
+}
+
+func TestCommand_Timeout(t *testing.T) {
+	bot := NewMockBot()
+
+	var deadline bool
+
+	cmd := handlers.NewCommand(bot, g.String("test"), func(c *ctx.Context) error {
+		_, deadline = c.Std().Deadline()
+		return nil
+	})
+
+	if cmd.Timeout(time.Minute) != cmd {
+		t.Error("Expected Timeout to return the same command")
+	}
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), commandUpdate(), nil)
+
+	if !deadline {
+		t.Error("Expected command context to have a deadline")
+	}
+}
+
+func TestTimeout_RestoresContext(t *testing.T) {
+	bot := NewMockBot()
+
+	var outer error
+
+	handlers.NewHandlers(bot).Message.Text(MockHandler).Use(
+		func(c *ctx.Context, next func() error) error {
+			err := next()
+			outer = c.Std().Err()
+
+			return err
+		},
+		handlers.Timeout(time.Minute),
+	)
+
+	bot.Dispatcher().ProcessUpdate(bot.Raw(), textUpdate("hello"), nil)
+
+	if outer != nil {
+		t.Errorf("Expected outer middleware to get its context back, got %v", outer)
+	}
+}
@@ -1,9 +1,13 @@
 package keyboard_test
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/callback"
 	. "github.com/enetx/tg/keyboard"
 )
 
@@ -914,3 +918,142 @@ func TestInlineKeyboard_FromKeyboardCompleteScenarios(t *testing.T) {
 		t.Error("Expected fromMarkup value case to work")
 	}
 }
+
+func TestInlineKeyboard_Data(t *testing.T) {
+	type page struct{ N int }
+
+	pages := callback.New[page]("page")
+
+	kb := Inline().
+		Data("Next", pages, page{N: 2}).
+		Data("Last", pages, page{N: 10})
+
+	if err := kb.Err(); err != nil {
+		t.Fatalf("Err = %v", err)
+	}
+
+	row := kb.Markup().(gotgbot.InlineKeyboardMarkup).InlineKeyboard[0]
+	if len(row) != 2 || row[0].CallbackData != "page:2" || row[1].CallbackData != "page:a" {
+		t.Errorf("buttons = %+v", row)
+	}
+}
+
+func TestInlineKeyboard_DataError(t *testing.T) {
+	texts := callback.New[string]("text")
+
+	kb := Inline().
+		Text("OK", "ok").
+		Data("Long", texts, strings.Repeat("x", 64))
+
+	if err := kb.Err(); !errors.Is(err, callback.ErrTooLong) {
+		t.Errorf("Err = %v, want ErrTooLong", err)
+	}
+
+	row := kb.Markup().(gotgbot.InlineKeyboardMarkup).InlineKeyboard[0]
+	if len(row) != 1 {
+		t.Errorf("the failed button should be left out, got %d buttons", len(row))
+	}
+}
+
+func TestInlineKeyboard_DataType(t *testing.T) {
+	texts := callback.New[string]("text")
+
+	kb := Inline().Data("Number", texts, 42)
+
+	if err := kb.Err(); !errors.Is(err, callback.ErrType) {
+		t.Errorf("Err = %v, want ErrType", err)
+	}
+}
+
+type memoryStorage map[g.String]g.String
+
+func (m memoryStorage) Shorten(data g.String) g.Result[g.String] {
+	if len(data) <= MaxCallbackLen {
+		return g.Ok(data)
+	}
+
+	for token, stored := range m {
+		if stored == data {
+			return g.Ok(token)
+		}
+	}
+
+	token := g.Format("~{}", len(m))
+	m[token] = data
+
+	return g.Ok(token)
+}
+
+type failingStorage struct{}
+
+func (failingStorage) Shorten(g.String) g.Result[g.String] {
+	return g.Err[g.String](errors.New("store is down"))
+}
+
+func TestInlineKeyboard_CallbackTooLong(t *testing.T) {
+	long := g.String(strings.Repeat("x", 65))
+
+	kb := Inline().
+		Text("OK", g.String(strings.Repeat("x", 64))).
+		Text("Long", long)
+
+	if err := kb.Err(); !errors.Is(err, ErrTooLong) {
+		t.Errorf("Err = %v, want ErrTooLong", err)
+	}
+
+	if Inline().Button(NewButton().Text("Long").Callback(long)).Err() == nil {
+		t.Error("Button with long callback data should be reported")
+	}
+}
+
+func TestInlineKeyboard_Storage(t *testing.T) {
+	storage := memoryStorage{}
+	long := g.String(strings.Repeat("x", 100))
+
+	kb := Inline().
+		Storage(storage).
+		Text("Short", "short").
+		Text("Long", long)
+
+	if err := kb.Err(); err != nil {
+		t.Fatalf("Err = %v", err)
+	}
+
+	row := kb.Markup().(gotgbot.InlineKeyboardMarkup).InlineKeyboard[0]
+	if row[0].CallbackData != "short" {
+		t.Errorf("short data changed to %q", row[0].CallbackData)
+	}
+
+	if storage[g.String(row[1].CallbackData)] != long {
+		t.Errorf("long data was not replaced by its token, got %q", row[1].CallbackData)
+	}
+}
+
+func TestInlineKeyboard_StorageButtonUpdate(t *testing.T) {
+	long := g.String(strings.Repeat("x", 100))
+
+	kb := Inline().Storage(memoryStorage{})
+	btn := NewButton().Callback(long).On("On").Off("Off")
+
+	kb.Button(btn)
+	btn.Flip()
+
+	row := kb.Markup().(gotgbot.InlineKeyboardMarkup).InlineKeyboard[0]
+	if len(row) != 1 {
+		t.Fatalf("toggled button should be updated in place, got %d buttons", len(row))
+	}
+
+	if row[0].Text != "On" {
+		t.Errorf("Text = %q, want On", row[0].Text)
+	}
+}
+
+func TestInlineKeyboard_StorageError(t *testing.T) {
+	kb := Inline().
+		Storage(failingStorage{}).
+		Text("Long", g.String(strings.Repeat("x", 100)))
+
+	if err := kb.Err(); err == nil || !strings.Contains(err.Error(), "store is down") {
+		t.Errorf("Err = %v, want the storage error", err)
+	}
+}
@@ -0,0 +1,222 @@
+package ratelimit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/tg/ratelimit"
+	"github.com/enetx/tg/retry"
+)
+
+// fakeClient records request times and answers with the queued errors.
+type fakeClient struct {
+	gotgbot.BotClient
+	mu    sync.Mutex
+	calls []time.Time
+	errs  []error
+}
+
+func (f *fakeClient) RequestWithContext(
+	_ context.Context,
+	_ string,
+	_ string,
+	_ map[string]string,
+	_ map[string]gotgbot.FileReader,
+	_ *gotgbot.RequestOpts,
+) (json.RawMessage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, time.Now())
+
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return nil, err
+	}
+
+	return json.RawMessage(`true`), nil
+}
+
+func (f *fakeClient) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.calls)
+}
+
+func send(c gotgbot.BotClient, method, chatID string) error {
+	_, err := c.RequestWithContext(context.Background(), "token", method, map[string]string{"chat_id": chatID}, nil, nil)
+	return err
+}
+
+func TestLimits_PrivateChat(t *testing.T) {
+	fake := new(fakeClient)
+	c := ratelimit.New().Private(1, 100*time.Millisecond).Wrap(fake)
+
+	start := time.Now()
+
+	for range 3 {
+		if err := send(c, "sendMessage", "42"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("Expected 3 messages to one private chat to take at least 200ms, took %v", elapsed)
+	}
+}
+
+func TestLimits_UnrelatedChatsNotBlocked(t *testing.T) {
+	fake := new(fakeClient)
+	c := ratelimit.New().Private(1, time.Second).Wrap(fake)
+
+	start := time.Now()
+
+	for _, id := range []string{"1", "2", "3", "4"} {
+		if err := send(c, "sendMessage", id); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected messages to different chats not to wait, took %v", elapsed)
+	}
+}
+
+func TestLimits_GroupChat(t *testing.T) {
+	fake := new(fakeClient)
+	c := ratelimit.New().Group(2, 200*time.Millisecond).Wrap(fake)
+
+	start := time.Now()
+
+	for range 3 {
+		if err := send(c, "sendMessage", "-100123"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("Expected the third group message to wait for the window, took %v", elapsed)
+	}
+}
+
+func TestLimits_Global(t *testing.T) {
+	fake := new(fakeClient)
+	c := ratelimit.New().Global(2, 200*time.Millisecond).Wrap(fake)
+
+	start := time.Now()
+
+	for _, id := range []string{"1", "2", "3"} {
+		if err := send(c, "sendMessage", id); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("Expected the third message to wait for the global window, took %v", elapsed)
+	}
+}
+
+func TestLimits_UnlimitedMethods(t *testing.T) {
+	fake := new(fakeClient)
+	c := ratelimit.New().Private(1, time.Hour).Wrap(fake)
+
+	start := time.Now()
+
+	for range 3 {
+		if err := send(c, "getChat", "42"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if err := send(c, "sendChatAction", "42"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected read-only requests and chat actions not to be limited, took %v", elapsed)
+	}
+}
+
+func TestLimits_RetryAfterPausesChat(t *testing.T) {
+	flood := &gotgbot.TelegramError{
+		Method:         "sendMessage",
+		Code:           429,
+		Description:    "Too Many Requests: retry after 1",
+		ResponseParams: &gotgbot.ResponseParameters{RetryAfter: 1},
+	}
+
+	fake := &fakeClient{errs: []error{flood}}
+	c := ratelimit.New().Private(0, 0).Wrap(fake)
+
+	var tgErr *gotgbot.TelegramError
+	if err := send(c, "sendMessage", "42"); !errors.As(err, &tgErr) || tgErr.Code != 429 {
+		t.Fatalf("Expected the flood error to be returned, got %v", err)
+	}
+
+	if fake.count() != 1 {
+		t.Errorf("Expected the limiter not to resend, got %d attempts", fake.count())
+	}
+
+	start := time.Now()
+
+	if err := send(c, "sendMessage", "7"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected other chats not to wait, took %v", elapsed)
+	}
+
+	if err := send(c, "sendMessage", "42"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("Expected the flooded chat to wait for retry_after, took %v", elapsed)
+	}
+}
+
+func TestLimits_RetriedByPolicyOnce(t *testing.T) {
+	flood := &gotgbot.TelegramError{Code: 429, ResponseParams: &gotgbot.ResponseParameters{RetryAfter: 1}}
+
+	fake := &fakeClient{errs: []error{flood}}
+	c := retry.Wrap(ratelimit.New().Wrap(fake), retry.New().Attempts(3))
+
+	start := time.Now()
+
+	if err := send(c, "sendMessage", "42"); err != nil {
+		t.Fatalf("Expected request to succeed after retry, got %v", err)
+	}
+
+	if fake.count() != 2 {
+		t.Errorf("Expected 2 attempts, got %d", fake.count())
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Expected retry to wait for retry_after, took %v", elapsed)
+	}
+}
+
+func TestLimits_ContextCancel(t *testing.T) {
+	fake := new(fakeClient)
+	c := ratelimit.New().Private(1, time.Hour).Wrap(fake)
+
+	if err := send(c, "sendMessage", "42"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.RequestWithContext(ctx, "token", "sendMessage", map[string]string{"chat_id": "42"}, nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
@@ -0,0 +1,238 @@
+package retry_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/tg/retry"
+)
+
+// fakeClient counts requests and answers with the queued errors.
+type fakeClient struct {
+	gotgbot.BotClient
+	calls int
+	errs  []error
+}
+
+func (f *fakeClient) RequestWithContext(
+	_ context.Context,
+	_ string,
+	_ string,
+	_ map[string]string,
+	_ map[string]gotgbot.FileReader,
+	_ *gotgbot.RequestOpts,
+) (json.RawMessage, error) {
+	f.calls++
+
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return nil, err
+	}
+
+	return json.RawMessage(`true`), nil
+}
+
+func request(ctx context.Context, c gotgbot.BotClient, method string) error {
+	_, err := c.RequestWithContext(ctx, "token", method, map[string]string{"chat_id": "42"}, nil, nil)
+	return err
+}
+
+func fast() *retry.Policy {
+	return retry.New().Backoff(time.Millisecond, 5*time.Millisecond)
+}
+
+func serverError() error {
+	return &gotgbot.TelegramError{Code: 502, Description: "Bad Gateway"}
+}
+
+func TestRetry_TooManyRequestsRetriedForAnyMethod(t *testing.T) {
+	fake := &fakeClient{errs: []error{&gotgbot.TelegramError{Code: 429, Description: "Too Many Requests"}}}
+	c := retry.Wrap(fake, fast())
+
+	if err := request(context.Background(), c, "sendMessage"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if fake.calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", fake.calls)
+	}
+}
+
+func TestRetry_RetryAfterRespected(t *testing.T) {
+	fake := &fakeClient{errs: []error{&gotgbot.TelegramError{
+		Code:           429,
+		Description:    "Too Many Requests: retry after 1",
+		ResponseParams: &gotgbot.ResponseParameters{RetryAfter: 1},
+	}}}
+	c := retry.Wrap(fake, fast())
+
+	start := time.Now()
+
+	if err := request(context.Background(), c, "sendMessage"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Expected retry to wait for retry_after, waited %v", elapsed)
+	}
+}
+
+func TestRetry_ServerErrorRetriedForIdempotentMethod(t *testing.T) {
+	fake := &fakeClient{errs: []error{serverError(), serverError()}}
+	c := retry.Wrap(fake, fast())
+
+	if err := request(context.Background(), c, "getChat"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if fake.calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", fake.calls)
+	}
+}
+
+func TestRetry_ServerErrorNotRetriedForSend(t *testing.T) {
+	fake := &fakeClient{errs: []error{serverError()}}
+	c := retry.Wrap(fake, fast())
+
+	if err := request(context.Background(), c, "sendMessage"); err == nil {
+		t.Fatal("Expected error for non-idempotent method")
+	}
+
+	if fake.calls != 1 {
+		t.Errorf("Expected 1 call, got %d", fake.calls)
+	}
+}
+
+func TestRetry_Force(t *testing.T) {
+	fake := &fakeClient{errs: []error{errors.New("connection reset")}}
+	c := retry.Wrap(fake, fast().Force())
+
+	if err := request(context.Background(), c, "sendMessage"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if fake.calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", fake.calls)
+	}
+}
+
+func TestRetry_Flood(t *testing.T) {
+	flood := &gotgbot.TelegramError{Code: 429, Description: "Too Many Requests"}
+
+	fake := &fakeClient{errs: []error{flood, flood}}
+	c := retry.Wrap(fake, retry.Flood().Backoff(time.Millisecond, 5*time.Millisecond))
+
+	if err := request(context.Background(), c, "sendMessage"); err == nil {
+		t.Fatal("Expected the second flood error after one retry")
+	}
+
+	if fake.calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", fake.calls)
+	}
+
+	fake = &fakeClient{errs: []error{serverError()}}
+	c = retry.Wrap(fake, retry.Flood())
+
+	if err := request(context.Background(), c, "getChat"); err == nil {
+		t.Fatal("Expected server error not to be retried")
+	}
+
+	if fake.calls != 1 {
+		t.Errorf("Expected 1 call, got %d", fake.calls)
+	}
+}
+
+func TestRetry_ClientErrorNotRetried(t *testing.T) {
+	fake := &fakeClient{errs: []error{&gotgbot.TelegramError{Code: 400, Description: "Bad Request: chat not found"}}}
+	c := retry.Wrap(fake, fast())
+
+	if err := request(context.Background(), c, "getChat"); err == nil {
+		t.Fatal("Expected error")
+	}
+
+	if fake.calls != 1 {
+		t.Errorf("Expected 1 call, got %d", fake.calls)
+	}
+}
+
+func TestRetry_AttemptsExhausted(t *testing.T) {
+	fake := &fakeClient{errs: []error{serverError(), serverError(), serverError(), serverError()}}
+	c := retry.Wrap(fake, fast().Attempts(2))
+
+	err := request(context.Background(), c, "getChat")
+	if err == nil || !strings.Contains(err.Error(), "Bad Gateway") {
+		t.Fatalf("Expected last error to be returned, got %v", err)
+	}
+
+	if fake.calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", fake.calls)
+	}
+}
+
+func TestRetry_WithPolicyOverridesDefault(t *testing.T) {
+	fake := &fakeClient{errs: []error{serverError()}}
+	c := retry.Wrap(fake, nil)
+
+	ctx := retry.WithPolicy(context.Background(), fast())
+
+	if err := request(ctx, c, "getChat"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if fake.calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", fake.calls)
+	}
+}
+
+func TestRetry_NilDefaultDisablesRetries(t *testing.T) {
+	fake := &fakeClient{errs: []error{serverError()}}
+	c := retry.Wrap(fake, nil)
+
+	if err := request(context.Background(), c, "getChat"); err == nil {
+		t.Fatal("Expected error")
+	}
+
+	if fake.calls != 1 {
+		t.Errorf("Expected 1 call, got %d", fake.calls)
+	}
+}
+
+func TestRetry_UploadsNotRetried(t *testing.T) {
+	fake := &fakeClient{errs: []error{&gotgbot.TelegramError{Code: 429, Description: "Too Many Requests"}}}
+	c := retry.Wrap(fake, fast())
+
+	data := map[string]gotgbot.FileReader{"photo": {Name: "photo.jpg", Data: strings.NewReader("x")}}
+
+	_, err := c.RequestWithContext(context.Background(), "token", "sendPhoto", nil, data, nil)
+	if err == nil {
+		t.Fatal("Expected error for upload request")
+	}
+
+	if fake.calls != 1 {
+		t.Errorf("Expected 1 call, got %d", fake.calls)
+	}
+}
+
+func TestRetry_CancelledContextStopsRetrying(t *testing.T) {
+	fake := &fakeClient{errs: []error{serverError(), serverError()}}
+	c := retry.Wrap(fake, retry.New().Backoff(time.Second, time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	if err := request(ctx, c, "getChat"); err == nil {
+		t.Fatal("Expected error")
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected cancellation to stop the backoff, waited %v", elapsed)
+	}
+}
@@ -0,0 +1,166 @@
+package scheduler_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/scheduler"
+)
+
+func TestParse_Next(t *testing.T) {
+	// 2025-01-01 is a Wednesday.
+	from := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr g.String
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2025, 1, 1, 10, 31, 0, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)},
+		{"45 10 * * *", time.Date(2025, 1, 1, 10, 45, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 1, 1, 10, 45, 0, 0, time.UTC)},
+		{"0 9 * * MON", time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * mon-fri", time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 12 1,15 * *", time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{"0 9 1,15 * *", time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 JUN *", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 * *", time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{"0 8-18/4 * * *", time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{"5/20 * * * *", time.Date(2025, 1, 1, 10, 45, 0, 0, time.UTC)},
+		{"0 0 ? * SAT", time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"@midnight", time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"@annually", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 90m", time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		spec := scheduler.Parse(tt.expr)
+		if spec.IsErr() {
+			t.Errorf("Parse(%q) failed: %v", tt.expr, spec.Err())
+			continue
+		}
+
+		if got := spec.Ok().Next(from); !got.Equal(tt.want) {
+			t.Errorf("Parse(%q).Next = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestParse_DaysMatchEither(t *testing.T) {
+	// Both days restricted: the 13th or any Friday.
+	spec := scheduler.Parse("0 0 13 * FRI").Unwrap()
+
+	next := spec.Next(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if want := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("Next = %v, want %v", next, want)
+	}
+
+	next = spec.Next(time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC))
+	if want := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("Next = %v, want %v", next, want)
+	}
+}
+
+func TestParse_TimeZone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	spec := scheduler.Parse("CRON_TZ=Europe/Berlin 0 9 * * *").Unwrap()
+
+	next := spec.Next(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if want := time.Date(2025, 1, 1, 9, 0, 0, 0, berlin); !next.Equal(want) {
+		t.Errorf("Next = %v, want %v", next, want)
+	}
+
+	if next.Location().String() != berlin.String() {
+		t.Errorf("Next location = %v, want %v", next.Location(), berlin)
+	}
+
+	// Without a prefix, the expression follows the time zone of the given time.
+	next = scheduler.Parse("0 9 * * *").Unwrap().Next(time.Date(2025, 1, 1, 0, 0, 0, 0, berlin))
+	if want := time.Date(2025, 1, 1, 9, 0, 0, 0, berlin); !next.Equal(want) {
+		t.Errorf("Next = %v, want %v", next, want)
+	}
+
+	if scheduler.Parse("TZ=Europe/Berlin @daily").IsErr() {
+		t.Error("TZ prefix with a descriptor should parse")
+	}
+}
+
+func TestParse_SkipsMissingLocalTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	// 02:30 does not exist on 2025-03-30 in Berlin.
+	spec := scheduler.Parse("30 2 * * *").Unwrap()
+	next := spec.Next(time.Date(2025, 3, 29, 3, 0, 0, 0, berlin))
+
+	if next.Day() == 30 && next.Hour() == 2 {
+		t.Errorf("Next = %v, a time that does not exist", next)
+	}
+
+	if next.Before(time.Date(2025, 3, 30, 0, 0, 0, 0, berlin)) {
+		t.Errorf("Next = %v, before the requested time", next)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, expr := range []g.String{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"* * * * FOO",
+		"30-10 * * * *",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"@every",
+		"@every 0s",
+		"@every -1m",
+		"@every soon",
+		"@often",
+		"CRON_TZ=Nowhere/City * * * * *",
+	} {
+		if r := scheduler.Parse(expr); r.IsOk() || !errors.Is(r.Err(), scheduler.ErrInvalidSpec) {
+			t.Errorf("Parse(%q) = %v, want ErrInvalidSpec", expr, r)
+		}
+	}
+}
+
+func TestParse_NoMatch(t *testing.T) {
+	spec := scheduler.Parse("0 0 30 2 *").Unwrap()
+
+	if next := spec.Next(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)); !next.IsZero() {
+		t.Errorf("Next = %v, want the zero time", next)
+	}
+}
+
+func TestInterval(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if next := scheduler.Interval(time.Hour).Next(from); !next.Equal(from.Add(time.Hour)) {
+		t.Errorf("Next = %v, want %v", next, from.Add(time.Hour))
+	}
+
+	if s := scheduler.Interval(90 * time.Second).String(); s != "@every 1m30s" {
+		t.Errorf("String = %q, want %q", s, "@every 1m30s")
+	}
+}
@@ -0,0 +1,351 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/scheduler"
+)
+
+func TestRecurring_Every(t *testing.T) {
+	store := scheduler.NewMemory()
+	s, clock, _, errs := newScheduler(t, store)
+	start := clock.Now()
+
+	var runs []time.Time
+	job := s.Every(10*time.Minute, func(std context.Context) error {
+		runs = append(runs, scheduler.Scheduled(std))
+		return nil
+	})
+
+	if job.Err() != nil {
+		t.Fatalf("Every failed: %v", job.Err())
+	}
+
+	if next := job.Next(); next.IsNone() || !next.Some().Equal(start.Add(10*time.Minute)) {
+		t.Fatalf("Expected the first run in 10 minutes, got %v", next)
+	}
+
+	clock.Advance(9 * time.Minute)
+
+	if len(runs) != 0 {
+		t.Fatal("Job ran before it was due")
+	}
+
+	clock.Advance(21 * time.Minute)
+
+	if len(runs) != 3 {
+		t.Fatalf("Expected 3 runs, got %d", len(runs))
+	}
+
+	for i, at := range runs {
+		if want := start.Add(time.Duration(i+1) * 10 * time.Minute); !at.Equal(want) {
+			t.Errorf("Run %d scheduled for %v, want %v", i, at, want)
+		}
+	}
+
+	stored := store.Load(context.Background()).Unwrap()
+	if stored.Len() != 1 || stored[0].Kind != scheduler.KindRecurring || !stored[0].At.Equal(start.Add(40*time.Minute)) {
+		t.Errorf("Expected the next run in the store, got %v", stored)
+	}
+
+	if !s.Jobs().IsEmpty() {
+		t.Errorf("Recurring jobs should not be listed as pending jobs, got %v", s.Jobs())
+	}
+
+	if len(*errs) != 0 {
+		t.Errorf("Unexpected errors: %v", *errs)
+	}
+}
+
+func TestRecurring_CronIn(t *testing.T) {
+	s, clock, _, _ := newScheduler(t, nil)
+	zone := time.FixedZone("UTC+3", 3*60*60)
+
+	ran := 0
+	job := s.Cron("0 9 * * *", func(context.Context) error { ran++; return nil }).In(zone)
+
+	want := time.Date(2025, 1, 1, 6, 0, 0, 0, time.UTC)
+	if next := job.Next(); next.IsNone() || !next.Some().Equal(want) {
+		t.Fatalf("Expected the next run at %v, got %v", want, next)
+	}
+
+	clock.Advance(6 * time.Hour)
+
+	if ran != 1 {
+		t.Fatalf("Expected one run, got %d", ran)
+	}
+
+	if next := job.Next(); !next.Some().Equal(want.Add(24 * time.Hour)) {
+		t.Errorf("Expected the next run a day later, got %v", next)
+	}
+}
+
+func TestRecurring_Invalid(t *testing.T) {
+	s, clock, _, _ := newScheduler(t, nil)
+
+	ran := false
+	fn := func(context.Context) error { ran = true; return nil }
+
+	for _, job := range []*scheduler.Recurring{s.Cron("61 * * * *", fn), s.Every(0, fn)} {
+		if !errors.Is(job.Err(), scheduler.ErrInvalidSpec) {
+			t.Errorf("Expected ErrInvalidSpec, got %v", job.Err())
+		}
+
+		if job.Next().IsSome() {
+			t.Error("Invalid job should not be scheduled")
+		}
+
+		if !errors.Is(job.Cancel(), scheduler.ErrNotFound) {
+			t.Error("Cancel of an invalid job should return ErrNotFound")
+		}
+	}
+
+	clock.Advance(time.Hour)
+
+	if ran {
+		t.Error("Invalid job ran")
+	}
+}
+
+func TestRecurring_Cancel(t *testing.T) {
+	store := scheduler.NewMemory()
+	s, clock, _, _ := newScheduler(t, store)
+
+	ran := 0
+	job := s.Every(time.Minute, func(context.Context) error { ran++; return nil })
+
+	clock.Advance(time.Minute)
+
+	if err := job.Cancel(); err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+
+	clock.Advance(time.Hour)
+
+	if ran != 1 {
+		t.Errorf("Expected no runs after Cancel, got %d runs", ran)
+	}
+
+	if job.Next().IsSome() {
+		t.Error("Cancelled job should not be scheduled")
+	}
+
+	if !store.Load(context.Background()).Unwrap().IsEmpty() {
+		t.Error("Expected the stored next run to be removed")
+	}
+
+	if !errors.Is(job.Cancel(), scheduler.ErrNotFound) {
+		t.Error("Second Cancel should return ErrNotFound")
+	}
+}
+
+func TestRecurring_Name(t *testing.T) {
+	store := scheduler.NewMemory()
+	s, _, _, _ := newScheduler(t, store)
+
+	s.Every(time.Minute, func(context.Context) error { return nil }).Name("digest")
+
+	stored := store.Load(context.Background()).Unwrap()
+	if stored.Len() != 1 || stored[0].ID != "recurring:digest" {
+		t.Errorf("Expected only the named record in the store, got %v", stored)
+	}
+}
+
+func TestRecurring_ErrorsAndPanics(t *testing.T) {
+	s, clock, _, errs := newScheduler(t, nil)
+
+	s.Every(time.Minute, func(context.Context) error { return errors.New("boom") }).Name("fail")
+	s.Every(time.Minute, func(context.Context) error { panic("oops") }).Name("panic")
+
+	clock.Advance(time.Minute)
+
+	if len(*errs) != 2 {
+		t.Fatalf("Expected two errors, got %v", *errs)
+	}
+
+	var text []string
+	for _, err := range *errs {
+		text = append(text, err.Error())
+	}
+
+	joined := strings.Join(text, "; ")
+	if !strings.Contains(joined, "boom") || !strings.Contains(joined, "job panicked: oops") {
+		t.Errorf("Expected the error and the panic in the hook, got %q", joined)
+	}
+
+	clock.Advance(time.Minute)
+
+	if len(*errs) != 4 {
+		t.Errorf("Expected the jobs to keep running after failures, got %v", *errs)
+	}
+}
+
+// overlapping starts a job whose first run blocks until release is closed, advances the clock
+// to its second run while the first is in progress, and returns the number of runs.
+func overlapping(t *testing.T, allow bool) (int, []error) {
+	t.Helper()
+
+	s, clock, _, errs := newScheduler(t, nil)
+
+	var (
+		mu      sync.Mutex
+		runs    int
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+
+	job := s.Every(time.Minute, func(context.Context) error {
+		mu.Lock()
+		runs++
+		first := runs == 1
+		mu.Unlock()
+
+		if first {
+			close(started)
+			<-release
+		}
+
+		return nil
+	})
+
+	if allow {
+		job.AllowOverlap()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		clock.Advance(time.Minute)
+		close(done)
+	}()
+
+	<-started
+	clock.Advance(time.Minute)
+	close(release)
+	<-done
+
+	s.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	return runs, *errs
+}
+
+func TestRecurring_SkipsOverlap(t *testing.T) {
+	runs, errs := overlapping(t, false)
+
+	if runs != 1 {
+		t.Errorf("Expected the overlapping run to be skipped, got %d runs", runs)
+	}
+
+	if len(errs) != 1 || !errors.Is(errs[0], scheduler.ErrOverlap) {
+		t.Errorf("Expected ErrOverlap in the hook, got %v", errs)
+	}
+}
+
+func TestRecurring_AllowOverlap(t *testing.T) {
+	runs, errs := overlapping(t, true)
+
+	if runs != 2 {
+		t.Errorf("Expected both runs, got %d runs", runs)
+	}
+
+	if len(errs) != 0 {
+		t.Errorf("Unexpected errors: %v", errs)
+	}
+}
+
+// restarted runs an hourly job named "report", stops the bot for five and a half hours
+// and restarts it with the given policy. It returns the scheduled times of the runs after the restart.
+func restarted(t *testing.T, policy g.Option[scheduler.Missed]) ([]time.Time, *scheduler.Recurring, *scheduler.FakeClock) {
+	t.Helper()
+
+	store := scheduler.NewMemory()
+
+	first, clock, _, _ := newScheduler(t, store)
+	first.Every(time.Hour, func(context.Context) error { return nil }).Name("report")
+	first.Stop()
+
+	second, later, _, _ := newScheduler(t, store)
+	later.Set(clock.Now().Add(5*time.Hour + 30*time.Minute))
+
+	var runs []time.Time
+	job := second.Every(time.Hour, func(std context.Context) error {
+		runs = append(runs, scheduler.Scheduled(std))
+		return nil
+	}).Name("report")
+
+	if p, ok := policy.Option(); ok {
+		job.Missed(p)
+	}
+
+	later.Advance(0)
+
+	return runs, job, later
+}
+
+func TestRecurring_MissedSkip(t *testing.T) {
+	runs, job, clock := restarted(t, g.None[scheduler.Missed]())
+
+	if len(runs) != 0 {
+		t.Errorf("Expected missed runs to be skipped, got %v", runs)
+	}
+
+	if next := job.Next(); next.IsNone() || !next.Some().Equal(clock.Now().Add(time.Hour)) {
+		t.Errorf("Expected the next run an interval from now, got %v", next)
+	}
+}
+
+func TestRecurring_MissedRunOnce(t *testing.T) {
+	runs, _, clock := restarted(t, g.Some(scheduler.MissedRunOnce))
+
+	start := clock.Now().Add(-5*time.Hour - 30*time.Minute)
+	if len(runs) != 1 || !runs[0].Equal(start.Add(5*time.Hour)) {
+		t.Errorf("Expected one run for the last missed time, got %v", runs)
+	}
+}
+
+func TestRecurring_MissedRunAll(t *testing.T) {
+	runs, _, clock := restarted(t, g.Some(scheduler.MissedRunAll))
+
+	start := clock.Now().Add(-5*time.Hour - 30*time.Minute)
+	if len(runs) != 5 {
+		t.Fatalf("Expected five runs, got %v", runs)
+	}
+
+	for i, at := range runs {
+		if want := start.Add(time.Duration(i+1) * time.Hour); !at.Equal(want) {
+			t.Errorf("Run %d scheduled for %v, want %v", i, at, want)
+		}
+	}
+}
+
+func TestRecurring_StopAndStart(t *testing.T) {
+	s, clock, _, _ := newScheduler(t, nil)
+
+	ran := 0
+	s.Every(time.Hour, func(context.Context) error { ran++; return nil }).Missed(scheduler.MissedRunOnce)
+
+	s.Stop()
+	clock.Advance(3 * time.Hour)
+
+	if ran != 0 {
+		t.Fatal("Job ran after Stop")
+	}
+
+	if err := s.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	clock.Advance(0)
+
+	if ran != 1 {
+		t.Errorf("Expected the missed runs to be made up once, got %d runs", ran)
+	}
+}
@@ -0,0 +1,231 @@
+package scheduler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/tg/retry"
+	"github.com/enetx/tg/scheduler"
+)
+
+func TestRecord_CapturesRequest(t *testing.T) {
+	bot, c := newBot()
+
+	req := scheduler.Record(bot.Raw(), func(raw *gotgbot.Bot) error {
+		_, err := raw.SendMessage(7, "hello", &gotgbot.SendMessageOpts{
+			RequestOpts: &gotgbot.RequestOpts{Timeout: time.Second, APIURL: "https://api.example.com"},
+		})
+		return err
+	})
+
+	if req.IsErr() {
+		t.Fatalf("Record failed: %v", req.Err())
+	}
+
+	r := req.Ok()
+	if r.Method != "sendMessage" || r.Params["chat_id"] != "7" || r.Params["text"] != "hello" {
+		t.Errorf("Unexpected request: %+v", r)
+	}
+
+	if r.Timeout != time.Second || r.APIURL != "https://api.example.com" {
+		t.Errorf("Expected request options to be kept, got %v and %q", r.Timeout, r.APIURL)
+	}
+
+	if len(c.Calls()) != 0 {
+		t.Error("Record must not send the request")
+	}
+}
+
+func TestRecord_ReturnsSendError(t *testing.T) {
+	bot, _ := newBot()
+	invalid := errors.New("invalid options")
+
+	req := scheduler.Record(bot.Raw(), func(*gotgbot.Bot) error { return invalid })
+	if !errors.Is(req.Err(), invalid) {
+		t.Errorf("Expected the error of send, got %v", req.Err())
+	}
+}
+
+func TestRecord_RejectsSeveralRequests(t *testing.T) {
+	bot, _ := newBot()
+
+	req := scheduler.Record(bot.Raw(), func(raw *gotgbot.Bot) error {
+		raw.SendMessage(7, "one", nil)
+		raw.SendMessage(7, "two", nil)
+		return nil
+	})
+
+	if req.IsOk() {
+		t.Error("Expected an error for two requests")
+	}
+}
+
+func TestScheduler_RequestSendsAndDeletes(t *testing.T) {
+	store := scheduler.NewMemory()
+	s, clock, c, errs := newScheduler(t, store)
+
+	req := scheduler.Record(&gotgbot.Bot{BotClient: c}, func(raw *gotgbot.Bot) error {
+		_, err := raw.SendMessage(7, "hello", nil)
+		return err
+	}).Unwrap()
+
+	req.DeleteAfter = time.Minute
+
+	id := s.Request(clock.Now().Add(time.Hour), req)
+	if id.IsErr() {
+		t.Fatalf("Request failed: %v", id.Err())
+	}
+
+	if job := s.Job(id.Ok()); job.IsNone() || job.Some().Kind != scheduler.KindRequest {
+		t.Fatalf("Expected a stored request job, got %v", job)
+	}
+
+	if store.Load(context.Background()).Unwrap().Len() != 1 {
+		t.Fatal("Expected the request to be stored")
+	}
+
+	clock.Advance(time.Hour)
+
+	calls := c.Calls()
+	if len(calls) != 1 || calls[0].method != "sendMessage" || calls[0].params["text"] != "hello" {
+		t.Fatalf("Expected the message to be sent, got %v", calls)
+	}
+
+	jobs := s.Jobs()
+	if jobs.Len() != 1 || !jobs[0].At.Equal(clock.Now().Add(time.Minute)) {
+		t.Fatalf("Expected a deletion job a minute later, got %v", jobs)
+	}
+
+	clock.Advance(time.Minute)
+
+	calls = c.Calls()
+	if len(calls) != 2 || calls[1].method != "deleteMessage" ||
+		calls[1].params["chat_id"] != "7" || calls[1].params["message_id"] != "42" {
+		t.Fatalf("Expected the sent message to be deleted, got %v", calls)
+	}
+
+	if !s.Jobs().IsEmpty() || !store.Load(context.Background()).Unwrap().IsEmpty() {
+		t.Error("Expected no jobs left")
+	}
+
+	if len(*errs) != 0 {
+		t.Errorf("Unexpected errors: %v", *errs)
+	}
+}
+
+func TestScheduler_RequestFailure(t *testing.T) {
+	s, clock, c, errs := newScheduler(t, nil)
+	c.fail = true
+
+	s.Request(clock.Now(), scheduler.DeleteMessages("7", 1))
+	clock.Advance(0)
+
+	if len(*errs) != 1 || !strings.Contains((*errs)[0].Error(), "network down") {
+		t.Errorf("Expected the request error in the hook, got %v", *errs)
+	}
+}
+
+func TestScheduler_RequestWithUploadIsKeptInMemory(t *testing.T) {
+	store := scheduler.NewMemory()
+	s, clock, c, _ := newScheduler(t, store)
+
+	req := scheduler.Record(&gotgbot.Bot{BotClient: c}, func(raw *gotgbot.Bot) error {
+		_, err := raw.SendDocument(7, gotgbot.InputFileByReader("notes.txt", strings.NewReader("content")), nil)
+		return err
+	}).Unwrap()
+
+	id := s.Request(clock.Now().Add(time.Minute), req).Unwrap()
+
+	if job := s.Job(id); job.IsNone() || job.Some().Kind != scheduler.KindFunc {
+		t.Fatalf("Expected an in-memory job, got %v", job)
+	}
+
+	if !store.Load(context.Background()).Unwrap().IsEmpty() {
+		t.Fatal("Requests with uploads must not be stored")
+	}
+
+	clock.Advance(time.Minute)
+
+	calls := c.Calls()
+	if len(calls) != 1 || calls[0].method != "sendDocument" {
+		t.Fatalf("Expected the document to be sent, got %v", calls)
+	}
+
+	var found bool
+	for _, file := range calls[0].files {
+		found = found || file == "notes.txt:content"
+	}
+
+	if !found {
+		t.Errorf("Expected the recorded file to be uploaded, got %v", calls[0].files)
+	}
+}
+
+func TestDeleteMessages(t *testing.T) {
+	one := scheduler.DeleteMessages("7", 5)
+	if one.Method != "deleteMessage" || one.Params["message_id"] != "5" || one.Params["chat_id"] != "7" {
+		t.Errorf("Unexpected request for one message: %+v", one)
+	}
+
+	many := scheduler.DeleteMessages("@channel", 5, 6)
+	if many.Method != "deleteMessages" || many.Params["message_ids"] != "[5,6]" || many.Params["chat_id"] != "@channel" {
+		t.Errorf("Unexpected request for several messages: %+v", many)
+	}
+}
+
+func TestRecord_KeepsRetryPolicy(t *testing.T) {
+	store := scheduler.NewMemory()
+	s, clock, c, _ := newScheduler(t, store)
+
+	policy := retry.New().Attempts(5).Force()
+
+	req := scheduler.Record(&gotgbot.Bot{BotClient: c}, func(raw *gotgbot.Bot) error {
+		_, err := raw.SendMessageWithContext(retry.WithPolicy(context.Background(), policy), 7, "hello", nil)
+		return err
+	}).Unwrap()
+
+	if req.Retry != policy {
+		t.Fatalf("Expected the retry policy of the request, got %v", req.Retry)
+	}
+
+	s.Request(clock.Now().Add(time.Hour), req)
+
+	job := store.Load(context.Background()).Unwrap()[0]
+
+	var stored scheduler.Request
+	if err := json.Unmarshal(job.Payload, &stored); err != nil {
+		t.Fatalf("Failed to decode the stored request: %v", err)
+	}
+
+	want, _ := json.Marshal(policy)
+	got, _ := json.Marshal(stored.Retry)
+
+	if stored.Retry == nil || string(got) != string(want) {
+		t.Errorf("Expected the stored policy %s, got %s", want, got)
+	}
+}
+
+func TestScheduler_RequestUsesRetryPolicy(t *testing.T) {
+	bot, c := newBot()
+	c.fail = true
+	bot.raw.BotClient = retry.Wrap(c, nil)
+
+	clock := newClock()
+	s := scheduler.New(bot).Clock(clock)
+	s.Start(context.Background())
+
+	req := scheduler.DeleteMessages("7", 1)
+	req.Retry = retry.New().Attempts(2).Backoff(time.Millisecond, time.Millisecond)
+
+	s.Request(clock.Now(), req)
+	clock.Advance(0)
+
+	if calls := c.Calls(); len(calls) != 2 {
+		t.Errorf("Expected the request to be retried once, got %d calls", len(calls))
+	}
+}
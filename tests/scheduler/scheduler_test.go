@@ -0,0 +1,545 @@
+package scheduler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/scheduler"
+)
+
+func newClock() *scheduler.FakeClock {
+	return scheduler.NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+// call is a request received by the client.
+type call struct {
+	method string
+	params map[string]string
+	files  map[string]string
+}
+
+// client records every request and answers with a sent message.
+type client struct {
+	gotgbot.BotClient
+	mu    sync.Mutex
+	calls []call
+	fail  bool
+}
+
+func (c *client) RequestWithContext(
+	_ context.Context,
+	_ string,
+	method string,
+	params map[string]string,
+	data map[string]gotgbot.FileReader,
+	_ *gotgbot.RequestOpts,
+) (json.RawMessage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	files := make(map[string]string)
+	for field, file := range data {
+		var sb strings.Builder
+		buf := make([]byte, 64)
+
+		for {
+			n, err := file.Data.Read(buf)
+			sb.Write(buf[:n])
+
+			if err != nil {
+				break
+			}
+		}
+
+		files[field] = file.Name + ":" + sb.String()
+	}
+
+	c.calls = append(c.calls, call{method, params, files})
+
+	if c.fail {
+		return nil, errors.New("network down")
+	}
+
+	return json.RawMessage(`{"message_id":42,"date":0,"chat":{"id":7,"type":"private"}}`), nil
+}
+
+func (c *client) Calls() []call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]call(nil), c.calls...)
+}
+
+type mockBot struct {
+	raw *gotgbot.Bot
+}
+
+func newBot() (*mockBot, *client) {
+	c := &client{}
+	return &mockBot{raw: &gotgbot.Bot{Token: "token", BotClient: c}}, c
+}
+
+func (m *mockBot) Raw() *gotgbot.Bot           { return m.raw }
+func (m *mockBot) Dispatcher() *ext.Dispatcher { return &ext.Dispatcher{} }
+func (m *mockBot) Updater() *ext.Updater       { return &ext.Updater{} }
+
+// newScheduler returns a started scheduler with a fake clock and the recorded errors of its jobs.
+func newScheduler(t *testing.T, store scheduler.Store) (*scheduler.Scheduler, *scheduler.FakeClock, *client, *[]error) {
+	t.Helper()
+
+	bot, c := newBot()
+	clock := newClock()
+
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+
+	s := scheduler.New(bot).Clock(clock).OnError(func(_ scheduler.Job, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		errs = append(errs, err)
+	})
+
+	if store != nil {
+		s.Store(store)
+	}
+
+	if err := s.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	return s, clock, c, &errs
+}
+
+func TestScheduler_AddRunsWhenDue(t *testing.T) {
+	store := scheduler.NewMemory()
+	s, clock, _, errs := newScheduler(t, store)
+
+	var got []string
+	s.Handle("greet", func(_ context.Context, job scheduler.Job) error {
+		var name string
+		if err := json.Unmarshal(job.Payload, &name); err != nil {
+			return err
+		}
+
+		got = append(got, name)
+
+		return nil
+	})
+
+	id := s.Add("greet", clock.Now().Add(time.Hour), "alice")
+	if id.IsErr() {
+		t.Fatalf("Add failed: %v", id.Err())
+	}
+
+	if stored := store.Load(context.Background()).Unwrap(); stored.Len() != 1 || stored[0].ID != id.Ok() {
+		t.Fatalf("Expected the job in the store, got %v", stored)
+	}
+
+	clock.Advance(59 * time.Minute)
+
+	if len(got) != 0 {
+		t.Fatal("Job ran before it was due")
+	}
+
+	clock.Advance(time.Minute)
+
+	if len(got) != 1 || got[0] != "alice" {
+		t.Fatalf("Expected the job to run once with its payload, got %v", got)
+	}
+
+	if s.Job(id.Ok()).IsSome() || !s.Jobs().IsEmpty() {
+		t.Error("Expected no pending jobs after the run")
+	}
+
+	if !store.Load(context.Background()).Unwrap().IsEmpty() {
+		t.Error("Expected the job to be deleted from the store after the run")
+	}
+
+	if len(*errs) != 0 {
+		t.Errorf("Unexpected errors: %v", *errs)
+	}
+}
+
+func TestScheduler_AddBeforeStartWaits(t *testing.T) {
+	bot, _ := newBot()
+	clock := newClock()
+	s := scheduler.New(bot).Clock(clock)
+
+	ran := 0
+	s.Handle("tick", func(context.Context, scheduler.Job) error { ran++; return nil })
+	s.Add("tick", clock.Now(), nil)
+
+	clock.Advance(time.Hour)
+
+	if ran != 0 {
+		t.Fatal("Job ran before Start")
+	}
+
+	if err := s.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	clock.Advance(0)
+
+	if ran != 1 {
+		t.Fatalf("Expected the overdue job to run after Start, ran %d times", ran)
+	}
+
+	if err := s.Start(context.Background()); err != nil {
+		t.Errorf("Second Start should be a no-op, got %v", err)
+	}
+}
+
+func TestScheduler_Cancel(t *testing.T) {
+	store := scheduler.NewMemory()
+	s, clock, _, _ := newScheduler(t, store)
+
+	ran := false
+	s.Handle("tick", func(context.Context, scheduler.Job) error { ran = true; return nil })
+
+	id := s.Add("tick", clock.Now().Add(time.Minute), nil).Unwrap()
+
+	if err := s.Cancel(id); err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+
+	clock.Advance(time.Hour)
+
+	if ran {
+		t.Error("Cancelled job ran")
+	}
+
+	if !store.Load(context.Background()).Unwrap().IsEmpty() {
+		t.Error("Expected the cancelled job to be deleted from the store")
+	}
+
+	if err := s.Cancel(id); !errors.Is(err, scheduler.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a cancelled job, got %v", err)
+	}
+}
+
+func TestScheduler_Reschedule(t *testing.T) {
+	store := scheduler.NewMemory()
+	s, clock, _, _ := newScheduler(t, store)
+
+	ran := 0
+	s.Handle("tick", func(context.Context, scheduler.Job) error { ran++; return nil })
+
+	id := s.Add("tick", clock.Now().Add(time.Minute), nil).Unwrap()
+	at := clock.Now().Add(time.Hour)
+
+	if err := s.Reschedule(id, at); err != nil {
+		t.Fatalf("Reschedule failed: %v", err)
+	}
+
+	if job := s.Job(id); job.IsNone() || !job.Some().At.Equal(at) {
+		t.Errorf("Expected the job to be due at %v, got %v", at, job)
+	}
+
+	if stored := store.Load(context.Background()).Unwrap(); stored.Len() != 1 || !stored[0].At.Equal(at) {
+		t.Errorf("Expected the stored job to be due at %v, got %v", at, stored)
+	}
+
+	clock.Advance(time.Minute)
+
+	if ran != 0 {
+		t.Fatal("Job ran at its old time")
+	}
+
+	clock.Advance(time.Hour)
+
+	if ran != 1 {
+		t.Fatalf("Expected the job to run once at its new time, ran %d times", ran)
+	}
+
+	if err := s.Reschedule(id, at); !errors.Is(err, scheduler.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a job that ran, got %v", err)
+	}
+}
+
+func TestScheduler_Jobs(t *testing.T) {
+	s, clock, _, _ := newScheduler(t, nil)
+
+	later := s.Add("tick", clock.Now().Add(2*time.Hour), nil).Unwrap()
+	sooner := s.AddFunc(clock.Now().Add(time.Hour), func(context.Context) error { return nil })
+
+	jobs := s.Jobs()
+	if jobs.Len() != 2 || jobs[0].ID != sooner || jobs[1].ID != later {
+		t.Fatalf("Expected jobs ordered by time, got %v", jobs)
+	}
+
+	if jobs[0].Kind != scheduler.KindFunc || jobs[1].Kind != "tick" {
+		t.Errorf("Unexpected kinds: %s, %s", jobs[0].Kind, jobs[1].Kind)
+	}
+
+	if s.Job("missing").IsSome() {
+		t.Error("Expected None for an unknown job")
+	}
+}
+
+func TestScheduler_ErrorHook(t *testing.T) {
+	store := scheduler.NewMemory()
+	s, clock, _, errs := newScheduler(t, store)
+
+	boom := errors.New("boom")
+	s.Handle("fail", func(context.Context, scheduler.Job) error { return boom })
+	s.Add("fail", clock.Now(), nil)
+
+	clock.Advance(0)
+
+	if len(*errs) != 1 || !errors.Is((*errs)[0], boom) {
+		t.Fatalf("Expected the job error in the hook, got %v", *errs)
+	}
+
+	if !store.Load(context.Background()).Unwrap().IsEmpty() {
+		t.Error("Expected a failed job to be deleted from the store")
+	}
+}
+
+func TestScheduler_UnknownKindStaysPending(t *testing.T) {
+	store := scheduler.NewMemory()
+	s, clock, _, errs := newScheduler(t, store)
+
+	id := s.Add("later", clock.Now(), nil).Unwrap()
+
+	clock.Advance(time.Minute)
+
+	if len(*errs) != 1 || !errors.Is((*errs)[0], scheduler.ErrUnknownKind) {
+		t.Fatalf("Expected ErrUnknownKind in the hook, got %v", *errs)
+	}
+
+	if s.Job(id).IsNone() || store.Load(context.Background()).Unwrap().IsEmpty() {
+		t.Fatal("Expected the job to stay pending")
+	}
+
+	ran := false
+	s.Handle("later", func(context.Context, scheduler.Job) error { ran = true; return nil })
+
+	clock.Advance(0)
+
+	if !ran {
+		t.Error("Expected the job to run once its kind is handled")
+	}
+
+	if s.Job(id).IsSome() {
+		t.Error("Expected the job to be gone after the run")
+	}
+}
+
+func TestScheduler_AddFunc(t *testing.T) {
+	store := scheduler.NewMemory()
+	s, clock, _, errs := newScheduler(t, store)
+
+	boom := errors.New("boom")
+	s.AddFunc(clock.Now().Add(time.Second), func(context.Context) error { return boom })
+
+	if !store.Load(context.Background()).Unwrap().IsEmpty() {
+		t.Error("In-memory jobs must not be stored")
+	}
+
+	clock.Advance(time.Second)
+
+	if len(*errs) != 1 || !errors.Is((*errs)[0], boom) {
+		t.Errorf("Expected the function error in the hook, got %v", *errs)
+	}
+}
+
+func TestScheduler_StopDropsInMemoryJobs(t *testing.T) {
+	store := scheduler.NewMemory()
+	s, clock, _, errs := newScheduler(t, store)
+
+	ran := false
+	s.AddFunc(clock.Now().Add(time.Minute), func(context.Context) error { ran = true; return nil })
+	stored := s.Add("tick", clock.Now().Add(time.Minute), nil).Unwrap()
+
+	s.Stop()
+	clock.Advance(time.Hour)
+
+	if ran {
+		t.Error("Job ran after Stop")
+	}
+
+	if len(*errs) != 1 || !errors.Is((*errs)[0], scheduler.ErrStopped) {
+		t.Errorf("Expected ErrStopped for the dropped job, got %v", *errs)
+	}
+
+	if jobs := s.Jobs(); jobs.Len() != 1 || jobs[0].ID != stored {
+		t.Errorf("Expected only the stored job to remain, got %v", jobs)
+	}
+}
+
+func TestScheduler_StopWaitsForRunningJobs(t *testing.T) {
+	bot, _ := newBot()
+	s := scheduler.New(bot)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	s.AddFunc(time.Now(), func(context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+
+	s.Start(context.Background())
+	<-started
+
+	stopped := make(chan struct{})
+	go func() {
+		s.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		t.Fatal("Stop returned while a job was running")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Stop did not return after the job finished")
+	}
+}
+
+func TestScheduler_FileStoreReload(t *testing.T) {
+	path := g.String(filepath.Join(t.TempDir(), "jobs.json"))
+
+	first, clock, _, _ := newScheduler(t, scheduler.NewFile(path))
+	id := first.Add("greet", clock.Now().Add(time.Hour), "bob").Unwrap()
+	first.Stop()
+
+	bot, _ := newBot()
+	second := scheduler.New(bot).Clock(clock).Store(scheduler.NewFile(path))
+
+	var got string
+	second.Handle("greet", func(_ context.Context, job scheduler.Job) error {
+		if job.ID != id {
+			t.Errorf("Expected job %s, got %s", id, job.ID)
+		}
+
+		return json.Unmarshal(job.Payload, &got)
+	})
+
+	if err := second.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	if second.Job(id).IsNone() {
+		t.Fatal("Expected the pending job to be loaded")
+	}
+
+	clock.Advance(time.Hour)
+
+	if got != "bob" {
+		t.Errorf("Expected the reloaded job to run with its payload, got %q", got)
+	}
+}
+
+// failingStore is a store that cannot load its jobs.
+type failingStore struct{ *scheduler.Memory }
+
+func (failingStore) Load(context.Context) g.Result[g.Slice[scheduler.Job]] {
+	return g.Err[g.Slice[scheduler.Job]](errors.New("disk error"))
+}
+
+func TestScheduler_StartLoadError(t *testing.T) {
+	bot, _ := newBot()
+	s := scheduler.New(bot).Store(failingStore{scheduler.NewMemory()})
+
+	err := s.Start(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "disk error") {
+		t.Errorf("Expected the load error, got %v", err)
+	}
+}
+
+func TestScheduler_AddEncodeError(t *testing.T) {
+	s, clock, _, _ := newScheduler(t, nil)
+
+	if id := s.Add("tick", clock.Now(), make(chan int)); id.IsOk() {
+		t.Error("Expected an error for a payload that cannot be encoded")
+	}
+}
+
+func TestScheduler_DrainRunsJobsDueBeforeDeadline(t *testing.T) {
+	s, clock, c, _ := newScheduler(t, nil)
+
+	early := false
+	late := false
+
+	s.AddFunc(clock.Now().Add(time.Second), func(context.Context) error { early = true; return nil })
+	s.AddFunc(clock.Now().Add(time.Hour), func(context.Context) error { late = true; return nil })
+
+	req := scheduler.DeleteMessages("7", 1)
+	req.DeleteAfter = 2 * time.Second
+	s.Request(clock.Now().Add(time.Second), req)
+
+	done := make(chan struct{})
+	go func() {
+		s.Drain(clock.Now().Add(time.Minute))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Drain returned before the jobs due were run")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	clock.Advance(time.Second)
+	clock.Advance(2 * time.Second)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Drain did not return after the jobs due were run")
+	}
+
+	if !early || late {
+		t.Errorf("Expected only the job due before the deadline to run, got early %v, late %v", early, late)
+	}
+
+	if calls := c.Calls(); len(calls) != 2 || calls[1].method != "deleteMessage" {
+		t.Errorf("Expected the deletion added by a drained job to run, got %v", calls)
+	}
+}
+
+func TestScheduler_DrainStopsAtDeadline(t *testing.T) {
+	s, clock, _, errs := newScheduler(t, nil)
+
+	ran := false
+	s.AddFunc(clock.Now().Add(time.Minute), func(context.Context) error { ran = true; return nil })
+
+	done := make(chan struct{})
+	go func() {
+		s.Drain(clock.Now().Add(time.Second))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Drain waited for a job due after the deadline")
+	}
+
+	clock.Advance(time.Hour)
+
+	if ran || len(*errs) != 1 || !errors.Is((*errs)[0], scheduler.ErrStopped) {
+		t.Errorf("Expected the later job to be dropped, got ran %v, errors %v", ran, *errs)
+	}
+}
@@ -0,0 +1,96 @@
+package scheduler_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/scheduler"
+)
+
+func testStore(t *testing.T, store scheduler.Store) {
+	t.Helper()
+
+	std := context.Background()
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if jobs := store.Load(std); jobs.IsErr() || !jobs.Ok().IsEmpty() {
+		t.Fatalf("Expected an empty store, got %v", jobs)
+	}
+
+	job := scheduler.Job{ID: "a", Kind: "tick", At: at, Payload: []byte(`{"n":1}`)}
+	if err := store.Save(std, job); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	job.At = at.Add(time.Hour)
+	if err := store.Save(std, job); err != nil {
+		t.Fatalf("Save of an existing job failed: %v", err)
+	}
+
+	jobs := store.Load(std).Unwrap()
+	if jobs.Len() != 1 || jobs[0].ID != "a" || !jobs[0].At.Equal(job.At) || string(jobs[0].Payload) != `{"n":1}` {
+		t.Fatalf("Expected the replaced job, got %v", jobs)
+	}
+
+	if err := store.Delete(std, "a"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	if err := store.Delete(std, "a"); err != nil {
+		t.Errorf("Deleting a missing job should not fail, got %v", err)
+	}
+
+	if !store.Load(std).Unwrap().IsEmpty() {
+		t.Error("Expected an empty store after Delete")
+	}
+}
+
+func TestMemory(t *testing.T) {
+	testStore(t, scheduler.NewMemory())
+}
+
+func TestFile(t *testing.T) {
+	testStore(t, scheduler.NewFile(g.String(filepath.Join(t.TempDir(), "jobs.json"))))
+}
+
+func TestFile_Persists(t *testing.T) {
+	path := g.String(filepath.Join(t.TempDir(), "jobs.json"))
+	std := context.Background()
+
+	if err := scheduler.NewFile(path).Save(std, scheduler.Job{ID: "a", Kind: "tick"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	jobs := scheduler.NewFile(path).Load(std)
+	if jobs.IsErr() || jobs.Ok().Len() != 1 || jobs.Ok()[0].ID != "a" {
+		t.Errorf("Expected the job to be read back, got %v", jobs)
+	}
+}
+
+func TestFile_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if jobs := scheduler.NewFile(g.String(path)).Load(context.Background()); jobs.IsOk() {
+		t.Error("Expected an error for a corrupt file")
+	}
+}
+
+func TestFile_WriteErrorKeepsState(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+	store := scheduler.NewFile(g.String(filepath.Join(dir, "jobs.json")))
+
+	if err := store.Save(context.Background(), scheduler.Job{ID: "a"}); err == nil {
+		t.Fatal("Expected an error when the directory does not exist")
+	}
+
+	if !store.Load(context.Background()).Unwrap().IsEmpty() {
+		t.Error("A failed save must not keep the job")
+	}
+}
@@ -0,0 +1,297 @@
+package session_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/handlers"
+	"github.com/enetx/tg/session"
+)
+
+type mockBot struct{}
+
+func (m *mockBot) Raw() *gotgbot.Bot           { return &gotgbot.Bot{} }
+func (m *mockBot) Dispatcher() *ext.Dispatcher { return &ext.Dispatcher{} }
+func (m *mockBot) Updater() *ext.Updater       { return &ext.Updater{} }
+
+// clock is a manually advanced time source.
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newClock() *clock { return &clock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)} }
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func newContext(chatID, userID int64) *ctx.Context {
+	chat := &gotgbot.Chat{Id: chatID, Type: "group"}
+	user := &gotgbot.User{Id: userID, FirstName: "Test"}
+
+	return ctx.New(&mockBot{}, &ext.Context{
+		Update:           &gotgbot.Update{UpdateId: 1},
+		EffectiveChat:    chat,
+		EffectiveUser:    user,
+		EffectiveMessage: &gotgbot.Message{MessageId: 1, Chat: *chat, From: user},
+	})
+}
+
+func run(mw handlers.Middleware, c *ctx.Context, fn func(*ctx.Context) error) error {
+	return mw(c, func() error { return fn(c) })
+}
+
+func increment(c *ctx.Context) error {
+	n := ctx.SessionValue[int](c.Session(), "count").UnwrapOrDefault()
+	c.Session().Set("count", n+1)
+
+	return nil
+}
+
+func count(mw handlers.Middleware, c *ctx.Context) int {
+	var n int
+
+	run(mw, c, func(c *ctx.Context) error {
+		n = ctx.SessionValue[int](c.Session(), "count").UnwrapOrDefault()
+		return nil
+	})
+
+	return n
+}
+
+func TestMiddleware_PersistsBetweenUpdates(t *testing.T) {
+	mw := session.New(session.NewMemory()).Middleware()
+
+	for range 3 {
+		if err := run(mw, newContext(1, 42), increment); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if got := count(mw, newContext(1, 42)); got != 3 {
+		t.Errorf("Expected count 3, got %d", got)
+	}
+}
+
+func TestMiddleware_Keys(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       session.KeyFunc
+		otherChat int
+		otherUser int
+	}{
+		{"ByUser", session.ByUser, 2, 0},
+		{"ByChat", session.ByChat, 0, 2},
+		{"ByChatUser", session.ByChatUser, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := session.New(session.NewMemory()).Key(tt.key).Middleware()
+
+			run(mw, newContext(1, 42), increment)
+			run(mw, newContext(1, 42), increment)
+
+			if got := count(mw, newContext(2, 42)); got != tt.otherChat {
+				t.Errorf("Expected %d for same user in other chat, got %d", tt.otherChat, got)
+			}
+
+			if got := count(mw, newContext(1, 7)); got != tt.otherUser {
+				t.Errorf("Expected %d for other user in same chat, got %d", tt.otherUser, got)
+			}
+		})
+	}
+}
+
+func TestMiddleware_NoKeySkipsSession(t *testing.T) {
+	store := session.NewMemory()
+	mw := session.New(store).Middleware()
+
+	c := ctx.New(&mockBot{}, &ext.Context{Update: &gotgbot.Update{UpdateId: 1}})
+
+	called := false
+	if err := run(mw, c, func(c *ctx.Context) error {
+		called = true
+		return increment(c)
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !called || store.Len() != 0 {
+		t.Errorf("Expected handler to run without a stored session, called=%v len=%d", called, store.Len())
+	}
+}
+
+func TestMiddleware_NotSavedOnError(t *testing.T) {
+	store := session.NewMemory()
+	mw := session.New(store).Middleware()
+	boom := errors.New("boom")
+
+	err := run(mw, newContext(1, 42), func(c *ctx.Context) error {
+		increment(c)
+		return boom
+	})
+
+	if !errors.Is(err, boom) {
+		t.Errorf("Expected handler error, got %v", err)
+	}
+
+	if store.Len() != 0 {
+		t.Error("Expected session not to be saved after an error")
+	}
+}
+
+func TestMiddleware_SavedOnStop(t *testing.T) {
+	store := session.NewMemory()
+	mw := session.New(store).Middleware()
+
+	err := run(mw, newContext(1, 42), func(c *ctx.Context) error {
+		increment(c)
+		return handlers.Stop
+	})
+
+	if !errors.Is(err, handlers.Stop) {
+		t.Errorf("Expected Stop to be passed on, got %v", err)
+	}
+
+	if store.Len() != 1 {
+		t.Error("Expected session to be saved when the handler stops the update")
+	}
+}
+
+func TestMiddleware_UnchangedNotSaved(t *testing.T) {
+	store := session.NewMemory()
+	mw := session.New(store).Middleware()
+
+	run(mw, newContext(1, 42), increment)
+
+	rec := store.Load(context.Background(), "user:42").Unwrap().Some()
+
+	run(mw, newContext(1, 42), func(*ctx.Context) error { return nil })
+
+	if got := store.Load(context.Background(), "user:42").Unwrap().Some(); got.Version != rec.Version {
+		t.Errorf("Expected version %d to be kept, got %d", rec.Version, got.Version)
+	}
+}
+
+func TestMiddleware_ClearDeletes(t *testing.T) {
+	store := session.NewMemory()
+	mw := session.New(store).Middleware()
+
+	run(mw, newContext(1, 42), increment)
+	run(mw, newContext(1, 42), func(c *ctx.Context) error {
+		c.Session().Clear()
+		return nil
+	})
+
+	if store.Len() != 0 {
+		t.Error("Expected cleared session to be deleted")
+	}
+}
+
+func TestMiddleware_TTL(t *testing.T) {
+	clk := newClock()
+	store := session.NewMemory().Clock(clk.Now)
+	mw := session.New(store).TTL(time.Hour).Clock(clk.Now).Middleware()
+
+	run(mw, newContext(1, 42), increment)
+
+	clk.Advance(59 * time.Minute)
+
+	if got := count(mw, newContext(1, 42)); got != 1 {
+		t.Errorf("Expected session before expiry, got %d", got)
+	}
+
+	clk.Advance(2 * time.Minute)
+
+	if got := count(mw, newContext(1, 42)); got != 0 {
+		t.Errorf("Expected session to expire, got %d", got)
+	}
+}
+
+func TestMiddleware_Conflict(t *testing.T) {
+	mw := session.New(session.NewMemory()).Middleware()
+
+	err := run(mw, newContext(1, 42), func(c *ctx.Context) error {
+		// A concurrent update of the same user saves first.
+		if err := run(mw, newContext(1, 42), increment); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		return increment(c)
+	})
+
+	if !errors.Is(err, session.ErrConflict) {
+		t.Errorf("Expected ErrConflict, got %v", err)
+	}
+
+	if got := count(mw, newContext(1, 42)); got != 1 {
+		t.Errorf("Expected first save to be kept, got %d", got)
+	}
+}
+
+func TestMiddleware_LoadError(t *testing.T) {
+	boom := errors.New("boom")
+	mw := session.New(failingStore{boom}).Middleware()
+
+	called := false
+	err := run(mw, newContext(1, 42), func(*ctx.Context) error {
+		called = true
+		return nil
+	})
+
+	if !errors.Is(err, boom) || called {
+		t.Errorf("Expected load error before the handler, got %v (called=%v)", err, called)
+	}
+}
+
+func TestMiddleware_TypedValues(t *testing.T) {
+	type cart struct {
+		Items g.Slice[g.String] `json:"items"`
+	}
+
+	mw := session.New(session.NewMemory()).Middleware()
+
+	run(mw, newContext(1, 42), func(c *ctx.Context) error {
+		c.Session().Set("cart", cart{Items: g.SliceOf[g.String]("apple")})
+		return nil
+	})
+
+	var got cart
+
+	run(mw, newContext(1, 42), func(c *ctx.Context) error {
+		got = ctx.SessionValue[cart](c.Session(), "cart").UnwrapOrDefault()
+		return nil
+	})
+
+	if len(got.Items) != 1 || got.Items[0] != "apple" {
+		t.Errorf("Expected decoded cart, got %+v", got)
+	}
+}
+
+type failingStore struct{ err error }
+
+func (f failingStore) Load(context.Context, g.String) g.Result[g.Option[session.Record]] {
+	return g.Err[g.Option[session.Record]](f.err)
+}
+
+func (f failingStore) Save(context.Context, g.String, session.Record) error { return f.err }
+func (f failingStore) Delete(context.Context, g.String) error               { return f.err }
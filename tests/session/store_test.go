@@ -0,0 +1,145 @@
+package session_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/enetx/g"
+	"github.com/enetx/tg/session"
+)
+
+func stores(t *testing.T, clk *clock) map[string]session.Store {
+	return map[string]session.Store{
+		"Memory": session.NewMemory().Clock(clk.Now),
+		"File":   session.NewFile(g.String(filepath.Join(t.TempDir(), "sessions.json"))).Clock(clk.Now),
+	}
+}
+
+func TestStore_SaveLoad(t *testing.T) {
+	for name, store := range stores(t, newClock()) {
+		t.Run(name, func(t *testing.T) {
+			std := context.Background()
+
+			if store.Load(std, "k").Unwrap().IsSome() {
+				t.Fatal("Expected empty store")
+			}
+
+			if err := store.Save(std, "k", session.Record{Data: json.RawMessage(`{"a":1}`)}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			rec := store.Load(std, "k").Unwrap()
+			if rec.IsNone() || rec.Some().Version != 1 || string(rec.Some().Data) != `{"a":1}` {
+				t.Errorf("Expected saved record with version 1, got %+v", rec)
+			}
+		})
+	}
+}
+
+func TestStore_Conflict(t *testing.T) {
+	for name, store := range stores(t, newClock()) {
+		t.Run(name, func(t *testing.T) {
+			std := context.Background()
+
+			store.Save(std, "k", session.Record{Data: json.RawMessage(`{}`)})
+
+			if err := store.Save(std, "k", session.Record{Data: json.RawMessage(`{}`)}); !errors.Is(err, session.ErrConflict) {
+				t.Errorf("Expected ErrConflict for stale version, got %v", err)
+			}
+
+			if err := store.Save(std, "k", session.Record{Data: json.RawMessage(`{}`), Version: 1}); err != nil {
+				t.Errorf("Expected save with current version to succeed, got %v", err)
+			}
+
+			if got := store.Load(std, "k").Unwrap().Some().Version; got != 2 {
+				t.Errorf("Expected version 2, got %d", got)
+			}
+		})
+	}
+}
+
+func TestStore_Expiry(t *testing.T) {
+	clk := newClock()
+
+	for name, store := range stores(t, clk) {
+		t.Run(name, func(t *testing.T) {
+			std := context.Background()
+
+			store.Save(std, "k", session.Record{Data: json.RawMessage(`{}`), Expires: clk.Now().Add(time.Minute)})
+			clk.Advance(time.Minute)
+
+			if store.Load(std, "k").Unwrap().IsSome() {
+				t.Error("Expected expired record to be missing")
+			}
+
+			if err := store.Save(std, "k", session.Record{Data: json.RawMessage(`{}`)}); err != nil {
+				t.Errorf("Expected expired record to be replaceable as new, got %v", err)
+			}
+		})
+	}
+}
+
+func TestStore_Delete(t *testing.T) {
+	for name, store := range stores(t, newClock()) {
+		t.Run(name, func(t *testing.T) {
+			std := context.Background()
+
+			store.Save(std, "k", session.Record{Data: json.RawMessage(`{}`)})
+
+			if err := store.Delete(std, "k"); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if store.Load(std, "k").Unwrap().IsSome() {
+				t.Error("Expected deleted record to be missing")
+			}
+
+			if err := store.Delete(std, "missing"); err != nil {
+				t.Errorf("Expected deleting a missing record to succeed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestFile_SurvivesRestart(t *testing.T) {
+	path := g.String(filepath.Join(t.TempDir(), "sessions.json"))
+	std := context.Background()
+
+	if err := session.NewFile(path).Save(std, "k", session.Record{Data: json.RawMessage(`{"n":5}`)}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	rec := session.NewFile(path).Load(std, "k").Unwrap()
+	if rec.IsNone() || string(rec.Some().Data) != `{"n":5}` || rec.Some().Version != 1 {
+		t.Errorf("Expected record to be read back from file, got %+v", rec)
+	}
+
+	matches, _ := filepath.Glob(string(path) + ".*.tmp")
+	if len(matches) != 0 {
+		t.Errorf("Expected temporary files to be removed, got %v", matches)
+	}
+}
+
+func TestFile_CorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	os.WriteFile(path, []byte("not json"), 0o600)
+
+	if session.NewFile(g.String(path)).Load(context.Background(), "k").IsOk() {
+		t.Error("Expected error for a corrupt file")
+	}
+}
+
+func TestFile_MiddlewareRoundTrip(t *testing.T) {
+	path := g.String(filepath.Join(t.TempDir(), "sessions.json"))
+
+	run(session.New(session.NewFile(path)).Middleware(), newContext(1, 42), increment)
+
+	if got := count(session.New(session.NewFile(path)).Middleware(), newContext(1, 42)); got != 1 {
+		t.Errorf("Expected session to be restored from file, got %d", got)
+	}
+}
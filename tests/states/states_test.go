@@ -0,0 +1,426 @@
+package states_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/enetx/fsm"
+	"github.com/enetx/g"
+	"github.com/enetx/tg/ctx"
+	"github.com/enetx/tg/session"
+	"github.com/enetx/tg/states"
+)
+
+type mockBot struct{}
+
+func (m *mockBot) Raw() *gotgbot.Bot           { return &gotgbot.Bot{} }
+func (m *mockBot) Dispatcher() *ext.Dispatcher { return &ext.Dispatcher{} }
+func (m *mockBot) Updater() *ext.Updater       { return &ext.Updater{} }
+
+// clock is a manually advanced time source.
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newClock() *clock { return &clock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)} }
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func newContext(chatID, userID int64) *ctx.Context {
+	chat := &gotgbot.Chat{Id: chatID, Type: "private"}
+	user := &gotgbot.User{Id: userID, FirstName: "Test"}
+
+	return ctx.New(&mockBot{}, &ext.Context{
+		Update:           &gotgbot.Update{UpdateId: 1},
+		EffectiveChat:    chat,
+		EffectiveUser:    user,
+		EffectiveMessage: &gotgbot.Message{MessageId: 1, Chat: *chat, From: user},
+	})
+}
+
+// newTemplate returns a name -> age -> done machine that records the input of every step in Data.
+func newTemplate() *fsm.FSM {
+	template := fsm.New("name").
+		Transition("name", "next", "age").
+		Transition("age", "next", "done")
+
+	template.OnEnter("age", func(fctx *fsm.Context) error {
+		fctx.Data.Insert("name", fctx.Input)
+		return nil
+	})
+
+	template.OnEnter("done", func(fctx *fsm.Context) error {
+		fctx.Data.Insert("age", fctx.Input)
+		return nil
+	})
+
+	return template
+}
+
+func state(t *testing.T, m *states.Manager, c *ctx.Context) fsm.State {
+	t.Helper()
+
+	s := m.State(c)
+	if s.IsNone() {
+		t.Fatal("Expected a state, got None")
+	}
+
+	return s.Some()
+}
+
+func TestManager_StateOfNewUser(t *testing.T) {
+	m := states.New(newTemplate())
+
+	if got := state(t, m, newContext(1, 42)); got != "name" {
+		t.Errorf("Expected the template's initial state for a new user, got %s", got)
+	}
+}
+
+func TestManager_TriggerPersistsBetweenUpdates(t *testing.T) {
+	m := states.New(newTemplate())
+
+	if err := m.Enter(newContext(1, 42), "name"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := m.Trigger(newContext(1, 42), "next", "Alice"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	c := newContext(1, 42)
+	if got := state(t, m, c); got != "age" {
+		t.Errorf("Expected state age, got %s", got)
+	}
+
+	if err := m.Trigger(c, "next", 30); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	f := m.Get(newContext(1, 42)).Unwrap()
+
+	if f.Current() != "done" {
+		t.Errorf("Expected state done, got %s", f.Current())
+	}
+
+	if name := f.Context().Data.Get("name"); name.IsNone() || name.Some() != "Alice" {
+		t.Errorf("Expected name Alice in data, got %v", name)
+	}
+}
+
+func TestManager_UsersAreIndependent(t *testing.T) {
+	m := states.New(newTemplate())
+
+	m.Trigger(newContext(1, 42), "next", "Alice")
+
+	if got := state(t, m, newContext(1, 42)); got != "age" {
+		t.Errorf("Expected state age, got %s", got)
+	}
+
+	if got := state(t, m, newContext(1, 7)); got != "name" {
+		t.Errorf("Expected the initial state for another user, got %s", got)
+	}
+}
+
+func TestManager_KeyByChatUser(t *testing.T) {
+	m := states.New(newTemplate()).Key(session.ByChatUser)
+
+	m.Trigger(newContext(1, 42), "next", "Alice")
+
+	if got := state(t, m, newContext(2, 42)); got != "name" {
+		t.Errorf("Expected the initial state for the same user in another chat, got %s", got)
+	}
+}
+
+func TestManager_CtxInjected(t *testing.T) {
+	template := fsm.New("a").Transition("a", "go", "b")
+
+	var got *ctx.Context
+
+	template.OnEnter("b", func(fctx *fsm.Context) error {
+		got = states.Ctx(fctx)
+		return nil
+	})
+
+	m := states.New(template)
+	c := newContext(1, 42)
+
+	if err := m.Trigger(c, "go"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got != c {
+		t.Errorf("Expected the update's context in the callback, got %p", got)
+	}
+}
+
+func TestManager_CtxOfLaterUpdate(t *testing.T) {
+	template := fsm.New("a").Transition("a", "go", "b").Transition("b", "go", "c")
+
+	var got *ctx.Context
+
+	template.OnEnter("c", func(fctx *fsm.Context) error {
+		got = states.Ctx(fctx)
+		return nil
+	})
+
+	m := states.New(template)
+
+	m.Trigger(newContext(1, 42), "go")
+
+	later := newContext(1, 42)
+	m.Trigger(later, "go")
+
+	if got != later {
+		t.Error("Expected the context of the update that triggered the transition")
+	}
+}
+
+func TestManager_MetaNotStored(t *testing.T) {
+	store := session.NewMemory()
+	m := states.New(newTemplate()).Store(store)
+	c := newContext(1, 42)
+
+	if err := m.Trigger(c, "next", "Alice"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	rec := store.Load(context.Background(), "user:42").Unwrap().Some()
+
+	if strings.Contains(string(rec.Data), "tgctx") {
+		t.Errorf("Expected stored machine without the Telegram context, got %s", rec.Data)
+	}
+
+	if !json.Valid(rec.Data) {
+		t.Errorf("Expected valid JSON, got %s", rec.Data)
+	}
+
+	if states.Ctx(m.Get(c).Unwrap().Context()) != c {
+		t.Error("Expected the context to be attached again after saving")
+	}
+}
+
+func TestManager_FailedTransitionNotSaved(t *testing.T) {
+	template := fsm.New("a").Transition("a", "go", "b")
+	template.OnEnter("b", func(*fsm.Context) error { return errors.New("boom") })
+
+	store := session.NewMemory()
+	m := states.New(template).Store(store)
+
+	if err := m.Trigger(newContext(1, 42), "go"); err == nil {
+		t.Fatal("Expected the callback error")
+	}
+
+	if store.Len() != 0 {
+		t.Errorf("Expected nothing stored, got %d records", store.Len())
+	}
+
+	if err := m.Trigger(newContext(1, 42), "unknown"); err == nil {
+		t.Error("Expected an error for an unknown event")
+	}
+}
+
+func TestManager_Enter(t *testing.T) {
+	var entered int
+
+	template := newTemplate()
+	template.OnEnter("name", func(*fsm.Context) error {
+		entered++
+		return nil
+	})
+
+	m := states.New(template)
+
+	m.Trigger(newContext(1, 42), "next", "Alice")
+
+	if err := m.Enter(newContext(1, 42), "name"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if entered != 1 {
+		t.Errorf("Expected OnEnter to run once, got %d", entered)
+	}
+
+	if got := state(t, m, newContext(1, 42)); got != "name" {
+		t.Errorf("Expected state name, got %s", got)
+	}
+}
+
+func TestManager_Reset(t *testing.T) {
+	m := states.New(newTemplate())
+	c := newContext(1, 42)
+
+	m.Trigger(c, "next", "Alice")
+
+	if err := m.Reset(c); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := state(t, m, c); got != "name" {
+		t.Errorf("Expected the initial state after reset in the same update, got %s", got)
+	}
+
+	if got := state(t, m, newContext(1, 42)); got != "name" {
+		t.Errorf("Expected the initial state after reset, got %s", got)
+	}
+
+	if err := m.Trigger(newContext(1, 42), "next", "Bob"); err != nil {
+		t.Fatalf("Expected a fresh machine after reset, got %v", err)
+	}
+}
+
+func TestManager_GetAndSave(t *testing.T) {
+	m := states.New(newTemplate())
+	c := newContext(1, 42)
+
+	f := m.Get(c).Unwrap()
+	if f.Current() != "name" {
+		t.Errorf("Expected initial state name, got %s", f.Current())
+	}
+
+	f.SetState("done")
+
+	if got := state(t, m, newContext(1, 42)); got != "name" {
+		t.Errorf("Expected nothing stored before Save, got %s", got)
+	}
+
+	if err := m.Save(c, f); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := state(t, m, newContext(1, 42)); got != "done" {
+		t.Errorf("Expected state done, got %s", got)
+	}
+
+	if err := m.Save(c, f); err != nil {
+		t.Errorf("Expected a second save in the same update to succeed, got %v", err)
+	}
+}
+
+func TestManager_SaveConflict(t *testing.T) {
+	m := states.New(newTemplate())
+
+	first := newContext(1, 42)
+	f := m.Get(first).Unwrap()
+
+	m.Trigger(newContext(1, 42), "next", "Alice")
+
+	f.SetState("done")
+
+	if err := m.Save(first, f); !errors.Is(err, session.ErrConflict) {
+		t.Errorf("Expected ErrConflict, got %v", err)
+	}
+}
+
+func TestManager_NoKey(t *testing.T) {
+	m := states.New(newTemplate())
+	c := ctx.New(&mockBot{}, &ext.Context{Update: &gotgbot.Update{UpdateId: 1}})
+
+	if err := m.Trigger(c, "next"); !errors.Is(err, states.ErrNoKey) {
+		t.Errorf("Expected ErrNoKey from Trigger, got %v", err)
+	}
+
+	if err := m.Get(c).Err(); !errors.Is(err, states.ErrNoKey) {
+		t.Errorf("Expected ErrNoKey from Get, got %v", err)
+	}
+
+	if err := m.Reset(c); !errors.Is(err, states.ErrNoKey) {
+		t.Errorf("Expected ErrNoKey from Reset, got %v", err)
+	}
+
+	if s := m.State(c); s.IsSome() {
+		t.Errorf("Expected None, got %s", s.Some())
+	}
+}
+
+func TestManager_TTL(t *testing.T) {
+	clk := newClock()
+	store := session.NewMemory().Clock(clk.Now)
+	m := states.New(newTemplate()).Store(store).TTL(time.Hour).Clock(clk.Now)
+
+	m.Trigger(newContext(1, 42), "next", "Alice")
+
+	clk.Advance(59 * time.Minute)
+
+	if s := m.State(newContext(1, 42)); s.IsNone() {
+		t.Fatal("Expected the machine before expiry")
+	}
+
+	clk.Advance(time.Minute)
+
+	if got := state(t, m, newContext(1, 42)); got != "name" {
+		t.Errorf("Expected the initial state after expiry, got %s", got)
+	}
+}
+
+func TestManager_FileStoreSurvivesRestart(t *testing.T) {
+	path := g.String(filepath.Join(t.TempDir(), "states.json"))
+
+	m := states.New(newTemplate()).Store(session.NewFile(path))
+	m.Trigger(newContext(1, 42), "next", "Alice")
+
+	restarted := states.New(newTemplate()).Store(session.NewFile(path))
+
+	if got := state(t, restarted, newContext(1, 42)); got != "age" {
+		t.Errorf("Expected state age after restart, got %s", got)
+	}
+
+	if err := restarted.Trigger(newContext(1, 42), "next", 30); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	f := restarted.Get(newContext(1, 42)).Unwrap()
+
+	if name := f.Context().Data.Get("name"); name.IsNone() || name.Some() != "Alice" {
+		t.Errorf("Expected name Alice restored from file, got %v", name)
+	}
+}
+
+func TestManager_ConcurrentTriggers(t *testing.T) {
+	template := fsm.New("a").Transition("a", "go", "a")
+	template.OnEnter("a", func(fctx *fsm.Context) error {
+		n, _ := fctx.Data.Get("n").UnwrapOr(0.0).(float64)
+		fctx.Data.Insert("n", n+1)
+
+		return nil
+	})
+
+	m := states.New(template)
+
+	var wg sync.WaitGroup
+
+	for range 20 {
+		wg.Go(func() {
+			if err := m.Trigger(newContext(1, 42), "go"); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	n := m.Get(newContext(1, 42)).Unwrap().Context().Data.Get("n")
+	if n.IsNone() || n.Some() != 20.0 {
+		t.Errorf("Expected 20 transitions, got %v", n)
+	}
+}
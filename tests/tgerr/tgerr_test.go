@@ -0,0 +1,183 @@
+package tgerr_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/enetx/tg/tgerr"
+)
+
+func TestClassify_Causes(t *testing.T) {
+	tests := []struct {
+		code int
+		desc string
+		want []error
+	}{
+		{403, "Forbidden: bot was blocked by the user", []error{tgerr.ErrForbidden, tgerr.ErrBotBlocked}},
+		{403, "Forbidden: bot was kicked from the supergroup chat", []error{tgerr.ErrForbidden, tgerr.ErrBotKicked}},
+		{403, "Forbidden: user is deactivated", []error{tgerr.ErrForbidden, tgerr.ErrUserDeactivated}},
+		{403, "Forbidden: bot can't initiate conversation with a user", []error{tgerr.ErrCantInitiate}},
+		{400, "Bad Request: chat not found", []error{tgerr.ErrBadRequest, tgerr.ErrChatNotFound}},
+		{
+			400,
+			"Bad Request: message is not modified: specified new message content and reply markup are exactly the same",
+			[]error{tgerr.ErrBadRequest, tgerr.ErrMessageNotModified},
+		},
+		{400, "Bad Request: message to edit not found", []error{tgerr.ErrMessageToEditNotFound}},
+		{400, "Bad Request: message to delete not found", []error{tgerr.ErrMessageToDeleteNotFound}},
+		{400, "Bad Request: message can’t be edited", []error{tgerr.ErrMessageCantBeEdited}},
+		{400, "Bad Request: query is too old and response timeout expired or query ID is invalid", []error{tgerr.ErrQueryTooOld}},
+		{400, "Bad Request: not enough rights to send text messages to the chat", []error{tgerr.ErrNotEnoughRights}},
+		{401, "Unauthorized", []error{tgerr.ErrUnauthorized}},
+		{409, "Conflict: terminated by other getUpdates request", []error{tgerr.ErrConflict, tgerr.ErrPollingConflict}},
+		{502, "Bad Gateway", []error{tgerr.ErrServer}},
+	}
+
+	for _, tt := range tests {
+		err := tgerr.Classify(&gotgbot.TelegramError{Code: tt.code, Description: tt.desc})
+
+		for _, want := range tt.want {
+			if !errors.Is(err, want) {
+				t.Errorf("Expected %q to match %q", tt.desc, want)
+			}
+		}
+	}
+}
+
+func TestClassify_NoFalseMatches(t *testing.T) {
+	err := tgerr.Classify(&gotgbot.TelegramError{Code: 400, Description: "Bad Request: chat not found"})
+
+	for _, other := range []error{tgerr.ErrForbidden, tgerr.ErrBotBlocked, tgerr.ErrMessageNotModified} {
+		if errors.Is(err, other) {
+			t.Errorf("Expected error not to match %q", other)
+		}
+	}
+}
+
+func TestClassify_CodeWithUnknownDescription(t *testing.T) {
+	err := tgerr.Classify(&gotgbot.TelegramError{Code: 403, Description: "Forbidden: some new reason"})
+
+	if !errors.Is(err, tgerr.ErrForbidden) {
+		t.Error("Expected unknown 403 description to still match ErrForbidden")
+	}
+}
+
+func TestClassify_DescriptionCaseAndSpacing(t *testing.T) {
+	err := tgerr.Classify(&gotgbot.TelegramError{Code: 403, Description: "Forbidden:  Bot was  BLOCKED by the user"})
+
+	if !errors.Is(err, tgerr.ErrBotBlocked) {
+		t.Error("Expected classification to ignore case and spacing")
+	}
+}
+
+func TestClassify_FloodWait(t *testing.T) {
+	err := tgerr.Classify(&gotgbot.TelegramError{
+		Code:           429,
+		Description:    "Too Many Requests: retry after 7",
+		ResponseParams: &gotgbot.ResponseParameters{RetryAfter: 7},
+	})
+
+	var flood *tgerr.FloodWait
+	if !errors.As(err, &flood) {
+		t.Fatal("Expected FloodWait")
+	}
+
+	if flood.RetryAfter != 7*time.Second {
+		t.Errorf("Expected RetryAfter 7s, got %v", flood.RetryAfter)
+	}
+
+	if !errors.Is(err, tgerr.ErrTooManyRequests) {
+		t.Error("Expected ErrTooManyRequests")
+	}
+}
+
+func TestClassify_FloodWaitFromDescription(t *testing.T) {
+	err := tgerr.Classify(&gotgbot.TelegramError{Code: 429, Description: "Too Many Requests: retry after 3"})
+
+	var flood *tgerr.FloodWait
+	if !errors.As(err, &flood) || flood.RetryAfter != 3*time.Second {
+		t.Fatalf("Expected FloodWait of 3s, got %v", flood)
+	}
+}
+
+func TestClassify_ChatMigrated(t *testing.T) {
+	err := tgerr.Classify(&gotgbot.TelegramError{
+		Code:           400,
+		Description:    "Bad Request: group chat was upgraded to a supergroup chat",
+		ResponseParams: &gotgbot.ResponseParameters{MigrateToChatId: -100123},
+	})
+
+	var migrated *tgerr.ChatMigrated
+	if !errors.As(err, &migrated) || migrated.ChatID != -100123 {
+		t.Fatalf("Expected ChatMigrated to -100123, got %v", migrated)
+	}
+}
+
+func TestClassify_KeepsOriginalError(t *testing.T) {
+	orig := &gotgbot.TelegramError{Code: 400, Description: "Bad Request: chat not found"}
+	err := tgerr.Classify(fmt.Errorf("send: %w", orig))
+
+	var tgErr *gotgbot.TelegramError
+	if !errors.As(err, &tgErr) || tgErr != orig {
+		t.Error("Expected original TelegramError to remain reachable")
+	}
+
+	var classified *tgerr.Error
+	if !errors.As(err, &classified) {
+		t.Fatal("Expected *tgerr.Error")
+	}
+
+	if classified.TelegramError.Code != 400 {
+		t.Errorf("Expected code 400, got %d", classified.TelegramError.Code)
+	}
+
+	if err.Error() != orig.Error() {
+		t.Errorf("Expected message %q, got %q", orig.Error(), err.Error())
+	}
+}
+
+func TestClassify_OtherErrors(t *testing.T) {
+	if tgerr.Classify(nil) != nil {
+		t.Error("Expected nil to stay nil")
+	}
+
+	plain := errors.New("connection reset")
+	if tgerr.Classify(plain) != plain {
+		t.Error("Expected non-Telegram error to be returned unchanged")
+	}
+
+	once := tgerr.Classify(&gotgbot.TelegramError{Code: 400, Description: "Bad Request"})
+	if tgerr.Classify(once) != once {
+		t.Error("Expected classified error to be returned unchanged")
+	}
+}
+
+type fakeClient struct {
+	gotgbot.BotClient
+	err error
+}
+
+func (f *fakeClient) RequestWithContext(
+	context.Context,
+	string,
+	string,
+	map[string]string,
+	map[string]gotgbot.FileReader,
+	*gotgbot.RequestOpts,
+) (json.RawMessage, error) {
+	return nil, f.err
+}
+
+func TestWrap(t *testing.T) {
+	c := tgerr.Wrap(&fakeClient{err: &gotgbot.TelegramError{Code: 403, Description: "Forbidden: bot was blocked by the user"}})
+
+	_, err := c.RequestWithContext(context.Background(), "token", "sendMessage", nil, nil, nil)
+	if !errors.Is(err, tgerr.ErrBotBlocked) {
+		t.Errorf("Expected ErrBotBlocked, got %v", err)
+	}
+}
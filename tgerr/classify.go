@@ -0,0 +1,120 @@
+package tgerr
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// cause maps description fragments to a cause error.
+// Fragments are matched against the lowercased description, so alternative
+// wordings used by different Bot API versions can be listed side by side.
+type cause struct {
+	err       error
+	fragments []string
+}
+
+var causes = []cause{
+	{ErrBotBlocked, []string{"bot was blocked by the user", "blocked by user"}},
+	{ErrBotKicked, []string{"bot was kicked", "bot is not a member", "bot is not a participant"}},
+	{ErrUserDeactivated, []string{"user is deactivated"}},
+	{ErrCantInitiate, []string{"can't initiate conversation", "cannot initiate conversation"}},
+	{ErrChatNotFound, []string{"chat not found"}},
+	{ErrUserNotFound, []string{"user not found", "participant_id_invalid"}},
+	{ErrMessageNotModified, []string{"message is not modified"}},
+	{ErrMessageToEditNotFound, []string{"message to edit not found"}},
+	{ErrMessageToDeleteNotFound, []string{"message to delete not found"}},
+	{ErrMessageToReplyNotFound, []string{"message to be replied not found", "replied message not found"}},
+	{ErrMessageCantBeEdited, []string{"message can't be edited", "message cannot be edited"}},
+	{ErrMessageCantBeDeleted, []string{"message can't be deleted", "message cannot be deleted"}},
+	{ErrMessageEmpty, []string{"message text is empty", "text must be non-empty"}},
+	{ErrMessageTooLong, []string{"message is too long", "text is too long"}},
+	{ErrQueryTooOld, []string{"query is too old", "query id is invalid"}},
+	{ErrNotEnoughRights, []string{"not enough rights", "have no rights", "chat_admin_required"}},
+	{ErrWebhookActive, []string{"webhook is active"}},
+	{ErrPollingConflict, []string{"terminated by other getupdates"}},
+}
+
+var retryAfterRe = regexp.MustCompile(`retry after (\d+)`)
+
+// Classify converts a *gotgbot.TelegramError found in err's chain into an *Error.
+// Any other error, including nil, is returned unchanged.
+func Classify(err error) error {
+	var tgErr *gotgbot.TelegramError
+	if !errors.As(err, &tgErr) {
+		return err
+	}
+
+	var classified *Error
+	if errors.As(err, &classified) {
+		return err
+	}
+
+	return &Error{TelegramError: tgErr, causes: classify(tgErr)}
+}
+
+func classify(tgErr *gotgbot.TelegramError) []error {
+	var errs []error
+
+	switch code := tgErr.Code; {
+	case code == 400:
+		errs = append(errs, ErrBadRequest)
+	case code == 401:
+		errs = append(errs, ErrUnauthorized)
+	case code == 403:
+		errs = append(errs, ErrForbidden)
+	case code == 404:
+		errs = append(errs, ErrNotFound)
+	case code == 409:
+		errs = append(errs, ErrConflict)
+	case code == 429:
+		errs = append(errs, ErrTooManyRequests)
+	case code >= 500:
+		errs = append(errs, ErrServer)
+	}
+
+	desc := normalize(tgErr.Description)
+
+	for _, c := range causes {
+		for _, fragment := range c.fragments {
+			if strings.Contains(desc, fragment) {
+				errs = append(errs, c.err)
+				break
+			}
+		}
+	}
+
+	params := tgErr.ResponseParams
+
+	switch {
+	case params != nil && params.RetryAfter > 0:
+		errs = append(errs, &FloodWait{RetryAfter: time.Duration(params.RetryAfter) * time.Second})
+	case tgErr.Code == 429:
+		retry := time.Second
+		if m := retryAfterRe.FindStringSubmatch(desc); m != nil {
+			if n, err := strconv.Atoi(m[1]); err == nil && n > 0 {
+				retry = time.Duration(n) * time.Second
+			}
+		}
+
+		errs = append(errs, &FloodWait{RetryAfter: retry})
+	}
+
+	if params != nil && params.MigrateToChatId != 0 {
+		errs = append(errs, &ChatMigrated{ChatID: params.MigrateToChatId})
+	}
+
+	return errs
+}
+
+// normalize lowercases the description and unifies apostrophes and whitespace.
+func normalize(desc string) string {
+	desc = strings.ToLower(desc)
+	desc = strings.ReplaceAll(desc, "’", "'")
+
+	return strings.Join(strings.Fields(desc), " ")
+}
@@ -0,0 +1,31 @@
+package tgerr
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// Wrap returns a BotClient whose requests fail with classified errors.
+func Wrap(c gotgbot.BotClient) gotgbot.BotClient {
+	return &client{BotClient: c}
+}
+
+// client is a gotgbot.BotClient that classifies Telegram errors.
+type client struct {
+	gotgbot.BotClient
+}
+
+// RequestWithContext sends the request and classifies the returned error.
+func (c *client) RequestWithContext(
+	ctx context.Context,
+	token string,
+	method string,
+	params map[string]string,
+	data map[string]gotgbot.FileReader,
+	opts *gotgbot.RequestOpts,
+) (json.RawMessage, error) {
+	resp, err := c.BotClient.RequestWithContext(ctx, token, method, params, data, opts)
+	return resp, Classify(err)
+}
@@ -0,0 +1,99 @@
+// Package tgerr classifies errors returned by the Telegram Bot API.
+//
+// Every request sent by a bot built with bot.New returns errors that can be
+// inspected with errors.Is and errors.As instead of matching descriptions:
+//
+//	err := ctx.SendMessage("hi").To(id).Send().Err()
+//
+//	var flood *tgerr.FloodWait
+//	switch {
+//	case errors.Is(err, tgerr.ErrBotBlocked):
+//		// remove the subscriber
+//	case errors.As(err, &flood):
+//		time.Sleep(flood.RetryAfter)
+//	}
+//
+// Errors are matched against the HTTP-like error code first, which is stable across
+// Bot API versions, and then against known description fragments for the specific cause.
+package tgerr
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// Errors matched by error code.
+var (
+	ErrBadRequest      = errors.New("bad request")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrForbidden       = errors.New("forbidden")
+	ErrNotFound        = errors.New("not found")
+	ErrConflict        = errors.New("conflict")
+	ErrTooManyRequests = errors.New("too many requests")
+	ErrServer          = errors.New("telegram server error")
+)
+
+// Errors matched by cause. Each of them also matches its code error,
+// e.g. ErrBotBlocked is ErrForbidden.
+var (
+	ErrBotBlocked              = errors.New("bot was blocked by the user")
+	ErrBotKicked               = errors.New("bot was kicked from the chat")
+	ErrUserDeactivated         = errors.New("user is deactivated")
+	ErrCantInitiate            = errors.New("bot can't initiate conversation with a user")
+	ErrChatNotFound            = errors.New("chat not found")
+	ErrUserNotFound            = errors.New("user not found")
+	ErrMessageNotModified      = errors.New("message is not modified")
+	ErrMessageToEditNotFound   = errors.New("message to edit not found")
+	ErrMessageToDeleteNotFound = errors.New("message to delete not found")
+	ErrMessageToReplyNotFound  = errors.New("message to be replied not found")
+	ErrMessageCantBeEdited     = errors.New("message can't be edited")
+	ErrMessageCantBeDeleted    = errors.New("message can't be deleted")
+	ErrMessageEmpty            = errors.New("message text is empty")
+	ErrMessageTooLong          = errors.New("message is too long")
+	ErrQueryTooOld             = errors.New("query is too old")
+	ErrNotEnoughRights         = errors.New("not enough rights")
+	ErrWebhookActive           = errors.New("can't use getUpdates while webhook is active")
+	ErrPollingConflict         = errors.New("terminated by other getUpdates request")
+)
+
+// Error is a Telegram Bot API error with its classification.
+// It unwraps to the original *gotgbot.TelegramError, its code error, its cause error if known,
+// and *FloodWait or *ChatMigrated when Telegram returned the corresponding response parameters.
+type Error struct {
+	*gotgbot.TelegramError
+	causes []error
+}
+
+// Unwrap returns the original error followed by the errors it was classified as.
+func (e *Error) Unwrap() []error {
+	return append([]error{e.TelegramError}, e.causes...)
+}
+
+// FloodWait reports that a request was rejected for exceeding flood limits.
+// It matches ErrTooManyRequests.
+type FloodWait struct {
+	// RetryAfter is the time to wait before the request can be repeated.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (f *FloodWait) Error() string {
+	return fmt.Sprintf("flood wait: retry after %s", f.RetryAfter)
+}
+
+// Is reports whether target is ErrTooManyRequests.
+func (f *FloodWait) Is(target error) bool { return target == ErrTooManyRequests }
+
+// ChatMigrated reports that a group was upgraded to a supergroup with a new identifier.
+type ChatMigrated struct {
+	// ChatID is the identifier of the supergroup the request should be repeated with.
+	ChatID int64
+}
+
+// Error implements the error interface.
+func (c *ChatMigrated) Error() string {
+	return fmt.Sprintf("chat migrated to %d", c.ChatID)
+}